@@ -4,16 +4,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"log"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
+	bitbucketadapter "github.com/bkyoung/code-reviewer/internal/adapter/bitbucket"
 	"github.com/bkyoung/code-reviewer/internal/adapter/cli"
+	"github.com/bkyoung/code-reviewer/internal/adapter/codenav"
 	"github.com/bkyoung/code-reviewer/internal/adapter/git"
+	giteaadapter "github.com/bkyoung/code-reviewer/internal/adapter/gitea"
 	githubadapter "github.com/bkyoung/code-reviewer/internal/adapter/github"
+	gitlabadapter "github.com/bkyoung/code-reviewer/internal/adapter/gitlab"
+	"github.com/bkyoung/code-reviewer/internal/adapter/llm"
 	"github.com/bkyoung/code-reviewer/internal/adapter/llm/anthropic"
 	"github.com/bkyoung/code-reviewer/internal/adapter/llm/gemini"
 	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
@@ -21,8 +29,13 @@ import (
 	"github.com/bkyoung/code-reviewer/internal/adapter/llm/openai"
 	"github.com/bkyoung/code-reviewer/internal/adapter/llm/static"
 	"github.com/bkyoung/code-reviewer/internal/adapter/observability"
+	"github.com/bkyoung/code-reviewer/internal/adapter/output/codeclimate"
+	"github.com/bkyoung/code-reviewer/internal/adapter/output/html"
 	"github.com/bkyoung/code-reviewer/internal/adapter/output/json"
+	"github.com/bkyoung/code-reviewer/internal/adapter/output/junit"
 	"github.com/bkyoung/code-reviewer/internal/adapter/output/markdown"
+	"github.com/bkyoung/code-reviewer/internal/adapter/output/metrics"
+	"github.com/bkyoung/code-reviewer/internal/adapter/output/rdjson"
 	"github.com/bkyoung/code-reviewer/internal/adapter/output/sarif"
 	"github.com/bkyoung/code-reviewer/internal/adapter/repository"
 	storeAdapter "github.com/bkyoung/code-reviewer/internal/adapter/store"
@@ -31,9 +44,15 @@ import (
 	"github.com/bkyoung/code-reviewer/internal/config"
 	"github.com/bkyoung/code-reviewer/internal/determinism"
 	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/jsonschema"
 	"github.com/bkyoung/code-reviewer/internal/redaction"
+	"github.com/bkyoung/code-reviewer/internal/store"
+	usecasebitbucket "github.com/bkyoung/code-reviewer/internal/usecase/bitbucket"
+	usecasegitea "github.com/bkyoung/code-reviewer/internal/usecase/gitea"
 	usecasegithub "github.com/bkyoung/code-reviewer/internal/usecase/github"
+	usecasegitlab "github.com/bkyoung/code-reviewer/internal/usecase/gitlab"
 	"github.com/bkyoung/code-reviewer/internal/usecase/merge"
+	"github.com/bkyoung/code-reviewer/internal/usecase/respond"
 	"github.com/bkyoung/code-reviewer/internal/usecase/review"
 	usecaseverify "github.com/bkyoung/code-reviewer/internal/usecase/verify"
 	"github.com/bkyoung/code-reviewer/internal/version"
@@ -77,6 +96,12 @@ func run() error {
 	markdownWriter := markdown.NewWriter(nowFunc)
 	jsonWriter := json.NewWriter(nowFunc)
 	sarifWriter := sarif.NewWriter(nowFunc)
+	outputs := review.NewOutputs().
+		RegisterRun(review.FormatHTML, html.NewWriter(nowFunc)).
+		Register(review.FormatJUnit, junit.NewWriter(nowFunc)).
+		Register(review.FormatCodeClimate, codeclimate.NewWriter(nowFunc)).
+		Register(review.FormatRDJSON, rdjson.NewWriter(nowFunc)).
+		Register(review.FormatRDJSONL, rdjson.NewLinesWriter(nowFunc))
 
 	// Build observability components
 	obs := buildObservability(cfg.Observability)
@@ -87,10 +112,34 @@ func run() error {
 		reviewLogger = observability.NewReviewLogger(obs.logger)
 	}
 
+	// Export OpenTelemetry traces of the review pipeline if enabled
+	if tracingCfg := cfg.Observability.Tracing; tracingCfg.Enabled {
+		tracing, err := observability.NewTracing(ctx, observability.TracingConfig{
+			Exporter:    tracingCfg.Exporter,
+			Endpoint:    tracingCfg.Endpoint,
+			File:        tracingCfg.File,
+			ServiceName: tracingCfg.ServiceName,
+			Version:     version.Value(),
+		})
+		if err != nil {
+			log.Printf("warning: tracing disabled: %v", err)
+		} else {
+			defer func() {
+				// The run context may already be cancelled; flush with a fresh deadline
+				shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancelShutdown()
+				if err := tracing.Shutdown(shutdownCtx); err != nil {
+					log.Printf("warning: failed to flush traces: %v", err)
+				}
+			}()
+		}
+	}
+
 	providers := buildProviders(cfg.Providers, cfg.HTTP, obs)
 
 	// Initialize store if enabled
 	var reviewStore review.Store
+	var historyStore store.Store // Shared with cr serve's job store
 	if cfg.Store.Enabled {
 		// Create store directory if it doesn't exist
 		storeDir := filepath.Dir(cfg.Store.Path)
@@ -103,6 +152,7 @@ func run() error {
 				log.Printf("warning: failed to initialize store: %v", err)
 			} else {
 				// Wrap in adapter bridge
+				historyStore = sqliteStore
 				reviewStore = storeAdapter.NewBridge(sqliteStore)
 				// Ensure store is closed on exit
 				defer reviewStore.Close()
@@ -174,12 +224,44 @@ func run() error {
 		}
 	}
 
-	// Create GitHub poster if token is available
-	var githubPoster review.GitHubPoster
+	// Create review publishers for each code host whose token is available
+	publishers := make(platformPublisher)
+	var githubClient *githubadapter.Client
 	if githubToken := os.Getenv("GITHUB_TOKEN"); githubToken != "" {
-		githubClient := githubadapter.NewClient(githubToken)
+		githubClient = githubadapter.NewClient(githubToken)
+		if apiURL := githubAPIURL(cfg.GitHub); apiURL != "" {
+			githubClient.SetBaseURL(apiURL)
+		}
 		reviewPoster := usecasegithub.NewReviewPoster(githubClient)
-		githubPoster = &githubPosterAdapter{poster: reviewPoster}
+		publishers["github"] = &githubPublisherAdapter{poster: reviewPoster}
+	}
+	if gitlabToken := os.Getenv("GITLAB_TOKEN"); gitlabToken != "" {
+		gitlabClient := gitlabadapter.NewClient(gitlabToken)
+		if apiURL := gitlabAPIURL(); apiURL != "" {
+			gitlabClient.SetBaseURL(apiURL)
+		}
+		reviewPoster := usecasegitlab.NewReviewPoster(gitlabClient)
+		publishers["gitlab"] = &gitlabPublisherAdapter{poster: reviewPoster}
+	}
+	if giteaToken := os.Getenv("GITEA_TOKEN"); giteaToken != "" {
+		giteaClient := giteaadapter.NewClient(giteaToken)
+		if apiURL := os.Getenv("GITEA_API_URL"); apiURL != "" {
+			giteaClient.SetBaseURL(apiURL)
+		}
+		reviewPoster := usecasegitea.NewReviewPoster(giteaClient)
+		publishers["gitea"] = &giteaPublisherAdapter{poster: reviewPoster}
+	}
+	if bitbucketToken := os.Getenv("BITBUCKET_TOKEN"); bitbucketToken != "" {
+		bitbucketClient := bitbucketadapter.NewClient(bitbucketToken)
+		if username := os.Getenv("BITBUCKET_USERNAME"); username != "" {
+			bitbucketClient.SetUsername(username)
+		}
+		reviewPoster := usecasebitbucket.NewReviewPoster(bitbucketClient)
+		publishers["bitbucket"] = &bitbucketPublisherAdapter{poster: reviewPoster}
+	}
+	var publisher review.ReviewPublisher
+	if len(publishers) > 0 {
+		publisher = publishers
 	}
 
 	// Create verification agent if enabled and a suitable provider is available
@@ -189,6 +271,39 @@ func run() error {
 		verifier = createVerifier(cfg, providers, repoDir, obs)
 	}
 
+	// Create the conversational responder if GitHub and an LLM are available
+	var commentResponder cli.CommentResponder
+	if githubClient != nil {
+		if llmClient, _, _, _ := createVerificationLLM(cfg, obs); llmClient != nil {
+			commentResponder = respond.NewResponder(githubClient, llmClient, repository.NewLocalRepository(repoDir))
+		}
+	}
+
+	// Expand diff hunks with enclosing declarations and imports
+	var codeContext *review.CodeContextExpander
+	if cfg.Review.CodeContext.Enabled {
+		codeContext = review.NewCodeContextExpander(repoDir, review.CodeContextConfig{
+			MaxLinesPerFile: cfg.Review.CodeContext.MaxLinesPerFile,
+			MaxTotalLines:   cfg.Review.CodeContext.MaxTotalLines,
+		})
+	}
+
+	// List callers of changed Go functions, resolved with the code index
+	var impact *review.ImpactAnalyzer
+	if cfg.Review.ImpactAnalysis.Enabled {
+		index := codenav.NewIndex(repository.NewLocalRepository(repoDir))
+		impact = review.NewImpactAnalyzer(repoDir, &callSiteFinderAdapter{index: index}, review.ImpactConfig{
+			MaxCallSites:   cfg.Review.ImpactAnalysis.MaxCallSites,
+			MaxPerFunction: cfg.Review.ImpactAnalysis.MaxPerFunction,
+		})
+	}
+
+	// Skip generated files, vendored code and lockfiles
+	var generatedFiles *review.GeneratedFileDetector
+	if cfg.Review.GeneratedFiles.Skip {
+		generatedFiles = review.NewGeneratedFileDetector(repoDir)
+	}
+
 	// Build per-provider max tokens map from config
 	providerMaxTokens := buildProviderMaxTokens(cfg.Providers)
 
@@ -199,16 +314,24 @@ func run() error {
 		Markdown:          markdownWriter,
 		JSON:              jsonWriter,
 		SARIF:             sarifWriter,
+		Outputs:           outputs,
 		Redactor:          redactor,
 		SeedGenerator:     determinism.GenerateSeed,
 		PromptBuilder:     promptBuilder.Build,
 		Store:             reviewStore,
 		Logger:            reviewLogger,
+		Tracer:            observability.NewTracer(),
+		Metrics:           metrics.NewWriter(nowFunc, obs.metrics),
 		PlanningAgent:     planningAgent,
 		RepoDir:           repoDir,
-		GitHubPoster:      githubPoster,
+		Publisher:         publisher,
 		Verifier:          verifier,
 		ProviderMaxTokens: providerMaxTokens,
+		CodeContext:       codeContext,
+		Impact:            impact,
+		ContextConfig:     buildContextConfig(cfg.Context),
+		PathPolicies:      pathPolicyLoader(gitEngine),
+		GeneratedFiles:    generatedFiles,
 	})
 
 	root := cli.NewRootCommand(cli.Dependencies{
@@ -226,6 +349,22 @@ func run() error {
 			AlwaysBlockCategories: cfg.Review.AlwaysBlockCategories,
 		},
 		DefaultBotUsername: cfg.Review.BotUsername,
+		DefaultFormats:     cfg.Output.Formats,
+		DefaultMergedOnly:  cfg.Output.MergedOnly,
+		Schemas:            map[string]*jsonschema.Schema{"output": json.Schema()},
+		ReviewServer: &reviewServer{
+			reviewer:            orchestrator,
+			store:               historyStore,
+			storePath:           cfg.Store.Path,
+			repositoryAvailable: gitEngine.IsRepository(),
+		},
+		DefaultServe: cli.DefaultServe{
+			Addr:        cfg.Serve.Addr,
+			Token:       cfg.Serve.Token,
+			Concurrency: cfg.Serve.Concurrency,
+			MaxQueued:   cfg.Serve.MaxQueued,
+		},
+		CommentResponder: commentResponder,
 		DefaultVerification: cli.DefaultVerification{
 			Enabled:            cfg.Verification.Enabled,
 			Depth:              cfg.Verification.Depth,
@@ -236,7 +375,8 @@ func run() error {
 			ConfidenceMedium:   cfg.Verification.Confidence.Medium,
 			ConfidenceLow:      cfg.Verification.Confidence.Low,
 		},
-		Version: version.Value(),
+		Version:             version.Value(),
+		RepositoryAvailable: gitEngine.IsRepository(),
 	})
 
 	if err := root.ExecuteContext(ctx); err != nil {
@@ -269,6 +409,7 @@ type observabilityComponents struct {
 	logger  llmhttp.Logger
 	metrics llmhttp.Metrics
 	pricing llmhttp.Pricing
+	guards  *llmhttp.Guards // Rate limiter and circuit breaker per provider
 }
 
 // buildObservability creates observability components based on configuration
@@ -307,6 +448,7 @@ func buildObservability(cfg config.ObservabilityConfig) observabilityComponents
 		logger:  logger,
 		metrics: metrics,
 		pricing: pricing,
+		guards:  llmhttp.NewGuards(metrics),
 	}
 }
 
@@ -339,6 +481,7 @@ func createPlanningProvider(cfg *config.Config, providers map[string]review.Prov
 				return nil
 			}
 			client := openai.NewHTTPClient(providerCfg.APIKey, model, providerCfg, cfg.HTTP)
+			client.SetGuard(obs.guards.For("openai", providerCfg, cfg.HTTP))
 			if obs.logger != nil {
 				client.SetLogger(obs.logger)
 			}
@@ -356,6 +499,7 @@ func createPlanningProvider(cfg *config.Config, providers map[string]review.Prov
 				return nil
 			}
 			client := anthropic.NewHTTPClient(providerCfg.APIKey, model, providerCfg, cfg.HTTP)
+			client.SetGuard(obs.guards.For("anthropic", providerCfg, cfg.HTTP))
 			if obs.logger != nil {
 				client.SetLogger(obs.logger)
 			}
@@ -373,6 +517,7 @@ func createPlanningProvider(cfg *config.Config, providers map[string]review.Prov
 				return nil
 			}
 			client := gemini.NewHTTPClient(providerCfg.APIKey, model, providerCfg, cfg.HTTP)
+			client.SetGuard(obs.guards.For("gemini", providerCfg, cfg.HTTP))
 			if obs.logger != nil {
 				client.SetLogger(obs.logger)
 			}
@@ -391,6 +536,7 @@ func createPlanningProvider(cfg *config.Config, providers map[string]review.Prov
 				host = "http://localhost:11434"
 			}
 			client := ollama.NewHTTPClient(host, model, providerCfg, cfg.HTTP)
+			client.SetGuard(obs.guards.For("ollama", providerCfg, cfg.HTTP))
 			if obs.logger != nil {
 				client.SetLogger(obs.logger)
 			}
@@ -442,6 +588,7 @@ func createMergeSynthesisProvider(cfg *config.Config, obs observabilityComponent
 			return nil
 		}
 		client := openai.NewHTTPClient(providerCfg.APIKey, model, providerCfg, cfg.HTTP)
+		client.SetGuard(obs.guards.For("openai", providerCfg, cfg.HTTP))
 		if obs.logger != nil {
 			client.SetLogger(obs.logger)
 		}
@@ -460,6 +607,7 @@ func createMergeSynthesisProvider(cfg *config.Config, obs observabilityComponent
 			return nil
 		}
 		client := anthropic.NewHTTPClient(providerCfg.APIKey, model, providerCfg, cfg.HTTP)
+		client.SetGuard(obs.guards.For("anthropic", providerCfg, cfg.HTTP))
 		if obs.logger != nil {
 			client.SetLogger(obs.logger)
 		}
@@ -478,6 +626,7 @@ func createMergeSynthesisProvider(cfg *config.Config, obs observabilityComponent
 			return nil
 		}
 		client := gemini.NewHTTPClient(providerCfg.APIKey, model, providerCfg, cfg.HTTP)
+		client.SetGuard(obs.guards.For("gemini", providerCfg, cfg.HTTP))
 		if obs.logger != nil {
 			client.SetLogger(obs.logger)
 		}
@@ -496,6 +645,7 @@ func createMergeSynthesisProvider(cfg *config.Config, obs observabilityComponent
 			host = "http://localhost:11434"
 		}
 		client := ollama.NewHTTPClient(host, model, providerCfg, cfg.HTTP)
+		client.SetGuard(obs.guards.For("ollama", providerCfg, cfg.HTTP))
 		if obs.logger != nil {
 			client.SetLogger(obs.logger)
 		}
@@ -524,24 +674,12 @@ func buildProviders(providersConfig map[string]config.ProviderConfig, httpConfig
 			model = "gpt-4o-mini"
 		}
 		// Use real HTTP client if API key is provided
-		apiKey := cfg.APIKey
-		if apiKey == "" {
+		if cfg.APIKey == "" {
 			// Fallback to static client if no API key
 			log.Println("OpenAI: No API key provided, using static client")
 			providers["openai"] = openai.NewProvider(model, openai.NewStaticClient())
 		} else {
-			client := openai.NewHTTPClient(apiKey, model, cfg, httpConfig)
-			// Wire up observability
-			if obs.logger != nil {
-				client.SetLogger(obs.logger)
-			}
-			if obs.metrics != nil {
-				client.SetMetrics(obs.metrics)
-			}
-			if obs.pricing != nil {
-				client.SetPricing(obs.pricing)
-			}
-			providers["openai"] = openai.NewProvider(model, client)
+			providers["openai"] = newReviewProvider("openai", model, cfg, httpConfig, obs)
 		}
 	}
 
@@ -552,22 +690,10 @@ func buildProviders(providersConfig map[string]config.ProviderConfig, httpConfig
 			model = "claude-3-5-sonnet-20241022"
 		}
 		// Use real HTTP client if API key is provided
-		apiKey := cfg.APIKey
-		if apiKey == "" {
+		if cfg.APIKey == "" {
 			log.Println("Anthropic: No API key provided, skipping provider")
 		} else {
-			client := anthropic.NewHTTPClient(apiKey, model, cfg, httpConfig)
-			// Wire up observability
-			if obs.logger != nil {
-				client.SetLogger(obs.logger)
-			}
-			if obs.metrics != nil {
-				client.SetMetrics(obs.metrics)
-			}
-			if obs.pricing != nil {
-				client.SetPricing(obs.pricing)
-			}
-			providers["anthropic"] = anthropic.NewProvider(model, client)
+			providers["anthropic"] = newReviewProvider("anthropic", model, cfg, httpConfig, obs)
 		}
 	}
 
@@ -578,22 +704,10 @@ func buildProviders(providersConfig map[string]config.ProviderConfig, httpConfig
 			model = "gemini-1.5-pro"
 		}
 		// Use real HTTP client if API key is provided
-		apiKey := cfg.APIKey
-		if apiKey == "" {
+		if cfg.APIKey == "" {
 			log.Println("Gemini: No API key provided, skipping provider")
 		} else {
-			client := gemini.NewHTTPClient(apiKey, model, cfg, httpConfig)
-			// Wire up observability
-			if obs.logger != nil {
-				client.SetLogger(obs.logger)
-			}
-			if obs.metrics != nil {
-				client.SetMetrics(obs.metrics)
-			}
-			if obs.pricing != nil {
-				client.SetPricing(obs.pricing)
-			}
-			providers["gemini"] = gemini.NewProvider(model, client)
+			providers["gemini"] = newReviewProvider("gemini", model, cfg, httpConfig, obs)
 		}
 	}
 
@@ -603,12 +717,105 @@ func buildProviders(providersConfig map[string]config.ProviderConfig, httpConfig
 		if model == "" {
 			model = "codellama"
 		}
+		providers["ollama"] = newReviewProvider("ollama", model, cfg, httpConfig, obs)
+	}
+
+	// Static provider (for testing)
+	if cfg, ok := providersConfig["static"]; ok && isProviderEnabled(cfg) {
+		model := cfg.Model
+		if model == "" {
+			model = "static-model"
+		}
+		providers["static"] = static.NewProvider(model)
+	}
+
+	return providers
+}
+
+// newReviewProvider creates the review provider for a model, falling back
+// to the provider's fallbackModels in order when the model fails.
+func newReviewProvider(name, model string, cfg config.ProviderConfig, httpConfig config.HTTPConfig, obs observabilityComponents) review.Provider {
+	primary := newHTTPProvider(name, model, cfg, httpConfig, obs)
+	if len(cfg.FallbackModels) == 0 {
+		return primary
+	}
+
+	chain := []llm.ModelProvider{{Model: model, Provider: primary}}
+	seen := map[string]bool{model: true}
+	for _, fallback := range cfg.FallbackModels {
+		if fallback == "" || seen[fallback] {
+			continue
+		}
+		seen[fallback] = true
+		chain = append(chain, llm.ModelProvider{
+			Model:    fallback,
+			Provider: newHTTPProvider(name, fallback, cfg, httpConfig, obs),
+		})
+	}
+	if len(chain) == 1 {
+		return primary
+	}
+	return llm.NewFallbackProvider(name, chain...)
+}
+
+// newHTTPProvider creates a review provider for one model of an HTTP
+// provider (openai, anthropic, gemini or ollama), wired to the shared
+// observability components and rate limiter.
+func newHTTPProvider(name, model string, cfg config.ProviderConfig, httpConfig config.HTTPConfig, obs observabilityComponents) review.Provider {
+	switch name {
+	case "openai":
+		client := openai.NewHTTPClient(cfg.APIKey, model, cfg, httpConfig)
+		client.SetGuard(obs.guards.For("openai", cfg, httpConfig))
+		// Wire up observability
+		if obs.logger != nil {
+			client.SetLogger(obs.logger)
+		}
+		if obs.metrics != nil {
+			client.SetMetrics(obs.metrics)
+		}
+		if obs.pricing != nil {
+			client.SetPricing(obs.pricing)
+		}
+		return openai.NewProvider(model, client)
+
+	case "anthropic":
+		client := anthropic.NewHTTPClient(cfg.APIKey, model, cfg, httpConfig)
+		client.SetGuard(obs.guards.For("anthropic", cfg, httpConfig))
+		// Wire up observability
+		if obs.logger != nil {
+			client.SetLogger(obs.logger)
+		}
+		if obs.metrics != nil {
+			client.SetMetrics(obs.metrics)
+		}
+		if obs.pricing != nil {
+			client.SetPricing(obs.pricing)
+		}
+		return anthropic.NewProvider(model, client)
+
+	case "gemini":
+		client := gemini.NewHTTPClient(cfg.APIKey, model, cfg, httpConfig)
+		client.SetGuard(obs.guards.For("gemini", cfg, httpConfig))
+		// Wire up observability
+		if obs.logger != nil {
+			client.SetLogger(obs.logger)
+		}
+		if obs.metrics != nil {
+			client.SetMetrics(obs.metrics)
+		}
+		if obs.pricing != nil {
+			client.SetPricing(obs.pricing)
+		}
+		return gemini.NewProvider(model, client)
+
+	case "ollama":
 		// Use configured host or default to localhost
 		host := os.Getenv("OLLAMA_HOST")
 		if host == "" {
 			host = "http://localhost:11434"
 		}
 		client := ollama.NewHTTPClient(host, model, cfg, httpConfig)
+		client.SetGuard(obs.guards.For("ollama", cfg, httpConfig))
 		// Wire up observability
 		if obs.logger != nil {
 			client.SetLogger(obs.logger)
@@ -619,19 +826,11 @@ func buildProviders(providersConfig map[string]config.ProviderConfig, httpConfig
 		if obs.pricing != nil {
 			client.SetPricing(obs.pricing)
 		}
-		providers["ollama"] = ollama.NewProvider(model, client)
-	}
+		return ollama.NewProvider(model, client)
 
-	// Static provider (for testing)
-	if cfg, ok := providersConfig["static"]; ok && isProviderEnabled(cfg) {
-		model := cfg.Model
-		if model == "" {
-			model = "static-model"
-		}
-		providers["static"] = static.NewProvider(model)
+	default:
+		return nil
 	}
-
-	return providers
 }
 
 // providerWrapper adapts review.Provider to merge.ReviewProvider.
@@ -641,13 +840,28 @@ type providerWrapper struct {
 }
 
 func (w *providerWrapper) Review(ctx context.Context, req merge.ProviderRequest) (domain.Review, error) {
+	ctx, span := observability.StartSpan(ctx, "review.synthesis", nil)
+	defer span.End()
+
 	// Convert merge.ProviderRequest to review.ProviderRequest
 	reviewReq := review.ProviderRequest{
 		Prompt:  req.Prompt,
 		Seed:    req.Seed,
 		MaxSize: req.MaxSize,
 	}
-	return w.provider.Review(ctx, reviewReq)
+	result, err := w.provider.Review(ctx, reviewReq)
+	if err != nil {
+		span.RecordError(err)
+		return result, err
+	}
+	span.SetAttributes(map[string]interface{}{
+		"provider":  result.ProviderName,
+		"model":     result.ModelName,
+		"tokensIn":  result.TokensIn,
+		"tokensOut": result.TokensOut,
+		"cost":      result.Cost,
+	})
+	return result, nil
 }
 
 // Compile-time interface compliance checks
@@ -662,16 +876,60 @@ var _ review.MarkdownWriter = (*markdown.Writer)(nil)
 var _ review.JSONWriter = (*json.Writer)(nil)
 var _ review.SARIFWriter = (*sarif.Writer)(nil)
 var _ review.Redactor = (*redaction.Engine)(nil)
-var _ review.GitHubPoster = (*githubPosterAdapter)(nil)
+var _ review.ReviewPublisher = (*githubPublisherAdapter)(nil)
+var _ review.ReviewPublisher = (*gitlabPublisherAdapter)(nil)
+var _ review.ReviewPublisher = (*giteaPublisherAdapter)(nil)
+var _ review.ReviewPublisher = (*bitbucketPublisherAdapter)(nil)
+var _ review.ReviewPublisher = platformPublisher(nil)
+var _ verifyadapter.ToolCallingClient = (*openaiLLMAdapter)(nil)
+var _ verifyadapter.ToolCallingClient = (*anthropicLLMAdapter)(nil)
+var _ verifyadapter.ToolCallingClient = (*geminiLLMAdapter)(nil)
+
+// platformPublisher routes a publish request to the publisher for its code host.
+// Only platforms with a configured token are present.
+type platformPublisher map[string]review.ReviewPublisher
+
+// PublishReview implements review.ReviewPublisher.
+func (p platformPublisher) PublishReview(ctx context.Context, req review.PublishRequest) (*review.PublishResult, error) {
+	platform := strings.ToLower(req.Platform)
+	if platform == "" {
+		platform = "github"
+	}
+	publisher, ok := p[platform]
+	if !ok {
+		return nil, fmt.Errorf("no %s token configured (set %s_TOKEN)", platform, strings.ToUpper(platform))
+	}
+	return publisher.PublishReview(ctx, req)
+}
+
+// githubAPIURL returns the GitHub API base URL for GitHub Enterprise Server.
+// github.apiURL takes precedence over GITHUB_API_URL, which GitHub Actions sets
+// automatically; empty means api.github.com.
+func githubAPIURL(cfg config.GitHubConfig) string {
+	if cfg.APIURL != "" {
+		return cfg.APIURL
+	}
+	return os.Getenv("GITHUB_API_URL")
+}
 
-// githubPosterAdapter bridges review.GitHubPoster to the underlying GitHub client.
+// gitlabAPIURL returns the GitLab API base URL for self-managed instances.
+// GITLAB_API_URL takes precedence over CI_API_V4_URL, which GitLab CI sets
+// automatically; empty means gitlab.com.
+func gitlabAPIURL() string {
+	if apiURL := os.Getenv("GITLAB_API_URL"); apiURL != "" {
+		return apiURL
+	}
+	return os.Getenv("CI_API_V4_URL")
+}
+
+// githubPublisherAdapter bridges review.ReviewPublisher to the underlying GitHub client.
 // It handles diff position calculation and maps between usecase types.
-type githubPosterAdapter struct {
+type githubPublisherAdapter struct {
 	poster *usecasegithub.ReviewPoster
 }
 
-// PostReview implements review.GitHubPoster.
-func (a *githubPosterAdapter) PostReview(ctx context.Context, req review.GitHubPostRequest) (*review.GitHubPostResult, error) {
+// PublishReview implements review.ReviewPublisher.
+func (a *githubPublisherAdapter) PublishReview(ctx context.Context, req review.PublishRequest) (*review.PublishResult, error) {
 	// Map findings to positioned findings with diff positions
 	positionedFindings := githubadapter.MapFindings(req.Review.Findings, req.Diff)
 
@@ -684,6 +942,7 @@ func (a *githubPosterAdapter) PostReview(ctx context.Context, req review.GitHubP
 		OnClean:               req.ActionOnClean,
 		OnNonBlocking:         req.ActionOnNonBlocking,
 		AlwaysBlockCategories: req.AlwaysBlockCategories,
+		PathOverrides:         pathOverridesFromPolicies(req.PathPolicies),
 	}
 
 	// Build programmatic summary (replaces LLM-generated summary)
@@ -722,21 +981,235 @@ func (a *githubPosterAdapter) PostReview(ctx context.Context, req review.GitHubP
 		return nil, err
 	}
 
-	return &review.GitHubPostResult{
-		ReviewID:        result.ReviewID,
-		CommentsPosted:  result.CommentsPosted,
-		CommentsSkipped: result.CommentsSkipped,
-		HTMLURL:         result.HTMLURL,
+	return &review.PublishResult{
+		ReviewID:          strconv.FormatInt(result.ReviewID, 10),
+		CommentsPosted:    result.CommentsPosted,
+		CommentsSkipped:   result.CommentsSkipped,
+		HTMLURL:           result.HTMLURL,
+		DuplicatesSkipped: result.DuplicatesSkipped + result.SemanticDuplicatesSkipped,
+	}, nil
+}
+
+// reviewActionsFromRequest builds the per-severity review action config from a publish request.
+func reviewActionsFromRequest(req review.PublishRequest) githubadapter.ReviewActions {
+	return githubadapter.ReviewActions{
+		OnCritical:            req.ActionOnCritical,
+		OnHigh:                req.ActionOnHigh,
+		OnMedium:              req.ActionOnMedium,
+		OnLow:                 req.ActionOnLow,
+		OnClean:               req.ActionOnClean,
+		OnNonBlocking:         req.ActionOnNonBlocking,
+		AlwaysBlockCategories: req.AlwaysBlockCategories,
+		PathOverrides:         pathOverridesFromPolicies(req.PathPolicies),
+	}
+}
+
+// programmaticReview replaces the LLM summary with the programmatic summary used
+// on GitHub so every platform reports identical content; only inline positions
+// differ. Diff positions are used for summary bucketing only.
+func programmaticReview(req review.PublishRequest, actions githubadapter.ReviewActions) domain.Review {
+	summaryFindings := githubadapter.MapFindings(req.Review.Findings, req.Diff)
+	programmaticSummary := githubadapter.BuildProgrammaticSummary(summaryFindings, req.Diff, actions)
+	appendix := githubadapter.BuildSummaryAppendix(summaryFindings, req.Diff)
+
+	return domain.Review{
+		ProviderName: req.Review.ProviderName,
+		ModelName:    req.Review.ModelName,
+		Summary:      githubadapter.AppendSections(programmaticSummary, appendix),
+		Findings:     req.Review.Findings,
+		Cost:         req.Review.Cost,
+	}
+}
+
+// gitlabPublisherAdapter bridges review.ReviewPublisher to the GitLab merge request poster.
+type gitlabPublisherAdapter struct {
+	poster *usecasegitlab.ReviewPoster
+}
+
+// PublishReview implements review.ReviewPublisher.
+func (a *gitlabPublisherAdapter) PublishReview(ctx context.Context, req review.PublishRequest) (*review.PublishResult, error) {
+	reviewActions := reviewActionsFromRequest(req)
+
+	project := req.Repo
+	if req.Owner != "" {
+		project = req.Owner + "/" + req.Repo
+	}
+
+	result, err := a.poster.PostReview(ctx, usecasegitlab.PostReviewRequest{
+		Project:         project,
+		MergeRequestIID: req.PRNumber,
+		CommitSHA:       req.CommitSHA,
+		Review:          programmaticReview(req, reviewActions),
+		Findings:        gitlabadapter.MapFindings(req.Review.Findings, req.Diff),
+		ReviewActions:   reviewActions,
+		BotUsername:     req.BotUsername,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &review.PublishResult{
+		ReviewID:          result.SummaryDiscussionID,
+		CommentsPosted:    result.CommentsPosted,
+		CommentsSkipped:   result.CommentsSkipped,
+		HTMLURL:           result.HTMLURL,
+		DuplicatesSkipped: result.DuplicatesSkipped,
+	}, nil
+}
+
+// giteaPublisherAdapter bridges review.ReviewPublisher to the Gitea pull request poster.
+type giteaPublisherAdapter struct {
+	poster *usecasegitea.ReviewPoster
+}
+
+// PublishReview implements review.ReviewPublisher.
+func (a *giteaPublisherAdapter) PublishReview(ctx context.Context, req review.PublishRequest) (*review.PublishResult, error) {
+	reviewActions := reviewActionsFromRequest(req)
+
+	result, err := a.poster.PostReview(ctx, usecasegitea.PostReviewRequest{
+		Owner:         req.Owner,
+		Repo:          req.Repo,
+		PullNumber:    req.PRNumber,
+		CommitSHA:     req.CommitSHA,
+		Review:        programmaticReview(req, reviewActions),
+		Findings:      giteaadapter.MapFindings(req.Review.Findings, req.Diff),
+		ReviewActions: reviewActions,
+		BotUsername:   req.BotUsername,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &review.PublishResult{
+		ReviewID:          strconv.FormatInt(result.ReviewID, 10),
+		CommentsPosted:    result.CommentsPosted,
+		CommentsSkipped:   result.CommentsSkipped,
+		HTMLURL:           result.HTMLURL,
+		DuplicatesSkipped: result.DuplicatesSkipped,
 	}, nil
 }
 
-// createVerifier creates a batch verifier using the configured LLM provider.
+// bitbucketPublisherAdapter bridges review.ReviewPublisher to the Bitbucket Cloud
+// pull request poster. Owner is the workspace and Repo the repository slug.
+type bitbucketPublisherAdapter struct {
+	poster *usecasebitbucket.ReviewPoster
+}
+
+// PublishReview implements review.ReviewPublisher.
+func (a *bitbucketPublisherAdapter) PublishReview(ctx context.Context, req review.PublishRequest) (*review.PublishResult, error) {
+	reviewActions := reviewActionsFromRequest(req)
+
+	result, err := a.poster.PostReview(ctx, usecasebitbucket.PostReviewRequest{
+		Workspace:     req.Owner,
+		RepoSlug:      req.Repo,
+		PullRequestID: req.PRNumber,
+		CommitSHA:     req.CommitSHA,
+		Review:        programmaticReview(req, reviewActions),
+		Findings:      bitbucketadapter.MapFindings(req.Review.Findings, req.Diff),
+		ReviewActions: reviewActions,
+		BotUsername:   req.BotUsername,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &review.PublishResult{
+		ReviewID:          strconv.FormatInt(result.SummaryCommentID, 10),
+		CommentsPosted:    result.CommentsPosted,
+		CommentsSkipped:   result.CommentsSkipped,
+		HTMLURL:           result.HTMLURL,
+		DuplicatesSkipped: result.DuplicatesSkipped,
+	}, nil
+}
+
+// createVerifier creates a depth-selecting verifier (batch, agent, or hybrid) using the configured LLM provider.
 // Uses verification.provider and verification.model from config, with fallback to other providers.
 // Returns nil if no suitable provider is available.
 func createVerifier(cfg config.Config, providers map[string]review.Provider, repoDir string, obs observabilityComponents) review.Verifier {
-	var llmClient verifyadapter.LLMClient
-	var providerName string
-	var modelName string
+	llmClient, providerName, modelName, maxTokens := createVerificationLLM(cfg, obs)
+	if llmClient == nil {
+		log.Println("Verification disabled: no suitable LLM provider available")
+		return nil
+	}
+
+	depth := cfg.Verification.Depth
+	if depth == "" {
+		depth = "medium"
+	}
+	log.Printf("Verification enabled using %s/%s (%s mode, maxTokens=%d)", providerName, modelName, domain.VerificationModeForDepth(depth), maxTokens)
+
+	// Create repository adapter for file access
+	repo := repository.NewLocalRepository(repoDir)
+
+	// Create cost tracker with configured ceiling
+	costTracker := usecaseverify.NewCostTracker(cfg.Verification.CostCeiling)
+
+	confidence := config.ConfidenceThresholds{
+		Default:  cfg.Verification.Confidence.Default,
+		Critical: cfg.Verification.Confidence.Critical,
+		High:     cfg.Verification.Confidence.High,
+		Medium:   cfg.Verification.Confidence.Medium,
+		Low:      cfg.Verification.Confidence.Low,
+	}
+
+	// Batch verifier for minimal depth and the first pass of medium depth
+	batchVerifier := verifyadapter.NewBatchVerifier(llmClient, repo, costTracker, verifyadapter.BatchConfig{
+		Confidence: confidence,
+	})
+
+	// Agent verifiers: medium escalates a few findings, thorough investigates
+	// every finding and gets more tool iterations
+	escalationConfig := verifyadapter.DefaultAgentConfig()
+	escalationConfig.Confidence = confidence
+	escalationConfig.Depth = "medium"
+	if testTool := createTestTool(cfg.Verification.TestExecution, repoDir); testTool != nil {
+		escalationConfig.ExtraTools = []verifyadapter.Tool{testTool}
+	}
+	thoroughConfig := escalationConfig
+	thoroughConfig.MaxIterations = 20
+	thoroughConfig.Depth = "thorough"
+
+	return verifyadapter.NewDepthVerifier(
+		batchVerifier,
+		verifyadapter.NewAgentVerifier(llmClient, repo, costTracker, escalationConfig),
+		verifyadapter.NewAgentVerifier(llmClient, repo, costTracker, thoroughConfig),
+		verifyadapter.DepthConfig{Depth: depth, Confidence: confidence},
+	)
+}
+
+// createTestTool builds the sandboxed test tool when test execution is enabled.
+// Returns nil if it is disabled or no sandbox is available on this host.
+func createTestTool(cfg config.TestExecutionConfig, repoDir string) verifyadapter.Tool {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	sandboxConfig := verifyadapter.SandboxConfig{
+		MemoryMB:   cfg.MemoryMB,
+		CPUSeconds: cfg.CPUSeconds,
+	}
+	if cfg.Timeout != "" {
+		if parsed, err := time.ParseDuration(cfg.Timeout); err == nil {
+			sandboxConfig.Timeout = parsed
+		} else {
+			log.Printf("warning: invalid verification.testExecution.timeout %q, using default", cfg.Timeout)
+		}
+	}
+	sandbox := verifyadapter.NewSandbox(sandboxConfig)
+
+	isolation, err := sandbox.Isolation()
+	if err != nil {
+		log.Printf("Test execution disabled: %v", err)
+		return nil
+	}
+	log.Printf("Test execution enabled (sandbox: %s, timeout=%s)", isolation, sandbox.Timeout())
+	return verifyadapter.NewTestTool(repoDir, sandbox)
+}
+
+// createVerificationLLM selects the LLM used for verification and other
+// lightweight calls: the configured verification provider first, then
+// gemini, anthropic and openai. Returns a nil client if none is usable.
+func createVerificationLLM(cfg config.Config, obs observabilityComponents) (llmClient verifyadapter.LLMClient, providerName, modelName string, maxTokens int) {
 
 	// Get configured provider and model, with defaults
 	configuredProvider := cfg.Verification.Provider
@@ -749,7 +1222,7 @@ func createVerifier(cfg config.Config, providers map[string]review.Provider, rep
 	}
 
 	// MaxTokens from config, default to 64000
-	maxTokens := cfg.Verification.MaxTokens
+	maxTokens = cfg.Verification.MaxTokens
 	if maxTokens == 0 {
 		maxTokens = 64000
 	}
@@ -782,18 +1255,21 @@ func createVerifier(cfg config.Config, providers map[string]review.Provider, rep
 		switch name {
 		case "gemini":
 			client := gemini.NewHTTPClient(providerCfg.APIKey, model, providerCfg, cfg.HTTP)
+			client.SetGuard(obs.guards.For("gemini", providerCfg, cfg.HTTP))
 			if obs.logger != nil {
 				client.SetLogger(obs.logger)
 			}
 			llmClient = &geminiLLMAdapter{client: client, maxTokens: maxTokens}
 		case "anthropic":
 			client := anthropic.NewHTTPClient(providerCfg.APIKey, model, providerCfg, cfg.HTTP)
+			client.SetGuard(obs.guards.For("anthropic", providerCfg, cfg.HTTP))
 			if obs.logger != nil {
 				client.SetLogger(obs.logger)
 			}
 			llmClient = &anthropicLLMAdapter{client: client, maxTokens: maxTokens}
 		case "openai":
 			client := openai.NewHTTPClient(providerCfg.APIKey, model, providerCfg, cfg.HTTP)
+			client.SetGuard(obs.guards.For("openai", providerCfg, cfg.HTTP))
 			if obs.logger != nil {
 				client.SetLogger(obs.logger)
 			}
@@ -807,31 +1283,7 @@ func createVerifier(cfg config.Config, providers map[string]review.Provider, rep
 		}
 	}
 
-	if llmClient == nil {
-		log.Println("Verification disabled: no suitable LLM provider available")
-		return nil
-	}
-
-	log.Printf("Verification enabled using %s/%s (batch mode, maxTokens=%d)", providerName, modelName, maxTokens)
-
-	// Create repository adapter for file access
-	repo := repository.NewLocalRepository(repoDir)
-
-	// Create cost tracker with configured ceiling
-	costTracker := usecaseverify.NewCostTracker(cfg.Verification.CostCeiling)
-
-	// Build batch config from verification settings
-	batchConfig := verifyadapter.BatchConfig{
-		Confidence: config.ConfidenceThresholds{
-			Default:  cfg.Verification.Confidence.Default,
-			Critical: cfg.Verification.Confidence.Critical,
-			High:     cfg.Verification.Confidence.High,
-			Medium:   cfg.Verification.Confidence.Medium,
-			Low:      cfg.Verification.Confidence.Low,
-		},
-	}
-
-	return verifyadapter.NewBatchVerifier(llmClient, repo, costTracker, batchConfig)
+	return llmClient, providerName, modelName, maxTokens
 }
 
 // defaultVerificationModel returns a default model for verification when provider's model is empty.
@@ -860,6 +1312,112 @@ func buildProviderMaxTokens(providers map[string]config.ProviderConfig) map[stri
 	return result
 }
 
+// buildContextConfig converts the context config section to review context rules.
+func buildContextConfig(cfg config.ContextConfig) *review.ContextConfig {
+	contextConfig := review.DefaultContextConfig()
+	if len(cfg.DesignDocs) > 0 {
+		contextConfig.DesignDocsGlobs = cfg.DesignDocs
+	}
+	contextConfig.DisableBuiltinRules = cfg.DisableBuiltinRules
+	contextConfig.DirectoryContext = cfg.IsDirectoryContextEnabled()
+	for _, rule := range cfg.Rules {
+		contextConfig.Rules = append(contextConfig.Rules, review.ContextRule{
+			Paths:        rule.Paths,
+			Tags:         rule.Tags,
+			Docs:         rule.Docs,
+			Instructions: rule.Instructions,
+		})
+	}
+	if len(cfg.Tags) > 0 {
+		contextConfig.Tags = make(map[string]review.ContextTag, len(cfg.Tags))
+		for tag, value := range cfg.Tags {
+			contextConfig.Tags[tag] = review.ContextTag{Docs: value.Docs, Instructions: value.Instructions}
+		}
+	}
+	return &contextConfig
+}
+
+// pathPolicyLoader reads .cr/rules.yaml at a ref through git. Rules come
+// from the review's base, not the checked-out change, so a pull request
+// cannot ignore or downgrade findings in the files it touches.
+func pathPolicyLoader(engine *git.Engine) review.PathPolicyLoader {
+	return func(ctx context.Context, ref string) (review.PathPolicies, error) {
+		if ref == "" {
+			ref = "HEAD"
+		}
+		content, err := engine.ReadFileAtRef(ctx, ref, config.RulesFile)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rules, err := config.ParsePathRules(content)
+		if err != nil {
+			return nil, err
+		}
+		return buildPathPolicies(rules), nil
+	}
+}
+
+// buildPathPolicies converts the rules from .cr/rules.yaml to review path policies.
+func buildPathPolicies(rules config.PathRules) review.PathPolicies {
+	var policies review.PathPolicies
+	for _, rule := range rules.Rules {
+		policies = append(policies, review.PathPolicy{
+			Paths:                 rule.Paths,
+			Ignore:                rule.Ignore,
+			Instructions:          rule.Instructions,
+			MinSeverity:           rule.MinSeverity,
+			MaxSeverity:           rule.MaxSeverity,
+			ActionOnCritical:      rule.Actions.OnCritical,
+			ActionOnHigh:          rule.Actions.OnHigh,
+			ActionOnMedium:        rule.Actions.OnMedium,
+			ActionOnLow:           rule.Actions.OnLow,
+			AlwaysBlockCategories: rule.AlwaysBlockCategories,
+		})
+	}
+	return policies
+}
+
+// pathOverridesFromPolicies builds the per-path blocking overrides for the review event.
+func pathOverridesFromPolicies(policies review.PathPolicies) []githubadapter.PathReviewActions {
+	var overrides []githubadapter.PathReviewActions
+	for _, policy := range policies {
+		overrides = append(overrides, githubadapter.PathReviewActions{
+			Paths:                 policy.Paths,
+			OnCritical:            policy.ActionOnCritical,
+			OnHigh:                policy.ActionOnHigh,
+			OnMedium:              policy.ActionOnMedium,
+			OnLow:                 policy.ActionOnLow,
+			AlwaysBlockCategories: policy.AlwaysBlockCategories,
+		})
+	}
+	return overrides
+}
+
+// callSiteFinderAdapter adapts codenav.Index to review.CallSiteFinder.
+type callSiteFinderAdapter struct {
+	index *codenav.Index
+}
+
+func (a *callSiteFinderAdapter) CallSites(file string, line int) (string, []review.CallSite, error) {
+	def, refs, err := a.index.ReferencesAt(file, line)
+	if err != nil {
+		return "", nil, err
+	}
+	sites := make([]review.CallSite, 0, len(refs))
+	for _, ref := range refs {
+		sites = append(sites, review.CallSite{
+			File:      ref.File,
+			Line:      ref.Line,
+			Enclosing: ref.Enclosing,
+			Content:   ref.Content,
+		})
+	}
+	return def.Symbol, sites, nil
+}
+
 // isProviderEnabled checks if a provider should be used based on its configuration.
 // The logic handles three cases for the Enabled field:
 //   - nil (not set): provider is enabled if it has an API key (backward compatible)
@@ -905,6 +1463,18 @@ func (a *openaiLLMAdapter) Call(ctx context.Context, systemPrompt, userPrompt st
 	return resp.Text, resp.TokensIn, resp.TokensOut, resp.Cost, nil
 }
 
+func (a *openaiLLMAdapter) CallWithTools(ctx context.Context, systemPrompt string, messages []llm.ChatMessage, tools []llm.ToolDefinition) (llm.ChatResponse, error) {
+	resp, err := a.client.Chat(ctx, messages, tools, openai.CallOptions{
+		System:      systemPrompt,
+		Temperature: 0.0,
+		MaxTokens:   a.maxTokens,
+	})
+	if err != nil {
+		return llm.ChatResponse{}, err
+	}
+	return *resp, nil
+}
+
 // anthropicLLMAdapter adapts anthropic.HTTPClient to verifyadapter.LLMClient.
 type anthropicLLMAdapter struct {
 	client    *anthropic.HTTPClient
@@ -923,6 +1493,18 @@ func (a *anthropicLLMAdapter) Call(ctx context.Context, systemPrompt, userPrompt
 	return resp.Text, resp.TokensIn, resp.TokensOut, resp.Cost, nil
 }
 
+func (a *anthropicLLMAdapter) CallWithTools(ctx context.Context, systemPrompt string, messages []llm.ChatMessage, tools []llm.ToolDefinition) (llm.ChatResponse, error) {
+	resp, err := a.client.Chat(ctx, messages, tools, anthropic.CallOptions{
+		System:      systemPrompt,
+		Temperature: 0.0,
+		MaxTokens:   a.maxTokens,
+	})
+	if err != nil {
+		return llm.ChatResponse{}, err
+	}
+	return *resp, nil
+}
+
 // geminiLLMAdapter adapts gemini.HTTPClient to verifyadapter.LLMClient.
 type geminiLLMAdapter struct {
 	client    *gemini.HTTPClient
@@ -941,3 +1523,15 @@ func (a *geminiLLMAdapter) Call(ctx context.Context, systemPrompt, userPrompt st
 	}
 	return resp.Text, resp.TokensIn, resp.TokensOut, resp.Cost, nil
 }
+
+func (a *geminiLLMAdapter) CallWithTools(ctx context.Context, systemPrompt string, messages []llm.ChatMessage, tools []llm.ToolDefinition) (llm.ChatResponse, error) {
+	resp, err := a.client.Chat(ctx, messages, tools, gemini.CallOptions{
+		Temperature:       0.0,
+		MaxTokens:         a.maxTokens,
+		SystemInstruction: &systemPrompt,
+	})
+	if err != nil {
+		return llm.ChatResponse{}, err
+	}
+	return *resp, nil
+}
@@ -2,10 +2,21 @@ package main
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 
+	goGit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/git"
+	"github.com/bkyoung/code-reviewer/internal/adapter/llm"
 	"github.com/bkyoung/code-reviewer/internal/config"
 	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/merge"
 	"github.com/bkyoung/code-reviewer/internal/usecase/review"
 )
 
@@ -177,6 +188,30 @@ func TestCreatePlanningProvider(t *testing.T) {
 	}
 }
 
+func TestNewReviewProvider_FallbackModels(t *testing.T) {
+	tests := []struct {
+		name           string
+		fallbackModels []string
+		wantFallback   bool
+	}{
+		{name: "no fallback models", fallbackModels: nil, wantFallback: false},
+		{name: "fallback models configured", fallbackModels: []string{"claude-haiku-4-5"}, wantFallback: true},
+		{name: "only the primary model repeated", fallbackModels: []string{"claude-sonnet-4-5", ""}, wantFallback: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.ProviderConfig{APIKey: "test-key", FallbackModels: tt.fallbackModels}
+			got := newReviewProvider("anthropic", "claude-sonnet-4-5", cfg, config.HTTPConfig{}, observabilityComponents{})
+
+			_, isFallback := got.(*llm.FallbackProvider)
+			if isFallback != tt.wantFallback {
+				t.Errorf("newReviewProvider() = %T, want fallback provider: %v", got, tt.wantFallback)
+			}
+		})
+	}
+}
+
 func TestIsProviderEnabled(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -279,3 +314,103 @@ func TestIsProviderUsable(t *testing.T) {
 		})
 	}
 }
+
+// findingProvider reports a fixed finding.
+type findingProvider struct {
+	finding domain.Finding
+}
+
+func (p *findingProvider) Review(ctx context.Context, req review.ProviderRequest) (domain.Review, error) {
+	return domain.Review{ProviderName: "test", ModelName: "test", Findings: []domain.Finding{p.finding}}, nil
+}
+
+func (p *findingProvider) EstimateTokens(text string) int { return len(text) / 4 }
+
+func TestPathPolicyLoader_IgnoresRulesChangedInTheReviewedBranch(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := goGit.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("init repo: %v", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("worktree: %v", err)
+	}
+	commit := func(files map[string]string) {
+		t.Helper()
+		for name, content := range files {
+			path := filepath.Join(dir, name)
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := worktree.Add(name); err != nil {
+				t.Fatalf("add %s: %v", name, err)
+			}
+		}
+		if _, err := worktree.Commit("change", &goGit.CommitOptions{
+			Author: &object.Signature{Name: "Test", Email: "test@example.com", When: time.Unix(0, 0)},
+		}); err != nil {
+			t.Fatalf("commit: %v", err)
+		}
+	}
+
+	// The base caps test files at medium; the pull request tries to skip
+	// review of main.go and to cap everything at low
+	commit(map[string]string{
+		"main.go":        "package main\n",
+		config.RulesFile: "rules:\n  - paths: [\"*_test.go\"]\n    maxSeverity: medium\n",
+	})
+	if err := worktree.Checkout(&goGit.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("feature"), Create: true}); err != nil {
+		t.Fatalf("checkout: %v", err)
+	}
+	commit(map[string]string{
+		"main.go":        "package main\n\nfunc main() { panic(nil) }\n",
+		config.RulesFile: "rules:\n  - paths: [\"main.go\"]\n    ignore: true\n  - paths: [\"**\"]\n    maxSeverity: low\n",
+	})
+
+	engine := git.NewEngine(dir)
+	var reviewed []string
+	provider := &findingProvider{finding: domain.Finding{File: "main.go", LineStart: 3, Severity: "critical", Category: "bug", Description: "panics"}}
+	orchestrator := review.NewOrchestrator(review.OrchestratorDeps{
+		Git:           engine,
+		Providers:     map[string]review.Provider{"test": provider},
+		Merger:        merge.NewService(),
+		PathPolicies:  pathPolicyLoader(engine),
+		SeedGenerator: func(_, _ string) uint64 { return 1 },
+		PromptBuilder: func(ctx review.ProjectContext, d domain.Diff, req review.BranchRequest, providerName string) (review.ProviderRequest, error) {
+			for _, file := range d.Files {
+				reviewed = append(reviewed, file.Path)
+			}
+			return review.ProviderRequest{Prompt: "prompt"}, nil
+		},
+	})
+
+	result, err := orchestrator.ReviewBranch(context.Background(), review.BranchRequest{
+		BaseRef:   "master",
+		TargetRef: "feature",
+		OutputDir: t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("ReviewBranch: %v", err)
+	}
+
+	if !slices.Contains(reviewed, "main.go") {
+		t.Errorf("expected main.go to be reviewed despite the branch's ignore rule, reviewed %v", reviewed)
+	}
+	merged := result.Reviews[len(result.Reviews)-1]
+	if len(merged.Findings) != 1 || merged.Findings[0].Severity != "critical" {
+		t.Errorf("expected the critical finding to keep its severity, got %+v", merged.Findings)
+	}
+
+	// The base's rules do apply
+	policies, err := pathPolicyLoader(engine)(context.Background(), "master")
+	if err != nil {
+		t.Fatalf("load base rules: %v", err)
+	}
+	if len(policies) != 1 || policies[0].MaxSeverity != "medium" {
+		t.Errorf("expected the base's test-file rule, got %+v", policies)
+	}
+}
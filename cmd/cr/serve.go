@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/cli"
+	"github.com/bkyoung/code-reviewer/internal/adapter/httpapi"
+	storeAdapter "github.com/bkyoung/code-reviewer/internal/adapter/store"
+	"github.com/bkyoung/code-reviewer/internal/adapter/store/sqlite"
+	"github.com/bkyoung/code-reviewer/internal/store"
+	"github.com/bkyoung/code-reviewer/internal/usecase/serve"
+)
+
+// shutdownTimeout bounds how long the server waits for open requests when
+// shutting down.
+const shutdownTimeout = 10 * time.Second
+
+// reviewServer runs the HTTP API of cr serve, keeping jobs in the SQLite
+// store.
+type reviewServer struct {
+	reviewer            serve.Reviewer
+	store               store.Store // The run history store, if open
+	storePath           string      // Opened for jobs when store is nil
+	repositoryAvailable bool
+}
+
+// Serve implements cli.ReviewServer.
+func (s *reviewServer) Serve(ctx context.Context, opts cli.ServeOptions) error {
+	// Jobs are persisted even when the run history is disabled
+	jobStore := s.store
+	if jobStore == nil {
+		if err := os.MkdirAll(filepath.Dir(s.storePath), 0755); err != nil {
+			return fmt.Errorf("failed to create store directory: %w", err)
+		}
+		opened, err := sqlite.NewStore(s.storePath)
+		if err != nil {
+			return fmt.Errorf("failed to initialize store: %w", err)
+		}
+		defer opened.Close()
+		jobStore = opened
+	}
+
+	listener, err := net.Listen("tcp", opts.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", opts.Addr, err)
+	}
+
+	// Canceling stops the queue: running reviews are canceled and run again
+	// on the next start
+	queueCtx, stopQueue := context.WithCancel(ctx)
+	defer stopQueue()
+	queue := serve.NewQueue(s.reviewer, storeAdapter.NewJobBridge(jobStore), serve.Options{
+		Concurrency: opts.Concurrency,
+		MaxQueued:   opts.MaxQueued,
+	})
+	if err := queue.Start(queueCtx); err != nil {
+		_ = listener.Close()
+		return err
+	}
+
+	server := &http.Server{
+		Handler: httpapi.NewHandler(queue, httpapi.Options{
+			Token:               opts.Token,
+			Defaults:            opts.Defaults,
+			RepositoryAvailable: s.repositoryAvailable,
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+	log.Printf("serving reviews on http://%s (concurrency %d)", listener.Addr(), opts.Concurrency)
+
+	select {
+	case err := <-serveErr:
+		stopQueue()
+		queue.Wait()
+		return fmt.Errorf("server failed: %w", err)
+	case <-ctx.Done():
+	}
+
+	// Stopping the queue first ends the event streams, which would
+	// otherwise hold the shutdown open
+	log.Printf("shutting down")
+	stopQueue()
+	queue.Wait()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to shut down server: %w", err)
+	}
+	return nil
+}
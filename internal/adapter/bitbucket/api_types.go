@@ -0,0 +1,119 @@
+package bitbucket
+
+// Bitbucket Cloud REST API 2.0 types.
+// See: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/
+
+// BuildState is the state of a commit build status.
+type BuildState string
+
+const (
+	// BuildSuccessful marks the build as passed.
+	BuildSuccessful BuildState = "SUCCESSFUL"
+
+	// BuildFailed marks the build as failed.
+	BuildFailed BuildState = "FAILED"
+
+	// BuildInProgress marks the build as running.
+	BuildInProgress BuildState = "INPROGRESS"
+
+	// BuildStopped marks the build as stopped.
+	BuildStopped BuildState = "STOPPED"
+)
+
+// User is the subset of a Bitbucket account returned by the API.
+type User struct {
+	UUID        string `json:"uuid"`
+	AccountID   string `json:"account_id"`
+	DisplayName string `json:"display_name"`
+	Nickname    string `json:"nickname"`
+}
+
+// Link is a single hypermedia link.
+type Link struct {
+	Href string `json:"href"`
+}
+
+// Links holds the links Bitbucket attaches to resources.
+type Links struct {
+	HTML Link `json:"html"`
+}
+
+// PullRequest is the subset of a pull request needed for publishing reviews.
+type PullRequest struct {
+	ID     int    `json:"id"`
+	Title  string `json:"title"`
+	Links  Links  `json:"links"`
+	Source struct {
+		Commit struct {
+			Hash string `json:"hash"`
+		} `json:"commit"`
+	} `json:"source"`
+}
+
+// Content is the body of a comment.
+type Content struct {
+	Raw string `json:"raw"`
+}
+
+// Inline anchors a comment to a file line. To is the line in the new file,
+// From the line in the old file; exactly one is set for a new comment.
+type Inline struct {
+	Path string `json:"path"`
+	To   *int   `json:"to,omitempty"`
+	From *int   `json:"from,omitempty"`
+}
+
+// CommentRef references another comment (used for replies).
+type CommentRef struct {
+	ID int64 `json:"id"`
+}
+
+// CommentResolution records that a comment thread was resolved.
+type CommentResolution struct {
+	Type string `json:"type"`
+	User User   `json:"user"`
+}
+
+// Comment is a pull request comment. Resolution is set once the thread has
+// been resolved.
+type Comment struct {
+	ID         int64              `json:"id"`
+	Content    Content            `json:"content"`
+	User       User               `json:"user"`
+	Inline     *Inline            `json:"inline,omitempty"`
+	Parent     *CommentRef        `json:"parent,omitempty"`
+	Deleted    bool               `json:"deleted"`
+	Resolution *CommentResolution `json:"resolution,omitempty"`
+	Links      Links              `json:"links"`
+}
+
+// CreateCommentRequest is the request body for creating a pull request comment.
+type CreateCommentRequest struct {
+	Content Content     `json:"content"`
+	Inline  *Inline     `json:"inline,omitempty"`
+	Parent  *CommentRef `json:"parent,omitempty"`
+}
+
+// commentsPage is one page of a paginated comment listing.
+type commentsPage struct {
+	Values []Comment `json:"values"`
+	Next   string    `json:"next"`
+}
+
+// BuildStatus is the request body for POST /repositories/{workspace}/{repo}/commit/{sha}/statuses/build.
+type BuildStatus struct {
+	Key         string     `json:"key"`
+	State       BuildState `json:"state"`
+	Name        string     `json:"name,omitempty"`
+	URL         string     `json:"url"`
+	Description string     `json:"description,omitempty"`
+}
+
+// BitbucketErrorResponse represents an error response from the Bitbucket API.
+type BitbucketErrorResponse struct {
+	Type  string `json:"type"`
+	Error struct {
+		Message string `json:"message"`
+		Detail  string `json:"detail"`
+	} `json:"error"`
+}
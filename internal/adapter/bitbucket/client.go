@@ -0,0 +1,368 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+)
+
+const (
+	defaultBaseURL        = "https://api.bitbucket.org/2.0"
+	defaultTimeout        = 30 * time.Second
+	defaultMaxRetries     = 3
+	defaultInitialBackoff = 2 * time.Second
+	maxPaginationPages    = 100 // Prevent infinite pagination loops
+)
+
+// validatePathSegment validates that a path segment (workspace, repo slug, SHA)
+// doesn't contain characters that could cause path injection attacks.
+func validatePathSegment(value, name string) error {
+	if strings.Contains(value, "..") {
+		return fmt.Errorf("invalid %s: must not contain '..'", name)
+	}
+	if strings.Contains(value, "/") {
+		return fmt.Errorf("invalid %s: must not contain '/'", name)
+	}
+	if value == "" {
+		return fmt.Errorf("invalid %s: must not be empty", name)
+	}
+	return nil
+}
+
+// Client is an HTTP client for the Bitbucket Cloud pull request and commit status APIs.
+type Client struct {
+	token      string
+	username   string
+	baseURL    string
+	httpClient *http.Client
+	retryConf  llmhttp.RetryConfig
+}
+
+// NewClient creates a new Bitbucket Cloud API client.
+// The token is sent as a bearer token (repository, project or workspace access
+// token) unless SetUsername is called, in which case it is used as an app password.
+func NewClient(token string) *Client {
+	return &Client{
+		token:      token,
+		baseURL:    defaultBaseURL,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		retryConf: llmhttp.RetryConfig{
+			MaxRetries:     defaultMaxRetries,
+			InitialBackoff: defaultInitialBackoff,
+			MaxBackoff:     32 * time.Second,
+			Multiplier:     2.0,
+		},
+	}
+}
+
+// SetUsername switches to HTTP basic auth with the token as an app password.
+func (c *Client) SetUsername(username string) {
+	c.username = username
+}
+
+// SetBaseURL sets a custom API base URL (primarily for testing).
+// All trailing slashes are trimmed to ensure consistent URL construction.
+func (c *Client) SetBaseURL(baseURL string) {
+	c.baseURL = strings.TrimRight(baseURL, "/")
+}
+
+// SetTimeout sets the HTTP timeout.
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.httpClient.Timeout = timeout
+}
+
+// SetMaxRetries sets the maximum number of retry attempts.
+func (c *Client) SetMaxRetries(maxRetries int) {
+	c.retryConf.MaxRetries = maxRetries
+}
+
+// SetInitialBackoff sets the initial backoff duration for retries.
+func (c *Client) SetInitialBackoff(backoff time.Duration) {
+	c.retryConf.InitialBackoff = backoff
+}
+
+// repoURL builds the base URL for a repository after validating and escaping
+// the workspace and repo slug.
+func (c *Client) repoURL(workspace, repoSlug string) (string, error) {
+	if err := validatePathSegment(workspace, "workspace"); err != nil {
+		return "", err
+	}
+	if err := validatePathSegment(repoSlug, "repo"); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/repositories/%s/%s",
+		c.baseURL, url.PathEscape(workspace), url.PathEscape(repoSlug)), nil
+}
+
+// pullRequestURL builds the base URL for a pull request resource.
+func (c *Client) pullRequestURL(workspace, repoSlug string, id int) (string, error) {
+	repoURL, err := c.repoURL(workspace, repoSlug)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/pullrequests/%d", repoURL, id), nil
+}
+
+// CurrentUser returns the account that owns the credentials.
+func (c *Client) CurrentUser(ctx context.Context) (*User, error) {
+	var user User
+	if err := c.doJSON(ctx, http.MethodGet, c.baseURL+"/user", nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetPullRequest fetches a pull request.
+func (c *Client) GetPullRequest(ctx context.Context, workspace, repoSlug string, id int) (*PullRequest, error) {
+	prURL, err := c.pullRequestURL(workspace, repoSlug, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if err := c.doJSON(ctx, http.MethodGet, prURL, nil, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// ListComments fetches all comments on a pull request, following the "next"
+// links Bitbucket returns. Each next link is validated against the base URL
+// before it is requested.
+func (c *Client) ListComments(ctx context.Context, workspace, repoSlug string, id int) ([]Comment, error) {
+	prURL, err := c.pullRequestURL(workspace, repoSlug, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []Comment
+	visitedURLs := make(map[string]bool)
+	nextURL := prURL + "/comments?pagelen=100"
+
+	for nextURL != "" {
+		if len(visitedURLs) >= maxPaginationPages {
+			return nil, fmt.Errorf("pagination limit exceeded (%d pages)", maxPaginationPages)
+		}
+		if visitedURLs[nextURL] {
+			return nil, fmt.Errorf("pagination loop detected: URL already visited")
+		}
+		visitedURLs[nextURL] = true
+
+		var page commentsPage
+		if err := c.doJSON(ctx, http.MethodGet, nextURL, nil, &page); err != nil {
+			return nil, err
+		}
+		all = append(all, page.Values...)
+
+		nextURL = ""
+		if page.Next != "" {
+			resolved, err := c.validatePaginationURL(page.Next)
+			if err != nil {
+				return nil, fmt.Errorf("unsafe pagination URL: %w", err)
+			}
+			nextURL = resolved
+		}
+	}
+
+	return all, nil
+}
+
+// validatePaginationURL checks that a "next" link stays on the configured API
+// host and scheme and points at a repositories endpoint, preventing SSRF via
+// a tampered response.
+func (c *Client) validatePaginationURL(rawURL string) (string, error) {
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL: %w", err)
+	}
+	if parsed.User != nil {
+		return "", fmt.Errorf("URL must not contain userinfo")
+	}
+	if !parsed.IsAbs() {
+		parsed = base.ResolveReference(parsed)
+	}
+	if parsed.Scheme != base.Scheme {
+		return "", fmt.Errorf("unexpected scheme: %s (expected %s)", parsed.Scheme, base.Scheme)
+	}
+	if parsed.Host != base.Host {
+		return "", fmt.Errorf("untrusted host: %s (expected %s)", parsed.Host, base.Host)
+	}
+	if !strings.Contains(parsed.Path, "/repositories/") {
+		return "", fmt.Errorf("unexpected API path: %s (must be a /repositories/ endpoint)", parsed.Path)
+	}
+
+	return parsed.String(), nil
+}
+
+// CreateComment posts a pull request comment. Inline comments carry an Inline
+// anchor; replies carry a Parent.
+func (c *Client) CreateComment(ctx context.Context, workspace, repoSlug string, id int, input CreateCommentRequest) (*Comment, error) {
+	prURL, err := c.pullRequestURL(workspace, repoSlug, id)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	var comment Comment
+	if err := c.doJSON(ctx, http.MethodPost, prURL+"/comments", jsonData, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// DeleteComment deletes a pull request comment.
+func (c *Client) DeleteComment(ctx context.Context, workspace, repoSlug string, id int, commentID int64) error {
+	prURL, err := c.pullRequestURL(workspace, repoSlug, id)
+	if err != nil {
+		return err
+	}
+	return c.doJSON(ctx, http.MethodDelete, fmt.Sprintf("%s/comments/%d", prURL, commentID), nil, nil)
+}
+
+// ResolveComment resolves a comment thread. Replies remain visible but the
+// thread is collapsed in the pull request view.
+func (c *Client) ResolveComment(ctx context.Context, workspace, repoSlug string, id int, commentID int64) error {
+	prURL, err := c.pullRequestURL(workspace, repoSlug, id)
+	if err != nil {
+		return err
+	}
+	return c.doJSON(ctx, http.MethodPost, fmt.Sprintf("%s/comments/%d/resolve", prURL, commentID), nil, nil)
+}
+
+// Approve approves the pull request as the token user.
+func (c *Client) Approve(ctx context.Context, workspace, repoSlug string, id int) error {
+	return c.prAction(ctx, http.MethodPost, workspace, repoSlug, id, "approve")
+}
+
+// Unapprove withdraws the token user's approval.
+func (c *Client) Unapprove(ctx context.Context, workspace, repoSlug string, id int) error {
+	return c.prAction(ctx, http.MethodDelete, workspace, repoSlug, id, "approve")
+}
+
+// RequestChanges marks the pull request as needing changes from the token user.
+func (c *Client) RequestChanges(ctx context.Context, workspace, repoSlug string, id int) error {
+	return c.prAction(ctx, http.MethodPost, workspace, repoSlug, id, "request-changes")
+}
+
+// RemoveRequestChanges withdraws the token user's change request.
+func (c *Client) RemoveRequestChanges(ctx context.Context, workspace, repoSlug string, id int) error {
+	return c.prAction(ctx, http.MethodDelete, workspace, repoSlug, id, "request-changes")
+}
+
+// prAction calls one of the body-less pull request participant endpoints.
+func (c *Client) prAction(ctx context.Context, method, workspace, repoSlug string, id int, action string) error {
+	prURL, err := c.pullRequestURL(workspace, repoSlug, id)
+	if err != nil {
+		return err
+	}
+	return c.doJSON(ctx, method, prURL+"/"+action, nil, nil)
+}
+
+// SetBuildStatus creates or updates the build status identified by status.Key
+// on a commit.
+func (c *Client) SetBuildStatus(ctx context.Context, workspace, repoSlug, commitSHA string, status BuildStatus) error {
+	repoURL, err := c.repoURL(workspace, repoSlug)
+	if err != nil {
+		return err
+	}
+	if err := validatePathSegment(commitSHA, "commit SHA"); err != nil {
+		return err
+	}
+
+	jsonData, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	statusURL := fmt.Sprintf("%s/commit/%s/statuses/build", repoURL, url.PathEscape(commitSHA))
+	return c.doJSON(ctx, http.MethodPost, statusURL, jsonData, nil)
+}
+
+// doJSON executes a request with retry, maps error responses to llmhttp.Error
+// and decodes a successful response into out (if non-nil).
+func (c *Client) doJSON(ctx context.Context, method, apiURL string, body []byte, out interface{}) error {
+	var resp *http.Response
+	err := llmhttp.RetryWithBackoff(ctx, func(ctx context.Context) error {
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+
+		req, reqErr := http.NewRequestWithContext(ctx, method, apiURL, reader)
+		if reqErr != nil {
+			return &llmhttp.Error{
+				Type:      llmhttp.ErrTypeUnknown,
+				Message:   reqErr.Error(),
+				Retryable: false,
+				Provider:  providerName,
+			}
+		}
+
+		if c.username != "" {
+			req.SetBasicAuth(c.username, c.token)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+		req.Header.Set("Accept", "application/json")
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		var callErr error
+		resp, callErr = c.httpClient.Do(req)
+		if callErr != nil {
+			return &llmhttp.Error{
+				Type:      llmhttp.ErrTypeTimeout,
+				Message:   callErr.Error(),
+				Retryable: true,
+				Provider:  providerName,
+			}
+		}
+
+		if resp.StatusCode >= 400 {
+			bodyBytes, readErr := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if readErr != nil {
+				return &llmhttp.Error{
+					Type:       llmhttp.ErrTypeUnknown,
+					Message:    fmt.Sprintf("HTTP %d (failed to read response: %v)", resp.StatusCode, readErr),
+					StatusCode: resp.StatusCode,
+					Retryable:  resp.StatusCode >= 500,
+					Provider:   providerName,
+				}
+			}
+			return MapHTTPError(resp.StatusCode, bodyBytes)
+		}
+
+		return nil
+	}, c.retryConf)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package bitbucket_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/bitbucket"
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(serverURL string) *bitbucket.Client {
+	client := bitbucket.NewClient("test-token")
+	client.SetBaseURL(serverURL + "/")
+	client.SetMaxRetries(0)
+	client.SetInitialBackoff(time.Millisecond)
+	return client
+}
+
+func TestNewClient(t *testing.T) {
+	require.NotNil(t, bitbucket.NewClient("test-token"))
+}
+
+func TestClient_Authentication(t *testing.T) {
+	var headers []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"uuid":"{bot}"}`)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	_, err := client.CurrentUser(context.Background())
+	require.NoError(t, err)
+
+	client.SetUsername("me")
+	_, err = client.CurrentUser(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "Bearer test-token", headers[0])
+	assert.Equal(t, "Basic bWU6dGVzdC10b2tlbg==", headers[1])
+}
+
+func TestClient_CreateInlineComment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/repositories/ws/repo/pullrequests/4/comments", r.URL.Path)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "finding", body["content"].(map[string]interface{})["raw"])
+		inline := body["inline"].(map[string]interface{})
+		assert.Equal(t, "main.go", inline["path"])
+		assert.Equal(t, float64(12), inline["to"])
+		_, hasFrom := inline["from"]
+		assert.False(t, hasFrom)
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":77}`)
+	}))
+	defer server.Close()
+
+	line := 12
+	comment, err := newTestClient(server.URL).CreateComment(context.Background(), "ws", "repo", 4, bitbucket.CreateCommentRequest{
+		Content: bitbucket.Content{Raw: "finding"},
+		Inline:  &bitbucket.Inline{Path: "main.go", To: &line},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(77), comment.ID)
+}
+
+func TestClient_ListComments_FollowsNextLinks(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"values":[{"id":2,"parent":{"id":1}}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"values":[{"id":1,"inline":{"path":"a.go","to":3}}],"next":"%s/repositories/ws/repo/pullrequests/4/comments?page=2"}`, server.URL)
+	}))
+	defer server.Close()
+
+	comments, err := newTestClient(server.URL).ListComments(context.Background(), "ws", "repo", 4)
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, "a.go", comments[0].Inline.Path)
+	assert.Equal(t, int64(1), comments[1].Parent.ID)
+}
+
+func TestClient_ListComments_RejectsForeignNextLink(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"values":[],"next":"http://evil.example.com/repositories/x"}`)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server.URL).ListComments(context.Background(), "ws", "repo", 4)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "untrusted host")
+}
+
+func TestClient_ParticipantActions(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	ctx := context.Background()
+	require.NoError(t, client.Approve(ctx, "ws", "repo", 4))
+	require.NoError(t, client.Unapprove(ctx, "ws", "repo", 4))
+	require.NoError(t, client.RequestChanges(ctx, "ws", "repo", 4))
+	require.NoError(t, client.RemoveRequestChanges(ctx, "ws", "repo", 4))
+	require.NoError(t, client.DeleteComment(ctx, "ws", "repo", 4, 9))
+	require.NoError(t, client.ResolveComment(ctx, "ws", "repo", 4, 9))
+
+	assert.Equal(t, []string{
+		"POST /repositories/ws/repo/pullrequests/4/approve",
+		"DELETE /repositories/ws/repo/pullrequests/4/approve",
+		"POST /repositories/ws/repo/pullrequests/4/request-changes",
+		"DELETE /repositories/ws/repo/pullrequests/4/request-changes",
+		"DELETE /repositories/ws/repo/pullrequests/4/comments/9",
+		"POST /repositories/ws/repo/pullrequests/4/comments/9/resolve",
+	}, calls)
+}
+
+func TestClient_SetBuildStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/ws/repo/commit/abc123/statuses/build", r.URL.Path)
+
+		var body bitbucket.BuildStatus
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "code-reviewer", body.Key)
+		assert.Equal(t, bitbucket.BuildFailed, body.State)
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	err := newTestClient(server.URL).SetBuildStatus(context.Background(), "ws", "repo", "abc123", bitbucket.BuildStatus{
+		Key:   "code-reviewer",
+		State: bitbucket.BuildFailed,
+		URL:   "https://bitbucket.org/ws/repo/pull-requests/4",
+	})
+	require.NoError(t, err)
+
+	assert.Error(t, bitbucket.NewClient("t").SetBuildStatus(context.Background(), "ws", "repo", "../x", bitbucket.BuildStatus{}))
+}
+
+func TestClient_MapsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"type":"error","error":{"message":"Conflict","detail":"already approved"}}`)
+	}))
+	defer server.Close()
+
+	err := newTestClient(server.URL).Approve(context.Background(), "ws", "repo", 4)
+	require.Error(t, err)
+
+	var httpErr *llmhttp.Error
+	require.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, llmhttp.ErrTypeInvalidRequest, httpErr.Type)
+	assert.Equal(t, "Conflict: already approved", httpErr.Message)
+}
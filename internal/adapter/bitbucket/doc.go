@@ -0,0 +1,22 @@
+// Package bitbucket provides types and utilities for Bitbucket Cloud pull request integration.
+//
+// Bitbucket Cloud has no review object: a review is published as a summary
+// comment plus inline comments anchored by new-file line ("to"), the verdict
+// is expressed through the approve/request-changes endpoints, and a commit
+// build status lets branch restrictions gate merges on the result.
+//
+// # Types
+//   - PositionedFinding: Wraps domain.Finding with a new-file line number
+//   - Comment/Inline: Pull request comment API types
+//   - BuildStatus: Commit build status API type
+//
+// # Functions
+//   - MapFindings: Enriches findings with line numbers for inline comments
+//   - MapHTTPError: Maps Bitbucket HTTP errors to typed llmhttp.Error
+//
+// # Client
+//   - Client: HTTP client for the Bitbucket Cloud 2.0 pull request and commit status APIs
+//
+// Finding comments reuse the hidden fingerprint markers from the github adapter,
+// so deduplication and reply-status tracking work the same on every platform.
+package bitbucket
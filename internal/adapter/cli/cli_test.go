@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/bkyoung/code-reviewer/internal/adapter/cli"
+	"github.com/bkyoung/code-reviewer/internal/jsonschema"
 	"github.com/bkyoung/code-reviewer/internal/usecase/review"
 )
 
@@ -398,3 +399,438 @@ func TestVerificationFlags_ConfigDefaultOutOfRangeWarns(t *testing.T) {
 		t.Errorf("expected fallback to safe default 75, got %d", stub.request.VerificationConfig.ConfidenceCritical)
 	}
 }
+
+func TestPlatformFlag_GitLabSplitsProjectPath(t *testing.T) {
+	stub := &branchStub{}
+	root := cli.NewRootCommand(cli.Dependencies{
+		BranchReviewer: stub,
+		Args:           cli.Arguments{OutWriter: io.Discard, ErrWriter: io.Discard},
+		Version:        "v1.0.0",
+	})
+
+	root.SetArgs([]string{"review", "branch", "feature",
+		"--post-review", "--platform", "GitLab",
+		"--gitlab-project", "group/sub/proj", "--pr-number", "12", "--commit-sha", "abc123"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("command execution failed: %v", err)
+	}
+
+	if !stub.request.PostReview {
+		t.Errorf("expected posting to be enabled")
+	}
+	if stub.request.Platform != "gitlab" {
+		t.Errorf("expected platform gitlab, got %q", stub.request.Platform)
+	}
+	if stub.request.RepoOwner != "group/sub" || stub.request.RepoName != "proj" {
+		t.Errorf("expected namespace group/sub and project proj, got %q and %q",
+			stub.request.RepoOwner, stub.request.RepoName)
+	}
+	if stub.request.PRNumber != 12 {
+		t.Errorf("expected MR IID 12, got %d", stub.request.PRNumber)
+	}
+}
+
+func TestPlatformFlag_GitLabRequiresProject(t *testing.T) {
+	stub := &branchStub{}
+	root := cli.NewRootCommand(cli.Dependencies{
+		BranchReviewer: stub,
+		Args:           cli.Arguments{OutWriter: io.Discard, ErrWriter: io.Discard},
+		Version:        "v1.0.0",
+	})
+
+	root.SetArgs([]string{"review", "branch", "feature",
+		"--post-review", "--platform", "gitlab", "--pr-number", "12", "--commit-sha", "abc123"})
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--gitlab-project") {
+		t.Fatalf("expected --gitlab-project error, got %v", err)
+	}
+}
+
+func TestPlatformFlag_RejectsUnknownPlatform(t *testing.T) {
+	stub := &branchStub{}
+	root := cli.NewRootCommand(cli.Dependencies{
+		BranchReviewer: stub,
+		Args:           cli.Arguments{OutWriter: io.Discard, ErrWriter: io.Discard},
+		Version:        "v1.0.0",
+	})
+
+	root.SetArgs([]string{"review", "branch", "feature", "--platform", "svn"})
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--platform") {
+		t.Fatalf("expected --platform error, got %v", err)
+	}
+}
+
+func TestPlatformFlag_DefaultsToGitHub(t *testing.T) {
+	stub := &branchStub{}
+	root := cli.NewRootCommand(cli.Dependencies{
+		BranchReviewer: stub,
+		Args:           cli.Arguments{OutWriter: io.Discard, ErrWriter: io.Discard},
+		Version:        "v1.0.0",
+	})
+
+	root.SetArgs([]string{"review", "branch", "feature",
+		"--post-github-review", "--github-owner", "o", "--github-repo", "r",
+		"--pr-number", "3", "--commit-sha", "abc123"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("command execution failed: %v", err)
+	}
+
+	if stub.request.Platform != "github" {
+		t.Errorf("expected platform github, got %q", stub.request.Platform)
+	}
+	if stub.request.RepoOwner != "o" || stub.request.RepoName != "r" {
+		t.Errorf("unexpected owner/repo %q/%q", stub.request.RepoOwner, stub.request.RepoName)
+	}
+}
+
+func TestPlatformFlag_BitbucketUsesRepoFlags(t *testing.T) {
+	stub := &branchStub{}
+	root := cli.NewRootCommand(cli.Dependencies{
+		BranchReviewer: stub,
+		Args:           cli.Arguments{OutWriter: io.Discard, ErrWriter: io.Discard},
+		Version:        "v1.0.0",
+	})
+
+	root.SetArgs([]string{"review", "branch", "feature",
+		"--post-review", "--platform", "bitbucket", "--repo-owner", "ws", "--repo-name", "slug",
+		"--pr-number", "7", "--commit-sha", "abc123"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("command execution failed: %v", err)
+	}
+
+	if stub.request.Platform != "bitbucket" {
+		t.Errorf("expected platform bitbucket, got %q", stub.request.Platform)
+	}
+	if stub.request.RepoOwner != "ws" || stub.request.RepoName != "slug" {
+		t.Errorf("unexpected workspace/slug %q/%q", stub.request.RepoOwner, stub.request.RepoName)
+	}
+}
+
+func TestPlatformFlag_GiteaRequiresRepo(t *testing.T) {
+	stub := &branchStub{}
+	root := cli.NewRootCommand(cli.Dependencies{
+		BranchReviewer: stub,
+		Args:           cli.Arguments{OutWriter: io.Discard, ErrWriter: io.Discard},
+		Version:        "v1.0.0",
+	})
+
+	root.SetArgs([]string{"review", "branch", "feature",
+		"--post-review", "--platform", "gitea", "--pr-number", "7", "--commit-sha", "abc123"})
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--repo-owner") {
+		t.Fatalf("expected --repo-owner error, got %v", err)
+	}
+}
+
+func TestMetricsFileFlag(t *testing.T) {
+	stub := &branchStub{}
+	root := cli.NewRootCommand(cli.Dependencies{
+		BranchReviewer: stub,
+		Args:           cli.Arguments{OutWriter: io.Discard, ErrWriter: io.Discard},
+		Version:        "v1.0.0",
+	})
+
+	root.SetArgs([]string{"review", "branch", "feature", "--metrics-file", "/var/lib/node_exporter/textfile/cr.prom"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("command execution failed: %v", err)
+	}
+
+	if stub.request.MetricsFile != "/var/lib/node_exporter/textfile/cr.prom" {
+		t.Errorf("expected metrics file to be passed through, got %q", stub.request.MetricsFile)
+	}
+}
+
+func TestFormatFlag(t *testing.T) {
+	tests := []struct {
+		name     string
+		defaults []string
+		args     []string
+		want     []string
+		wantErr  string
+	}{
+		{name: "config default", defaults: []string{"json", "junit"}, want: []string{"json", "junit"}},
+		{name: "flag replaces config", defaults: []string{"json"}, args: []string{"--format", "SARIF,codeclimate"}, want: []string{"sarif", "codeclimate"}},
+		{name: "repeatable flag", args: []string{"--format", "rdjson", "--format", "rdjsonl"}, want: []string{"rdjson", "rdjsonl"}},
+		{name: "unknown format", args: []string{"--format", "xml"}, wantErr: `unsupported output format "xml"`},
+		{name: "unknown config format", defaults: []string{"pdf"}, wantErr: `unsupported output format "pdf"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &branchStub{}
+			root := cli.NewRootCommand(cli.Dependencies{
+				BranchReviewer: stub,
+				Args:           cli.Arguments{OutWriter: io.Discard, ErrWriter: io.Discard},
+				DefaultFormats: tt.defaults,
+				Version:        "v1.0.0",
+			})
+
+			root.SetArgs(append([]string{"review", "branch", "feature"}, tt.args...))
+			err := root.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("command execution failed: %v", err)
+			}
+			if strings.Join(stub.request.Formats, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected formats %v, got %v", tt.want, stub.request.Formats)
+			}
+		})
+	}
+}
+
+func TestOutputSelectionFlags(t *testing.T) {
+	tests := []struct {
+		name           string
+		mergedOnly     bool
+		args           []string
+		wantMergedOnly bool
+		wantStdout     string
+		wantErr        string
+	}{
+		{name: "defaults"},
+		{name: "config merged only", mergedOnly: true, wantMergedOnly: true},
+		{name: "flag overrides config", mergedOnly: true, args: []string{"--merged-only=false"}},
+		{name: "merged only flag", args: []string{"--merged-only"}, wantMergedOnly: true},
+		{name: "stdout", args: []string{"--stdout", "JSON"}, wantStdout: "json"},
+		{name: "unknown stdout format", args: []string{"--stdout", "xml"}, wantErr: `--stdout: unsupported output format "xml"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &branchStub{}
+			root := cli.NewRootCommand(cli.Dependencies{
+				BranchReviewer:    stub,
+				Args:              cli.Arguments{OutWriter: io.Discard, ErrWriter: io.Discard},
+				DefaultMergedOnly: tt.mergedOnly,
+				Version:           "v1.0.0",
+			})
+
+			root.SetArgs(append([]string{"review", "branch", "feature"}, tt.args...))
+			err := root.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("command execution failed: %v", err)
+			}
+			if stub.request.MergedOnly != tt.wantMergedOnly || stub.request.Stdout != tt.wantStdout {
+				t.Errorf("expected merged only %v and stdout %q, got %v and %q", tt.wantMergedOnly, tt.wantStdout, stub.request.MergedOnly, stub.request.Stdout)
+			}
+		})
+	}
+}
+
+func TestSchemaCommand(t *testing.T) {
+	type doc struct {
+		Name string `json:"name"`
+	}
+	schemas := map[string]*jsonschema.Schema{"output": jsonschema.Generate(doc{})}
+
+	run := func(args ...string) (string, error) {
+		out := &bytes.Buffer{}
+		root := cli.NewRootCommand(cli.Dependencies{
+			Args:    cli.Arguments{OutWriter: out, ErrWriter: io.Discard},
+			Schemas: schemas,
+			Version: "v1.0.0",
+		})
+		root.SetArgs(append([]string{"schema"}, args...))
+		err := root.Execute()
+		return out.String(), err
+	}
+
+	out, err := run()
+	if err != nil || out != "output\n" {
+		t.Errorf("expected schema names to be listed, got %q (%v)", out, err)
+	}
+
+	out, err = run("output")
+	if err != nil {
+		t.Fatalf("command execution failed: %v", err)
+	}
+	if !strings.Contains(out, `"$schema": "https://json-schema.org/draft/2020-12/schema"`) || !strings.Contains(out, `"required": [`) {
+		t.Errorf("expected the output schema, got %s", out)
+	}
+
+	if _, err := run("config"); err == nil || !strings.Contains(err.Error(), `unknown schema "config"`) {
+		t.Errorf("expected unknown schema error, got %v", err)
+	}
+}
+
+func TestReviewCommitCommand(t *testing.T) {
+	stub := &branchStub{}
+	root := cli.NewRootCommand(cli.Dependencies{
+		BranchReviewer: stub,
+		Args:           cli.Arguments{OutWriter: io.Discard, ErrWriter: io.Discard},
+		DefaultOutput:  "out",
+		Version:        "v1.0.0",
+	})
+
+	root.SetArgs([]string{"review", "commit", "abc123", "--no-verify"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("command execution failed: %v", err)
+	}
+
+	if stub.request.BaseRef != "abc123^" || stub.request.TargetRef != "abc123" {
+		t.Errorf("expected abc123^..abc123, got %s..%s", stub.request.BaseRef, stub.request.TargetRef)
+	}
+	if stub.request.OutputDir != "out" || !stub.request.SkipVerification {
+		t.Errorf("expected shared review flags to apply, got %+v", stub.request)
+	}
+}
+
+func TestReviewRangeCommand(t *testing.T) {
+	tests := []struct {
+		spec       string
+		wantBase   string
+		wantTarget string
+		wantErr    string
+	}{
+		{spec: "v1.2.0..feature", wantBase: "v1.2.0", wantTarget: "feature"},
+		{spec: "main..", wantBase: "main", wantTarget: "HEAD"},
+		{spec: "main...feature", wantErr: "symmetric ranges"},
+		{spec: "main", wantErr: "expected <base>..<target>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			stub := &branchStub{}
+			root := cli.NewRootCommand(cli.Dependencies{
+				BranchReviewer: stub,
+				Args:           cli.Arguments{OutWriter: io.Discard, ErrWriter: io.Discard},
+				Version:        "v1.0.0",
+			})
+
+			root.SetArgs([]string{"review", "range", tt.spec})
+			err := root.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("command execution failed: %v", err)
+			}
+			if stub.request.BaseRef != tt.wantBase || stub.request.TargetRef != tt.wantTarget {
+				t.Errorf("expected %s..%s, got %s..%s", tt.wantBase, tt.wantTarget, stub.request.BaseRef, stub.request.TargetRef)
+			}
+		})
+	}
+}
+
+func TestReviewPatchCommand_Stdin(t *testing.T) {
+	stub := &branchStub{}
+	root := cli.NewRootCommand(cli.Dependencies{
+		BranchReviewer: stub,
+		Args:           cli.Arguments{OutWriter: io.Discard, ErrWriter: io.Discard},
+		Version:        "v1.0.0",
+	})
+
+	patch := "diff --git a/main.go b/main.go\n" +
+		"--- a/main.go\n" +
+		"+++ b/main.go\n" +
+		"@@ -1 +1 @@\n" +
+		"-package old\n" +
+		"+package main\n"
+	root.SetIn(strings.NewReader(patch))
+	root.SetArgs([]string{"review", "patch", "-"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("command execution failed: %v", err)
+	}
+
+	req := stub.request
+	if req.Diff == nil || len(req.Diff.Files) != 1 || req.Diff.Files[0].Path != "main.go" {
+		t.Fatalf("expected the parsed patch in the request, got %+v", req.Diff)
+	}
+	if req.TargetRef != "stdin" || req.BaseRef == "" {
+		t.Errorf("expected refs labelling the patch, got %q..%q", req.BaseRef, req.TargetRef)
+	}
+	if !req.NoRepository {
+		t.Error("expected repository context to be skipped without a repository")
+	}
+}
+
+func TestReviewPatchCommand_RejectsEmptyPatch(t *testing.T) {
+	stub := &branchStub{}
+	root := cli.NewRootCommand(cli.Dependencies{
+		BranchReviewer:      stub,
+		Args:                cli.Arguments{OutWriter: io.Discard, ErrWriter: io.Discard},
+		Version:             "v1.0.0",
+		RepositoryAvailable: true,
+	})
+
+	root.SetIn(strings.NewReader("not a diff\n"))
+	root.SetArgs([]string{"review", "patch", "-"})
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), "parse patch") {
+		t.Fatalf("expected parse error, got %v", err)
+	}
+}
+
+type serverStub struct {
+	opts   cli.ServeOptions
+	called bool
+}
+
+func (s *serverStub) Serve(ctx context.Context, opts cli.ServeOptions) error {
+	s.opts = opts
+	s.called = true
+	return nil
+}
+
+func TestServeCommand(t *testing.T) {
+	run := func(defaults cli.DefaultServe, args ...string) (*serverStub, error) {
+		stub := &serverStub{}
+		root := cli.NewRootCommand(cli.Dependencies{
+			ReviewServer:  stub,
+			DefaultServe:  defaults,
+			DefaultOutput: "build",
+			DefaultRepo:   "demo",
+			Args:          cli.Arguments{OutWriter: io.Discard, ErrWriter: io.Discard},
+			Version:       "v1.0.0",
+		})
+		root.SetArgs(append([]string{"serve"}, args...))
+		return stub, root.Execute()
+	}
+
+	stub, err := run(cli.DefaultServe{Addr: "127.0.0.1:9000", Token: "from-config", MaxQueued: 5}, "--concurrency", "3", "--format", "json", "--no-verify")
+	if err != nil {
+		t.Fatalf("command execution failed: %v", err)
+	}
+	if stub.opts.Addr != "127.0.0.1:9000" || stub.opts.Token != "from-config" || stub.opts.Concurrency != 3 || stub.opts.MaxQueued != 5 {
+		t.Errorf("expected flags over config defaults, got %+v", stub.opts)
+	}
+	defaults := stub.opts.Defaults
+	if defaults.OutputDir != "build" || defaults.Repository != "demo" || strings.Join(defaults.Formats, ",") != "json" || !defaults.SkipVerification {
+		t.Errorf("expected review flags as job defaults, got %+v", defaults)
+	}
+
+	stub, err = run(cli.DefaultServe{Token: "from-config"}, "--token", "from-flag")
+	if err != nil || stub.opts.Token != "from-flag" || stub.opts.Addr != "127.0.0.1:8080" || stub.opts.Concurrency != 1 {
+		t.Errorf("expected --token and built-in defaults, got %+v (%v)", stub.opts, err)
+	}
+
+	for _, tt := range []struct {
+		args    []string
+		wantErr string
+	}{
+		{args: nil, wantErr: "a token is required"},
+		{args: []string{"--token", "t", "--concurrency", "0"}, wantErr: "--concurrency must be a positive integer"},
+		{args: []string{"--token", "t", "--stdout", "json"}, wantErr: "--stdout is not supported"},
+		{args: []string{"--token", "t", "--post-review", "--repo-owner", "o", "--repo-name", "r", "--pr-number", "1", "--commit-sha", "abc"}, wantErr: "posting reviews is not supported"},
+	} {
+		stub, err := run(cli.DefaultServe{}, tt.args...)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%v: expected error containing %q, got %v", tt.args, tt.wantErr, err)
+		}
+		if stub.called {
+			t.Errorf("%v: expected the server not to start", tt.args)
+		}
+	}
+}
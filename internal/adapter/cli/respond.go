@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bkyoung/code-reviewer/internal/usecase/respond"
+)
+
+// CommentResponder defines the dependency required to run the respond command.
+type CommentResponder interface {
+	Respond(ctx context.Context, req respond.Request) (respond.Result, error)
+}
+
+// GitHub events respond handles. Review comment replies are answered in their
+// thread; issue comments are answered in the pull request conversation.
+const (
+	reviewCommentEvent = "pull_request_review_comment"
+	issueCommentEvent  = "issue_comment"
+)
+
+// respondCommand creates the respond subcommand.
+// It answers a developer's reply on one of the bot's inline review comments,
+// or a pull request conversation comment that mentions the bot.
+// Skips (own comments, non-finding threads, rate limits) exit 0.
+func respondCommand(responder CommentResponder, defaultBotUsername string) *cobra.Command {
+	var owner string
+	var repo string
+	var prNumber int
+	var commentID int64
+	var event string
+	var botUsername string
+	var maxThreadReplies int
+	var maxHourlyReplies int
+
+	cmd := &cobra.Command{
+		Use:   "respond",
+		Short: "Reply to a follow-up question on a review comment",
+		Long: `Answer a developer's reply on one of the bot's inline review comments.
+
+The thread, the original finding and the current contents of the file are
+sent to the verification LLM, and its answer is posted as a threaded reply.
+
+With --event issue_comment the comment ID refers to a comment in the pull
+request conversation. It is answered only if it mentions the bot; the bot's
+findings, the recent conversation and the files the comment names are sent
+to the LLM, and the answer is posted to the conversation, quoting the question.
+
+The bot never answers its own (or any bot's) comments, and limits itself per
+thread and per hour. The pull request conversation counts as one thread.
+
+Example usage in a pull_request_review_comment or issue_comment workflow:
+  ./cr respond --github-owner "$OWNER" --github-repo "$REPO" \
+    --pr-number "$PR_NUMBER" --comment-id "$COMMENT_ID" --event "$GITHUB_EVENT_NAME"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if responder == nil {
+				return fmt.Errorf("respond requires GITHUB_TOKEN and a configured LLM provider")
+			}
+			if owner == "" || repo == "" {
+				return fmt.Errorf("--github-owner and --github-repo are required")
+			}
+			if prNumber <= 0 {
+				return fmt.Errorf("--pr-number must be a positive integer")
+			}
+			if commentID <= 0 {
+				return fmt.Errorf("--comment-id must be a positive integer")
+			}
+			if event != "" && !strings.EqualFold(event, reviewCommentEvent) && !strings.EqualFold(event, issueCommentEvent) {
+				return fmt.Errorf("--event %q is not supported: use %s or %s", event, reviewCommentEvent, issueCommentEvent)
+			}
+			if botUsername == "" || botUsername == "none" {
+				return fmt.Errorf("--bot-username is required to identify the bot's threads")
+			}
+
+			result, err := responder.Respond(cmd.Context(), respond.Request{
+				Owner:            owner,
+				Repo:             repo,
+				PullNumber:       prNumber,
+				CommentID:        commentID,
+				IssueComment:     strings.EqualFold(event, issueCommentEvent),
+				BotUsername:      botUsername,
+				MaxThreadReplies: maxThreadReplies,
+				MaxHourlyReplies: maxHourlyReplies,
+			})
+			if err != nil {
+				return err
+			}
+
+			if !result.Replied {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "skipped: %s\n", result.SkipReason)
+				return nil
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "replied: %s ($%.4f)\n", result.HTMLURL, result.Cost)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&owner, "github-owner", "", "GitHub repository owner")
+	cmd.Flags().StringVar(&repo, "github-repo", "", "GitHub repository name")
+	cmd.Flags().IntVar(&prNumber, "pr-number", 0, "Pull request number")
+	cmd.Flags().Int64Var(&commentID, "comment-id", 0, "ID of the review or issue comment to respond to")
+	cmd.Flags().StringVar(&event, "event", reviewCommentEvent, "GitHub event that triggered the run: "+reviewCommentEvent+" or "+issueCommentEvent)
+	cmd.Flags().StringVar(&botUsername, "bot-username", defaultBotUsername, "Bot username that authored the review comments")
+	cmd.Flags().IntVar(&maxThreadReplies, "max-thread-replies", respond.DefaultMaxThreadReplies, "Maximum replies per comment thread")
+	cmd.Flags().IntVar(&maxHourlyReplies, "max-hourly-replies", respond.DefaultMaxHourlyReplies, "Maximum replies per pull request per hour")
+
+	return cmd
+}
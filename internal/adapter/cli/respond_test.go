@@ -0,0 +1,129 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/cli"
+	"github.com/bkyoung/code-reviewer/internal/usecase/respond"
+)
+
+type stubResponder struct {
+	request respond.Request
+	result  respond.Result
+}
+
+func (s *stubResponder) Respond(ctx context.Context, req respond.Request) (respond.Result, error) {
+	s.request = req
+	return s.result, nil
+}
+
+func TestRespondCommand(t *testing.T) {
+	tests := []struct {
+		name           string
+		result         respond.Result
+		expectedOutput string
+	}{
+		{
+			name:           "replied",
+			result:         respond.Result{Replied: true, HTMLURL: "https://example.com/r/1", Cost: 0.0012},
+			expectedOutput: "replied: https://example.com/r/1 ($0.0012)\n",
+		},
+		{
+			name:           "skipped",
+			result:         respond.Result{SkipReason: "comment was written by a bot"},
+			expectedOutput: "skipped: comment was written by a bot\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubResponder{result: tt.result}
+			var out bytes.Buffer
+			root := cli.NewRootCommand(cli.Dependencies{
+				BranchReviewer:     &stubBranchReviewer{},
+				CommentResponder:   stub,
+				DefaultBotUsername: "github-actions[bot]",
+				Args:               cli.Arguments{OutWriter: &out, ErrWriter: &out},
+			})
+			root.SetArgs([]string{"respond", "--github-owner", "o", "--github-repo", "r",
+				"--pr-number", "5", "--comment-id", "123", "--max-thread-replies", "2"})
+
+			if err := root.Execute(); err != nil {
+				t.Fatalf("command execution failed: %v", err)
+			}
+			if out.String() != tt.expectedOutput {
+				t.Errorf("expected output %q, got %q", tt.expectedOutput, out.String())
+			}
+			if stub.request.CommentID != 123 || stub.request.PullNumber != 5 {
+				t.Errorf("unexpected request %+v", stub.request)
+			}
+			if stub.request.BotUsername != "github-actions[bot]" {
+				t.Errorf("expected default bot username, got %q", stub.request.BotUsername)
+			}
+			if stub.request.MaxThreadReplies != 2 {
+				t.Errorf("expected thread limit 2, got %d", stub.request.MaxThreadReplies)
+			}
+		})
+	}
+}
+
+func TestRespondCommand_RequiresResponder(t *testing.T) {
+	root := cli.NewRootCommand(cli.Dependencies{
+		BranchReviewer: &stubBranchReviewer{},
+		Args:           cli.Arguments{OutWriter: &bytes.Buffer{}, ErrWriter: &bytes.Buffer{}},
+	})
+	root.SetArgs([]string{"respond", "--github-owner", "o", "--github-repo", "r",
+		"--pr-number", "5", "--comment-id", "123"})
+
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), "GITHUB_TOKEN") {
+		t.Fatalf("expected configuration error, got %v", err)
+	}
+}
+
+func TestRespondCommand_Events(t *testing.T) {
+	tests := []struct {
+		name         string
+		event        string
+		issueComment bool
+		expectError  bool
+	}{
+		{name: "review comment", event: "pull_request_review_comment"},
+		{name: "issue comment", event: "issue_comment", issueComment: true},
+		{name: "unsupported event", event: "pull_request", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubResponder{result: respond.Result{SkipReason: "comment does not mention the bot"}}
+			root := cli.NewRootCommand(cli.Dependencies{
+				BranchReviewer:     &stubBranchReviewer{},
+				CommentResponder:   stub,
+				DefaultBotUsername: "github-actions[bot]",
+				Args:               cli.Arguments{OutWriter: &bytes.Buffer{}, ErrWriter: &bytes.Buffer{}},
+			})
+			root.SetArgs([]string{"respond", "--github-owner", "o", "--github-repo", "r",
+				"--pr-number", "5", "--comment-id", "123", "--event", tt.event})
+
+			err := root.Execute()
+			if tt.expectError {
+				if err == nil || !strings.Contains(err.Error(), tt.event) {
+					t.Fatalf("expected %s to be rejected, got %v", tt.event, err)
+				}
+				if stub.request.CommentID != 0 {
+					t.Errorf("responder should not be called, got request %+v", stub.request)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("command execution failed: %v", err)
+			}
+			if stub.request.IssueComment != tt.issueComment {
+				t.Errorf("expected IssueComment=%v, got %v", tt.issueComment, stub.request.IssueComment)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bkyoung/code-reviewer/internal/diff"
+)
+
+// commitCommand reviews the changes introduced by a single commit.
+func commitCommand(deps Dependencies) *cobra.Command {
+	opts := newReviewOptions(deps)
+
+	cmd := &cobra.Command{
+		Use:   "commit <sha>",
+		Short: "Review the changes introduced by a single commit",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			commit := strings.TrimSpace(args[0])
+			if commit == "" {
+				return fmt.Errorf("commit not specified")
+			}
+
+			req, err := opts.request(cmd)
+			if err != nil {
+				return err
+			}
+			// The git engine diffs a root commit against the empty tree
+			req.BaseRef = commit + "^"
+			req.TargetRef = commit
+
+			_, err = deps.BranchReviewer.ReviewBranch(cmd.Context(), req)
+			return err
+		},
+	}
+	opts.addFlags(cmd)
+
+	return cmd
+}
+
+// rangeCommand reviews the diff between the two ends of a commit range.
+func rangeCommand(deps Dependencies) *cobra.Command {
+	opts := newReviewOptions(deps)
+
+	cmd := &cobra.Command{
+		Use:   "range <base>..<target>",
+		Short: "Review the diff between two commits, e.g. v1.2.0..HEAD",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			baseRef, targetRef, err := parseCommitRange(args[0])
+			if err != nil {
+				return err
+			}
+
+			req, err := opts.request(cmd)
+			if err != nil {
+				return err
+			}
+			req.BaseRef = baseRef
+			req.TargetRef = targetRef
+
+			_, err = deps.BranchReviewer.ReviewBranch(cmd.Context(), req)
+			return err
+		},
+	}
+	opts.addFlags(cmd)
+
+	return cmd
+}
+
+// patchCommand reviews a unified diff read from a file or stdin. No git
+// repository is needed; without one, context from the working tree is skipped.
+func patchCommand(deps Dependencies) *cobra.Command {
+	opts := newReviewOptions(deps)
+
+	cmd := &cobra.Command{
+		Use:   "patch <file|->",
+		Short: "Review a unified diff or git format-patch series from a file or stdin (-)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source := args[0]
+			var content []byte
+			var err error
+			if source == "-" {
+				content, err = io.ReadAll(cmd.InOrStdin())
+			} else {
+				content, err = os.ReadFile(source)
+			}
+			if err != nil {
+				return fmt.Errorf("read patch: %w", err)
+			}
+
+			patch, err := diff.ParsePatchSet(string(content))
+			if err != nil {
+				return fmt.Errorf("parse patch: %w", err)
+			}
+
+			req, err := opts.request(cmd)
+			if err != nil {
+				return err
+			}
+			req.BaseRef = "patch"
+			req.TargetRef = patchLabel(source)
+			req.Diff = &patch
+			req.NoRepository = !deps.RepositoryAvailable
+
+			_, err = deps.BranchReviewer.ReviewBranch(cmd.Context(), req)
+			return err
+		},
+	}
+	opts.addFlags(cmd)
+
+	return cmd
+}
+
+// parseCommitRange splits "A..B" into its base and target. An empty side
+// means HEAD, as in git.
+func parseCommitRange(spec string) (baseRef, targetRef string, err error) {
+	if strings.Contains(spec, "...") {
+		return "", "", fmt.Errorf("symmetric ranges (%s) are not supported; use <base>..<target>", spec)
+	}
+	baseRef, targetRef, ok := strings.Cut(strings.TrimSpace(spec), "..")
+	if !ok {
+		return "", "", fmt.Errorf("invalid range %q: expected <base>..<target>", spec)
+	}
+	if baseRef == "" {
+		baseRef = "HEAD"
+	}
+	if targetRef == "" {
+		targetRef = "HEAD"
+	}
+	return baseRef, targetRef, nil
+}
+
+// patchLabel names a patch review after its file, or "stdin".
+func patchLabel(source string) string {
+	if source == "-" {
+		return "stdin"
+	}
+	return strings.TrimSuffix(filepath.Base(source), filepath.Ext(source))
+}
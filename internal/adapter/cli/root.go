@@ -10,12 +10,21 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/bkyoung/code-reviewer/internal/jsonschema"
 	"github.com/bkyoung/code-reviewer/internal/usecase/review"
 )
 
 // ErrVersionRequested indicates the user requested the CLI version and no further work should be done.
 var ErrVersionRequested = errors.New("version requested")
 
+// Supported code hosts for --platform.
+const (
+	platformGitHub    = "github"
+	platformGitLab    = "gitlab"
+	platformGitea     = "gitea"
+	platformBitbucket = "bitbucket"
+)
+
 // BranchReviewer defines the dependency required to run the branch command.
 type BranchReviewer interface {
 	ReviewBranch(ctx context.Context, req review.BranchRequest) (review.Result, error)
@@ -63,6 +72,7 @@ type DefaultVerification struct {
 // Dependencies captures the collaborators for the CLI.
 type Dependencies struct {
 	BranchReviewer       BranchReviewer
+	CommentResponder     CommentResponder
 	Args                 Arguments
 	DefaultOutput        string
 	DefaultRepo          string
@@ -70,7 +80,16 @@ type Dependencies struct {
 	DefaultReviewActions DefaultReviewActions
 	DefaultBotUsername   string // Bot username for auto-dismissing stale reviews
 	DefaultVerification  DefaultVerification
+	DefaultFormats       []string                      // From config output.formats
+	DefaultMergedOnly    bool                          // From config output.mergedOnly
+	Schemas              map[string]*jsonschema.Schema // Output schemas printed by cr schema, by name
+	ReviewServer         ReviewServer
+	DefaultServe         DefaultServe // From config serve
 	Version              string
+
+	// RepositoryAvailable reports whether the working directory is a git
+	// repository; review patch skips working tree context without one.
+	RepositoryAvailable bool
 }
 
 // NewRootCommand constructs the root Cobra command.
@@ -102,9 +121,15 @@ func NewRootCommand(deps Dependencies) *cobra.Command {
 		Use:   "review",
 		Short: "Run a code review",
 	}
-	reviewCmd.AddCommand(branchCommand(deps.BranchReviewer, deps.DefaultOutput, deps.DefaultRepo, deps.DefaultInstructions, deps.DefaultReviewActions, deps.DefaultBotUsername, deps.DefaultVerification))
+	reviewCmd.AddCommand(branchCommand(deps))
+	reviewCmd.AddCommand(commitCommand(deps))
+	reviewCmd.AddCommand(rangeCommand(deps))
+	reviewCmd.AddCommand(patchCommand(deps))
 	root.AddCommand(reviewCmd)
 	root.AddCommand(checkSkipCommand())
+	root.AddCommand(schemaCommand(deps.Schemas))
+	root.AddCommand(serveCommand(deps))
+	root.AddCommand(respondCommand(deps.CommentResponder, deps.DefaultBotUsername))
 
 	var showVersion bool
 	root.PersistentFlags().BoolVarP(&showVersion, "version", "v", false, "Show version and exit")
@@ -127,48 +152,12 @@ func NewRootCommand(deps Dependencies) *cobra.Command {
 	return root
 }
 
-func branchCommand(branchReviewer BranchReviewer, defaultOutput, defaultRepo, defaultInstructions string, defaultActions DefaultReviewActions, defaultBotUsername string, defaultVerification DefaultVerification) *cobra.Command {
+func branchCommand(deps Dependencies) *cobra.Command {
 	var baseRef string
 	var targetRef string
-	var outputDir string
-	var repository string
 	var includeUncommitted bool
 	var detectTarget bool
-	var customInstructions string
-	var contextFiles []string
-	var interactive bool
-	var noPlanning bool
-	var planOnly bool
-	var noArchitecture bool
-	var noAutoContext bool
-
-	// GitHub integration flags
-	var postGitHubReview bool
-	var githubOwner string
-	var githubRepo string
-	var prNumber int
-	var commitSHA string
-
-	// Review action override flags
-	var actionCritical string
-	var actionHigh string
-	var actionMedium string
-	var actionLow string
-	var actionClean string
-	var actionNonBlocking string
-	var blockThreshold string
-	var alwaysBlockCategories []string
-
-	// Verification flags
-	var verify bool
-	var noVerify bool
-	var verificationDepth string
-	var verificationCostCeiling float64
-	var confidenceDefault int
-	var confidenceCritical int
-	var confidenceHigh int
-	var confidenceMedium int
-	var confidenceLow int
+	opts := newReviewOptions(deps)
 
 	cmd := &cobra.Command{
 		Use:   "branch [target]",
@@ -180,7 +169,7 @@ func branchCommand(branchReviewer BranchReviewer, defaultOutput, defaultRepo, de
 			}
 			ctx := cmd.Context()
 			if targetRef == "" && detectTarget {
-				resolved, err := branchReviewer.CurrentBranch(ctx)
+				resolved, err := deps.BranchReviewer.CurrentBranch(ctx)
 				if err != nil {
 					return fmt.Errorf("detect target branch: %w", err)
 				}
@@ -190,151 +179,320 @@ func branchCommand(branchReviewer BranchReviewer, defaultOutput, defaultRepo, de
 				return fmt.Errorf("target branch not specified; pass as an argument, use --target, or disable --detect-target")
 			}
 
-			// Use config instructions as fallback if --instructions flag not provided
-			if customInstructions == "" {
-				customInstructions = defaultInstructions
-			}
-
-			// Validate GitHub flags if posting to GitHub
-			if postGitHubReview {
-				if githubOwner == "" || githubRepo == "" {
-					return fmt.Errorf("--github-owner and --github-repo are required when --post-github-review is set")
-				}
-				if prNumber <= 0 {
-					return fmt.Errorf("--pr-number must be a positive integer when --post-github-review is set")
-				}
-				if commitSHA == "" {
-					return fmt.Errorf("--commit-sha is required when --post-github-review is set")
-				}
-			}
-
-			// Resolve review actions: CLI flags override defaults from config
-			// Priority: explicit CLI per-severity > CLI threshold > config (already has threshold expanded)
-			//
-			// If CLI --block-threshold is set, expand it to per-severity values,
-			// then apply explicit CLI per-severity overrides on top.
-			cliThresholdActions, err := expandBlockThresholdCLI(cmd, blockThreshold)
+			req, err := opts.request(cmd)
 			if err != nil {
 				return err
 			}
-			resolvedActionCritical := resolveActionWithThreshold(actionCritical, cliThresholdActions.OnCritical, defaultActions.OnCritical)
-			resolvedActionHigh := resolveActionWithThreshold(actionHigh, cliThresholdActions.OnHigh, defaultActions.OnHigh)
-			resolvedActionMedium := resolveActionWithThreshold(actionMedium, cliThresholdActions.OnMedium, defaultActions.OnMedium)
-			resolvedActionLow := resolveActionWithThreshold(actionLow, cliThresholdActions.OnLow, defaultActions.OnLow)
-			resolvedActionClean := resolveAction(actionClean, defaultActions.OnClean)
-			resolvedActionNonBlocking := resolveAction(actionNonBlocking, defaultActions.OnNonBlocking)
-
-			// Resolve always-block categories: CLI values add to config values (additive)
-			resolvedAlwaysBlockCategories := mergeAlwaysBlockCategories(alwaysBlockCategories, defaultActions.AlwaysBlockCategories)
-
-			// Resolve bot username for auto-dismiss feature
-			// "none" (case-insensitive) explicitly disables auto-dismiss; empty uses default
-			resolvedBotUsername := strings.TrimSpace(defaultBotUsername)
-			if resolvedBotUsername == "" {
-				resolvedBotUsername = "github-actions[bot]"
-			} else if strings.EqualFold(resolvedBotUsername, "none") {
-				// Explicit opt-out: pass empty to poster (which skips dismissal)
-				resolvedBotUsername = ""
-			}
+			req.BaseRef = baseRef
+			req.TargetRef = targetRef
+			req.IncludeUncommitted = includeUncommitted
 
-			// Resolve verification settings: CLI flags override config defaults
-			// --no-verify takes precedence, then --verify, then config
-			resolvedVerifyEnabled := resolveVerifyEnabled(cmd, verify, noVerify, defaultVerification.Enabled)
-			resolvedDepth := resolveVerificationDepth(cmd, verificationDepth, defaultVerification.Depth)
-			resolvedCostCeiling := resolveFloat64(cmd, "verification-cost-ceiling", verificationCostCeiling, defaultVerification.CostCeiling)
-			resolvedConfDefault := resolveInt(cmd, "confidence-default", confidenceDefault, defaultVerification.ConfidenceDefault)
-			resolvedConfCritical := resolveInt(cmd, "confidence-critical", confidenceCritical, defaultVerification.ConfidenceCritical)
-			resolvedConfHigh := resolveInt(cmd, "confidence-high", confidenceHigh, defaultVerification.ConfidenceHigh)
-			resolvedConfMedium := resolveInt(cmd, "confidence-medium", confidenceMedium, defaultVerification.ConfidenceMedium)
-			resolvedConfLow := resolveInt(cmd, "confidence-low", confidenceLow, defaultVerification.ConfidenceLow)
-
-			_, err = branchReviewer.ReviewBranch(ctx, review.BranchRequest{
-				BaseRef:               baseRef,
-				TargetRef:             targetRef,
-				OutputDir:             outputDir,
-				Repository:            repository,
-				IncludeUncommitted:    includeUncommitted,
-				CustomInstructions:    customInstructions,
-				ContextFiles:          contextFiles,
-				NoArchitecture:        noArchitecture,
-				NoAutoContext:         noAutoContext,
-				Interactive:           interactive,
-				PostToGitHub:          postGitHubReview,
-				GitHubOwner:           githubOwner,
-				GitHubRepo:            githubRepo,
-				PRNumber:              prNumber,
-				CommitSHA:             commitSHA,
-				ActionOnCritical:      resolvedActionCritical,
-				ActionOnHigh:          resolvedActionHigh,
-				ActionOnMedium:        resolvedActionMedium,
-				ActionOnLow:           resolvedActionLow,
-				ActionOnClean:         resolvedActionClean,
-				ActionOnNonBlocking:   resolvedActionNonBlocking,
-				AlwaysBlockCategories: resolvedAlwaysBlockCategories,
-				BotUsername:           resolvedBotUsername,
-				SkipVerification:      !resolvedVerifyEnabled,
-				VerificationConfig: review.VerificationSettings{
-					Depth:              resolvedDepth,
-					CostCeiling:        resolvedCostCeiling,
-					ConfidenceDefault:  resolvedConfDefault,
-					ConfidenceCritical: resolvedConfCritical,
-					ConfidenceHigh:     resolvedConfHigh,
-					ConfidenceMedium:   resolvedConfMedium,
-					ConfidenceLow:      resolvedConfLow,
-				},
-			})
+			_, err = deps.BranchReviewer.ReviewBranch(ctx, req)
 			return err
 		},
 	}
 
 	cmd.Flags().StringVar(&baseRef, "base", "main", "Base reference to diff against")
 	cmd.Flags().StringVar(&targetRef, "target", "", "Target branch to review (overrides positional)")
+	cmd.Flags().BoolVar(&includeUncommitted, "include-uncommitted", false, "Include uncommitted changes on the target branch")
+	cmd.Flags().BoolVar(&detectTarget, "detect-target", true, "Automatically detect the checked out branch when no target is provided")
+	opts.addFlags(cmd)
+
+	return cmd
+}
+
+// reviewOptions holds the flags shared by the review subcommands and the
+// config defaults they fall back to.
+type reviewOptions struct {
+	defaults Dependencies
+
+	outputDir          string
+	repository         string
+	customInstructions string
+	contextFiles       []string
+	interactive        bool
+	noPlanning         bool
+	planOnly           bool
+	noArchitecture     bool
+	noAutoContext      bool
+	noCodeContext      bool
+	noImpactAnalysis   bool
+	includeGenerated   bool
+	metricsFile        string
+	formats            []string
+	mergedOnly         bool
+	stdout             string
+
+	// GitHub integration flags
+	postGitHubReview bool
+	postReview       bool
+	platform         string
+	gitlabProject    string
+	githubOwner      string
+	githubRepo       string
+	repoOwner        string
+	repoName         string
+	prNumber         int
+	commitSHA        string
+
+	// Review action override flags
+	actionCritical        string
+	actionHigh            string
+	actionMedium          string
+	actionLow             string
+	actionClean           string
+	actionNonBlocking     string
+	blockThreshold        string
+	alwaysBlockCategories []string
+
+	// Verification flags
+	verify                  bool
+	noVerify                bool
+	verificationDepth       string
+	verificationCostCeiling float64
+	confidenceDefault       int
+	confidenceCritical      int
+	confidenceHigh          int
+	confidenceMedium        int
+	confidenceLow           int
+}
+
+func newReviewOptions(deps Dependencies) *reviewOptions {
+	return &reviewOptions{defaults: deps}
+}
+
+// addFlags registers the shared review flags on cmd.
+func (o *reviewOptions) addFlags(cmd *cobra.Command) {
+	defaultOutput := o.defaults.DefaultOutput
 	if defaultOutput == "" {
 		defaultOutput = "out"
 	}
-	cmd.Flags().StringVar(&outputDir, "output", defaultOutput, "Directory to write review artifacts")
-	cmd.Flags().StringVar(&repository, "repository", defaultRepo, "Optional repository name override")
-	cmd.Flags().BoolVar(&includeUncommitted, "include-uncommitted", false, "Include uncommitted changes on the target branch")
-	cmd.Flags().BoolVar(&detectTarget, "detect-target", true, "Automatically detect the checked out branch when no target is provided")
-	cmd.Flags().StringVar(&customInstructions, "instructions", "", "Custom instructions to include in review prompts")
-	cmd.Flags().StringSliceVar(&contextFiles, "context", []string{}, "Additional context files to include in prompts")
-	cmd.Flags().BoolVar(&interactive, "interactive", false, "Enable interactive planning mode (asks clarifying questions before review)")
-	cmd.Flags().BoolVar(&noPlanning, "no-planning", false, "Skip planning in interactive mode")
+	cmd.Flags().StringVar(&o.outputDir, "output", defaultOutput, "Directory to write review artifacts")
+	cmd.Flags().StringVar(&o.repository, "repository", o.defaults.DefaultRepo, "Optional repository name override")
+	cmd.Flags().StringVar(&o.customInstructions, "instructions", "", "Custom instructions to include in review prompts")
+	cmd.Flags().StringSliceVar(&o.contextFiles, "context", []string{}, "Additional context files to include in prompts")
+	cmd.Flags().BoolVar(&o.interactive, "interactive", false, "Enable interactive planning mode (asks clarifying questions before review)")
+	cmd.Flags().BoolVar(&o.noPlanning, "no-planning", false, "Skip planning in interactive mode")
 	_ = cmd.Flags().MarkHidden("no-planning") // Not yet implemented
-	cmd.Flags().BoolVar(&planOnly, "plan-only", false, "Dry-run showing what context would be gathered")
+	cmd.Flags().BoolVar(&o.planOnly, "plan-only", false, "Dry-run showing what context would be gathered")
 	_ = cmd.Flags().MarkHidden("plan-only") // Not yet implemented
-	cmd.Flags().BoolVar(&noArchitecture, "no-architecture", false, "Skip loading ARCHITECTURE.md")
-	cmd.Flags().BoolVar(&noAutoContext, "no-auto-context", false, "Disable automatic context gathering (design docs, relevant docs)")
+	cmd.Flags().BoolVar(&o.noArchitecture, "no-architecture", false, "Skip loading ARCHITECTURE.md")
+	cmd.Flags().BoolVar(&o.noAutoContext, "no-auto-context", false, "Disable automatic context gathering (design docs, relevant docs)")
+	cmd.Flags().BoolVar(&o.noCodeContext, "no-code-context", false, "Don't add enclosing functions and imports around diff hunks")
+	cmd.Flags().BoolVar(&o.noImpactAnalysis, "no-impact-analysis", false, "Don't list callers of changed Go functions")
+	cmd.Flags().BoolVar(&o.includeGenerated, "include-generated", false, "Review generated files, vendored code and lockfiles too")
+	cmd.Flags().StringSliceVar(&o.formats, "format", nil, "Output formats to write (markdown, json, sarif, html, junit, codeclimate, rdjson, rdjsonl); default from config, else markdown, json and sarif")
+	cmd.Flags().BoolVar(&o.mergedOnly, "merged-only", o.defaults.DefaultMergedOnly, "Write outputs for the merged review only, not for each provider")
+	cmd.Flags().StringVar(&o.stdout, "stdout", "", "Also write the merged review to stdout in this format (e.g. json, sarif), for piping")
+	cmd.Flags().StringVar(&o.metricsFile, "metrics-file", "", "Also write run metrics in Prometheus text format to this file (for the node_exporter textfile collector)")
 
 	// GitHub integration flags
-	cmd.Flags().BoolVar(&postGitHubReview, "post-github-review", false, "Post review as GitHub PR review with inline comments")
-	cmd.Flags().StringVar(&githubOwner, "github-owner", "", "GitHub repository owner (required with --post-github-review)")
-	cmd.Flags().StringVar(&githubRepo, "github-repo", "", "GitHub repository name (required with --post-github-review)")
-	cmd.Flags().IntVar(&prNumber, "pr-number", 0, "Pull request number (required with --post-github-review)")
-	cmd.Flags().StringVar(&commitSHA, "commit-sha", "", "Head commit SHA (required with --post-github-review)")
+	cmd.Flags().BoolVar(&o.postGitHubReview, "post-github-review", false, "Post review as GitHub PR review with inline comments")
+	cmd.Flags().BoolVar(&o.postReview, "post-review", false, "Post review with inline comments to the PR/MR on --platform")
+	cmd.Flags().StringVar(&o.platform, "platform", platformGitHub, "Code host to post reviews to (github, gitlab, gitea, bitbucket)")
+	cmd.Flags().StringVar(&o.gitlabProject, "gitlab-project", "", "GitLab project path, e.g. group/subgroup/project (required with --platform gitlab)")
+	cmd.Flags().StringVar(&o.githubOwner, "github-owner", "", "GitHub repository owner (required with --post-github-review)")
+	cmd.Flags().StringVar(&o.githubRepo, "github-repo", "", "GitHub repository name (required with --post-github-review)")
+	cmd.Flags().StringVar(&o.repoOwner, "repo-owner", "", "Repository owner, or workspace on Bitbucket (alternative to --github-owner)")
+	cmd.Flags().StringVar(&o.repoName, "repo-name", "", "Repository name, or repo slug on Bitbucket (alternative to --github-repo)")
+	cmd.Flags().IntVar(&o.prNumber, "pr-number", 0, "Pull request number, or merge request IID on GitLab (required when posting)")
+	cmd.Flags().StringVar(&o.commitSHA, "commit-sha", "", "Head commit SHA (required when posting)")
 
 	// Review action configuration flags (override config file values)
-	cmd.Flags().StringVar(&actionCritical, "action-critical", "", "Review action for critical severity (approve, comment, request_changes)")
-	cmd.Flags().StringVar(&actionHigh, "action-high", "", "Review action for high severity (approve, comment, request_changes)")
-	cmd.Flags().StringVar(&actionMedium, "action-medium", "", "Review action for medium severity (approve, comment, request_changes)")
-	cmd.Flags().StringVar(&actionLow, "action-low", "", "Review action for low severity (approve, comment, request_changes)")
-	cmd.Flags().StringVar(&actionClean, "action-clean", "", "Review action when no findings (approve, comment, request_changes)")
-	cmd.Flags().StringVar(&actionNonBlocking, "action-non-blocking", "", "Review action when findings exist but none block (approve, comment)")
-	cmd.Flags().StringVar(&blockThreshold, "block-threshold", "", "Minimum severity to trigger REQUEST_CHANGES (critical, high, medium, low, none)")
-	cmd.Flags().StringSliceVar(&alwaysBlockCategories, "always-block-category", []string{}, "Categories that always trigger REQUEST_CHANGES regardless of severity (repeatable)")
+	cmd.Flags().StringVar(&o.actionCritical, "action-critical", "", "Review action for critical severity (approve, comment, request_changes)")
+	cmd.Flags().StringVar(&o.actionHigh, "action-high", "", "Review action for high severity (approve, comment, request_changes)")
+	cmd.Flags().StringVar(&o.actionMedium, "action-medium", "", "Review action for medium severity (approve, comment, request_changes)")
+	cmd.Flags().StringVar(&o.actionLow, "action-low", "", "Review action for low severity (approve, comment, request_changes)")
+	cmd.Flags().StringVar(&o.actionClean, "action-clean", "", "Review action when no findings (approve, comment, request_changes)")
+	cmd.Flags().StringVar(&o.actionNonBlocking, "action-non-blocking", "", "Review action when findings exist but none block (approve, comment)")
+	cmd.Flags().StringVar(&o.blockThreshold, "block-threshold", "", "Minimum severity to trigger REQUEST_CHANGES (critical, high, medium, low, none)")
+	cmd.Flags().StringSliceVar(&o.alwaysBlockCategories, "always-block-category", []string{}, "Categories that always trigger REQUEST_CHANGES regardless of severity (repeatable)")
 
 	// Verification flags
-	cmd.Flags().BoolVar(&verify, "verify", false, "Enable agent-based verification of findings (overrides config)")
-	cmd.Flags().BoolVar(&noVerify, "no-verify", false, "Skip agent-based verification of findings (faster, but may include more false positives)")
-	cmd.Flags().StringVar(&verificationDepth, "verification-depth", "", "Verification depth: minimal, medium, or thorough (default from config)")
-	cmd.Flags().Float64Var(&verificationCostCeiling, "verification-cost-ceiling", 0, "Max cost in dollars for verification (0 uses config default)")
-	cmd.Flags().IntVar(&confidenceDefault, "confidence-default", 0, "Default confidence threshold (0 uses config default)")
-	cmd.Flags().IntVar(&confidenceCritical, "confidence-critical", 0, "Confidence threshold for critical findings (0 uses config default)")
-	cmd.Flags().IntVar(&confidenceHigh, "confidence-high", 0, "Confidence threshold for high severity findings (0 uses config default)")
-	cmd.Flags().IntVar(&confidenceMedium, "confidence-medium", 0, "Confidence threshold for medium severity findings (0 uses config default)")
-	cmd.Flags().IntVar(&confidenceLow, "confidence-low", 0, "Confidence threshold for low severity findings (0 uses config default)")
+	cmd.Flags().BoolVar(&o.verify, "verify", false, "Enable agent-based verification of findings (overrides config)")
+	cmd.Flags().BoolVar(&o.noVerify, "no-verify", false, "Skip agent-based verification of findings (faster, but may include more false positives)")
+	cmd.Flags().StringVar(&o.verificationDepth, "verification-depth", "", "Verification depth: minimal, medium, or thorough (default from config)")
+	cmd.Flags().Float64Var(&o.verificationCostCeiling, "verification-cost-ceiling", 0, "Max cost in dollars for verification (0 uses config default)")
+	cmd.Flags().IntVar(&o.confidenceDefault, "confidence-default", 0, "Default confidence threshold (0 uses config default)")
+	cmd.Flags().IntVar(&o.confidenceCritical, "confidence-critical", 0, "Confidence threshold for critical findings (0 uses config default)")
+	cmd.Flags().IntVar(&o.confidenceHigh, "confidence-high", 0, "Confidence threshold for high severity findings (0 uses config default)")
+	cmd.Flags().IntVar(&o.confidenceMedium, "confidence-medium", 0, "Confidence threshold for medium severity findings (0 uses config default)")
+	cmd.Flags().IntVar(&o.confidenceLow, "confidence-low", 0, "Confidence threshold for low severity findings (0 uses config default)")
+}
 
-	return cmd
+// request validates the shared flags and resolves them against the config
+// defaults. The caller sets what to review (refs or a diff).
+func (o *reviewOptions) request(cmd *cobra.Command) (review.BranchRequest, error) {
+	defaultActions := o.defaults.DefaultReviewActions
+	defaultVerification := o.defaults.DefaultVerification
+
+	// Use config instructions as fallback if --instructions flag not provided
+	customInstructions := o.customInstructions
+	if customInstructions == "" {
+		customInstructions = o.defaults.DefaultInstructions
+	}
+
+	// Resolve output formats: --format replaces the config list
+	formats := o.defaults.DefaultFormats
+	if cmd.Flags().Changed("format") {
+		formats = o.formats
+	}
+	formats = normalizeFormats(formats)
+	if err := review.ValidateFormats(formats); err != nil {
+		return review.BranchRequest{}, err
+	}
+	stdout := strings.ToLower(strings.TrimSpace(o.stdout))
+	if stdout != "" {
+		if err := review.ValidateFormats([]string{stdout}); err != nil {
+			return review.BranchRequest{}, fmt.Errorf("--stdout: %w", err)
+		}
+	}
+
+	// Validate code host flags if posting a review
+	platform := strings.ToLower(strings.TrimSpace(o.platform))
+	switch platform {
+	case platformGitHub, platformGitLab, platformGitea, platformBitbucket:
+	default:
+		return review.BranchRequest{}, fmt.Errorf("--platform must be one of %s, %s, %s or %s, got %q",
+			platformGitHub, platformGitLab, platformGitea, platformBitbucket, platform)
+	}
+	// --repo-owner/--repo-name are the platform-neutral spellings of
+	// --github-owner/--github-repo
+	repoOwner, repoName := o.repoOwner, o.repoName
+	if repoOwner == "" {
+		repoOwner = o.githubOwner
+	}
+	if repoName == "" {
+		repoName = o.githubRepo
+	}
+	postToPlatform := o.postGitHubReview || o.postReview
+	if postToPlatform {
+		switch platform {
+		case platformGitLab:
+			if o.gitlabProject == "" {
+				return review.BranchRequest{}, fmt.Errorf("--gitlab-project is required when posting to GitLab")
+			}
+			repoOwner, repoName = splitProjectPath(o.gitlabProject)
+		case platformGitHub:
+			if repoOwner == "" || repoName == "" {
+				return review.BranchRequest{}, fmt.Errorf("--github-owner and --github-repo are required when --post-github-review is set")
+			}
+		default:
+			if repoOwner == "" || repoName == "" {
+				return review.BranchRequest{}, fmt.Errorf("--repo-owner and --repo-name are required when posting to %s", platform)
+			}
+		}
+		if o.prNumber <= 0 {
+			return review.BranchRequest{}, fmt.Errorf("--pr-number must be a positive integer when posting a review")
+		}
+		if o.commitSHA == "" {
+			return review.BranchRequest{}, fmt.Errorf("--commit-sha is required when posting a review")
+		}
+	}
+
+	// Resolve review actions: CLI flags override defaults from config
+	// Priority: explicit CLI per-severity > CLI threshold > config (already has threshold expanded)
+	//
+	// If CLI --block-threshold is set, expand it to per-severity values,
+	// then apply explicit CLI per-severity overrides on top.
+	cliThresholdActions, err := expandBlockThresholdCLI(cmd, o.blockThreshold)
+	if err != nil {
+		return review.BranchRequest{}, err
+	}
+	resolvedActionCritical := resolveActionWithThreshold(o.actionCritical, cliThresholdActions.OnCritical, defaultActions.OnCritical)
+	resolvedActionHigh := resolveActionWithThreshold(o.actionHigh, cliThresholdActions.OnHigh, defaultActions.OnHigh)
+	resolvedActionMedium := resolveActionWithThreshold(o.actionMedium, cliThresholdActions.OnMedium, defaultActions.OnMedium)
+	resolvedActionLow := resolveActionWithThreshold(o.actionLow, cliThresholdActions.OnLow, defaultActions.OnLow)
+	resolvedActionClean := resolveAction(o.actionClean, defaultActions.OnClean)
+	resolvedActionNonBlocking := resolveAction(o.actionNonBlocking, defaultActions.OnNonBlocking)
+
+	// Resolve always-block categories: CLI values add to config values (additive)
+	resolvedAlwaysBlockCategories := mergeAlwaysBlockCategories(o.alwaysBlockCategories, defaultActions.AlwaysBlockCategories)
+
+	// Resolve bot username for auto-dismiss feature
+	// "none" (case-insensitive) explicitly disables auto-dismiss; empty uses default
+	resolvedBotUsername := strings.TrimSpace(o.defaults.DefaultBotUsername)
+	if resolvedBotUsername == "" {
+		resolvedBotUsername = "github-actions[bot]"
+	} else if strings.EqualFold(resolvedBotUsername, "none") {
+		// Explicit opt-out: pass empty to poster (which skips dismissal)
+		resolvedBotUsername = ""
+	}
+
+	// Resolve verification settings: CLI flags override config defaults
+	// --no-verify takes precedence, then --verify, then config
+	resolvedVerifyEnabled := resolveVerifyEnabled(cmd, o.verify, o.noVerify, defaultVerification.Enabled)
+	resolvedDepth := resolveVerificationDepth(cmd, o.verificationDepth, defaultVerification.Depth)
+	resolvedCostCeiling := resolveFloat64(cmd, "verification-cost-ceiling", o.verificationCostCeiling, defaultVerification.CostCeiling)
+	resolvedConfDefault := resolveInt(cmd, "confidence-default", o.confidenceDefault, defaultVerification.ConfidenceDefault)
+	resolvedConfCritical := resolveInt(cmd, "confidence-critical", o.confidenceCritical, defaultVerification.ConfidenceCritical)
+	resolvedConfHigh := resolveInt(cmd, "confidence-high", o.confidenceHigh, defaultVerification.ConfidenceHigh)
+	resolvedConfMedium := resolveInt(cmd, "confidence-medium", o.confidenceMedium, defaultVerification.ConfidenceMedium)
+	resolvedConfLow := resolveInt(cmd, "confidence-low", o.confidenceLow, defaultVerification.ConfidenceLow)
+
+	return review.BranchRequest{
+		OutputDir:             o.outputDir,
+		Repository:            o.repository,
+		CustomInstructions:    customInstructions,
+		ContextFiles:          o.contextFiles,
+		NoArchitecture:        o.noArchitecture,
+		NoAutoContext:         o.noAutoContext,
+		NoCodeContext:         o.noCodeContext,
+		NoImpactAnalysis:      o.noImpactAnalysis,
+		IncludeGenerated:      o.includeGenerated,
+		MetricsFile:           o.metricsFile,
+		Formats:               formats,
+		MergedOnly:            o.mergedOnly,
+		Stdout:                stdout,
+		Interactive:           o.interactive,
+		PostReview:            postToPlatform,
+		Platform:              platform,
+		RepoOwner:             repoOwner,
+		RepoName:              repoName,
+		PRNumber:              o.prNumber,
+		CommitSHA:             o.commitSHA,
+		ActionOnCritical:      resolvedActionCritical,
+		ActionOnHigh:          resolvedActionHigh,
+		ActionOnMedium:        resolvedActionMedium,
+		ActionOnLow:           resolvedActionLow,
+		ActionOnClean:         resolvedActionClean,
+		ActionOnNonBlocking:   resolvedActionNonBlocking,
+		AlwaysBlockCategories: resolvedAlwaysBlockCategories,
+		BotUsername:           resolvedBotUsername,
+		SkipVerification:      !resolvedVerifyEnabled,
+		VerificationConfig: review.VerificationSettings{
+			Depth:              resolvedDepth,
+			CostCeiling:        resolvedCostCeiling,
+			ConfidenceDefault:  resolvedConfDefault,
+			ConfidenceCritical: resolvedConfCritical,
+			ConfidenceHigh:     resolvedConfHigh,
+			ConfidenceMedium:   resolvedConfMedium,
+			ConfidenceLow:      resolvedConfLow,
+		},
+	}, nil
+}
+
+// normalizeFormats lowercases and trims the formats, dropping empty ones.
+func normalizeFormats(formats []string) []string {
+	var normalized []string
+	for _, format := range formats {
+		if format = strings.ToLower(strings.TrimSpace(format)); format != "" {
+			normalized = append(normalized, format)
+		}
+	}
+	return normalized
+}
+
+// splitProjectPath splits a GitLab project path into namespace and project name.
+// Nested groups stay in the namespace ("a/b/c" -> "a/b", "c"); a bare project
+// ID or name yields an empty namespace.
+func splitProjectPath(project string) (namespace, name string) {
+	project = strings.Trim(strings.TrimSpace(project), "/")
+	idx := strings.LastIndex(project, "/")
+	if idx < 0 {
+		return "", project
+	}
+	return project[:idx], project[idx+1:]
 }
 
 // resolveAction returns the override value if non-empty, otherwise the default.
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bkyoung/code-reviewer/internal/jsonschema"
+)
+
+// schemaCommand creates the schema subcommand, which prints the JSON
+// Schema of an output document.
+func schemaCommand(schemas map[string]*jsonschema.Schema) *cobra.Command {
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return &cobra.Command{
+		Use:   "schema [name]",
+		Short: "Print the JSON Schema of an output format",
+		Long: `Print the JSON Schema (draft 2020-12) of an output document, for
+validating outputs or generating client types. Without a name, list the
+available schemas.
+
+Available schemas: ` + strings.Join(names, ", ") + `
+
+Example:
+  cr schema output > cr-output.schema.json`,
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: names,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				for _, name := range names {
+					_, _ = fmt.Fprintln(cmd.OutOrStdout(), name)
+				}
+				return nil
+			}
+
+			schema, ok := schemas[args[0]]
+			if !ok {
+				return fmt.Errorf("unknown schema %q: must be one of %s", args[0], strings.Join(names, ", "))
+			}
+			encoded, err := json.MarshalIndent(schema, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode schema: %w", err)
+			}
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), string(encoded))
+			return nil
+		},
+	}
+}
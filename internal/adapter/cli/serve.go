@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bkyoung/code-reviewer/internal/usecase/review"
+)
+
+// ReviewServer defines the dependency required to run the serve command.
+type ReviewServer interface {
+	// Serve runs the HTTP API until ctx is canceled.
+	Serve(ctx context.Context, opts ServeOptions) error
+}
+
+// ServeOptions configures the review HTTP API.
+type ServeOptions struct {
+	Addr        string
+	Token       string
+	Concurrency int
+	MaxQueued   int
+
+	// Defaults is the request each submitted review starts from.
+	Defaults review.BranchRequest
+}
+
+// DefaultServe holds default serve configuration from config.
+type DefaultServe struct {
+	Addr        string
+	Token       string
+	Concurrency int
+	MaxQueued   int
+}
+
+// serveCommand creates the serve command, which runs reviews submitted
+// over HTTP.
+func serveCommand(deps Dependencies) *cobra.Command {
+	var addr string
+	var token string
+	var concurrency int
+	var maxQueued int
+	opts := newReviewOptions(deps)
+
+	defaultAddr := deps.DefaultServe.Addr
+	if defaultAddr == "" {
+		defaultAddr = "127.0.0.1:8080"
+	}
+	defaultConcurrency := deps.DefaultServe.Concurrency
+	if defaultConcurrency <= 0 {
+		defaultConcurrency = 1
+	}
+	defaultMaxQueued := deps.DefaultServe.MaxQueued
+	if defaultMaxQueued <= 0 {
+		defaultMaxQueued = 100
+	}
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve reviews over a local HTTP API",
+		Long: `Run reviews submitted over HTTP as queued jobs.
+
+  POST /v1/reviews              Start a review of refs or a patch
+  GET  /v1/reviews/{id}         Its status and, once done, its result
+  GET  /v1/reviews/{id}/events  Its progress as server-sent events
+
+Requests must send the token as "Authorization: Bearer <token>". Jobs and
+their results are kept in the SQLite store (store.path), so they survive
+restarts; reviews interrupted by a shutdown run again on the next start.
+The review flags set the defaults of every submitted review.
+
+Example:
+  CR_SERVE_TOKEN=$(openssl rand -hex 32) cr serve --concurrency 2 --format json
+  curl -H "Authorization: Bearer $CR_SERVE_TOKEN" -d '{"targetRef":"feature"}' \
+    http://127.0.0.1:8080/v1/reviews`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.ReviewServer == nil {
+				return fmt.Errorf("serve is not available")
+			}
+			// The token is not a flag default, which help would print
+			if token == "" {
+				token = deps.DefaultServe.Token
+			}
+			if token == "" {
+				return fmt.Errorf("a token is required: set --token, serve.token or CR_SERVE_TOKEN")
+			}
+			if concurrency <= 0 {
+				return fmt.Errorf("--concurrency must be a positive integer")
+			}
+			if maxQueued <= 0 {
+				return fmt.Errorf("--max-queued must be a positive integer")
+			}
+
+			defaults, err := opts.request(cmd)
+			if err != nil {
+				return err
+			}
+			switch {
+			case defaults.PostReview:
+				return fmt.Errorf("posting reviews is not supported by serve")
+			case defaults.Stdout != "":
+				return fmt.Errorf("--stdout is not supported by serve")
+			case defaults.Interactive:
+				return fmt.Errorf("--interactive is not supported by serve")
+			}
+
+			return deps.ReviewServer.Serve(cmd.Context(), ServeOptions{
+				Addr:        addr,
+				Token:       token,
+				Concurrency: concurrency,
+				MaxQueued:   maxQueued,
+				Defaults:    defaults,
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&addr, "addr", defaultAddr, "Address to listen on")
+	cmd.Flags().StringVar(&token, "token", "", "Bearer token clients must send (default from serve.token or CR_SERVE_TOKEN)")
+	cmd.Flags().IntVar(&concurrency, "concurrency", defaultConcurrency, "Reviews to run at once")
+	cmd.Flags().IntVar(&maxQueued, "max-queued", defaultMaxQueued, "Reviews that may wait to run before new ones are rejected")
+	opts.addFlags(cmd)
+
+	return cmd
+}
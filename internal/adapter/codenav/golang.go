@@ -0,0 +1,348 @@
+package codenav
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bkyoung/code-reviewer/internal/usecase/verify"
+)
+
+// goDefinition is a Go declaration and the position of its identifier, which
+// identifies its types.Object across separately type-checked packages.
+type goDefinition struct {
+	Location
+	qualifier string // Receiver or enclosing type name
+	pkgName   string
+	pos       token.Pos
+}
+
+// goIndex holds Go definitions and type-resolved references.
+type goIndex struct {
+	definitionsByName map[string][]goDefinition
+	references        map[token.Pos][]Location
+}
+
+// goPackage is the set of files in one directory with the same package clause.
+type goPackage struct {
+	dir   string
+	name  string
+	files []*ast.File
+	lines map[string][]string
+}
+
+// modulePattern extracts the module path from go.mod.
+var modulePattern = regexp.MustCompile(`(?m)^module\s+"?([^\s"]+)"?`)
+
+// buildGoIndex parses and type-checks the Go files in the repository.
+// Imports of packages inside the module are type-checked from source; other
+// imports are replaced by empty packages and the resulting errors ignored, so
+// the index needs neither a build nor network access.
+func buildGoIndex(repo verify.Repository, files []string) *goIndex {
+	idx := &goIndex{
+		definitionsByName: make(map[string][]goDefinition),
+		references:        make(map[token.Pos][]Location),
+	}
+	if len(files) == 0 {
+		return idx
+	}
+
+	modulePath := ""
+	if content, err := repo.ReadFile("go.mod"); err == nil {
+		if m := modulePattern.FindSubmatch(content); m != nil {
+			modulePath = string(m[1])
+		}
+	}
+
+	fset := token.NewFileSet()
+	packages := make(map[string]*goPackage) // keyed by dir + package name
+	var keys []string
+	for _, file := range files {
+		content, err := repo.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		parsed, err := parser.ParseFile(fset, file, content, parser.SkipObjectResolution)
+		if parsed == nil || parsed.Name == nil {
+			continue
+		}
+		_ = err // Partial ASTs are still useful
+
+		dir := path.Dir(file)
+		key := dir + ":" + parsed.Name.Name
+		pkg, ok := packages[key]
+		if !ok {
+			pkg = &goPackage{dir: dir, name: parsed.Name.Name, lines: make(map[string][]string)}
+			packages[key] = pkg
+			keys = append(keys, key)
+		}
+		pkg.files = append(pkg.files, parsed)
+		pkg.lines[file] = strings.Split(string(content), "\n")
+	}
+	sort.Strings(keys)
+
+	imp := &sourceImporter{
+		fset:       fset,
+		modulePath: modulePath,
+		packages:   packages,
+		checked:    make(map[string]*types.Package),
+	}
+
+	for _, key := range keys {
+		pkg := packages[key]
+		info := &types.Info{
+			Defs: make(map[*ast.Ident]types.Object),
+			Uses: make(map[*ast.Ident]types.Object),
+		}
+		conf := types.Config{
+			Importer:    imp,
+			Error:       func(error) {}, // Keep going past unresolved imports
+			FakeImportC: true,
+		}
+		_, _ = conf.Check(imp.importPath(pkg.dir), fset, pkg.files, info)
+
+		for _, file := range pkg.files {
+			filename := fset.Position(file.Pos()).Filename
+			lines := pkg.lines[filename]
+			idx.addDefinitions(fset, pkg.name, file, lines)
+			idx.addReferences(fset, file, lines, info)
+		}
+	}
+
+	return idx
+}
+
+// definitions returns the definitions named name, filtered by qualifier
+// (receiver/type name or package name) when one is given.
+func (idx *goIndex) definitions(qualifier, name string) []goDefinition {
+	if idx == nil {
+		return nil
+	}
+	var out []goDefinition
+	for _, def := range idx.definitionsByName[name] {
+		if qualifier == "" || def.qualifier == qualifier || def.pkgName == qualifier {
+			out = append(out, def)
+		}
+	}
+	return out
+}
+
+// addDefinitions records the package-level declarations, methods, struct
+// fields and interface methods in file.
+func (idx *goIndex) addDefinitions(fset *token.FileSet, pkgName string, file *ast.File, lines []string) {
+	add := func(ident *ast.Ident, kind, qualifier string) {
+		if ident == nil || ident.Name == "_" {
+			return
+		}
+		filename, line := position(fset, ident.Pos())
+		symbol := pkgName + "." + ident.Name
+		if qualifier != "" {
+			symbol = qualifier + "." + ident.Name
+		}
+		idx.definitionsByName[ident.Name] = append(idx.definitionsByName[ident.Name], goDefinition{
+			Location: Location{
+				File:    filename,
+				Line:    line,
+				Kind:    kind,
+				Symbol:  symbol,
+				Content: lineAt(lines, line),
+			},
+			qualifier: qualifier,
+			pkgName:   pkgName,
+			pos:       ident.Pos(),
+		})
+	}
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv != nil {
+				add(d.Name, KindMethod, receiverName(d))
+			} else {
+				add(d.Name, KindFunc, "")
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					add(s.Name, KindType, "")
+					switch t := s.Type.(type) {
+					case *ast.StructType:
+						for _, field := range t.Fields.List {
+							for _, name := range field.Names {
+								add(name, KindField, s.Name.Name)
+							}
+						}
+					case *ast.InterfaceType:
+						for _, method := range t.Methods.List {
+							for _, name := range method.Names {
+								add(name, KindMethod, s.Name.Name)
+							}
+						}
+					}
+				case *ast.ValueSpec:
+					kind := KindVar
+					if d.Tok == token.CONST {
+						kind = KindConst
+					}
+					for _, name := range s.Names {
+						add(name, kind, "")
+					}
+				}
+			}
+		}
+	}
+}
+
+// addReferences records every identifier in file that the type checker
+// resolved to an object declared in the repository.
+func (idx *goIndex) addReferences(fset *token.FileSet, file *ast.File, lines []string, info *types.Info) {
+	for _, decl := range file.Decls {
+		enclosing := ""
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			enclosing = fn.Name.Name
+			if fn.Recv != nil {
+				enclosing = receiverName(fn) + "." + fn.Name.Name
+			}
+		}
+
+		ast.Inspect(decl, func(n ast.Node) bool {
+			ident, ok := n.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			obj := info.Uses[ident]
+			if obj == nil || !obj.Pos().IsValid() {
+				return true
+			}
+			filename, line := position(fset, ident.Pos())
+			idx.references[obj.Pos()] = append(idx.references[obj.Pos()], Location{
+				File:      filename,
+				Line:      line,
+				Enclosing: enclosing,
+				Content:   lineAt(lines, line),
+			})
+			return true
+		})
+	}
+}
+
+// receiverName returns the receiver type name of a method, without pointer
+// or type parameters.
+func receiverName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	expr := fn.Recv.List[0].Type
+	for {
+		switch t := expr.(type) {
+		case *ast.StarExpr:
+			expr = t.X
+		case *ast.IndexExpr:
+			expr = t.X
+		case *ast.IndexListExpr:
+			expr = t.X
+		case *ast.ParenExpr:
+			expr = t.X
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// sourceImporter type-checks imports of the module's own packages from the
+// parsed files and returns empty packages for everything else.
+type sourceImporter struct {
+	fset       *token.FileSet
+	modulePath string
+	packages   map[string]*goPackage
+	checked    map[string]*types.Package
+}
+
+// importPath returns the import path of a directory in the module.
+func (imp *sourceImporter) importPath(dir string) string {
+	if dir == "." {
+		if imp.modulePath == "" {
+			return "main"
+		}
+		return imp.modulePath
+	}
+	if imp.modulePath == "" {
+		return dir
+	}
+	return imp.modulePath + "/" + dir
+}
+
+// Import implements types.Importer.
+func (imp *sourceImporter) Import(importPath string) (*types.Package, error) {
+	if pkg, ok := imp.checked[importPath]; ok {
+		return pkg, nil
+	}
+
+	dir, ok := imp.dirFor(importPath)
+	var files []*ast.File
+	name := path.Base(importPath)
+	if ok {
+		// Use the non-test files of the directory's primary package
+		for _, pkg := range imp.packages {
+			if pkg.dir != dir || strings.HasSuffix(pkg.name, "_test") {
+				continue
+			}
+			var nonTest []*ast.File
+			for _, f := range pkg.files {
+				if !strings.HasSuffix(imp.fset.Position(f.Pos()).Filename, "_test.go") {
+					nonTest = append(nonTest, f)
+				}
+			}
+			if len(nonTest) > len(files) {
+				files, name = nonTest, pkg.name
+			}
+		}
+	}
+
+	if len(files) == 0 {
+		pkg := types.NewPackage(importPath, name)
+		pkg.MarkComplete()
+		imp.checked[importPath] = pkg
+		return pkg, nil
+	}
+
+	// Register a placeholder first so import cycles terminate
+	placeholder := types.NewPackage(importPath, name)
+	placeholder.MarkComplete()
+	imp.checked[importPath] = placeholder
+
+	conf := types.Config{Importer: imp, Error: func(error) {}, FakeImportC: true}
+	pkg, _ := conf.Check(importPath, imp.fset, files, nil)
+	if pkg != nil {
+		imp.checked[importPath] = pkg
+	}
+	return imp.checked[importPath], nil
+}
+
+// dirFor maps an import path to a directory in the module. Without a go.mod,
+// import paths are matched against repository directories directly.
+func (imp *sourceImporter) dirFor(importPath string) (string, bool) {
+	if imp.modulePath == "" {
+		for _, pkg := range imp.packages {
+			if pkg.dir == importPath {
+				return importPath, true
+			}
+		}
+		return "", false
+	}
+	if importPath == imp.modulePath {
+		return ".", true
+	}
+	if rest, ok := strings.CutPrefix(importPath, imp.modulePath+"/"); ok {
+		return rest, true
+	}
+	return "", false
+}
@@ -0,0 +1,226 @@
+// Package codenav provides language-aware code navigation (definitions and
+// references) over a repository. Go code is type-checked with go/types;
+// other languages use a ctags-style index of definition patterns.
+package codenav
+
+import (
+	"fmt"
+	"go/token"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/bkyoung/code-reviewer/internal/usecase/verify"
+)
+
+// Definition kinds.
+const (
+	KindFunc   = "func"
+	KindMethod = "method"
+	KindType   = "type"
+	KindVar    = "var"
+	KindConst  = "const"
+	KindField  = "field"
+	KindClass  = "class"
+	KindModule = "module"
+)
+
+// Location is a definition or reference site.
+type Location struct {
+	File string // Repository-relative path
+	Line int    // 1-indexed line number
+
+	// Kind is the definition kind (KindFunc, KindType, ...). Empty for references.
+	Kind string
+
+	// Symbol is the qualified name of the definition, e.g. "AgentVerifier.Verify"
+	// or "config.Load".
+	Symbol string
+
+	// Enclosing is the function containing a reference, e.g. "Orchestrator.Run".
+	// Empty for definitions and package-level references.
+	Enclosing string
+
+	// Content is the trimmed source line.
+	Content string
+}
+
+// Index answers definition and reference queries for a repository.
+// It is built lazily on the first query and reused for the rest of the run.
+type Index struct {
+	repo verify.Repository
+
+	once     sync.Once
+	buildErr error
+
+	goIndex   *goIndex
+	tagsIndex *tagsIndex
+}
+
+// NewIndex creates an index over the repository. Nothing is read until the
+// first query.
+func NewIndex(repo verify.Repository) *Index {
+	return &Index{repo: repo}
+}
+
+// Definitions returns the definitions matching symbol. The symbol is a plain
+// name ("Verify") or qualified by a receiver or type ("AgentVerifier.Verify")
+// or a package name ("config.Load").
+func (x *Index) Definitions(symbol string) ([]Location, error) {
+	qualifier, name, err := splitSymbol(symbol)
+	if err != nil {
+		return nil, err
+	}
+	if err := x.build(); err != nil {
+		return nil, err
+	}
+
+	var locations []Location
+	for _, def := range x.goIndex.definitions(qualifier, name) {
+		locations = append(locations, def.Location)
+	}
+	locations = append(locations, x.tagsIndex.definitions(name)...)
+	sortLocations(locations)
+	return locations, nil
+}
+
+// References returns the uses of the definitions matching symbol, excluding
+// the definitions themselves. Go references are resolved by the type checker,
+// so unrelated identifiers with the same name are not reported.
+func (x *Index) References(symbol string) ([]Location, error) {
+	qualifier, name, err := splitSymbol(symbol)
+	if err != nil {
+		return nil, err
+	}
+	if err := x.build(); err != nil {
+		return nil, err
+	}
+
+	var locations []Location
+	for _, def := range x.goIndex.definitions(qualifier, name) {
+		locations = append(locations, x.goIndex.references[def.pos]...)
+	}
+	locations = append(locations, x.tagsIndex.references(name)...)
+	sortLocations(locations)
+	return locations, nil
+}
+
+// ReferencesAt returns the Go function, method or other declaration whose
+// name is at file:line, with its resolved symbol (e.g. "Store.Get"), and its
+// uses. It lets callers that already know where a declaration is avoid
+// ambiguity between same-named symbols. The definition is the zero Location
+// if nothing is declared there.
+func (x *Index) ReferencesAt(file string, line int) (Location, []Location, error) {
+	if err := x.build(); err != nil {
+		return Location{}, nil, err
+	}
+
+	file = path.Clean(strings.ReplaceAll(file, "\\", "/"))
+	var definition Location
+	var locations []Location
+	for _, defs := range x.goIndex.definitionsByName {
+		for _, def := range defs {
+			if def.File == file && def.Line == line {
+				// Several names can be declared on one line; pick one deterministically
+				if definition.Symbol == "" || def.Symbol < definition.Symbol {
+					definition = def.Location
+				}
+				locations = append(locations, x.goIndex.references[def.pos]...)
+			}
+		}
+	}
+	sortLocations(locations)
+	return definition, locations, nil
+}
+
+// build reads and indexes the repository once.
+func (x *Index) build() error {
+	x.once.Do(func() {
+		files, err := x.repo.Glob("**/*")
+		if err != nil {
+			x.buildErr = fmt.Errorf("listing repository files: %w", err)
+			return
+		}
+
+		var goFiles, otherFiles []string
+		for _, f := range files {
+			f = path.Clean(strings.ReplaceAll(f, "\\", "/"))
+			if skipPath(f) {
+				continue
+			}
+			switch {
+			case strings.HasSuffix(f, ".go"):
+				goFiles = append(goFiles, f)
+			case languageForFile(f) != nil:
+				otherFiles = append(otherFiles, f)
+			}
+		}
+
+		x.goIndex = buildGoIndex(x.repo, goFiles)
+		x.tagsIndex = buildTagsIndex(x.repo, otherFiles)
+	})
+	return x.buildErr
+}
+
+// skippedDirs are directories that never contain code under review.
+var skippedDirs = map[string]bool{
+	"vendor":       true,
+	"node_modules": true,
+	"testdata":     true,
+}
+
+// skipPath reports whether a file is outside the code to index: hidden,
+// vendored or test fixture paths.
+func skipPath(p string) bool {
+	for _, part := range strings.Split(p, "/") {
+		if strings.HasPrefix(part, ".") && part != "." || skippedDirs[part] {
+			return true
+		}
+	}
+	return false
+}
+
+// splitSymbol splits "Qualifier.Name" into its parts.
+func splitSymbol(symbol string) (qualifier, name string, err error) {
+	symbol = strings.TrimSpace(symbol)
+	symbol = strings.TrimSuffix(symbol, "()")
+	if symbol == "" {
+		return "", "", fmt.Errorf("symbol name required")
+	}
+	if i := strings.LastIndex(symbol, "."); i >= 0 {
+		qualifier, name = symbol[:i], symbol[i+1:]
+		// Accept "(*T).Method" and "*T.Method" receiver notation
+		qualifier = strings.Trim(qualifier, "()*")
+	} else {
+		name = symbol
+	}
+	if name == "" || strings.ContainsAny(name, " \t/") {
+		return "", "", fmt.Errorf("invalid symbol %q", symbol)
+	}
+	return qualifier, name, nil
+}
+
+// sortLocations orders locations by file and line.
+func sortLocations(locations []Location) {
+	sort.SliceStable(locations, func(i, j int) bool {
+		if locations[i].File != locations[j].File {
+			return locations[i].File < locations[j].File
+		}
+		return locations[i].Line < locations[j].Line
+	})
+}
+
+// lineAt returns the trimmed source line containing pos.
+func lineAt(lines []string, line int) string {
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	return strings.TrimSpace(lines[line-1])
+}
+
+// position converts a token.Pos to a repository-relative file and line.
+func position(fset *token.FileSet, pos token.Pos) (string, int) {
+	p := fset.Position(pos)
+	return p.Filename, p.Line
+}
@@ -0,0 +1,246 @@
+package codenav_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/codenav"
+	"github.com/bkyoung/code-reviewer/internal/adapter/repository"
+)
+
+func writeRepo(t *testing.T, files map[string]string) *codenav.Index {
+	t.Helper()
+	root := t.TempDir()
+	for path, content := range files {
+		full := filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return codenav.NewIndex(repository.NewLocalRepository(root))
+}
+
+var goRepo = map[string]string{
+	"go.mod": "module example.com/app\n\ngo 1.21\n",
+	"store/store.go": `package store
+
+import "errors"
+
+var ErrNotFound = errors.New("not found")
+
+type Store struct {
+	items map[string]string
+}
+
+func (s *Store) Get(key string) (string, error) {
+	v, ok := s.items[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return v, nil
+}
+`,
+	"cache/cache.go": `package cache
+
+// Get is unrelated to Store.Get
+func Get(key string) string { return key }
+`,
+	"api/handler.go": `package api
+
+import (
+	"fmt"
+
+	"example.com/app/store"
+)
+
+type Handler struct {
+	store *store.Store
+}
+
+func (h *Handler) Serve(key string) {
+	v, _ := h.store.Get(key)
+	fmt.Println(v)
+}
+
+func lookup(s *store.Store) {
+	if _, err := s.Get("x"); err == store.ErrNotFound {
+		return
+	}
+}
+`,
+	"store/store_test.go": `package store
+
+import "testing"
+
+func TestGet(t *testing.T) {
+	s := &Store{}
+	s.Get("a")
+}
+`,
+	"vendor/example.org/lib/lib.go": "package lib\n\nfunc Get() {}\n",
+}
+
+func TestIndex_GoDefinitions(t *testing.T) {
+	index := writeRepo(t, goRepo)
+
+	defs, err := index.Definitions("Get")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Store.Get and cache.Get; vendored code is skipped
+	if len(defs) != 2 {
+		t.Fatalf("expected 2 definitions, got %d: %+v", len(defs), defs)
+	}
+
+	defs, err = index.Definitions("Store.Get")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(defs) != 1 {
+		t.Fatalf("expected 1 definition, got %d: %+v", len(defs), defs)
+	}
+	def := defs[0]
+	if def.File != "store/store.go" || def.Line != 11 || def.Kind != codenav.KindMethod || def.Symbol != "Store.Get" {
+		t.Errorf("unexpected definition: %+v", def)
+	}
+
+	defs, _ = index.Definitions("(*Store).Get")
+	if len(defs) != 1 {
+		t.Errorf("expected receiver notation to match, got %+v", defs)
+	}
+
+	defs, _ = index.Definitions("store.ErrNotFound")
+	if len(defs) != 1 || defs[0].Kind != codenav.KindVar {
+		t.Errorf("expected package-qualified var, got %+v", defs)
+	}
+
+	defs, _ = index.Definitions("Store.items")
+	if len(defs) != 1 || defs[0].Kind != codenav.KindField {
+		t.Errorf("expected struct field, got %+v", defs)
+	}
+}
+
+func TestIndex_GoReferencesAreTypeResolved(t *testing.T) {
+	index := writeRepo(t, goRepo)
+
+	refs, err := index.References("Store.Get")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Cross-package calls and the in-package test, but not cache.Get
+	want := map[string]string{
+		"api/handler.go:14":     "Handler.Serve",
+		"api/handler.go:19":     "lookup",
+		"store/store_test.go:7": "TestGet",
+	}
+	if len(refs) != len(want) {
+		t.Fatalf("expected %d references, got %d: %+v", len(want), len(refs), refs)
+	}
+	for _, ref := range refs {
+		key := fmt.Sprintf("%s:%d", ref.File, ref.Line)
+		enclosing, ok := want[key]
+		if !ok {
+			t.Errorf("unexpected reference %s", key)
+			continue
+		}
+		if ref.Enclosing != enclosing {
+			t.Errorf("reference %s: expected enclosing %q, got %q", key, enclosing, ref.Enclosing)
+		}
+	}
+
+	refs, _ = index.References("ErrNotFound")
+	if len(refs) != 2 {
+		t.Errorf("expected 2 references to ErrNotFound, got %+v", refs)
+	}
+}
+
+func TestIndex_ReferencesAt(t *testing.T) {
+	index := writeRepo(t, goRepo)
+
+	// Store.Get is declared at store/store.go:11
+	def, refs, err := index.ReferencesAt("store/store.go", 11)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if def.Symbol != "Store.Get" || def.Kind != codenav.KindMethod {
+		t.Errorf("expected the Store.Get method, got %+v", def)
+	}
+	if len(refs) != 3 {
+		t.Errorf("expected 3 references, got %+v", refs)
+	}
+
+	// cache.Get has the same name but no callers
+	def, refs, _ = index.ReferencesAt("cache/cache.go", 4)
+	if len(refs) != 0 {
+		t.Errorf("expected no references, got %+v", refs)
+	}
+	if def.Symbol != "cache.Get" {
+		t.Errorf("expected the package-qualified function, got %q", def.Symbol)
+	}
+
+	def, refs, _ = index.ReferencesAt("store/store.go", 12)
+	if len(refs) != 0 || def.Symbol != "" {
+		t.Errorf("expected nothing for a line without a declaration, got %+v and %+v", def, refs)
+	}
+}
+
+func TestIndex_OtherLanguages(t *testing.T) {
+	index := writeRepo(t, map[string]string{
+		"app/service.py": `class UserService:
+    def load_user(self, user_id):
+        return self.db.get(user_id)
+
+# load_user is cached elsewhere
+`,
+		"app/views.py": `from app.service import UserService
+
+def show(request):
+    return UserService().load_user(request.id)
+`,
+		"web/client.ts": `export async function fetchUser(id: string) {
+  return fetch("/users/" + id);
+}
+
+export const render = (id: string) => fetchUser(id);
+`,
+	})
+
+	defs, err := index.Definitions("load_user")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(defs) != 1 || defs[0].File != "app/service.py" || defs[0].Line != 2 {
+		t.Errorf("unexpected definitions: %+v", defs)
+	}
+
+	// Comment lines and the definition itself are not references
+	refs, _ := index.References("load_user")
+	if len(refs) != 1 || refs[0].File != "app/views.py" || refs[0].Line != 4 {
+		t.Errorf("unexpected references: %+v", refs)
+	}
+
+	defs, _ = index.Definitions("fetchUser")
+	if len(defs) != 1 || defs[0].Kind != codenav.KindFunc {
+		t.Errorf("unexpected definitions: %+v", defs)
+	}
+	defs, _ = index.Definitions("render")
+	if len(defs) != 1 {
+		t.Errorf("expected arrow function definition, got %+v", defs)
+	}
+}
+
+func TestIndex_InvalidSymbol(t *testing.T) {
+	index := writeRepo(t, goRepo)
+
+	for _, symbol := range []string{"", "  ", "Store.", "a b"} {
+		if _, err := index.Definitions(symbol); err == nil {
+			t.Errorf("expected error for symbol %q", symbol)
+		}
+	}
+}
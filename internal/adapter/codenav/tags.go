@@ -0,0 +1,198 @@
+package codenav
+
+import (
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bkyoung/code-reviewer/internal/usecase/verify"
+)
+
+// language describes how to find definitions in a non-Go language, in the
+// style of ctags: one pattern per definition kind, matched line by line.
+type language struct {
+	extensions    []string
+	definitions   []tagPattern
+	commentPrefix []string
+}
+
+// tagPattern matches a definition; the first submatch is the symbol name.
+type tagPattern struct {
+	kind    string
+	pattern *regexp.Regexp
+}
+
+// languages are the non-Go languages indexed by definition patterns.
+var languages = []*language{
+	{
+		extensions: []string{".py"},
+		definitions: []tagPattern{
+			{KindFunc, regexp.MustCompile(`^\s*(?:async\s+)?def\s+(\w+)`)},
+			{KindClass, regexp.MustCompile(`^\s*class\s+(\w+)`)},
+		},
+		commentPrefix: []string{"#"},
+	},
+	{
+		extensions: []string{".js", ".jsx", ".mjs", ".cjs", ".ts", ".tsx"},
+		definitions: []tagPattern{
+			{KindFunc, regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:async\s+)?function\s*\*?\s*(\w+)`)},
+			{KindClass, regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:abstract\s+)?class\s+(\w+)`)},
+			{KindType, regexp.MustCompile(`^\s*(?:export\s+)?(?:declare\s+)?(?:interface|type|enum)\s+(\w+)`)},
+			{KindFunc, regexp.MustCompile(`^\s*(?:export\s+)?(?:const|let|var)\s+(\w+)\s*(?::[^=]+)?=\s*(?:async\s+)?(?:function|\([^)]*\)\s*(?::[^=]+)?=>|\w+\s*=>)`)},
+			{KindMethod, regexp.MustCompile(`^\s+(?:(?:public|private|protected|static|async|readonly|override)\s+)*(\w+)\s*\([^)]*\)\s*(?::[^{]+)?\{\s*$`)},
+		},
+		commentPrefix: []string{"//", "/*", "*"},
+	},
+	{
+		extensions: []string{".rs"},
+		definitions: []tagPattern{
+			{KindFunc, regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?(?:const\s+)?(?:async\s+)?(?:unsafe\s+)?fn\s+(\w+)`)},
+			{KindType, regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?(?:struct|enum|trait|type|union)\s+(\w+)`)},
+			{KindModule, regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?mod\s+(\w+)`)},
+		},
+		commentPrefix: []string{"//", "/*", "*"},
+	},
+	{
+		extensions: []string{".rb"},
+		definitions: []tagPattern{
+			{KindMethod, regexp.MustCompile(`^\s*def\s+(?:self\.)?(\w+[?!=]?)`)},
+			{KindClass, regexp.MustCompile(`^\s*class\s+(\w+)`)},
+			{KindModule, regexp.MustCompile(`^\s*module\s+(\w+)`)},
+		},
+		commentPrefix: []string{"#"},
+	},
+	{
+		extensions: []string{".java", ".kt", ".cs"},
+		definitions: []tagPattern{
+			{KindClass, regexp.MustCompile(`\b(?:class|interface|enum|record|object)\s+(\w+)`)},
+			{KindMethod, regexp.MustCompile(`^\s*(?:(?:public|private|protected|internal|static|final|abstract|override|suspend|async|virtual|synchronized)\s+)+(?:fun\s+)?(?:[\w<>\[\],.?]+\s+)?(\w+)\s*\(`)},
+		},
+		commentPrefix: []string{"//", "/*", "*"},
+	},
+}
+
+// languageForFile returns the language of a file, or nil if it is not indexed.
+func languageForFile(file string) *language {
+	ext := strings.ToLower(path.Ext(file))
+	for _, lang := range languages {
+		for _, e := range lang.extensions {
+			if e == ext {
+				return lang
+			}
+		}
+	}
+	return nil
+}
+
+// controlKeywords are never definition names, although method patterns can
+// match them (e.g. "if (x) {").
+var controlKeywords = map[string]bool{
+	"if": true, "for": true, "while": true, "switch": true, "catch": true,
+	"return": true, "function": true, "new": true, "else": true,
+}
+
+// tagsFile is an indexed non-Go source file.
+type tagsFile struct {
+	path  string
+	lang  *language
+	lines []string
+}
+
+// tagsIndex holds pattern-based definitions for non-Go files. References are
+// found by a word match at query time, so they are name-based only.
+type tagsIndex struct {
+	files             []tagsFile
+	definitionsByName map[string][]Location
+}
+
+// buildTagsIndex reads the files and records their definitions.
+func buildTagsIndex(repo verify.Repository, files []string) *tagsIndex {
+	idx := &tagsIndex{definitionsByName: make(map[string][]Location)}
+	for _, file := range files {
+		content, err := repo.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		tf := tagsFile{path: file, lang: languageForFile(file), lines: strings.Split(string(content), "\n")}
+		idx.files = append(idx.files, tf)
+
+		for i, line := range tf.lines {
+			if isComment(tf.lang, line) {
+				continue
+			}
+			for _, tp := range tf.lang.definitions {
+				m := tp.pattern.FindStringSubmatch(line)
+				if m == nil || controlKeywords[m[1]] {
+					continue
+				}
+				idx.definitionsByName[m[1]] = append(idx.definitionsByName[m[1]], Location{
+					File:    file,
+					Line:    i + 1,
+					Kind:    tp.kind,
+					Symbol:  m[1],
+					Content: strings.TrimSpace(line),
+				})
+				break
+			}
+		}
+	}
+	return idx
+}
+
+// definitions returns the definitions named name.
+func (idx *tagsIndex) definitions(name string) []Location {
+	if idx == nil {
+		return nil
+	}
+	return append([]Location(nil), idx.definitionsByName[name]...)
+}
+
+// references returns lines in files of the defining languages that mention
+// name as a whole word, excluding the definitions and comment lines.
+func (idx *tagsIndex) references(name string) []Location {
+	if idx == nil {
+		return nil
+	}
+	defs := idx.definitionsByName[name]
+	if len(defs) == 0 {
+		return nil
+	}
+
+	langs := make(map[*language]bool)
+	isDef := make(map[string]bool)
+	for _, def := range defs {
+		langs[languageForFile(def.File)] = true
+		isDef[def.File+":"+strconv.Itoa(def.Line)] = true
+	}
+
+	word := regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\b`)
+	var out []Location
+	for _, tf := range idx.files {
+		if !langs[tf.lang] {
+			continue
+		}
+		for i, line := range tf.lines {
+			if !word.MatchString(line) || isComment(tf.lang, line) || isDef[tf.path+":"+strconv.Itoa(i+1)] {
+				continue
+			}
+			out = append(out, Location{
+				File:    tf.path,
+				Line:    i + 1,
+				Content: strings.TrimSpace(line),
+			})
+		}
+	}
+	return out
+}
+
+// isComment reports whether a line is a comment in the language.
+func isComment(lang *language, line string) bool {
+	trimmed := strings.TrimSpace(line)
+	for _, prefix := range lang.commentPrefix {
+		if strings.HasPrefix(trimmed, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -8,6 +8,7 @@ import (
 	"log"
 	"strings"
 
+	"github.com/bkyoung/code-reviewer/internal/adapter/observability"
 	"github.com/bkyoung/code-reviewer/internal/domain"
 	"github.com/bkyoung/code-reviewer/internal/usecase/dedup"
 )
@@ -40,12 +41,18 @@ func (c *Comparer) Compare(ctx context.Context, candidates []dedup.CandidatePair
 		return &dedup.ComparisonResult{}, nil
 	}
 
+	ctx, span := observability.StartSpan(ctx, "dedup.semantic", map[string]interface{}{
+		"candidates": len(candidates),
+	})
+	defer span.End()
+
 	// Build the prompt
 	prompt := buildComparisonPrompt(candidates)
 
 	// Call the LLM
 	response, err := c.client.Compare(ctx, prompt, c.maxTokens)
 	if err != nil {
+		span.RecordError(err)
 		log.Printf("warning: semantic dedup LLM call failed: %v (treating all as unique)", err)
 		// Fail open: return all new findings as unique
 		return failOpen(candidates), nil
@@ -54,10 +61,12 @@ func (c *Comparer) Compare(ctx context.Context, candidates []dedup.CandidatePair
 	// Parse the response
 	result, err := parseComparisonResponse(response, candidates)
 	if err != nil {
+		span.RecordError(err)
 		log.Printf("warning: failed to parse semantic dedup response: %v (treating all as unique)", err)
 		return failOpen(candidates), nil
 	}
 
+	span.SetAttributes(map[string]interface{}{"duplicates": len(result.Duplicates)})
 	return result, nil
 }
 
@@ -3,7 +3,9 @@ package git
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os/exec"
 	"strings"
 
@@ -32,7 +34,7 @@ func (e *Engine) GetCumulativeDiff(ctx context.Context, baseRef, targetRef strin
 		return domain.Diff{}, fmt.Errorf("open repo: %w", err)
 	}
 
-	baseCommit, err := resolveCommit(repo, baseRef)
+	baseTree, baseHash, err := resolveBaseTree(repo, baseRef)
 	if err != nil {
 		return domain.Diff{}, fmt.Errorf("resolve base ref: %w", err)
 	}
@@ -43,18 +45,24 @@ func (e *Engine) GetCumulativeDiff(ctx context.Context, baseRef, targetRef strin
 	}
 
 	if includeUncommitted {
-		fileDiffs, err := diffWithWorkingTree(ctx, e.repoDir, baseRef)
+		fileDiffs, err := diffWithWorkingTree(ctx, e.repoDir, baseHash)
 		if err != nil {
 			return domain.Diff{}, err
 		}
 		return domain.Diff{
-			FromCommitHash: baseCommit.Hash.String(),
+			FromCommitHash: baseHash,
 			ToCommitHash:   targetCommit.Hash.String(),
 			Files:          fileDiffs,
 		}, nil
 	}
 
-	patch, err := baseCommit.Patch(targetCommit)
+	targetTree, err := targetCommit.Tree()
+	if err != nil {
+		return domain.Diff{}, fmt.Errorf("load target tree: %w", err)
+	}
+
+	// A nil base tree is the empty tree
+	patch, err := baseTree.PatchContext(ctx, targetTree)
 	if err != nil {
 		return domain.Diff{}, fmt.Errorf("compute patch: %w", err)
 	}
@@ -65,12 +73,18 @@ func (e *Engine) GetCumulativeDiff(ctx context.Context, baseRef, targetRef strin
 	}
 
 	return domain.Diff{
-		FromCommitHash: baseCommit.Hash.String(),
+		FromCommitHash: baseHash,
 		ToCommitHash:   targetCommit.Hash.String(),
 		Files:          fileDiffs,
 	}, nil
 }
 
+// IsRepository reports whether the engine's directory is inside a git repository.
+func (e *Engine) IsRepository() bool {
+	_, err := goGit.PlainOpenWithOptions(e.repoDir, &goGit.PlainOpenOptions{DetectDotGit: true})
+	return err == nil
+}
+
 // CurrentBranch returns the name of the checked-out branch.
 func (e *Engine) CurrentBranch(ctx context.Context) (string, error) {
 	repo, err := goGit.PlainOpenWithOptions(e.repoDir, &goGit.PlainOpenOptions{DetectDotGit: true})
@@ -132,6 +146,43 @@ func (e *Engine) GetIncrementalDiff(ctx context.Context, fromCommit, toCommit st
 	}, nil
 }
 
+// ReadFileAtRef returns the content of a file as committed at ref, ignoring
+// the working tree. The error wraps fs.ErrNotExist if the file does not
+// exist at ref.
+func (e *Engine) ReadFileAtRef(ctx context.Context, ref, path string) ([]byte, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	repo, err := goGit.PlainOpenWithOptions(e.repoDir, &goGit.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("open repo: %w", err)
+	}
+
+	// The parent of a root commit is the empty tree, which has no files
+	tree, _, err := resolveBaseTree(repo, ref)
+	if err != nil {
+		return nil, fmt.Errorf("resolve ref %s: %w", ref, err)
+	}
+	if tree == nil {
+		return nil, fmt.Errorf("%s at %s: %w", path, ref, fs.ErrNotExist)
+	}
+
+	file, err := tree.File(path)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return nil, fmt.Errorf("%s at %s: %w", path, ref, fs.ErrNotExist)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s at %s: %w", path, ref, err)
+	}
+
+	content, err := file.Contents()
+	if err != nil {
+		return nil, fmt.Errorf("read %s at %s: %w", path, ref, err)
+	}
+	return []byte(content), nil
+}
+
 // CommitExists checks if a commit SHA exists in the repository.
 // Used for force-push detection - if the previously reviewed commit no longer exists,
 // we should fall back to a full diff.
@@ -182,6 +233,30 @@ func resolveCommit(repo *goGit.Repository, ref string) (*object.Commit, error) {
 	return nil, fmt.Errorf("unable to resolve ref %s", ref)
 }
 
+// emptyTreeHash is git's hash of the empty tree.
+const emptyTreeHash = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+// resolveBaseTree resolves the tree a diff starts from and its hash. A root
+// commit has no parent, so "<root>^" resolves to the empty tree (returned as
+// nil) and the commit's changes are diffed against it, as git show does.
+func resolveBaseTree(repo *goGit.Repository, ref string) (*object.Tree, string, error) {
+	commit, err := resolveCommit(repo, ref)
+	if err == nil {
+		tree, err := commit.Tree()
+		if err != nil {
+			return nil, "", fmt.Errorf("load tree of %s: %w", ref, err)
+		}
+		return tree, commit.Hash.String(), nil
+	}
+
+	if child, ok := strings.CutSuffix(ref, "^"); ok {
+		if root, rootErr := resolveCommit(repo, child); rootErr == nil && root.NumParents() == 0 {
+			return nil, emptyTreeHash, nil
+		}
+	}
+	return nil, "", err
+}
+
 // patchToFileDiffs converts a go-git patch into a slice of domain FileDiffs.
 // This is shared between GetCumulativeDiff and GetIncrementalDiff.
 func patchToFileDiffs(patch *object.Patch) ([]domain.FileDiff, error) {
@@ -2,6 +2,8 @@ package git_test
 
 import (
 	"context"
+	"errors"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -115,6 +117,92 @@ func TestEngineIncludesUncommittedChanges(t *testing.T) {
 	}
 }
 
+func TestEngineGetCumulativeDiffForRootCommit(t *testing.T) {
+	ctx := context.Background()
+	tmp := t.TempDir()
+
+	repo, err := goGit.PlainInit(tmp, false)
+	if err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+
+	writeFile(t, tmp, "main.go", "package main\n\nfunc main() {}\n")
+	if _, err := worktree.Add("main.go"); err != nil {
+		t.Fatalf("add error: %v", err)
+	}
+	root, err := worktree.Commit("initial", &goGit.CommitOptions{Author: defaultSignature()})
+	if err != nil {
+		t.Fatalf("commit error: %v", err)
+	}
+
+	// cr review commit diffs <sha>^..<sha>; a root commit has no parent
+	engine := git.NewEngine(tmp)
+	diff, err := engine.GetCumulativeDiff(ctx, root.String()+"^", root.String(), false)
+	if err != nil {
+		t.Fatalf("GetCumulativeDiff returned error: %v", err)
+	}
+
+	if diff.FromCommitHash != "4b825dc642cb6eb9a060e54bf8d69288fbee4904" {
+		t.Errorf("expected the empty tree as base, got %s", diff.FromCommitHash)
+	}
+	if len(diff.Files) != 1 || diff.Files[0].Status != domain.FileStatusAdded {
+		t.Fatalf("expected 1 added file, got %+v", diff.Files)
+	}
+	if !contains(diff.Files[0].Patch, "+func main() {}") {
+		t.Errorf("expected patch to add the file's content, got %s", diff.Files[0].Patch)
+	}
+
+	// Other unresolvable bases still fail
+	if _, err := engine.GetCumulativeDiff(ctx, "missing^", root.String(), false); err == nil {
+		t.Error("expected an error for an unknown base ref")
+	}
+}
+
+func TestEngine_ReadFileAtRef(t *testing.T) {
+	ctx := context.Background()
+	tmp := t.TempDir()
+
+	repo, err := goGit.PlainInit(tmp, false)
+	if err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+
+	writeFile(t, tmp, "rules.yaml", "committed\n")
+	if _, err := worktree.Add("rules.yaml"); err != nil {
+		t.Fatalf("add error: %v", err)
+	}
+	if _, err := worktree.Commit("initial", &goGit.CommitOptions{Author: defaultSignature()}); err != nil {
+		t.Fatalf("commit error: %v", err)
+	}
+
+	// The working tree is ignored
+	writeFile(t, tmp, "rules.yaml", "edited\n")
+
+	engine := git.NewEngine(tmp)
+	content, err := engine.ReadFileAtRef(ctx, "master", "rules.yaml")
+	if err != nil {
+		t.Fatalf("ReadFileAtRef returned error: %v", err)
+	}
+	if string(content) != "committed\n" {
+		t.Errorf("expected committed content, got %q", content)
+	}
+
+	if _, err := engine.ReadFileAtRef(ctx, "master", "missing.yaml"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected fs.ErrNotExist for a missing file, got %v", err)
+	}
+	if _, err := engine.ReadFileAtRef(ctx, "no-such-branch", "rules.yaml"); err == nil || errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected a resolve error for an unknown ref, got %v", err)
+	}
+}
+
 func writeFile(t *testing.T, dir, name, content string) {
 	t.Helper()
 	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
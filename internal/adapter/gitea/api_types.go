@@ -0,0 +1,89 @@
+package gitea
+
+// Gitea Pull Request Reviews API types.
+// See: https://gitea.com/api/swagger#/repository/repoCreatePullReview
+
+// ReviewState is the state of a Gitea pull request review. The same values
+// are used as the event when creating a review.
+type ReviewState string
+
+const (
+	// StateApproved approves the pull request.
+	StateApproved ReviewState = "APPROVED"
+
+	// StateRequestChanges requests changes to the pull request.
+	StateRequestChanges ReviewState = "REQUEST_CHANGES"
+
+	// StateComment submits the review without approval.
+	StateComment ReviewState = "COMMENT"
+
+	// StatePending is a review that hasn't been submitted yet.
+	StatePending ReviewState = "PENDING"
+
+	// StateRequestReview marks a review request rather than a submitted review.
+	StateRequestReview ReviewState = "REQUEST_REVIEW"
+)
+
+// User is the subset of a Gitea user returned with reviews and comments.
+type User struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+}
+
+// CreateReviewRequest is the request body for POST /repos/{owner}/{repo}/pulls/{index}/reviews.
+type CreateReviewRequest struct {
+	// CommitID is the SHA of the commit that was reviewed.
+	CommitID string `json:"commit_id,omitempty"`
+
+	// Event is the review action: APPROVED, REQUEST_CHANGES, or COMMENT.
+	Event ReviewState `json:"event"`
+
+	// Body is the review summary comment.
+	Body string `json:"body"`
+
+	// Comments are the inline review comments.
+	Comments []ReviewComment `json:"comments,omitempty"`
+}
+
+// ReviewComment is an inline comment anchored to a line in the new file.
+type ReviewComment struct {
+	Path        string `json:"path"`
+	Body        string `json:"body"`
+	NewPosition int    `json:"new_position"`
+}
+
+// PullReview is a submitted (or pending) pull request review.
+type PullReview struct {
+	ID          int64       `json:"id"`
+	Body        string      `json:"body"`
+	User        User        `json:"user"`
+	State       ReviewState `json:"state"`
+	CommitID    string      `json:"commit_id"`
+	Dismissed   bool        `json:"dismissed"`
+	Stale       bool        `json:"stale"`
+	HTMLURL     string      `json:"html_url"`
+	SubmittedAt string      `json:"submitted_at"`
+}
+
+// PullReviewComment is an inline comment belonging to a review. Gitea has no
+// reply-to field; replies are separate comments on the same path and line.
+type PullReviewComment struct {
+	ID        int64  `json:"id"`
+	Body      string `json:"body"`
+	User      User   `json:"user"`
+	Path      string `json:"path"`
+	Position  int    `json:"position"`
+	ReviewID  int64  `json:"pull_request_review_id"`
+	CreatedAt string `json:"created_at"`
+}
+
+// DismissReviewRequest is the request body for dismissing a review.
+type DismissReviewRequest struct {
+	Message string `json:"message"`
+}
+
+// GiteaErrorResponse represents an error response from the Gitea API.
+type GiteaErrorResponse struct {
+	Message string `json:"message"`
+	URL     string `json:"url,omitempty"`
+}
@@ -0,0 +1,260 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+)
+
+const (
+	defaultBaseURL        = "https://gitea.com/api/v1"
+	defaultTimeout        = 30 * time.Second
+	defaultMaxRetries     = 3
+	defaultInitialBackoff = 2 * time.Second
+	maxPaginationPages    = 100 // Prevent infinite pagination loops
+	pageLimit             = 50  // Gitea's default maximum page size
+)
+
+// validatePathSegment validates that a path segment (owner, repo) doesn't contain
+// characters that could cause path injection attacks.
+func validatePathSegment(value, name string) error {
+	if strings.Contains(value, "..") {
+		return fmt.Errorf("invalid %s: must not contain '..'", name)
+	}
+	if strings.Contains(value, "/") {
+		return fmt.Errorf("invalid %s: must not contain '/'", name)
+	}
+	if value == "" {
+		return fmt.Errorf("invalid %s: must not be empty", name)
+	}
+	return nil
+}
+
+// Client is an HTTP client for the Gitea Pull Request Reviews API.
+type Client struct {
+	token      string
+	baseURL    string
+	httpClient *http.Client
+	retryConf  llmhttp.RetryConfig
+}
+
+// NewClient creates a new Gitea API client with the given access token.
+// Self-hosted instances must call SetBaseURL.
+func NewClient(token string) *Client {
+	return &Client{
+		token:      token,
+		baseURL:    defaultBaseURL,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		retryConf: llmhttp.RetryConfig{
+			MaxRetries:     defaultMaxRetries,
+			InitialBackoff: defaultInitialBackoff,
+			MaxBackoff:     32 * time.Second,
+			Multiplier:     2.0,
+		},
+	}
+}
+
+// SetBaseURL sets the API base URL, e.g. https://gitea.example.com/api/v1.
+// All trailing slashes are trimmed to ensure consistent URL construction.
+func (c *Client) SetBaseURL(baseURL string) {
+	c.baseURL = strings.TrimRight(baseURL, "/")
+}
+
+// SetTimeout sets the HTTP timeout.
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.httpClient.Timeout = timeout
+}
+
+// SetMaxRetries sets the maximum number of retry attempts.
+func (c *Client) SetMaxRetries(maxRetries int) {
+	c.retryConf.MaxRetries = maxRetries
+}
+
+// SetInitialBackoff sets the initial backoff duration for retries.
+func (c *Client) SetInitialBackoff(backoff time.Duration) {
+	c.retryConf.InitialBackoff = backoff
+}
+
+// pullURL builds the base URL for a pull request resource after validating
+// and escaping the owner and repo path segments.
+func (c *Client) pullURL(owner, repo string, index int) (string, error) {
+	if err := validatePathSegment(owner, "owner"); err != nil {
+		return "", err
+	}
+	if err := validatePathSegment(repo, "repo"); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/repos/%s/%s/pulls/%d",
+		c.baseURL, url.PathEscape(owner), url.PathEscape(repo), index), nil
+}
+
+// CurrentUser returns the user that owns the API token.
+func (c *Client) CurrentUser(ctx context.Context) (*User, error) {
+	var user User
+	if err := c.doJSON(ctx, http.MethodGet, c.baseURL+"/user", nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// CreateReview submits a pull request review with inline comments.
+func (c *Client) CreateReview(ctx context.Context, owner, repo string, index int, input CreateReviewRequest) (*PullReview, error) {
+	base, err := c.pullURL(owner, repo, index)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	var review PullReview
+	if err := c.doJSON(ctx, http.MethodPost, base+"/reviews", jsonData, &review); err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// ListReviews fetches all reviews for a pull request.
+func (c *Client) ListReviews(ctx context.Context, owner, repo string, index int) ([]PullReview, error) {
+	base, err := c.pullURL(owner, repo, index)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []PullReview
+	for page := 1; ; page++ {
+		if page > maxPaginationPages {
+			return nil, fmt.Errorf("pagination limit exceeded (%d pages)", maxPaginationPages)
+		}
+
+		var reviews []PullReview
+		pageURL := fmt.Sprintf("%s/reviews?page=%d&limit=%d", base, page, pageLimit)
+		if err := c.doJSON(ctx, http.MethodGet, pageURL, nil, &reviews); err != nil {
+			return nil, err
+		}
+		all = append(all, reviews...)
+
+		if len(reviews) < pageLimit {
+			return all, nil
+		}
+	}
+}
+
+// ListReviewComments fetches the inline comments of every review on a pull request.
+// Gitea only exposes comments per review, so this issues one request per review.
+func (c *Client) ListReviewComments(ctx context.Context, owner, repo string, index int) ([]PullReviewComment, error) {
+	reviews, err := c.ListReviews(ctx, owner, repo, index)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := c.pullURL(owner, repo, index)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []PullReviewComment
+	for _, review := range reviews {
+		var comments []PullReviewComment
+		commentsURL := fmt.Sprintf("%s/reviews/%d/comments", base, review.ID)
+		if err := c.doJSON(ctx, http.MethodGet, commentsURL, nil, &comments); err != nil {
+			return nil, err
+		}
+		all = append(all, comments...)
+	}
+	return all, nil
+}
+
+// DismissReview dismisses a review with the given message.
+func (c *Client) DismissReview(ctx context.Context, owner, repo string, index int, reviewID int64, message string) error {
+	base, err := c.pullURL(owner, repo, index)
+	if err != nil {
+		return err
+	}
+
+	jsonData, err := json.Marshal(DismissReviewRequest{Message: message})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	dismissURL := fmt.Sprintf("%s/reviews/%d/dismissals", base, reviewID)
+	return c.doJSON(ctx, http.MethodPost, dismissURL, jsonData, nil)
+}
+
+// doJSON executes a request with retry, maps error responses to llmhttp.Error
+// and decodes a successful response into out (if non-nil).
+func (c *Client) doJSON(ctx context.Context, method, apiURL string, body []byte, out interface{}) error {
+	var resp *http.Response
+	err := llmhttp.RetryWithBackoff(ctx, func(ctx context.Context) error {
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+
+		req, reqErr := http.NewRequestWithContext(ctx, method, apiURL, reader)
+		if reqErr != nil {
+			return &llmhttp.Error{
+				Type:      llmhttp.ErrTypeUnknown,
+				Message:   reqErr.Error(),
+				Retryable: false,
+				Provider:  providerName,
+			}
+		}
+
+		req.Header.Set("Authorization", "token "+c.token)
+		req.Header.Set("Accept", "application/json")
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		var callErr error
+		resp, callErr = c.httpClient.Do(req)
+		if callErr != nil {
+			return &llmhttp.Error{
+				Type:      llmhttp.ErrTypeTimeout,
+				Message:   callErr.Error(),
+				Retryable: true,
+				Provider:  providerName,
+			}
+		}
+
+		if resp.StatusCode >= 400 {
+			bodyBytes, readErr := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if readErr != nil {
+				return &llmhttp.Error{
+					Type:       llmhttp.ErrTypeUnknown,
+					Message:    fmt.Sprintf("HTTP %d (failed to read response: %v)", resp.StatusCode, readErr),
+					StatusCode: resp.StatusCode,
+					Retryable:  resp.StatusCode >= 500,
+					Provider:   providerName,
+				}
+			}
+			return MapHTTPError(resp.StatusCode, bodyBytes)
+		}
+
+		return nil
+	}, c.retryConf)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
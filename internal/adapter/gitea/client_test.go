@@ -0,0 +1,146 @@
+package gitea_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/gitea"
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(serverURL string) *gitea.Client {
+	client := gitea.NewClient("test-token")
+	client.SetBaseURL(serverURL + "/")
+	client.SetMaxRetries(0)
+	client.SetInitialBackoff(time.Millisecond)
+	return client
+}
+
+func TestNewClient(t *testing.T) {
+	require.NotNil(t, gitea.NewClient("test-token"))
+}
+
+func TestClient_CreateReview(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/repos/owner/repo/pulls/5/reviews", r.URL.Path)
+		assert.Equal(t, "token test-token", r.Header.Get("Authorization"))
+
+		var body gitea.CreateReviewRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, gitea.StateRequestChanges, body.Event)
+		assert.Equal(t, "abc123", body.CommitID)
+		require.Len(t, body.Comments, 1)
+		assert.Equal(t, "main.go", body.Comments[0].Path)
+		assert.Equal(t, 11, body.Comments[0].NewPosition)
+
+		fmt.Fprint(w, `{"id":42,"state":"REQUEST_CHANGES","html_url":"https://gitea.example.com/owner/repo/pulls/5"}`)
+	}))
+	defer server.Close()
+
+	review, err := newTestClient(server.URL).CreateReview(context.Background(), "owner", "repo", 5, gitea.CreateReviewRequest{
+		CommitID: "abc123",
+		Event:    gitea.StateRequestChanges,
+		Body:     "summary",
+		Comments: []gitea.ReviewComment{{Path: "main.go", Body: "bug", NewPosition: 11}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), review.ID)
+	assert.Equal(t, "https://gitea.example.com/owner/repo/pulls/5", review.HTMLURL)
+}
+
+func TestClient_RejectsInvalidPathSegments(t *testing.T) {
+	client := gitea.NewClient("test-token")
+
+	_, err := client.ListReviews(context.Background(), "../owner", "repo", 1)
+	assert.Error(t, err)
+	_, err = client.ListReviews(context.Background(), "owner", "a/b", 1)
+	assert.Error(t, err)
+}
+
+func TestClient_ListReviews_Paginates(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		assert.Equal(t, "50", r.URL.Query().Get("limit"))
+
+		var reviews []gitea.PullReview
+		count := 50
+		if page == "2" {
+			count = 3
+		}
+		for i := 0; i < count; i++ {
+			reviews = append(reviews, gitea.PullReview{ID: int64(i)})
+		}
+		json.NewEncoder(w).Encode(reviews)
+	}))
+	defer server.Close()
+
+	reviews, err := newTestClient(server.URL).ListReviews(context.Background(), "owner", "repo", 1)
+	require.NoError(t, err)
+	assert.Len(t, reviews, 53)
+	assert.Equal(t, []string{"1", "2"}, pages)
+}
+
+func TestClient_ListReviewComments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/pulls/1/reviews":
+			fmt.Fprint(w, `[{"id":7},{"id":8}]`)
+		case "/repos/owner/repo/pulls/1/reviews/7/comments":
+			fmt.Fprint(w, `[{"id":1,"body":"a","path":"x.go","position":3,"user":{"login":"bot"}}]`)
+		case "/repos/owner/repo/pulls/1/reviews/8/comments":
+			fmt.Fprint(w, `[{"id":2,"body":"b","path":"x.go","position":3,"user":{"login":"dev"}}]`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	comments, err := newTestClient(server.URL).ListReviewComments(context.Background(), "owner", "repo", 1)
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, "bot", comments[0].User.Login)
+	assert.Equal(t, 3, comments[1].Position)
+}
+
+func TestClient_DismissReview(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/repos/owner/repo/pulls/1/reviews/9/dismissals", r.URL.Path)
+
+		var body gitea.DismissReviewRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "stale", body.Message)
+		fmt.Fprint(w, `{"id":9,"dismissed":true}`)
+	}))
+	defer server.Close()
+
+	require.NoError(t, newTestClient(server.URL).DismissReview(context.Background(), "owner", "repo", 1, 9, "stale"))
+}
+
+func TestClient_MapsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"message":"approve your own pull is not allowed"}`)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server.URL).CreateReview(context.Background(), "owner", "repo", 1, gitea.CreateReviewRequest{})
+	require.Error(t, err)
+
+	var httpErr *llmhttp.Error
+	require.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, llmhttp.ErrTypeInvalidRequest, httpErr.Type)
+	assert.Equal(t, "approve your own pull is not allowed", httpErr.Message)
+	assert.Equal(t, "gitea", httpErr.Provider)
+}
@@ -0,0 +1,20 @@
+// Package gitea provides types and utilities for Gitea pull request review integration.
+//
+// Gitea's review API closely follows GitHub's, with two differences this adapter
+// handles: inline comments are anchored by file line numbers (new_position)
+// rather than diff positions, and review states use APPROVED instead of APPROVE.
+//
+// # Types
+//   - PositionedFinding: Wraps domain.Finding with a new-file line number
+//   - PullReview/PullReviewComment: Review and review comment API types
+//
+// # Functions
+//   - MapFindings: Enriches findings with line numbers for inline comments
+//   - MapHTTPError: Maps Gitea HTTP errors to typed llmhttp.Error
+//
+// # Client
+//   - Client: HTTP client for the Gitea Pull Request Reviews API
+//
+// Finding comments reuse the hidden fingerprint markers from the github adapter,
+// so deduplication and reply-status tracking work the same on every platform.
+package gitea
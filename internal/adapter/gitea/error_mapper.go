@@ -0,0 +1,83 @@
+package gitea
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+)
+
+const providerName = "gitea"
+
+// MapHTTPError maps Gitea API HTTP status codes to typed llmhttp.Error.
+// This allows reuse of existing retry logic and error handling infrastructure.
+func MapHTTPError(statusCode int, body []byte) *llmhttp.Error {
+	message := parseErrorMessage(statusCode, body)
+
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return &llmhttp.Error{
+			Type:       llmhttp.ErrTypeAuthentication,
+			Message:    message,
+			StatusCode: statusCode,
+			Retryable:  false,
+			Provider:   providerName,
+		}
+
+	case http.StatusTooManyRequests:
+		return &llmhttp.Error{
+			Type:       llmhttp.ErrTypeRateLimit,
+			Message:    message,
+			StatusCode: statusCode,
+			Retryable:  true,
+			Provider:   providerName,
+		}
+
+	case http.StatusNotFound, http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return &llmhttp.Error{
+			Type:       llmhttp.ErrTypeInvalidRequest,
+			Message:    message,
+			StatusCode: statusCode,
+			Retryable:  false,
+			Provider:   providerName,
+		}
+
+	case http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return &llmhttp.Error{
+			Type:       llmhttp.ErrTypeServiceUnavailable,
+			Message:    message,
+			StatusCode: statusCode,
+			Retryable:  true,
+			Provider:   providerName,
+		}
+
+	default:
+		return &llmhttp.Error{
+			Type:       llmhttp.ErrTypeUnknown,
+			Message:    message,
+			StatusCode: statusCode,
+			Retryable:  false,
+			Provider:   providerName,
+		}
+	}
+}
+
+// parseErrorMessage extracts a user-friendly error message from Gitea's response.
+func parseErrorMessage(statusCode int, body []byte) string {
+	var errResp GiteaErrorResponse
+	if err := json.Unmarshal(body, &errResp); err != nil || errResp.Message == "" {
+		bodyPreview := string(body)
+		if len(bodyPreview) > 100 {
+			bodyPreview = bodyPreview[:100] + "..."
+		}
+		if bodyPreview == "" {
+			return fmt.Sprintf("HTTP %d", statusCode)
+		}
+		return fmt.Sprintf("HTTP %d: %s", statusCode, bodyPreview)
+	}
+	return errResp.Message
+}
@@ -0,0 +1,68 @@
+package gitea
+
+import (
+	"github.com/bkyoung/code-reviewer/internal/diff"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+)
+
+// MapFindings enriches domain findings with the new-file line numbers Gitea
+// uses to anchor inline review comments. Only lines present in the diff
+// (added or context) can be commented on.
+//
+// For renamed files, the mapper checks both old and new paths, allowing
+// findings that reference the old filename to still be mapped correctly.
+//
+// This function is pure and does not modify the input findings.
+func MapFindings(findings []domain.Finding, d domain.Diff) []PositionedFinding {
+	if len(findings) == 0 {
+		return []PositionedFinding{}
+	}
+
+	type fileEntry struct {
+		parsed diff.ParsedDiff
+		path   string
+	}
+
+	files := make(map[string]fileEntry, len(d.Files))
+	for _, fileDiff := range d.Files {
+		if fileDiff.IsBinary {
+			continue
+		}
+
+		parsed, err := diff.Parse(fileDiff.Patch)
+		if err != nil {
+			continue
+		}
+
+		entry := fileEntry{parsed: parsed, path: fileDiff.Path}
+		files[fileDiff.Path] = entry
+		if fileDiff.OldPath != "" {
+			files[fileDiff.OldPath] = entry
+		}
+	}
+
+	result := make([]PositionedFinding, len(findings))
+	for i, finding := range findings {
+		pf := PositionedFinding{Finding: finding, Path: finding.File}
+		if entry, ok := files[finding.File]; ok {
+			pf.Path = entry.path
+			if line := entry.parsed.FindLine(finding.LineStart); line != nil {
+				pf.Line = line.NewLine
+			}
+		}
+		result[i] = pf
+	}
+
+	return result
+}
+
+// CountInDiffFindings returns the count of findings that are in the diff.
+func CountInDiffFindings(findings []PositionedFinding) int {
+	count := 0
+	for _, pf := range findings {
+		if pf.InDiff() {
+			count++
+		}
+	}
+	return count
+}
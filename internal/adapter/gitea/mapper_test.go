@@ -0,0 +1,48 @@
+package gitea_test
+
+import (
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/gitea"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapFindings(t *testing.T) {
+	d := domain.Diff{
+		Files: []domain.FileDiff{
+			{
+				Path:    "new.go",
+				OldPath: "old.go",
+				Status:  domain.FileStatusRenamed,
+				Patch: `@@ -10,3 +10,4 @@ func example() {
+ context line 10
++added line 11
+ context line 12
+`,
+			},
+		},
+	}
+
+	findings := []domain.Finding{
+		{File: "new.go", LineStart: 11},
+		{File: "old.go", LineStart: 12},
+		{File: "new.go", LineStart: 40},
+	}
+
+	result := gitea.MapFindings(findings, d)
+	require.Len(t, result, 3)
+
+	require.True(t, result[0].InDiff())
+	assert.Equal(t, 11, *result[0].Line)
+	assert.Equal(t, "new.go", result[0].Path)
+
+	require.True(t, result[1].InDiff())
+	assert.Equal(t, 12, *result[1].Line)
+	assert.Equal(t, "new.go", result[1].Path, "renamed files are anchored on the new path")
+	assert.Equal(t, "old.go", result[1].Finding.File)
+
+	assert.False(t, result[2].InDiff())
+	assert.Equal(t, 2, gitea.CountInDiffFindings(result))
+}
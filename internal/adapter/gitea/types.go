@@ -0,0 +1,24 @@
+package gitea
+
+import "github.com/bkyoung/code-reviewer/internal/domain"
+
+// PositionedFinding wraps a domain.Finding with the Gitea line number used to
+// anchor an inline review comment.
+type PositionedFinding struct {
+	// Finding is the original domain finding with all review details.
+	Finding domain.Finding
+
+	// Path is the file path on the head side of the diff.
+	// Differs from Finding.File when a finding references a renamed file by its old name.
+	Path string
+
+	// Line is the line number in the new version of the file.
+	// nil indicates the finding's line is not in the diff and cannot
+	// receive an inline comment (should be included in summary only).
+	Line *int
+}
+
+// InDiff returns true if the finding can receive an inline review comment.
+func (pf PositionedFinding) InDiff() bool {
+	return pf.Line != nil
+}
@@ -118,6 +118,12 @@ type DismissReviewResponse struct {
 	State string `json:"state"` // Should be "DISMISSED"
 }
 
+// CreateReplyRequest is the request body for replying to a review comment.
+// See: https://docs.github.com/en/rest/pulls/comments#create-a-reply-for-a-review-comment
+type CreateReplyRequest struct {
+	Body string `json:"body"`
+}
+
 // PullRequestComment represents a review comment on a pull request.
 // This is returned by GET /repos/{owner}/{repo}/pulls/{pull_number}/comments
 // See: https://docs.github.com/en/rest/pulls/comments#list-review-comments-on-a-pull-request
@@ -141,6 +147,25 @@ type PullRequestComment struct {
 	PullRequestReviewID int64 `json:"pull_request_review_id,omitempty"`
 }
 
+// IssueComment represents a comment in a pull request's conversation tab.
+// Pull requests are issues, so these come from the issue comments API.
+// See: https://docs.github.com/en/rest/issues/comments
+type IssueComment struct {
+	ID        int64  `json:"id"`
+	NodeID    string `json:"node_id"`
+	Body      string `json:"body"`
+	User      User   `json:"user"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	HTMLURL   string `json:"html_url"`
+}
+
+// CreateIssueCommentRequest is the request body for commenting on a pull request's conversation.
+// See: https://docs.github.com/en/rest/issues/comments#create-an-issue-comment
+type CreateIssueCommentRequest struct {
+	Body string `json:"body"`
+}
+
 // CommentWithReplies groups a parent review comment with its replies.
 // This structure is used for detecting status updates from reply chains.
 type CommentWithReplies struct {
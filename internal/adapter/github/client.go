@@ -63,7 +63,8 @@ func NewClient(token string) *Client {
 	}
 }
 
-// SetBaseURL sets a custom base URL (for testing).
+// SetBaseURL sets the REST API base URL, e.g. https://ghe.example.com/api/v3
+// for GitHub Enterprise Server. Pagination links must stay under this URL.
 // All trailing slashes are trimmed to ensure consistent URL construction.
 func (c *Client) SetBaseURL(baseURL string) {
 	c.baseURL = strings.TrimRight(baseURL, "/")
@@ -314,13 +315,6 @@ func (c *Client) ValidateAndResolvePaginationURL(rawURL string) (string, error)
 		return "", fmt.Errorf("untrusted host: %s (expected %s)", parsed.Host, base.Host)
 	}
 
-	// Validate path is a GitHub API repos endpoint
-	// The host check above is the primary SSRF defense; this provides defense in depth
-	// Accept both /repos/... and /api/v3/repos/... patterns for GHES compatibility
-	if !strings.Contains(parsed.Path, "/repos/") {
-		return "", fmt.Errorf("unexpected API path: %s (must be a /repos/ endpoint)", parsed.Path)
-	}
-
 	// Block known dangerous paths even on the same host (defense in depth)
 	dangerousPaths := []string{"/admin", "/settings", "/stafftools", "/_private", "/setup"}
 	pathLower := strings.ToLower(parsed.Path)
@@ -330,6 +324,14 @@ func (c *Client) ValidateAndResolvePaginationURL(rawURL string) (string, error)
 		}
 	}
 
+	// Validate path is a repos endpoint under the configured API prefix
+	// The host check above is the primary SSRF defense; this provides defense in depth
+	// On GHES the prefix is the base URL path (e.g. /api/v3/repos/...)
+	reposPrefix := strings.TrimRight(base.Path, "/") + "/repos/"
+	if !strings.HasPrefix(parsed.Path, reposPrefix) {
+		return "", fmt.Errorf("unexpected API path: %s (must be a %s endpoint)", parsed.Path, reposPrefix)
+	}
+
 	return parsed.String(), nil
 }
 
@@ -706,6 +706,77 @@ func TestClient_ListReviews_GitHubEnterprisePathPrefix(t *testing.T) {
 	assert.Equal(t, 2, pageCount, "should have made two requests")
 }
 
+func TestClient_ListReviews_GitHubEnterpriseRejectsLinkOutsideAPIPrefix(t *testing.T) {
+	// A same-host link that drops the /api/v3 prefix is not an API endpoint
+	pageCount := 0
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pageCount++
+		w.Header().Set("Content-Type", "application/json")
+
+		if pageCount == 1 {
+			w.Header().Set("Link", `<`+serverURL+`/repos/owner/repo/pulls/123/reviews?page=2>; rel="next"`)
+			json.NewEncoder(w).Encode([]github.ReviewSummary{{ID: 1, State: "APPROVED"}})
+		} else {
+			t.Fatal("client followed Link outside the API prefix!")
+		}
+	}))
+	defer server.Close()
+	serverURL = server.URL
+
+	client := github.NewClient("test-token")
+	client.SetBaseURL(server.URL + "/api/v3/")
+
+	_, err := client.ListReviews(context.Background(), "owner", "repo", 123)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be a /api/v3/repos/ endpoint")
+}
+
+func TestClient_GitHubEnterprise_CreateReviewAndListComments(t *testing.T) {
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v3/repos/owner/repo/pulls/7/reviews":
+			json.NewEncoder(w).Encode(github.CreateReviewResponse{
+				ID:      99,
+				State:   "COMMENTED",
+				HTMLURL: "https://ghe.example.com/owner/repo/pull/7#pullrequestreview-99",
+			})
+		case r.URL.Path == "/api/v3/repos/owner/repo/pulls/7/comments" && r.URL.Query().Get("page") == "":
+			w.Header().Set("Link", `<`+serverURL+`/api/v3/repos/owner/repo/pulls/7/comments?per_page=100&page=2>; rel="next"`)
+			json.NewEncoder(w).Encode([]github.PullRequestComment{{ID: 1, CreatedAt: "2024-01-01T00:00:00Z"}})
+		case r.URL.Path == "/api/v3/repos/owner/repo/pulls/7/comments":
+			json.NewEncoder(w).Encode([]github.PullRequestComment{{ID: 2, CreatedAt: "2024-01-02T00:00:00Z"}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	serverURL = server.URL
+
+	client := github.NewClient("test-token")
+	client.SetBaseURL(server.URL + "/api/v3")
+
+	resp, err := client.CreateReview(context.Background(), github.CreateReviewInput{
+		Owner:      "owner",
+		Repo:       "repo",
+		PullNumber: 7,
+		CommitSHA:  "sha123",
+		Event:      github.EventComment,
+		Summary:    "Review summary",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://ghe.example.com/owner/repo/pull/7#pullrequestreview-99", resp.HTMLURL)
+
+	comments, err := client.ListPullRequestComments(context.Background(), "owner", "repo", 7)
+	require.NoError(t, err)
+	assert.Len(t, comments, 2, "should follow GHES pagination links")
+}
+
 func TestClient_ListReviews_RealisticPaginationURL(t *testing.T) {
 	// Test with a realistic GitHub pagination URL format
 	pageCount := 0
@@ -0,0 +1,57 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+// ListIssueComments fetches all comments in a pull request's conversation
+// (as opposed to inline review comments), oldest first.
+func (c *Client) ListIssueComments(ctx context.Context, owner, repo string, number int) ([]IssueComment, error) {
+	if err := validatePathSegment(owner, "owner"); err != nil {
+		return nil, err
+	}
+	if err := validatePathSegment(repo, "repo"); err != nil {
+		return nil, err
+	}
+
+	comments, err := listAllPages[IssueComment](ctx, c, fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments?per_page=100",
+		c.baseURL, url.PathEscape(owner), url.PathEscape(repo), number))
+	if err != nil {
+		return nil, err
+	}
+
+	// CreatedAt is in RFC3339 format, which sorts lexicographically
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreatedAt < comments[j].CreatedAt
+	})
+	return comments, nil
+}
+
+// CreateIssueComment posts a comment to a pull request's conversation.
+func (c *Client) CreateIssueComment(ctx context.Context, owner, repo string, number int, body string) (*IssueComment, error) {
+	if err := validatePathSegment(owner, "owner"); err != nil {
+		return nil, err
+	}
+	if err := validatePathSegment(repo, "repo"); err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(CreateIssueCommentRequest{Body: body})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	apiURL := fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments",
+		c.baseURL, url.PathEscape(owner), url.PathEscape(repo), number)
+
+	var comment IssueComment
+	if err := c.doJSON(ctx, http.MethodPost, apiURL, jsonData, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
@@ -0,0 +1,84 @@
+package github_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListIssueComments_PaginatesAndSorts(t *testing.T) {
+	page := 0
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/repos/owner/repo/issues/42/comments", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+
+		if page == 0 {
+			w.Header().Set("Link", `<`+serverURL+`/repos/owner/repo/issues/42/comments?page=2>; rel="next"`)
+			json.NewEncoder(w).Encode([]github.IssueComment{
+				{ID: 2, Body: "second", CreatedAt: "2026-01-01T11:00:00Z"},
+			})
+		} else {
+			json.NewEncoder(w).Encode([]github.IssueComment{
+				{ID: 1, Body: "first", CreatedAt: "2026-01-01T10:00:00Z"},
+			})
+		}
+		page++
+	}))
+	defer server.Close()
+
+	serverURL = server.URL
+	client := github.NewClient("test-token")
+	client.SetBaseURL(server.URL)
+
+	comments, err := client.ListIssueComments(context.Background(), "owner", "repo", 42)
+
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, int64(1), comments[0].ID)
+	assert.Equal(t, int64(2), comments[1].ID)
+}
+
+func TestCreateIssueComment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/repos/owner/repo/issues/42/comments", r.URL.Path)
+
+		var req github.CreateIssueCommentRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "Thanks for asking.", req.Body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(github.IssueComment{ID: 300, Body: req.Body, HTMLURL: "https://github.com/owner/repo/pull/42#issuecomment-300"})
+	}))
+	defer server.Close()
+
+	client := github.NewClient("test-token")
+	client.SetBaseURL(server.URL)
+
+	comment, err := client.CreateIssueComment(context.Background(), "owner", "repo", 42, "Thanks for asking.")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), comment.ID)
+	assert.Contains(t, comment.HTMLURL, "issuecomment-300")
+}
+
+func TestIssueComments_RejectPathInjection(t *testing.T) {
+	client := github.NewClient("test-token")
+
+	_, err := client.ListIssueComments(context.Background(), "../admin", "repo", 42)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid owner")
+
+	_, err = client.CreateIssueComment(context.Background(), "owner", "../repo", 42, "body")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid repo")
+}
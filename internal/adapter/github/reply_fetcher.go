@@ -24,14 +24,26 @@ func (c *Client) ListPullRequestComments(ctx context.Context, owner, repo string
 		return nil, err
 	}
 
-	var allComments []PullRequestComment
+	allComments, err := listAllPages[PullRequestComment](ctx, c, fmt.Sprintf("%s/repos/%s/%s/pulls/%d/comments?per_page=100",
+		c.baseURL, url.PathEscape(owner), url.PathEscape(repo), pullNumber))
+	if err != nil {
+		return nil, err
+	}
+
+	// Sort comments chronologically by creation time
+	sortCommentsChronologically(allComments)
+
+	return allComments, nil
+}
+
+// listAllPages follows the Link headers of a paginated list endpoint and
+// returns the items of all pages.
+func listAllPages[T any](ctx context.Context, c *Client, firstURL string) ([]T, error) {
+	var all []T
 	visitedURLs := make(map[string]bool) // Prevent infinite pagination loops
 	pageCount := 0
 
-	// Start with the first page
-	nextURL := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/comments?per_page=100",
-		c.baseURL, url.PathEscape(owner), url.PathEscape(repo), pullNumber)
-
+	nextURL := firstURL
 	for nextURL != "" {
 		// Pagination loop protection
 		if pageCount >= maxPaginationPages {
@@ -43,11 +55,11 @@ func (c *Client) ListPullRequestComments(ctx context.Context, owner, repo string
 		visitedURLs[nextURL] = true
 		pageCount++
 
-		pageComments, next, err := c.fetchCommentsPage(ctx, nextURL)
+		page, next, err := fetchPage[T](ctx, c, nextURL)
 		if err != nil {
 			return nil, err
 		}
-		allComments = append(allComments, pageComments...)
+		all = append(all, page...)
 
 		// Validate and resolve pagination URL to prevent SSRF attacks
 		if next != "" {
@@ -60,14 +72,11 @@ func (c *Client) ListPullRequestComments(ctx context.Context, owner, repo string
 		nextURL = next
 	}
 
-	// Sort comments chronologically by creation time
-	sortCommentsChronologically(allComments)
-
-	return allComments, nil
+	return all, nil
 }
 
-// fetchCommentsPage fetches a single page of comments and returns the next page URL if present.
-func (c *Client) fetchCommentsPage(ctx context.Context, pageURL string) ([]PullRequestComment, string, error) {
+// fetchPage fetches a single page of a list endpoint and returns the next page URL if present.
+func fetchPage[T any](ctx context.Context, c *Client, pageURL string) ([]T, string, error) {
 	var resp *http.Response
 	err := llmhttp.RetryWithBackoff(ctx, func(ctx context.Context) error {
 		req, reqErr := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
@@ -118,15 +127,15 @@ func (c *Client) fetchCommentsPage(ctx context.Context, pageURL string) ([]PullR
 	}
 	defer resp.Body.Close()
 
-	var comments []PullRequestComment
-	if err := json.NewDecoder(resp.Body).Decode(&comments); err != nil {
+	var items []T
+	if err := json.NewDecoder(resp.Body).Decode(&items); err != nil {
 		return nil, "", fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	// Parse Link header for pagination
 	nextURL := parseNextLink(resp.Header.Get("Link"))
 
-	return comments, nextURL, nil
+	return items, nextURL, nil
 }
 
 // sortCommentsChronologically sorts comments by CreatedAt timestamp (oldest first).
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/pathglob"
 )
 
 // fingerprintMarkerStart is the HTML comment prefix for embedding fingerprints.
@@ -28,6 +29,53 @@ type ReviewActions struct {
 	// regardless of severity. This provides an additive override for specific categories
 	// like "security" that should always block, even if severity-based config wouldn't.
 	AlwaysBlockCategories []string
+
+	// PathOverrides change the blocking behavior for findings in matching files.
+	// Overrides are applied in order, so later matches win.
+	PathOverrides []PathReviewActions
+}
+
+// PathReviewActions overrides the severity actions and always-block categories
+// for findings in files matching Paths (from per-path review policies).
+type PathReviewActions struct {
+	Paths []string // Path globs; "**" matches any number of directories
+
+	// Empty values keep the inherited action
+	OnCritical string
+	OnHigh     string
+	OnMedium   string
+	OnLow      string
+
+	// AlwaysBlockCategories replaces the inherited list when non-nil
+	AlwaysBlockCategories []string
+}
+
+// ForFile returns the actions that apply to findings in file, with matching
+// path overrides applied.
+func (a ReviewActions) ForFile(file string) ReviewActions {
+	result := a
+	for _, override := range a.PathOverrides {
+		if !pathglob.MatchAny(override.Paths, file) {
+			continue
+		}
+		if override.OnCritical != "" {
+			result.OnCritical = override.OnCritical
+		}
+		if override.OnHigh != "" {
+			result.OnHigh = override.OnHigh
+		}
+		if override.OnMedium != "" {
+			result.OnMedium = override.OnMedium
+		}
+		if override.OnLow != "" {
+			result.OnLow = override.OnLow
+		}
+		if override.AlwaysBlockCategories != nil {
+			result.AlwaysBlockCategories = override.AlwaysBlockCategories
+		}
+	}
+	result.PathOverrides = nil
+	return result
 }
 
 // NormalizeAction converts a string action to ReviewEvent.
@@ -259,12 +307,23 @@ func wouldTriggerRequestChanges(action string, defaultBlocking bool) bool {
 // A finding blocks if EITHER:
 // - Its severity triggers REQUEST_CHANGES (via OnCritical/OnHigh/etc. or defaults)
 // - Its category is in the AlwaysBlockCategories list (additive override)
+//
+// Path overrides are resolved per finding, by the finding's file.
 func HasBlockingFindings(findings []PositionedFinding, actions ReviewActions) bool {
 	inDiffFindings := filterInDiff(findings)
 	if len(inDiffFindings) == 0 {
 		return false
 	}
 
+	if len(actions.PathOverrides) > 0 {
+		for _, pf := range inDiffFindings {
+			if HasBlockingFindings([]PositionedFinding{pf}, actions.ForFile(pf.Finding.File)) {
+				return true
+			}
+		}
+		return false
+	}
+
 	// Build action map from configuration
 	actionMap := map[string]string{
 		"critical": actions.OnCritical,
@@ -633,6 +633,52 @@ func TestHasBlockingFindings(t *testing.T) {
 	}
 }
 
+func TestHasBlockingFindings_PathOverrides(t *testing.T) {
+	finding := func(file, severity, category string) github.PositionedFinding {
+		return github.PositionedFinding{
+			Finding:      domain.Finding{File: file, LineStart: 1, LineEnd: 1, Severity: severity, Category: category},
+			DiffPosition: diff.IntPtr(1),
+		}
+	}
+	actions := github.ReviewActions{
+		AlwaysBlockCategories: []string{"security"},
+		PathOverrides: []github.PathReviewActions{
+			// Stricter blocking for payments
+			{Paths: []string{"payments/**"}, OnMedium: "request_changes"},
+			// Tests never block, not even for security findings
+			{Paths: []string{"*_test.go"}, OnCritical: "comment", OnHigh: "comment", AlwaysBlockCategories: []string{}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		finding  github.PositionedFinding
+		expected bool
+	}{
+		{"medium in payments blocks", finding("payments/charge.go", "medium", "bug"), true},
+		{"medium elsewhere doesn't block", finding("api/handler.go", "medium", "bug"), false},
+		{"high elsewhere blocks", finding("api/handler.go", "high", "bug"), true},
+		{"critical in tests doesn't block", finding("api/handler_test.go", "critical", "bug"), false},
+		{"security category in tests doesn't block", finding("api/handler_test.go", "low", "security"), false},
+		{"security category elsewhere blocks", finding("api/handler.go", "low", "security"), true},
+		{"later override wins", finding("payments/charge_test.go", "high", "bug"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := github.HasBlockingFindings([]github.PositionedFinding{tt.finding}, actions)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+
+	// The event for a mix follows the strictest file
+	event := github.DetermineReviewEventWithActions([]github.PositionedFinding{
+		finding("api/handler.go", "medium", "bug"),
+		finding("payments/charge.go", "medium", "bug"),
+	}, actions)
+	assert.Equal(t, github.EventRequestChanges, event)
+}
+
 func TestHasBlockingFindings_AlwaysBlockCategories(t *testing.T) {
 	// Helper to create a finding with specific category
 	makeFindingWithCategory := func(file string, line int, severity, category, description string) domain.Finding {
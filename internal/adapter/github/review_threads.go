@@ -0,0 +1,274 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+)
+
+// listReviewThreadsQuery fetches review threads with the REST ID of each
+// thread's root comment, so threads can be matched to PullRequestComments.
+const listReviewThreadsQuery = `query($owner: String!, $repo: String!, $number: Int!, $cursor: String) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100, after: $cursor) {
+        nodes {
+          id
+          isResolved
+          comments(first: 1) { nodes { databaseId } }
+        }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+}`
+
+const resolveReviewThreadMutation = `mutation($threadId: ID!) {
+  resolveReviewThread(input: {threadId: $threadId}) {
+    thread { id isResolved }
+  }
+}`
+
+// ReviewThread is a pull request review thread from the GraphQL API.
+type ReviewThread struct {
+	// ID is the GraphQL node ID used by resolveReviewThread.
+	ID string
+
+	// IsResolved reports whether the thread is already resolved.
+	IsResolved bool
+
+	// RootCommentID is the REST ID of the thread's first comment.
+	RootCommentID int64
+}
+
+// graphQLRequest is the body of a GraphQL API call.
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+// graphQLResponse is the envelope of a GraphQL API response.
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+type reviewThreadsData struct {
+	Repository struct {
+		PullRequest struct {
+			ReviewThreads struct {
+				Nodes []struct {
+					ID         string `json:"id"`
+					IsResolved bool   `json:"isResolved"`
+					Comments   struct {
+						Nodes []struct {
+							DatabaseID int64 `json:"databaseId"`
+						} `json:"nodes"`
+					} `json:"comments"`
+				} `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"reviewThreads"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+// ListReviewThreads fetches all review threads on a pull request via GraphQL.
+func (c *Client) ListReviewThreads(ctx context.Context, owner, repo string, pullNumber int) ([]ReviewThread, error) {
+	if err := validatePathSegment(owner, "owner"); err != nil {
+		return nil, err
+	}
+	if err := validatePathSegment(repo, "repo"); err != nil {
+		return nil, err
+	}
+
+	var threads []ReviewThread
+	var cursor interface{}
+	for page := 0; ; page++ {
+		if page >= maxPaginationPages {
+			return nil, fmt.Errorf("pagination limit exceeded (%d pages)", maxPaginationPages)
+		}
+
+		var data reviewThreadsData
+		err := c.graphQL(ctx, listReviewThreadsQuery, map[string]interface{}{
+			"owner":  owner,
+			"repo":   repo,
+			"number": pullNumber,
+			"cursor": cursor,
+		}, &data)
+		if err != nil {
+			return nil, err
+		}
+
+		reviewThreads := data.Repository.PullRequest.ReviewThreads
+		for _, node := range reviewThreads.Nodes {
+			thread := ReviewThread{ID: node.ID, IsResolved: node.IsResolved}
+			if len(node.Comments.Nodes) > 0 {
+				thread.RootCommentID = node.Comments.Nodes[0].DatabaseID
+			}
+			threads = append(threads, thread)
+		}
+
+		if !reviewThreads.PageInfo.HasNextPage || reviewThreads.PageInfo.EndCursor == "" {
+			return threads, nil
+		}
+		cursor = reviewThreads.PageInfo.EndCursor
+	}
+}
+
+// ResolveReviewThread marks a review thread as resolved via GraphQL.
+func (c *Client) ResolveReviewThread(ctx context.Context, threadID string) error {
+	if threadID == "" {
+		return fmt.Errorf("invalid thread ID: must not be empty")
+	}
+	return c.graphQL(ctx, resolveReviewThreadMutation, map[string]interface{}{"threadId": threadID}, nil)
+}
+
+// CreateReplyComment replies to a top-level review comment.
+func (c *Client) CreateReplyComment(ctx context.Context, owner, repo string, pullNumber int, commentID int64, body string) (*PullRequestComment, error) {
+	if err := validatePathSegment(owner, "owner"); err != nil {
+		return nil, err
+	}
+	if err := validatePathSegment(repo, "repo"); err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(CreateReplyRequest{Body: body})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	apiURL := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/comments/%d/replies",
+		c.baseURL, url.PathEscape(owner), url.PathEscape(repo), pullNumber, commentID)
+
+	var comment PullRequestComment
+	if err := c.doJSON(ctx, http.MethodPost, apiURL, jsonData, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// graphQLURL returns the GraphQL endpoint for the configured REST base URL.
+// GitHub Enterprise Server serves REST under /api/v3 and GraphQL under /api/graphql.
+func (c *Client) graphQLURL() string {
+	if strings.HasSuffix(c.baseURL, "/api/v3") {
+		return strings.TrimSuffix(c.baseURL, "/v3") + "/graphql"
+	}
+	return c.baseURL + "/graphql"
+}
+
+// graphQL executes a GraphQL query and decodes its data into out (if non-nil).
+// GraphQL reports failures in an errors array with HTTP 200; these are returned
+// as non-retryable invalid request errors.
+func (c *Client) graphQL(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	jsonData, err := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	var resp graphQLResponse
+	if err := c.doJSON(ctx, http.MethodPost, c.graphQLURL(), jsonData, &resp); err != nil {
+		return err
+	}
+
+	if len(resp.Errors) > 0 {
+		messages := make([]string, len(resp.Errors))
+		for i, e := range resp.Errors {
+			messages[i] = e.Message
+		}
+		return &llmhttp.Error{
+			Type:      llmhttp.ErrTypeInvalidRequest,
+			Message:   "GraphQL: " + strings.Join(messages, "; "),
+			Retryable: false,
+			Provider:  providerName,
+		}
+	}
+
+	if out == nil || len(resp.Data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Data, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// doJSON executes a request with retry, maps error responses to llmhttp.Error
+// and decodes a successful response into out (if non-nil).
+func (c *Client) doJSON(ctx context.Context, method, apiURL string, body []byte, out interface{}) error {
+	var resp *http.Response
+	err := llmhttp.RetryWithBackoff(ctx, func(ctx context.Context) error {
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+
+		req, reqErr := http.NewRequestWithContext(ctx, method, apiURL, reader)
+		if reqErr != nil {
+			return &llmhttp.Error{
+				Type:      llmhttp.ErrTypeUnknown,
+				Message:   reqErr.Error(),
+				Retryable: false,
+				Provider:  providerName,
+			}
+		}
+
+		req.Header.Set("Authorization", "Bearer "+c.token)
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		var callErr error
+		resp, callErr = c.httpClient.Do(req)
+		if callErr != nil {
+			return &llmhttp.Error{
+				Type:      llmhttp.ErrTypeTimeout,
+				Message:   callErr.Error(),
+				Retryable: true,
+				Provider:  providerName,
+			}
+		}
+
+		if resp.StatusCode >= 400 {
+			bodyBytes, readErr := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if readErr != nil {
+				return &llmhttp.Error{
+					Type:       llmhttp.ErrTypeUnknown,
+					Message:    fmt.Sprintf("HTTP %d (failed to read response: %v)", resp.StatusCode, readErr),
+					StatusCode: resp.StatusCode,
+					Retryable:  resp.StatusCode >= 500,
+					Provider:   providerName,
+				}
+			}
+			return MapHTTPError(resp.StatusCode, bodyBytes)
+		}
+
+		return nil
+	}, c.retryConf)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
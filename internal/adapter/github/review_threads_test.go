@@ -0,0 +1,132 @@
+package github_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type graphQLBody struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+func TestListReviewThreads_Pagination(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/graphql", r.URL.Path)
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		var body graphQLBody
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "owner", body.Variables["owner"])
+		assert.Equal(t, float64(42), body.Variables["number"])
+
+		w.Header().Set("Content-Type", "application/json")
+		if body.Variables["cursor"] == nil {
+			fmt.Fprint(w, `{"data":{"repository":{"pullRequest":{"reviewThreads":{
+				"nodes":[{"id":"T1","isResolved":false,"comments":{"nodes":[{"databaseId":101}]}}],
+				"pageInfo":{"hasNextPage":true,"endCursor":"c1"}}}}}}`)
+			return
+		}
+		assert.Equal(t, "c1", body.Variables["cursor"])
+		fmt.Fprint(w, `{"data":{"repository":{"pullRequest":{"reviewThreads":{
+			"nodes":[{"id":"T2","isResolved":true,"comments":{"nodes":[{"databaseId":102}]}}],
+			"pageInfo":{"hasNextPage":false,"endCursor":"c2"}}}}}}`)
+	}))
+	defer server.Close()
+
+	client := github.NewClient("test-token")
+	client.SetBaseURL(server.URL)
+
+	threads, err := client.ListReviewThreads(context.Background(), "owner", "repo", 42)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, []github.ReviewThread{
+		{ID: "T1", IsResolved: false, RootCommentID: 101},
+		{ID: "T2", IsResolved: true, RootCommentID: 102},
+	}, threads)
+}
+
+func TestResolveReviewThread_EnterpriseGraphQLEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/graphql", r.URL.Path)
+
+		var body graphQLBody
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Contains(t, body.Query, "resolveReviewThread")
+		assert.Equal(t, "T1", body.Variables["threadId"])
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data":{"resolveReviewThread":{"thread":{"id":"T1","isResolved":true}}}}`)
+	}))
+	defer server.Close()
+
+	client := github.NewClient("test-token")
+	client.SetBaseURL(server.URL + "/api/v3")
+
+	require.NoError(t, client.ResolveReviewThread(context.Background(), "T1"))
+}
+
+func TestResolveReviewThread_GraphQLErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data":null,"errors":[{"message":"Resource not accessible by integration"}]}`)
+	}))
+	defer server.Close()
+
+	client := github.NewClient("test-token")
+	client.SetBaseURL(server.URL)
+	client.SetMaxRetries(0)
+	client.SetInitialBackoff(time.Millisecond)
+
+	err := client.ResolveReviewThread(context.Background(), "T1")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Resource not accessible by integration")
+}
+
+func TestCreateReplyComment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/repos/owner/repo/pulls/42/comments/101/replies", r.URL.Path)
+
+		var req github.CreateReplyRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "✅ No longer detected in `abc1234`.", req.Body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(github.PullRequestComment{ID: 200, InReplyToID: 101, Body: req.Body})
+	}))
+	defer server.Close()
+
+	client := github.NewClient("test-token")
+	client.SetBaseURL(server.URL)
+
+	reply, err := client.CreateReplyComment(context.Background(), "owner", "repo", 42, 101, "✅ No longer detected in `abc1234`.")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(200), reply.ID)
+	assert.Equal(t, int64(101), reply.InReplyToID)
+}
+
+func TestCreateReplyComment_RejectsPathInjection(t *testing.T) {
+	client := github.NewClient("test-token")
+
+	_, err := client.CreateReplyComment(context.Background(), "../admin", "repo", 42, 101, "body")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid owner")
+}
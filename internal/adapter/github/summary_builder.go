@@ -13,6 +13,7 @@ import (
 // The appendix includes sections for:
 // - Findings outside diff (deleted lines, lines not in hunks)
 // - Binary files changed
+// - Generated, vendored and lock files skipped
 // - Renamed files
 func BuildSummaryAppendix(findings []PositionedFinding, d domain.Diff) string {
 	var sections []string
@@ -29,7 +30,13 @@ func BuildSummaryAppendix(findings []PositionedFinding, d domain.Diff) string {
 		sections = append(sections, formatBinaryFilesSection(binaryFiles))
 	}
 
-	// Section 3: Renamed files
+	// Section 3: Generated, vendored and lock files
+	generatedFiles := FilterGeneratedFiles(d.Files)
+	if len(generatedFiles) > 0 {
+		sections = append(sections, formatGeneratedFilesSection(generatedFiles))
+	}
+
+	// Section 4: Renamed files
 	renamedFiles := FilterRenamedFiles(d.Files)
 	if len(renamedFiles) > 0 {
 		sections = append(sections, formatRenamedFilesSection(renamedFiles))
@@ -73,6 +80,18 @@ func FilterBinaryFiles(files []domain.FileDiff) []domain.FileDiff {
 	return result
 }
 
+// FilterGeneratedFiles returns text files that were skipped as generated,
+// vendored or lockfiles. Binary files are listed in their own section.
+func FilterGeneratedFiles(files []domain.FileDiff) []domain.FileDiff {
+	var result []domain.FileDiff
+	for _, f := range files {
+		if f.Generated != "" && !f.IsBinary {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
 // FilterRenamedFiles returns files that were renamed.
 func FilterRenamedFiles(files []domain.FileDiff) []domain.FileDiff {
 	var result []domain.FileDiff
@@ -115,6 +134,20 @@ func formatBinaryFilesSection(files []domain.FileDiff) string {
 	return sb.String()
 }
 
+// formatGeneratedFilesSection formats the "Generated Files Skipped" section.
+func formatGeneratedFilesSection(files []domain.FileDiff) string {
+	var sb strings.Builder
+
+	sb.WriteString("## Generated Files Skipped\n\n")
+	sb.WriteString("The following generated, vendored or lock files were changed and excluded from review:\n\n")
+
+	for _, f := range files {
+		sb.WriteString(fmt.Sprintf("- `%s` (%s, %s)\n", escapeMarkdownInlineCode(f.Path), f.Status, f.Generated))
+	}
+
+	return sb.String()
+}
+
 // formatRenamedFilesSection formats the "Files Renamed" section.
 func formatRenamedFilesSection(files []domain.FileDiff) string {
 	var sb strings.Builder
@@ -78,6 +78,37 @@ func TestBuildSummaryAppendix_BinaryFiles(t *testing.T) {
 	}
 }
 
+func TestBuildSummaryAppendix_GeneratedFiles(t *testing.T) {
+	findings := []github.PositionedFinding{}
+	d := domain.Diff{
+		Files: []domain.FileDiff{
+			{Path: "api/api.pb.go", Status: domain.FileStatusModified, Generated: domain.GeneratedCode},
+			{Path: "go.sum", Status: domain.FileStatusModified, Generated: domain.GeneratedLockfile},
+			{Path: "vendor/logo.png", Status: domain.FileStatusAdded, IsBinary: true, Generated: domain.GeneratedVendored},
+			{Path: "main.go", Status: domain.FileStatusModified},
+		},
+	}
+
+	result := github.BuildSummaryAppendix(findings, d)
+
+	if !strings.Contains(result, "Generated Files Skipped") {
+		t.Errorf("expected 'Generated Files Skipped' section, got %q", result)
+	}
+	if !strings.Contains(result, "`api/api.pb.go` (modified, generated)") {
+		t.Errorf("expected generated file with its reason in appendix, got %q", result)
+	}
+	if !strings.Contains(result, "`go.sum` (modified, lockfile)") {
+		t.Errorf("expected lockfile with its reason in appendix, got %q", result)
+	}
+	// Binary files are only listed in the binary section
+	if strings.Count(result, "vendor/logo.png") != 1 {
+		t.Errorf("expected vendor/logo.png listed once, got %q", result)
+	}
+	if strings.Contains(result, "main.go") {
+		t.Errorf("expected reviewed files not to be listed, got %q", result)
+	}
+}
+
 func TestBuildSummaryAppendix_RenamedFiles(t *testing.T) {
 	findings := []github.PositionedFinding{}
 	d := domain.Diff{
@@ -0,0 +1,93 @@
+package gitlab
+
+// GitLab Merge Requests API types.
+// See: https://docs.gitlab.com/ee/api/discussions.html#merge-requests
+
+// PositionTypeText is the position type for inline comments on text diffs.
+const PositionTypeText = "text"
+
+// User represents a GitLab user in API responses.
+type User struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	Bot      bool   `json:"bot"`
+}
+
+// DiffRefs holds the SHAs that identify a merge request diff version.
+// All three are required when creating positioned discussions.
+type DiffRefs struct {
+	BaseSHA  string `json:"base_sha"`
+	StartSHA string `json:"start_sha"`
+	HeadSHA  string `json:"head_sha"`
+}
+
+// MergeRequest is the subset of GET /projects/:id/merge_requests/:iid used by the poster.
+type MergeRequest struct {
+	ID       int64    `json:"id"`
+	IID      int      `json:"iid"`
+	SHA      string   `json:"sha"`
+	WebURL   string   `json:"web_url"`
+	DiffRefs DiffRefs `json:"diff_refs"`
+}
+
+// Position anchors a discussion to a line in the merge request diff.
+type Position struct {
+	PositionType string `json:"position_type"`
+	BaseSHA      string `json:"base_sha"`
+	StartSHA     string `json:"start_sha"`
+	HeadSHA      string `json:"head_sha"`
+	OldPath      string `json:"old_path"`
+	NewPath      string `json:"new_path"`
+	NewLine      *int   `json:"new_line,omitempty"`
+	OldLine      *int   `json:"old_line,omitempty"`
+}
+
+// CreateDiscussionRequest is the request body for POST /projects/:id/merge_requests/:iid/discussions.
+// Position is omitted for general (non-inline) discussions such as the review summary.
+type CreateDiscussionRequest struct {
+	Body     string    `json:"body"`
+	Position *Position `json:"position,omitempty"`
+}
+
+// Note is a single comment within a discussion.
+type Note struct {
+	ID         int64     `json:"id"`
+	Body       string    `json:"body"`
+	Author     User      `json:"author"`
+	CreatedAt  string    `json:"created_at"`
+	System     bool      `json:"system"`
+	Resolvable bool      `json:"resolvable"`
+	Resolved   bool      `json:"resolved"`
+	Position   *Position `json:"position,omitempty"`
+}
+
+// Discussion is a thread of notes on a merge request.
+// The first note is the thread starter; subsequent notes are replies.
+type Discussion struct {
+	ID             string `json:"id"`
+	IndividualNote bool   `json:"individual_note"`
+	Notes          []Note `json:"notes"`
+}
+
+// Resolved reports whether every resolvable note in the discussion is resolved.
+func (d Discussion) Resolved() bool {
+	resolvable := false
+	for _, n := range d.Notes {
+		if !n.Resolvable {
+			continue
+		}
+		resolvable = true
+		if !n.Resolved {
+			return false
+		}
+	}
+	return resolvable
+}
+
+// GitLabErrorResponse represents an error response from the GitLab API.
+// GitLab returns either "message" (string or object) or "error".
+type GitLabErrorResponse struct {
+	Message interface{} `json:"message"`
+	Error   string      `json:"error"`
+}
@@ -0,0 +1,320 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+)
+
+const (
+	defaultBaseURL        = "https://gitlab.com/api/v4"
+	defaultTimeout        = 30 * time.Second
+	defaultMaxRetries     = 3
+	defaultInitialBackoff = 2 * time.Second
+	maxPaginationPages    = 100 // Prevent infinite pagination loops
+)
+
+// validateProject validates a project path or numeric ID.
+// Unlike GitHub owner/repo segments, GitLab project paths may contain
+// slashes for nested groups (e.g. "group/subgroup/project"); the whole path
+// is escaped into a single URL segment.
+func validateProject(project string) error {
+	if project == "" {
+		return fmt.Errorf("invalid project: must not be empty")
+	}
+	if strings.Contains(project, "..") {
+		return fmt.Errorf("invalid project: must not contain '..'")
+	}
+	if strings.HasPrefix(project, "/") || strings.HasSuffix(project, "/") {
+		return fmt.Errorf("invalid project: must not start or end with '/'")
+	}
+	return nil
+}
+
+// Client is an HTTP client for the GitLab Merge Request APIs.
+type Client struct {
+	token      string
+	baseURL    string
+	httpClient *http.Client
+	retryConf  llmhttp.RetryConfig
+}
+
+// NewClient creates a new GitLab API client with the given token.
+// The token should be a personal, project or group access token with the api scope.
+func NewClient(token string) *Client {
+	return &Client{
+		token:      token,
+		baseURL:    defaultBaseURL,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		retryConf: llmhttp.RetryConfig{
+			MaxRetries:     defaultMaxRetries,
+			InitialBackoff: defaultInitialBackoff,
+			MaxBackoff:     32 * time.Second,
+			Multiplier:     2.0,
+		},
+	}
+}
+
+// SetBaseURL sets a custom API base URL (self-managed instances, testing).
+// The URL must include the API prefix, e.g. https://gitlab.example.com/api/v4.
+// All trailing slashes are trimmed to ensure consistent URL construction.
+func (c *Client) SetBaseURL(baseURL string) {
+	c.baseURL = strings.TrimRight(baseURL, "/")
+}
+
+// SetTimeout sets the HTTP timeout.
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.httpClient.Timeout = timeout
+}
+
+// SetMaxRetries sets the maximum number of retry attempts.
+func (c *Client) SetMaxRetries(maxRetries int) {
+	c.retryConf.MaxRetries = maxRetries
+}
+
+// SetInitialBackoff sets the initial backoff duration for retries.
+func (c *Client) SetInitialBackoff(backoff time.Duration) {
+	c.retryConf.InitialBackoff = backoff
+}
+
+// mergeRequestURL builds the base URL for a merge request resource.
+func (c *Client) mergeRequestURL(project string, mrIID int) string {
+	return fmt.Sprintf("%s/projects/%s/merge_requests/%d",
+		c.baseURL, url.PathEscape(project), mrIID)
+}
+
+// CurrentUser returns the user that owns the API token.
+// The poster uses this to recognise its own discussions.
+func (c *Client) CurrentUser(ctx context.Context) (*User, error) {
+	resp, err := c.do(ctx, http.MethodGet, c.baseURL+"/user", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var user User
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &user, nil
+}
+
+// GetMergeRequest fetches a merge request, including the diff refs needed for positions.
+func (c *Client) GetMergeRequest(ctx context.Context, project string, mrIID int) (*MergeRequest, error) {
+	if err := validateProject(project); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, c.mergeRequestURL(project, mrIID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var mr MergeRequest
+	if err := json.NewDecoder(resp.Body).Decode(&mr); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &mr, nil
+}
+
+// ListDiscussions fetches all discussions on a merge request.
+// Handles GitLab pagination via the X-Next-Page header. Page URLs are built
+// locally from the page number rather than followed from response headers,
+// so a malicious response cannot redirect requests to another host.
+func (c *Client) ListDiscussions(ctx context.Context, project string, mrIID int) ([]Discussion, error) {
+	if err := validateProject(project); err != nil {
+		return nil, err
+	}
+
+	var all []Discussion
+	page := "1"
+	visited := make(map[string]bool)
+
+	for page != "" {
+		if len(visited) >= maxPaginationPages {
+			return nil, fmt.Errorf("pagination limit exceeded (%d pages)", maxPaginationPages)
+		}
+		if visited[page] {
+			return nil, fmt.Errorf("pagination loop detected: page %s already visited", page)
+		}
+		visited[page] = true
+
+		if _, err := strconv.Atoi(page); err != nil {
+			return nil, fmt.Errorf("invalid X-Next-Page header: %q", page)
+		}
+
+		pageURL := fmt.Sprintf("%s/discussions?per_page=100&page=%s", c.mergeRequestURL(project, mrIID), page)
+		resp, err := c.do(ctx, http.MethodGet, pageURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var discussions []Discussion
+		decodeErr := json.NewDecoder(resp.Body).Decode(&discussions)
+		resp.Body.Close()
+		if decodeErr != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", decodeErr)
+		}
+		all = append(all, discussions...)
+
+		page = strings.TrimSpace(resp.Header.Get("X-Next-Page"))
+	}
+
+	return all, nil
+}
+
+// CreateDiscussion starts a new discussion thread on a merge request.
+// Inline discussions carry a Position; general discussions leave it nil.
+func (c *Client) CreateDiscussion(ctx context.Context, project string, mrIID int, input CreateDiscussionRequest) (*Discussion, error) {
+	if err := validateProject(project); err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, c.mergeRequestURL(project, mrIID)+"/discussions", jsonData)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var discussion Discussion
+	if err := json.NewDecoder(resp.Body).Decode(&discussion); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &discussion, nil
+}
+
+// ResolveDiscussion resolves or unresolves a merge request discussion.
+func (c *Client) ResolveDiscussion(ctx context.Context, project string, mrIID int, discussionID string, resolved bool) error {
+	if err := validateProject(project); err != nil {
+		return err
+	}
+	if discussionID == "" || strings.ContainsAny(discussionID, "/?#") {
+		return fmt.Errorf("invalid discussion ID: %q", discussionID)
+	}
+
+	apiURL := fmt.Sprintf("%s/discussions/%s?resolved=%t",
+		c.mergeRequestURL(project, mrIID), url.PathEscape(discussionID), resolved)
+
+	resp, err := c.do(ctx, http.MethodPut, apiURL, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Approve approves the merge request as the token user.
+// The SHA pins the approval to the reviewed head commit; GitLab rejects the
+// approval if the merge request has moved on since.
+func (c *Client) Approve(ctx context.Context, project string, mrIID int, sha string) error {
+	if err := validateProject(project); err != nil {
+		return err
+	}
+
+	var body []byte
+	if sha != "" {
+		jsonData, err := json.Marshal(map[string]string{"sha": sha})
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = jsonData
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, c.mergeRequestURL(project, mrIID)+"/approve", body)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Unapprove revokes the token user's approval of the merge request.
+// GitLab answers 404 when the user has not approved; callers may ignore that.
+func (c *Client) Unapprove(ctx context.Context, project string, mrIID int) error {
+	if err := validateProject(project); err != nil {
+		return err
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, c.mergeRequestURL(project, mrIID)+"/unapprove", nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// do executes a request with retry and maps error responses to llmhttp.Error.
+// On success the caller owns the response body.
+func (c *Client) do(ctx context.Context, method, apiURL string, body []byte) (*http.Response, error) {
+	var resp *http.Response
+	err := llmhttp.RetryWithBackoff(ctx, func(ctx context.Context) error {
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+
+		req, reqErr := http.NewRequestWithContext(ctx, method, apiURL, reader)
+		if reqErr != nil {
+			return &llmhttp.Error{
+				Type:      llmhttp.ErrTypeUnknown,
+				Message:   reqErr.Error(),
+				Retryable: false,
+				Provider:  providerName,
+			}
+		}
+
+		req.Header.Set("PRIVATE-TOKEN", c.token)
+		req.Header.Set("Accept", "application/json")
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		var callErr error
+		resp, callErr = c.httpClient.Do(req)
+		if callErr != nil {
+			return &llmhttp.Error{
+				Type:      llmhttp.ErrTypeTimeout,
+				Message:   callErr.Error(),
+				Retryable: true,
+				Provider:  providerName,
+			}
+		}
+
+		if resp.StatusCode >= 400 {
+			bodyBytes, readErr := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if readErr != nil {
+				return &llmhttp.Error{
+					Type:       llmhttp.ErrTypeUnknown,
+					Message:    fmt.Sprintf("HTTP %d (failed to read response: %v)", resp.StatusCode, readErr),
+					StatusCode: resp.StatusCode,
+					Retryable:  resp.StatusCode >= 500,
+					Provider:   providerName,
+				}
+			}
+			return MapHTTPError(resp.StatusCode, bodyBytes)
+		}
+
+		return nil
+	}, c.retryConf)
+
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
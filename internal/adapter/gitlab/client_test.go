@@ -0,0 +1,209 @@
+package gitlab_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/gitlab"
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(serverURL string) *gitlab.Client {
+	client := gitlab.NewClient("test-token")
+	client.SetBaseURL(serverURL + "/")
+	client.SetMaxRetries(0)
+	client.SetInitialBackoff(time.Millisecond)
+	return client
+}
+
+func TestNewClient(t *testing.T) {
+	require.NotNil(t, gitlab.NewClient("test-token"))
+}
+
+func TestClient_GetMergeRequest_EscapesNestedProjectPath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/projects/group%2Fsub%2Fproj/merge_requests/42", r.URL.EscapedPath())
+		assert.Equal(t, "test-token", r.Header.Get("PRIVATE-TOKEN"))
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":1,"iid":42,"sha":"h","web_url":"https://gl/mr/42",
+			"diff_refs":{"base_sha":"b","start_sha":"s","head_sha":"h"}}`)
+	}))
+	defer server.Close()
+
+	mr, err := newTestClient(server.URL).GetMergeRequest(context.Background(), "group/sub/proj", 42)
+	require.NoError(t, err)
+	assert.Equal(t, 42, mr.IID)
+	assert.Equal(t, "https://gl/mr/42", mr.WebURL)
+	assert.Equal(t, gitlab.DiffRefs{BaseSHA: "b", StartSHA: "s", HeadSHA: "h"}, mr.DiffRefs)
+}
+
+func TestClient_InvalidProject(t *testing.T) {
+	client := gitlab.NewClient("test-token")
+
+	for _, project := range []string{"", "../etc", "/group/proj", "group/proj/"} {
+		_, err := client.GetMergeRequest(context.Background(), project, 1)
+		assert.Error(t, err, "project %q should be rejected", project)
+	}
+}
+
+func TestClient_ListDiscussions_Paginates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		w.Header().Set("Content-Type", "application/json")
+		switch page {
+		case "1":
+			w.Header().Set("X-Next-Page", "2")
+			fmt.Fprint(w, `[{"id":"d1","notes":[{"id":1,"body":"first","author":{"username":"bot"}}]}]`)
+		case "2":
+			w.Header().Set("X-Next-Page", "")
+			fmt.Fprint(w, `[{"id":"d2","notes":[{"id":2,"body":"second","resolvable":true,"resolved":true}]}]`)
+		default:
+			t.Errorf("unexpected page %q", page)
+		}
+	}))
+	defer server.Close()
+
+	discussions, err := newTestClient(server.URL).ListDiscussions(context.Background(), "g/p", 1)
+	require.NoError(t, err)
+	require.Len(t, discussions, 2)
+	assert.Equal(t, "d1", discussions[0].ID)
+	assert.Equal(t, "bot", discussions[0].Notes[0].Author.Username)
+	assert.False(t, discussions[0].Resolved())
+	assert.True(t, discussions[1].Resolved())
+}
+
+func TestClient_ListDiscussions_DetectsLoop(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Next-Page", "1")
+		fmt.Fprint(w, `[]`)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server.URL).ListDiscussions(context.Background(), "g/p", 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pagination loop")
+}
+
+func TestClient_ListDiscussions_RejectsNonNumericNextPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Next-Page", "https://evil.example.com")
+		fmt.Fprint(w, `[]`)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server.URL).ListDiscussions(context.Background(), "g/p", 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid X-Next-Page")
+}
+
+func TestClient_CreateDiscussion_SendsPosition(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/projects/g%2Fp/merge_requests/3/discussions", r.URL.EscapedPath())
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "hello", body["body"])
+		position := body["position"].(map[string]interface{})
+		assert.Equal(t, "text", position["position_type"])
+		assert.Equal(t, "a.go", position["new_path"])
+		assert.Equal(t, float64(7), position["new_line"])
+		_, hasOldLine := position["old_line"]
+		assert.False(t, hasOldLine, "old_line must be omitted for added lines")
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":"abc","notes":[]}`)
+	}))
+	defer server.Close()
+
+	line := 7
+	discussion, err := newTestClient(server.URL).CreateDiscussion(context.Background(), "g/p", 3, gitlab.CreateDiscussionRequest{
+		Body: "hello",
+		Position: &gitlab.Position{
+			PositionType: gitlab.PositionTypeText,
+			NewPath:      "a.go",
+			OldPath:      "a.go",
+			NewLine:      &line,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "abc", discussion.ID)
+}
+
+func TestClient_ResolveDiscussion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/projects/g%2Fp/merge_requests/3/discussions/abc123", r.URL.EscapedPath())
+		assert.Equal(t, "true", r.URL.Query().Get("resolved"))
+		fmt.Fprint(w, `{"id":"abc123"}`)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	require.NoError(t, client.ResolveDiscussion(context.Background(), "g/p", 3, "abc123", true))
+	assert.Error(t, client.ResolveDiscussion(context.Background(), "g/p", 3, "../x?y", true))
+}
+
+func TestClient_ApproveAndUnapprove(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		if r.URL.Path == "/projects/g/p/merge_requests/3/approve" {
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "head", body["sha"])
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	require.NoError(t, client.Approve(context.Background(), "g/p", 3, "head"))
+	require.NoError(t, client.Unapprove(context.Background(), "g/p", 3))
+
+	assert.Equal(t, []string{
+		"/projects/g%2Fp/merge_requests/3/approve",
+		"/projects/g%2Fp/merge_requests/3/unapprove",
+	}, paths)
+}
+
+func TestClient_CurrentUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/user", r.URL.Path)
+		fmt.Fprint(w, `{"id":9,"username":"project_1_bot","bot":true}`)
+	}))
+	defer server.Close()
+
+	user, err := newTestClient(server.URL).CurrentUser(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "project_1_bot", user.Username)
+	assert.True(t, user.Bot)
+}
+
+func TestClient_MapsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message":"403 Forbidden"}`)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server.URL).GetMergeRequest(context.Background(), "g/p", 1)
+	require.Error(t, err)
+
+	var httpErr *llmhttp.Error
+	require.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, llmhttp.ErrTypeAuthentication, httpErr.Type)
+	assert.Equal(t, http.StatusForbidden, httpErr.StatusCode)
+	assert.Contains(t, httpErr.Message, "403 Forbidden")
+}
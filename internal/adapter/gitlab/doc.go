@@ -0,0 +1,19 @@
+// Package gitlab provides types and utilities for GitLab merge request review integration.
+//
+// This adapter mirrors the github adapter for GitLab's REST API (v4):
+//
+// # Types
+//   - PositionedFinding: Wraps domain.Finding with GitLab new_line/old_line positions
+//   - Discussion/Note: Merge request discussion threads and their notes
+//   - Position: The position object attached to inline diff discussions
+//
+// # Functions
+//   - MapFindings: Enriches findings with GitLab diff positions
+//   - MapHTTPError: Maps GitLab HTTP errors to typed llmhttp.Error
+//
+// # Client
+//   - Client: HTTP client for the GitLab Merge Requests, Discussions and Approvals APIs
+//
+// Finding comments reuse the hidden fingerprint markers from the github adapter,
+// so deduplication and reply-status tracking work the same on both platforms.
+package gitlab
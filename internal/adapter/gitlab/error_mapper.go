@@ -0,0 +1,113 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+)
+
+const providerName = "gitlab"
+
+// MapHTTPError maps GitLab API HTTP status codes to typed llmhttp.Error.
+// This allows reuse of existing retry logic and error handling infrastructure.
+func MapHTTPError(statusCode int, body []byte) *llmhttp.Error {
+	message := parseErrorMessage(statusCode, body)
+
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return &llmhttp.Error{
+			Type:       llmhttp.ErrTypeAuthentication,
+			Message:    message,
+			StatusCode: statusCode,
+			Retryable:  false,
+			Provider:   providerName,
+		}
+
+	case http.StatusTooManyRequests:
+		return &llmhttp.Error{
+			Type:       llmhttp.ErrTypeRateLimit,
+			Message:    message,
+			StatusCode: statusCode,
+			Retryable:  true,
+			Provider:   providerName,
+		}
+
+	case http.StatusNotFound, http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return &llmhttp.Error{
+			Type:       llmhttp.ErrTypeInvalidRequest,
+			Message:    message,
+			StatusCode: statusCode,
+			Retryable:  false,
+			Provider:   providerName,
+		}
+
+	case http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return &llmhttp.Error{
+			Type:       llmhttp.ErrTypeServiceUnavailable,
+			Message:    message,
+			StatusCode: statusCode,
+			Retryable:  true,
+			Provider:   providerName,
+		}
+
+	default:
+		return &llmhttp.Error{
+			Type:       llmhttp.ErrTypeUnknown,
+			Message:    message,
+			StatusCode: statusCode,
+			Retryable:  false,
+			Provider:   providerName,
+		}
+	}
+}
+
+// parseErrorMessage extracts a user-friendly error message from GitLab's response.
+// GitLab reports errors as {"message": "..."}, {"message": {"field": ["..."]}}
+// or {"error": "..."} depending on the endpoint.
+func parseErrorMessage(statusCode int, body []byte) string {
+	var errResp GitLabErrorResponse
+	if err := json.Unmarshal(body, &errResp); err != nil {
+		bodyPreview := string(body)
+		if len(bodyPreview) > 100 {
+			bodyPreview = bodyPreview[:100] + "..."
+		}
+		if bodyPreview == "" {
+			return fmt.Sprintf("HTTP %d", statusCode)
+		}
+		return fmt.Sprintf("HTTP %d: %s", statusCode, bodyPreview)
+	}
+
+	switch msg := errResp.Message.(type) {
+	case string:
+		if msg != "" {
+			return msg
+		}
+	case map[string]interface{}:
+		// Validation errors: {"message": {"note": ["can't be blank"]}}
+		fields := make([]string, 0, len(msg))
+		for field := range msg {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		var details []string
+		for _, field := range fields {
+			details = append(details, fmt.Sprintf("%s: %v", field, msg[field]))
+		}
+		if len(details) > 0 {
+			return strings.Join(details, "; ")
+		}
+	}
+
+	if errResp.Error != "" {
+		return errResp.Error
+	}
+	return fmt.Sprintf("HTTP %d", statusCode)
+}
@@ -0,0 +1,113 @@
+package gitlab
+
+import (
+	"github.com/bkyoung/code-reviewer/internal/diff"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+)
+
+// MapFindings enriches domain findings with GitLab diff line numbers.
+// GitLab requires new_line for added lines, old_line for deleted lines and
+// both for unchanged context lines, so each finding is resolved to the
+// matching diff line rather than to a diff position.
+//
+// A finding's line is matched against the new side of the diff first; if no
+// new-side line matches, a deleted line with that old-side number is used so
+// findings on removed code still get an inline discussion.
+//
+// For renamed files, the mapper checks both old and new paths, allowing
+// findings that reference the old filename to still be mapped correctly.
+//
+// If a finding's line is not in the diff, NewLine and OldLine are nil.
+//
+// This function is pure and does not modify the input findings.
+func MapFindings(findings []domain.Finding, d domain.Diff) []PositionedFinding {
+	if len(findings) == 0 {
+		return []PositionedFinding{}
+	}
+
+	type fileEntry struct {
+		parsed  diff.ParsedDiff
+		newPath string
+		oldPath string
+	}
+
+	files := make(map[string]fileEntry, len(d.Files))
+	for _, fileDiff := range d.Files {
+		if fileDiff.IsBinary {
+			continue
+		}
+
+		parsed, err := diff.Parse(fileDiff.Patch)
+		if err != nil {
+			continue
+		}
+
+		oldPath := fileDiff.Path
+		if fileDiff.OldPath != "" {
+			oldPath = fileDiff.OldPath
+		}
+		entry := fileEntry{parsed: parsed, newPath: fileDiff.Path, oldPath: oldPath}
+
+		files[fileDiff.Path] = entry
+		if fileDiff.OldPath != "" {
+			files[fileDiff.OldPath] = entry
+		}
+	}
+
+	result := make([]PositionedFinding, len(findings))
+	for i, finding := range findings {
+		pf := PositionedFinding{
+			Finding: finding,
+			NewPath: finding.File,
+			OldPath: finding.File,
+		}
+
+		if entry, ok := files[finding.File]; ok {
+			// Anchor renamed files by both names; Finding.File is left untouched
+			// so fingerprints stay stable.
+			pf.NewPath = entry.newPath
+			pf.OldPath = entry.oldPath
+			if line := entry.parsed.FindLine(finding.LineStart); line != nil {
+				pf.NewLine = line.NewLine
+				if line.Type == diff.LineContext {
+					pf.OldLine = line.OldLine
+				}
+			} else if line := entry.parsed.FindDeletedLine(finding.LineStart); line != nil {
+				pf.OldLine = line.OldLine
+			}
+		}
+
+		result[i] = pf
+	}
+
+	return result
+}
+
+// CountInDiffFindings returns the count of findings that are in the diff.
+func CountInDiffFindings(findings []PositionedFinding) int {
+	count := 0
+	for _, pf := range findings {
+		if pf.InDiff() {
+			count++
+		}
+	}
+	return count
+}
+
+// NewPosition builds the position object for an inline discussion on a finding.
+// Returns nil for findings that are not in the diff.
+func NewPosition(pf PositionedFinding, refs DiffRefs) *Position {
+	if !pf.InDiff() {
+		return nil
+	}
+	return &Position{
+		PositionType: PositionTypeText,
+		BaseSHA:      refs.BaseSHA,
+		StartSHA:     refs.StartSHA,
+		HeadSHA:      refs.HeadSHA,
+		OldPath:      pf.OldPath,
+		NewPath:      pf.NewPath,
+		NewLine:      pf.NewLine,
+		OldLine:      pf.OldLine,
+	}
+}
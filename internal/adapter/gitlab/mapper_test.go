@@ -0,0 +1,125 @@
+package gitlab_test
+
+import (
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/gitlab"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const samplePatch = `@@ -10,3 +10,4 @@ func example() {
+ context line 10
++added line 11
+ context line 12
+`
+
+func TestMapFindings_AddedAndContextLines(t *testing.T) {
+	d := domain.Diff{
+		Files: []domain.FileDiff{
+			{Path: "main.go", Status: domain.FileStatusModified, Patch: samplePatch},
+		},
+	}
+
+	findings := []domain.Finding{
+		{ID: "added", File: "main.go", LineStart: 11},
+		{ID: "context", File: "main.go", LineStart: 12},
+		{ID: "outside", File: "main.go", LineStart: 50},
+		{ID: "other", File: "other.go", LineStart: 1},
+	}
+
+	result := gitlab.MapFindings(findings, d)
+	require.Len(t, result, 4)
+
+	// Added lines carry only new_line
+	require.NotNil(t, result[0].NewLine)
+	assert.Equal(t, 11, *result[0].NewLine)
+	assert.Nil(t, result[0].OldLine)
+
+	// Context lines carry both; line 12 in the new file is line 11 in the old one
+	require.NotNil(t, result[1].NewLine)
+	require.NotNil(t, result[1].OldLine)
+	assert.Equal(t, 12, *result[1].NewLine)
+	assert.Equal(t, 11, *result[1].OldLine)
+
+	assert.False(t, result[2].InDiff())
+	assert.False(t, result[3].InDiff())
+	assert.Equal(t, 2, gitlab.CountInDiffFindings(result))
+}
+
+func TestMapFindings_DeletedLines(t *testing.T) {
+	d := domain.Diff{
+		Files: []domain.FileDiff{
+			{Path: "main.go", Status: domain.FileStatusModified, Patch: `@@ -10,4 +10,3 @@ func example() {
+ context line 10
+-removed line 11
+-removed line 12
+ context line 13
+`},
+		},
+	}
+
+	findings := []domain.Finding{
+		{ID: "deleted", File: "main.go", LineStart: 12},
+		{ID: "context", File: "main.go", LineStart: 11},
+	}
+
+	result := gitlab.MapFindings(findings, d)
+	require.Len(t, result, 2)
+
+	// Deleted lines carry only old_line
+	assert.True(t, result[0].InDiff())
+	assert.Nil(t, result[0].NewLine)
+	require.NotNil(t, result[0].OldLine)
+	assert.Equal(t, 12, *result[0].OldLine)
+
+	// A line number on the new side takes precedence over a deleted old-side line
+	require.NotNil(t, result[1].NewLine)
+	require.NotNil(t, result[1].OldLine)
+	assert.Equal(t, 11, *result[1].NewLine)
+	assert.Equal(t, 13, *result[1].OldLine)
+
+	pos := gitlab.NewPosition(result[0], gitlab.DiffRefs{BaseSHA: "b", StartSHA: "s", HeadSHA: "h"})
+	require.NotNil(t, pos)
+	assert.Nil(t, pos.NewLine)
+	assert.Equal(t, result[0].OldLine, pos.OldLine)
+}
+
+func TestMapFindings_RenamedFile(t *testing.T) {
+	d := domain.Diff{
+		Files: []domain.FileDiff{
+			{Path: "new.go", OldPath: "old.go", Status: domain.FileStatusRenamed, Patch: samplePatch},
+		},
+	}
+
+	result := gitlab.MapFindings([]domain.Finding{{File: "old.go", LineStart: 11}}, d)
+	require.Len(t, result, 1)
+
+	assert.True(t, result[0].InDiff())
+	assert.Equal(t, "new.go", result[0].NewPath)
+	assert.Equal(t, "old.go", result[0].OldPath)
+	assert.Equal(t, "old.go", result[0].Finding.File, "finding file must be unchanged to keep fingerprints stable")
+}
+
+func TestMapFindings_Empty(t *testing.T) {
+	result := gitlab.MapFindings(nil, domain.Diff{})
+	assert.NotNil(t, result)
+	assert.Empty(t, result)
+}
+
+func TestNewPosition(t *testing.T) {
+	line := 11
+	refs := gitlab.DiffRefs{BaseSHA: "b", StartSHA: "s", HeadSHA: "h"}
+
+	pos := gitlab.NewPosition(gitlab.PositionedFinding{NewPath: "a.go", OldPath: "a.go", NewLine: &line}, refs)
+	require.NotNil(t, pos)
+	assert.Equal(t, gitlab.PositionTypeText, pos.PositionType)
+	assert.Equal(t, "b", pos.BaseSHA)
+	assert.Equal(t, "s", pos.StartSHA)
+	assert.Equal(t, "h", pos.HeadSHA)
+	assert.Equal(t, &line, pos.NewLine)
+	assert.Nil(t, pos.OldLine)
+
+	assert.Nil(t, gitlab.NewPosition(gitlab.PositionedFinding{NewPath: "a.go"}, refs))
+}
@@ -0,0 +1,35 @@
+package gitlab
+
+import "github.com/bkyoung/code-reviewer/internal/domain"
+
+// PositionedFinding wraps a domain.Finding with GitLab-specific diff line numbers.
+// GitLab positions inline discussions by file line numbers rather than by an
+// index into the diff, so both sides of the diff are tracked.
+type PositionedFinding struct {
+	// Finding is the original domain finding with all review details.
+	Finding domain.Finding
+
+	// NewPath is the file path on the head side of the diff.
+	// Differs from Finding.File when a finding references a renamed file by its old name.
+	NewPath string
+
+	// OldPath is the file path on the base side of the diff.
+	// Equal to NewPath unless the file was renamed.
+	OldPath string
+
+	// NewLine is the line number in the new version of the file.
+	// Set for added and unchanged context lines, nil for deleted lines.
+	NewLine *int
+
+	// OldLine is the line number in the old version of the file.
+	// Set for unchanged context and deleted lines, nil for added lines.
+	// If both are nil the finding's line is not in the diff and cannot
+	// receive an inline discussion (should be included in summary only).
+	OldLine *int
+}
+
+// InDiff returns true if the finding can receive an inline MR discussion.
+// Returns false if the finding's line is not part of the diff.
+func (pf PositionedFinding) InDiff() bool {
+	return pf.NewLine != nil || pf.OldLine != nil
+}
@@ -0,0 +1,13 @@
+// Package httpapi serves the review HTTP API of `cr serve`.
+//
+// Reviews are submitted as refs or a patch and run as jobs of a
+// serve.Queue:
+//
+//	POST /v1/reviews              Start a review; responds 202 with the job
+//	GET  /v1/reviews/{id}         The job's status and, once done, its result
+//	GET  /v1/reviews/{id}/events  Server-sent events of the job's progress
+//	GET  /healthz                 Liveness check, without authentication
+//
+// Requests to /v1 must carry the configured token as a bearer token.
+// Results use the versioned envelope of the json output format.
+package httpapi
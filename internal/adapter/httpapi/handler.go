@@ -0,0 +1,375 @@
+package httpapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	jsonoutput "github.com/bkyoung/code-reviewer/internal/adapter/output/json"
+	"github.com/bkyoung/code-reviewer/internal/diff"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/review"
+	"github.com/bkyoung/code-reviewer/internal/usecase/serve"
+)
+
+// defaultMaxBodyBytes limits the size of submitted reviews, patch included.
+const defaultMaxBodyBytes = 10 << 20
+
+// heartbeatInterval is how often idle event streams send a comment, so
+// proxies keep the connection open.
+const heartbeatInterval = 15 * time.Second
+
+// Jobs runs submitted reviews. It is implemented by serve.Queue.
+type Jobs interface {
+	Submit(ctx context.Context, req review.BranchRequest) (serve.Job, error)
+	Get(ctx context.Context, id string) (serve.Job, error)
+	Subscribe(id string) (<-chan serve.Event, func())
+}
+
+// Options configures the handler.
+type Options struct {
+	// Token is the bearer token requests to /v1 must carry. Requests are
+	// rejected when it is empty.
+	Token string
+
+	// Defaults is the request each submitted review starts from; the API
+	// sets what to review and may override instructions and outputs.
+	Defaults review.BranchRequest
+
+	// RepositoryAvailable reports whether the server runs in a git
+	// repository; patches are reviewed without working tree context
+	// otherwise.
+	RepositoryAvailable bool
+
+	MaxBodyBytes int64 // Default 10 MiB
+}
+
+// Handler serves the review API.
+type Handler struct {
+	jobs Jobs
+	opts Options
+	mux  *http.ServeMux
+}
+
+// NewHandler creates the API handler for the jobs.
+func NewHandler(jobs Jobs, opts Options) *Handler {
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = defaultMaxBodyBytes
+	}
+	h := &Handler{jobs: jobs, opts: opts, mux: http.NewServeMux()}
+	h.mux.HandleFunc("GET /healthz", h.health)
+	h.mux.HandleFunc("POST /v1/reviews", h.authorized(h.createReview))
+	h.mux.HandleFunc("GET /v1/reviews/{id}", h.authorized(h.getReview))
+	h.mux.HandleFunc("GET /v1/reviews/{id}/events", h.authorized(h.streamEvents))
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// reviewRequest is the body of POST /v1/reviews. Either TargetRef or Patch
+// is required.
+type reviewRequest struct {
+	BaseRef            string   `json:"baseRef"`   // Default "main"
+	TargetRef          string   `json:"targetRef"` // Labels the review when a patch is given
+	IncludeUncommitted bool     `json:"includeUncommitted"`
+	Patch              string   `json:"patch"` // Unified diff or git format-patch series
+	Instructions       string   `json:"instructions"`
+	Formats            []string `json:"formats"`
+	MergedOnly         *bool    `json:"mergedOnly"`
+}
+
+// jobResponse describes a job.
+type jobResponse struct {
+	ID         string                       `json:"id"`
+	Status     serve.Status                 `json:"status"`
+	BaseRef    string                       `json:"baseRef"`
+	TargetRef  string                       `json:"targetRef"`
+	CreatedAt  time.Time                    `json:"createdAt"`
+	StartedAt  *time.Time                   `json:"startedAt,omitempty"`
+	FinishedAt *time.Time                   `json:"finishedAt,omitempty"`
+	Error      string                       `json:"error,omitempty"`
+	Result     *jsonoutput.Output           `json:"result,omitempty"`
+	Outputs    map[string]map[string]string `json:"outputs,omitempty"`
+}
+
+// progressResponse describes a progress event of a running review.
+type progressResponse struct {
+	Stage      string                 `json:"stage"`
+	State      string                 `json:"state"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+	Time       time.Time              `json:"time"`
+}
+
+func (h *Handler) health(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (h *Handler) createReview(w http.ResponseWriter, r *http.Request) {
+	var body reviewRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, h.opts.MaxBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	req, err := h.branchRequest(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	job, err := h.jobs.Submit(r.Context(), req)
+	switch {
+	case errors.Is(err, serve.ErrQueueFull), errors.Is(err, serve.ErrQueueClosed):
+		w.Header().Set("Retry-After", "30")
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Location", "/v1/reviews/"+job.ID)
+	writeJSON(w, http.StatusAccepted, newJobResponse(job))
+}
+
+// branchRequest validates a submitted review and applies it to the defaults.
+func (h *Handler) branchRequest(body reviewRequest) (review.BranchRequest, error) {
+	req := h.opts.Defaults
+	req.BaseRef = strings.TrimSpace(body.BaseRef)
+	req.TargetRef = strings.TrimSpace(body.TargetRef)
+	req.IncludeUncommitted = body.IncludeUncommitted
+
+	if body.Patch != "" {
+		patch, err := diff.ParsePatchSet(body.Patch)
+		if err != nil {
+			return review.BranchRequest{}, fmt.Errorf("invalid patch: %w", err)
+		}
+		req.BaseRef = "patch"
+		if req.TargetRef == "" {
+			req.TargetRef = "patch"
+		}
+		req.Diff = &patch
+		req.NoRepository = !h.opts.RepositoryAvailable
+	} else {
+		if req.TargetRef == "" {
+			return review.BranchRequest{}, errors.New("targetRef or patch is required")
+		}
+		if req.BaseRef == "" {
+			req.BaseRef = "main"
+		}
+	}
+	for name, ref := range map[string]string{"baseRef": req.BaseRef, "targetRef": req.TargetRef} {
+		if err := validateRef(ref); err != nil {
+			return review.BranchRequest{}, fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+
+	if body.Instructions != "" {
+		req.CustomInstructions = body.Instructions
+	}
+	if body.Formats != nil {
+		formats := make([]string, 0, len(body.Formats))
+		for _, format := range body.Formats {
+			formats = append(formats, strings.ToLower(strings.TrimSpace(format)))
+		}
+		if err := review.ValidateFormats(formats); err != nil {
+			return review.BranchRequest{}, err
+		}
+		req.Formats = formats
+	}
+	if body.MergedOnly != nil {
+		req.MergedOnly = *body.MergedOnly
+	}
+	return req, nil
+}
+
+// validateRef rejects refs git would not accept that could also escape the
+// output directory, which is named after the target ref.
+func validateRef(ref string) error {
+	switch {
+	case strings.Contains(ref, ".."):
+		return errors.New("must not contain ..")
+	case strings.HasPrefix(ref, "/"), strings.HasPrefix(ref, "-"):
+		return errors.New("must not start with / or -")
+	case strings.ContainsFunc(ref, func(r rune) bool { return r < ' ' || r == 0x7f || r == ' ' || r == '\\' }):
+		return errors.New("must not contain spaces, control characters or backslashes")
+	}
+	return nil
+}
+
+func (h *Handler) getReview(w http.ResponseWriter, r *http.Request) {
+	job, ok := h.job(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, newJobResponse(job))
+}
+
+// streamEvents streams a job's status changes and progress as server-sent
+// events until it finishes. Status events carry the job without its result.
+func (h *Handler) streamEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+
+	// Subscribe first so no event between the lookup and the subscription
+	// is lost
+	events, unsubscribe := h.jobs.Subscribe(r.PathValue("id"))
+	defer unsubscribe()
+	job, ok := h.job(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(event string, data interface{}) bool {
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return false
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, encoded); err != nil {
+			return false
+		}
+		flusher.Flush()
+		return true
+	}
+
+	if !send("status", statusResponse(job)) || job.Status.Done() {
+		return
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case e, open := <-events:
+			if !open {
+				// The job finished or the server is shutting down
+				if job, err := h.jobs.Get(r.Context(), job.ID); err == nil {
+					send("status", statusResponse(job))
+				}
+				return
+			}
+			var sent bool
+			if e.Progress != nil {
+				sent = send("progress", progressResponse{
+					Stage:      e.Progress.Stage,
+					State:      e.Progress.State,
+					Attributes: e.Progress.Attributes,
+					Error:      e.Progress.Error,
+					Time:       e.Progress.Time,
+				})
+			} else if !e.Status.Done() {
+				// The final status is sent with the finished job once the
+				// channel closes
+				job.Status = e.Status
+				sent = send("status", statusResponse(job))
+			} else {
+				sent = true
+			}
+			if !sent {
+				return
+			}
+		}
+	}
+}
+
+// job looks up the job named in the path, writing the error response if
+// there is none.
+func (h *Handler) job(w http.ResponseWriter, r *http.Request) (serve.Job, bool) {
+	job, err := h.jobs.Get(r.Context(), r.PathValue("id"))
+	if errors.Is(err, serve.ErrJobNotFound) {
+		writeError(w, http.StatusNotFound, "review not found")
+		return serve.Job{}, false
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return serve.Job{}, false
+	}
+	return job, true
+}
+
+// authorized requires the configured bearer token.
+func (h *Handler) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if h.opts.Token == "" || !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.opts.Token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="cr"`)
+			writeError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+			return
+		}
+		next(w, r)
+	}
+}
+
+func newJobResponse(job serve.Job) jobResponse {
+	resp := statusResponse(job)
+	if job.Result != nil {
+		result := job.Result
+		output := jsonoutput.NewOutput(domain.JSONArtifact{
+			Repository:   job.Request.Repository,
+			BaseRef:      job.Request.BaseRef,
+			TargetRef:    job.Request.TargetRef,
+			Diff:         domain.Diff{FromCommitHash: result.BaseSHA, ToCommitHash: result.TargetSHA},
+			ConfigHash:   result.ConfigHash,
+			Review:       result.Review,
+			ProviderName: result.Review.ProviderName,
+		}, job.FinishedAt.UTC().Format("20060102T150405Z"))
+		resp.Result = &output
+		resp.Outputs = result.Paths
+	}
+	return resp
+}
+
+// statusResponse describes a job without its result.
+func statusResponse(job serve.Job) jobResponse {
+	resp := jobResponse{
+		ID:        job.ID,
+		Status:    job.Status,
+		BaseRef:   job.Request.BaseRef,
+		TargetRef: job.Request.TargetRef,
+		CreatedAt: job.CreatedAt,
+		Error:     job.Error,
+	}
+	if !job.StartedAt.IsZero() {
+		resp.StartedAt = &job.StartedAt
+	}
+	if !job.FinishedAt.IsZero() {
+		resp.FinishedAt = &job.FinishedAt
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
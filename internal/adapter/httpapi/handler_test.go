@@ -0,0 +1,295 @@
+package httpapi_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/httpapi"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/review"
+	"github.com/bkyoung/code-reviewer/internal/usecase/serve"
+)
+
+const testToken = "secret"
+
+const testPatch = `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1 +1 @@
+-package old
++package main
+`
+
+// memoryJobs is an in-memory serve.JobStore.
+type memoryJobs struct {
+	mu   sync.Mutex
+	jobs map[string]serve.Job
+}
+
+func (m *memoryJobs) SaveJob(_ context.Context, job serve.Job) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs[job.ID] = job
+	return nil
+}
+
+func (m *memoryJobs) GetJob(_ context.Context, id string) (serve.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return serve.Job{}, serve.ErrJobNotFound
+	}
+	return job, nil
+}
+
+func (m *memoryJobs) ListJobs(context.Context, ...serve.Status) ([]serve.Job, error) {
+	return nil, nil
+}
+
+// fakeReviewer records requests and, once released, returns a merged
+// review with one finding.
+type fakeReviewer struct {
+	release  chan struct{}
+	requests chan review.BranchRequest
+}
+
+func (r *fakeReviewer) ReviewBranch(ctx context.Context, req review.BranchRequest) (review.Result, error) {
+	r.requests <- req
+	select {
+	case <-r.release:
+	case <-ctx.Done():
+		return review.Result{}, ctx.Err()
+	}
+	return review.Result{
+		Reviews: []domain.Review{{
+			ProviderName: "merged",
+			Summary:      "one issue",
+			Findings:     []domain.Finding{{ID: "f1", File: "main.go", LineStart: 1, LineEnd: 1, Severity: "high", Category: "bug", Description: "wrong package"}},
+		}},
+		Paths:     map[string]map[string]string{"json": {"merged": "out/review-merged.json"}},
+		BaseSHA:   "abc",
+		TargetSHA: "def",
+	}, nil
+}
+
+func newTestServer(t *testing.T, opts httpapi.Options) (*httptest.Server, *fakeReviewer) {
+	t.Helper()
+	reviewer := &fakeReviewer{release: make(chan struct{}), requests: make(chan review.BranchRequest, 10)}
+	queue := serve.NewQueue(reviewer, &memoryJobs{jobs: make(map[string]serve.Job)}, serve.Options{})
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, queue.Start(ctx))
+
+	if opts.Token == "" {
+		opts.Token = testToken
+	}
+	server := httptest.NewServer(httpapi.NewHandler(queue, opts))
+	t.Cleanup(func() {
+		cancel()
+		queue.Wait()
+		server.Close()
+	})
+	return server, reviewer
+}
+
+func do(t *testing.T, method, url, token, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func decode(t *testing.T, resp *http.Response) map[string]interface{} {
+	t.Helper()
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return body
+}
+
+func waitForStatus(t *testing.T, url, want string) map[string]interface{} {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		body := decode(t, do(t, http.MethodGet, url, testToken, ""))
+		if body["status"] == want {
+			return body
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected status %s, got %v", want, body)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestHandler_RequiresToken(t *testing.T) {
+	server, _ := newTestServer(t, httpapi.Options{})
+
+	for _, token := range []string{"", "wrong"} {
+		resp := do(t, http.MethodGet, server.URL+"/v1/reviews/job-1", token, "")
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get("WWW-Authenticate"), "Bearer")
+	}
+
+	resp := do(t, http.MethodGet, server.URL+"/healthz", "", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "health checks need no token")
+}
+
+func TestHandler_ReviewsRefs(t *testing.T) {
+	server, reviewer := newTestServer(t, httpapi.Options{Defaults: review.BranchRequest{OutputDir: "out", Repository: "repo", Formats: []string{"json"}}})
+
+	resp := do(t, http.MethodPost, server.URL+"/v1/reviews", testToken, `{"targetRef":"feature","instructions":"focus on errors","formats":["SARIF"]}`)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	job := decode(t, resp)
+	id, _ := job["id"].(string)
+	require.NotEmpty(t, id)
+	assert.Equal(t, "/v1/reviews/"+id, resp.Header.Get("Location"))
+
+	req := <-reviewer.requests
+	assert.Equal(t, "main", req.BaseRef, "base should default to main")
+	assert.Equal(t, "feature", req.TargetRef)
+	assert.Equal(t, "out", req.OutputDir, "defaults should apply")
+	assert.Equal(t, "repo", req.Repository)
+	assert.Equal(t, "focus on errors", req.CustomInstructions)
+	assert.Equal(t, []string{"sarif"}, req.Formats)
+
+	body := waitForStatus(t, server.URL+"/v1/reviews/"+id, "running")
+	assert.Nil(t, body["result"])
+
+	close(reviewer.release)
+	body = waitForStatus(t, server.URL+"/v1/reviews/"+id, "succeeded")
+	result, ok := body["result"].(map[string]interface{})
+	require.True(t, ok, "expected a result, got %v", body)
+	assert.Equal(t, "1.0", result["schemaVersion"])
+	assert.Equal(t, "abc", result["run"].(map[string]interface{})["baseSha"])
+	assert.Len(t, result["findings"], 1)
+	assert.Equal(t, "out/review-merged.json", body["outputs"].(map[string]interface{})["json"].(map[string]interface{})["merged"])
+}
+
+func TestHandler_ReviewsPatch(t *testing.T) {
+	server, reviewer := newTestServer(t, httpapi.Options{})
+
+	payload, err := json.Marshal(map[string]string{"patch": testPatch})
+	require.NoError(t, err)
+	resp := do(t, http.MethodPost, server.URL+"/v1/reviews", testToken, string(payload))
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	req := <-reviewer.requests
+	assert.Equal(t, "patch", req.BaseRef)
+	assert.Equal(t, "patch", req.TargetRef)
+	require.NotNil(t, req.Diff)
+	assert.Equal(t, "main.go", req.Diff.Files[0].Path)
+	assert.True(t, req.NoRepository, "patches are reviewed without a repository when none is available")
+}
+
+func TestHandler_RejectsInvalidReviews(t *testing.T) {
+	server, _ := newTestServer(t, httpapi.Options{})
+
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{name: "nothing to review", body: `{"baseRef":"main"}`, wantErr: "targetRef or patch is required"},
+		{name: "unknown field", body: `{"targetRef":"feature","postReview":true}`, wantErr: "unknown field"},
+		{name: "escaping ref", body: `{"targetRef":"../../etc"}`, wantErr: "invalid targetRef"},
+		{name: "option-like ref", body: `{"baseRef":"--output=x","targetRef":"feature"}`, wantErr: "invalid baseRef"},
+		{name: "unknown format", body: `{"targetRef":"feature","formats":["pdf"]}`, wantErr: "unsupported output format"},
+		{name: "invalid patch", body: `{"patch":"not a patch"}`, wantErr: "invalid patch"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := do(t, http.MethodPost, server.URL+"/v1/reviews", testToken, tt.body)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			assert.Contains(t, decode(t, resp)["error"], tt.wantErr)
+		})
+	}
+}
+
+func TestHandler_UnknownReview(t *testing.T) {
+	server, _ := newTestServer(t, httpapi.Options{})
+
+	for _, path := range []string{"/v1/reviews/job-missing", "/v1/reviews/job-missing/events"} {
+		resp := do(t, http.MethodGet, server.URL+path, testToken, "")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, path)
+	}
+}
+
+func TestHandler_StreamsEvents(t *testing.T) {
+	server, reviewer := newTestServer(t, httpapi.Options{})
+
+	job := decode(t, do(t, http.MethodPost, server.URL+"/v1/reviews", testToken, `{"targetRef":"feature"}`))
+	id := job["id"].(string)
+	<-reviewer.requests
+
+	resp := do(t, http.MethodGet, server.URL+"/v1/reviews/"+id+"/events", testToken, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	scanner := bufio.NewScanner(resp.Body)
+	next := func() (string, map[string]interface{}) {
+		var event string
+		for scanner.Scan() {
+			line := scanner.Text()
+			if name, ok := strings.CutPrefix(line, "event: "); ok {
+				event = name
+			}
+			if data, ok := strings.CutPrefix(line, "data: "); ok {
+				var body map[string]interface{}
+				require.NoError(t, json.Unmarshal([]byte(data), &body))
+				return event, body
+			}
+		}
+		t.Fatalf("stream ended: %v", scanner.Err())
+		return "", nil
+	}
+
+	event, body := next()
+	assert.Equal(t, "status", event)
+	assert.Equal(t, "running", body["status"])
+
+	close(reviewer.release)
+	event, body = next()
+	assert.Equal(t, "status", event)
+	assert.Equal(t, "succeeded", body["status"])
+	assert.Nil(t, body["result"], "status events leave the result to GET")
+
+	for scanner.Scan() {
+		assert.Empty(t, scanner.Text(), "the stream should end with the job")
+	}
+}
+
+func TestHandler_StreamsFinishedJob(t *testing.T) {
+	server, reviewer := newTestServer(t, httpapi.Options{})
+	close(reviewer.release)
+
+	job := decode(t, do(t, http.MethodPost, server.URL+"/v1/reviews", testToken, `{"targetRef":"feature"}`))
+	id := job["id"].(string)
+	waitForStatus(t, server.URL+"/v1/reviews/"+id, "succeeded")
+
+	resp := do(t, http.MethodGet, server.URL+"/v1/reviews/"+id+"/events", testToken, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	scanner := bufio.NewScanner(resp.Body)
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	require.Len(t, lines, 3)
+	assert.Equal(t, "event: status", lines[0])
+	assert.Contains(t, lines[1], `"status":"succeeded"`)
+}
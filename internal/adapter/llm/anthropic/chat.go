@@ -0,0 +1,100 @@
+package anthropic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/llm"
+)
+
+// Chat sends a multi-turn conversation with native tool declarations to the
+// Messages API. Tool calls are returned as tool_use blocks and their results
+// are sent back as tool_result blocks in the next user message.
+func (c *HTTPClient) Chat(ctx context.Context, messages []llm.ChatMessage, tools []llm.ToolDefinition, options CallOptions) (*llm.ChatResponse, error) {
+	reqBody := ToolMessagesRequest{
+		Model:     c.model,
+		Messages:  toToolMessages(messages),
+		System:    options.System,
+		MaxTokens: options.MaxTokens,
+	}
+	if options.Temperature > 0 {
+		reqBody.Temperature = options.Temperature
+	}
+	for _, t := range tools {
+		reqBody.Tools = append(reqBody.Tools, Tool{
+			Name:        t.Name,
+			Description: t.Description,
+			InputSchema: llm.ToolInputSchema(),
+		})
+	}
+
+	bodyBytes, duration, err := c.send(ctx, reqBody, llm.ContentLength(messages))
+	if err != nil {
+		return nil, err
+	}
+
+	var messagesResp MessagesResponse
+	if err := json.Unmarshal(bodyBytes, &messagesResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	response := &llm.ChatResponse{
+		TokensIn:  messagesResp.Usage.InputTokens,
+		TokensOut: messagesResp.Usage.OutputTokens,
+	}
+
+	var textParts []string
+	for _, block := range messagesResp.Content {
+		switch block.Type {
+		case "text":
+			textParts = append(textParts, block.Text)
+		case "tool_use":
+			response.ToolCalls = append(response.ToolCalls, llm.ToolCall{
+				ID:    block.ID,
+				Name:  block.Name,
+				Input: llm.ToolInput(block.Input),
+			})
+		}
+	}
+	response.Text = strings.Join(textParts, "")
+	response.Cost = c.record(ctx, duration, response.TokensIn, response.TokensOut, messagesResp.StopReason)
+
+	return response, nil
+}
+
+// toToolMessages converts the neutral history to Messages API messages.
+// Consecutive tool results are combined into a single user message, as the
+// API requires all results for one assistant turn in the next message.
+func toToolMessages(messages []llm.ChatMessage) []ToolMessage {
+	var out []ToolMessage
+	for _, m := range messages {
+		switch m.Role {
+		case llm.ChatRoleAssistant:
+			var blocks []RequestBlock
+			if m.Content != "" {
+				blocks = append(blocks, RequestBlock{Type: "text", Text: m.Content})
+			}
+			for _, call := range m.ToolCalls {
+				blocks = append(blocks, RequestBlock{
+					Type:  "tool_use",
+					ID:    call.ID,
+					Name:  call.Name,
+					Input: llm.ToolArguments(call.Input),
+				})
+			}
+			out = append(out, ToolMessage{Role: "assistant", Content: blocks})
+		case llm.ChatRoleTool:
+			block := RequestBlock{Type: "tool_result", ToolUseID: m.ToolCallID, Content: m.Content}
+			if n := len(out); n > 0 && out[n-1].Role == "user" && out[n-1].Content[0].Type == "tool_result" {
+				out[n-1].Content = append(out[n-1].Content, block)
+				continue
+			}
+			out = append(out, ToolMessage{Role: "user", Content: []RequestBlock{block}})
+		default:
+			out = append(out, ToolMessage{Role: "user", Content: []RequestBlock{{Type: "text", Text: m.Content}}})
+		}
+	}
+	return out
+}
@@ -0,0 +1,81 @@
+package anthropic_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/llm"
+	"github.com/bkyoung/code-reviewer/internal/adapter/llm/anthropic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPClient_Chat_SendsHistoryAndParsesToolUse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/messages", r.URL.Path)
+
+		var req anthropic.ToolMessagesRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		assert.Equal(t, "verify things", req.System)
+		require.Len(t, req.Tools, 1)
+		assert.Equal(t, "read_file", req.Tools[0].Name)
+		assert.Equal(t, "object", req.Tools[0].InputSchema["type"])
+
+		// user text, assistant tool_use, user tool_results (both combined)
+		require.Len(t, req.Messages, 3)
+		assert.Equal(t, "user", req.Messages[0].Role)
+		assert.Equal(t, "candidate", req.Messages[0].Content[0].Text)
+
+		assert.Equal(t, "assistant", req.Messages[1].Role)
+		require.Len(t, req.Messages[1].Content, 3)
+		assert.Equal(t, "text", req.Messages[1].Content[0].Type)
+		assert.Equal(t, "tool_use", req.Messages[1].Content[1].Type)
+		assert.Equal(t, "toolu_1", req.Messages[1].Content[1].ID)
+		assert.Equal(t, "main.go", req.Messages[1].Content[1].Input["input"])
+
+		assert.Equal(t, "user", req.Messages[2].Role)
+		require.Len(t, req.Messages[2].Content, 2)
+		assert.Equal(t, "tool_result", req.Messages[2].Content[0].Type)
+		assert.Equal(t, "toolu_1", req.Messages[2].Content[0].ToolUseID)
+		assert.Equal(t, "package main", req.Messages[2].Content[0].Content)
+		assert.Equal(t, "toolu_2", req.Messages[2].Content[1].ToolUseID)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(anthropic.MessagesResponse{
+			Content: []anthropic.ContentBlock{
+				{Type: "text", Text: "Let me search."},
+				{Type: "tool_use", ID: "toolu_3", Name: "grep", Input: map[string]interface{}{"input": "func main"}},
+			},
+			StopReason: "tool_use",
+			Usage:      anthropic.Usage{InputTokens: 100, OutputTokens: 20},
+		})
+	}))
+	defer server.Close()
+
+	client := anthropic.NewHTTPClient("test-api-key", "claude-3-5-sonnet-20241022", testProviderConfig(), testHTTPConfig())
+	client.SetBaseURL(server.URL)
+
+	messages := []llm.ChatMessage{
+		{Role: llm.ChatRoleUser, Content: "candidate"},
+		{Role: llm.ChatRoleAssistant, Content: "Reading files.", ToolCalls: []llm.ToolCall{
+			{ID: "toolu_1", Name: "read_file", Input: "main.go"},
+			{ID: "toolu_2", Name: "read_file", Input: "util.go"},
+		}},
+		{Role: llm.ChatRoleTool, ToolCallID: "toolu_1", ToolName: "read_file", Content: "package main"},
+		{Role: llm.ChatRoleTool, ToolCallID: "toolu_2", ToolName: "read_file", Content: "package util"},
+	}
+	tools := []llm.ToolDefinition{{Name: "read_file", Description: "Read a file"}}
+
+	resp, err := client.Chat(context.Background(), messages, tools, anthropic.CallOptions{System: "verify things", MaxTokens: 1024})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Let me search.", resp.Text)
+	require.Len(t, resp.ToolCalls, 1)
+	assert.Equal(t, llm.ToolCall{ID: "toolu_3", Name: "grep", Input: "func main"}, resp.ToolCalls[0])
+	assert.Equal(t, 100, resp.TokensIn)
+	assert.Equal(t, 20, resp.TokensOut)
+}
@@ -36,6 +36,9 @@ type HTTPClient struct {
 	logger  llmhttp.Logger
 	metrics llmhttp.Metrics
 	pricing llmhttp.Pricing
+
+	// Client-side rate limiting and circuit breaking, shared per provider
+	guard *llmhttp.Guard
 }
 
 // NewHTTPClient creates a new Anthropic HTTP client.
@@ -74,6 +77,11 @@ func (c *HTTPClient) SetMetrics(metrics llmhttp.Metrics) {
 	c.metrics = metrics
 }
 
+// SetGuard sets the rate limiter and circuit breaker for this client.
+func (c *HTTPClient) SetGuard(guard *llmhttp.Guard) {
+	c.guard = guard
+}
+
 // SetPricing sets the pricing calculator for this client.
 func (c *HTTPClient) SetPricing(pricing llmhttp.Pricing) {
 	c.pricing = pricing
@@ -98,24 +106,6 @@ type APIResponse struct {
 
 // Call makes a request to the Anthropic Messages API.
 func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOptions) (*APIResponse, error) {
-	startTime := time.Now()
-
-	// Log request (if logger configured)
-	if c.logger != nil {
-		c.logger.LogRequest(ctx, llmhttp.RequestLog{
-			Provider:    "anthropic",
-			Model:       c.model,
-			Timestamp:   startTime,
-			PromptChars: len(prompt),
-			APIKey:      c.apiKey,
-		})
-	}
-
-	// Record request metric
-	if c.metrics != nil {
-		c.metrics.RecordRequest("anthropic", c.model)
-	}
-
 	// Build request
 	reqBody := MessagesRequest{
 		Model: c.model,
@@ -140,28 +130,73 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 		reqBody.Temperature = options.Temperature
 	}
 
-	// Marshal request
-	jsonData, err := json.Marshal(reqBody)
+	bodyBytes, duration, err := c.send(ctx, reqBody, len(prompt))
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return nil, err
 	}
 
-	// Create HTTP request
-	url := c.baseURL + "/v1/messages"
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	var messagesResp MessagesResponse
+	if err := json.Unmarshal(bodyBytes, &messagesResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// Extract text from content blocks
+	if len(messagesResp.Content) == 0 {
+		return nil, fmt.Errorf("no content in response")
+	}
+
+	var textParts []string
+	for _, block := range messagesResp.Content {
+		if block.Type == "text" {
+			textParts = append(textParts, block.Text)
+		}
+	}
+
+	response := &APIResponse{
+		Text:       strings.Join(textParts, ""),
+		TokensIn:   messagesResp.Usage.InputTokens,
+		TokensOut:  messagesResp.Usage.OutputTokens,
+		Model:      messagesResp.Model,
+		StopReason: messagesResp.StopReason,
+	}
+	response.Cost = c.record(ctx, duration, response.TokensIn, response.TokensOut, response.StopReason)
+
+	return response, nil
+}
+
+// send marshals a request, posts it to the Messages API with retry and returns
+// the response body. The request and any failure are logged and recorded.
+func (c *HTTPClient) send(ctx context.Context, reqBody interface{}, promptChars int) ([]byte, time.Duration, error) {
+	startTime := time.Now()
+
+	// Log request (if logger configured)
+	if c.logger != nil {
+		c.logger.LogRequest(ctx, llmhttp.RequestLog{
+			Provider:    "anthropic",
+			Model:       c.model,
+			Timestamp:   startTime,
+			PromptChars: promptChars,
+			APIKey:      c.apiKey,
+		})
+	}
+
+	// Record request metric
+	if metrics := llmhttp.ScopedMetrics(ctx, c.metrics); metrics != nil {
+		metrics.RecordRequest("anthropic", c.model)
+	}
+
+	// Marshal request
+	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, 0, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	// Set headers (Anthropic uses x-api-key instead of Authorization)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("x-api-key", c.apiKey)
-	req.Header.Set("anthropic-version", defaultAnthropicVersion)
+	url := c.baseURL + "/v1/messages"
 
 	// Execute request with retry logic (using configured retry settings)
 	var resp *http.Response
 
-	err = llmhttp.RetryWithBackoff(ctx, func(ctx context.Context) error {
+	operation := func(ctx context.Context) error {
 		// Recreate request for each retry with fresh context
 		retryReq, reqErr := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 		if reqErr != nil {
@@ -173,6 +208,7 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 			}
 		}
 
+		// Set headers (Anthropic uses x-api-key instead of Authorization)
 		retryReq.Header.Set("Content-Type", "application/json")
 		retryReq.Header.Set("x-api-key", c.apiKey)
 		retryReq.Header.Set("anthropic-version", defaultAnthropicVersion)
@@ -192,11 +228,16 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 		if resp.StatusCode >= 400 {
 			bodyBytes, _ := io.ReadAll(resp.Body)
 			resp.Body.Close()
-			return c.handleErrorResponse(resp.StatusCode, bodyBytes)
+			return llmhttp.WithRetryAfter(c.handleErrorResponse(resp.StatusCode, bodyBytes), resp.Header.Get("Retry-After"))
 		}
 
 		return nil
-	}, c.retryConf)
+	}
+
+	if c.guard != nil {
+		operation = c.guard.Wrap(operation, c.model, promptChars)
+	}
+	err = llmhttp.RetryWithBackoff(ctx, operation, c.retryConf)
 
 	duration := time.Since(startTime)
 
@@ -218,52 +259,30 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 			}
 		}
 		// Record error metric
-		if c.metrics != nil {
+		if metrics := llmhttp.ScopedMetrics(ctx, c.metrics); metrics != nil {
 			var httpErr *llmhttp.Error
 			if errors.As(err, &httpErr) {
-				c.metrics.RecordError("anthropic", c.model, httpErr.Type)
+				metrics.RecordError("anthropic", c.model, httpErr.Type)
 			}
 		}
-		return nil, err
+		return nil, duration, err
 	}
 	defer resp.Body.Close()
 
-	// Parse response
 	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	var messagesResp MessagesResponse
-	if err := json.Unmarshal(bodyBytes, &messagesResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	// Extract text from content blocks
-	if len(messagesResp.Content) == 0 {
-		return nil, fmt.Errorf("no content in response")
-	}
-
-	var textParts []string
-	for _, block := range messagesResp.Content {
-		if block.Type == "text" {
-			textParts = append(textParts, block.Text)
-		}
-	}
-
-	response := &APIResponse{
-		Text:       strings.Join(textParts, ""),
-		TokensIn:   messagesResp.Usage.InputTokens,
-		TokensOut:  messagesResp.Usage.OutputTokens,
-		Model:      messagesResp.Model,
-		StopReason: messagesResp.StopReason,
+		return nil, duration, fmt.Errorf("failed to read response body: %w", err)
 	}
+	return bodyBytes, duration, nil
+}
 
+// record calculates the cost of a successful call, logs the response and
+// records metrics. It returns the cost in USD.
+func (c *HTTPClient) record(ctx context.Context, duration time.Duration, tokensIn, tokensOut int, stopReason string) float64 {
 	// Calculate cost
 	var cost float64
 	if c.pricing != nil {
-		cost = c.pricing.GetCost("anthropic", c.model, response.TokensIn, response.TokensOut)
-		response.Cost = cost
+		cost = c.pricing.GetCost("anthropic", c.model, tokensIn, tokensOut)
 	}
 
 	// Log response
@@ -273,22 +292,22 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 			Model:        c.model,
 			Timestamp:    time.Now(),
 			Duration:     duration,
-			TokensIn:     response.TokensIn,
-			TokensOut:    response.TokensOut,
+			TokensIn:     tokensIn,
+			TokensOut:    tokensOut,
 			Cost:         cost,
 			StatusCode:   200,
-			FinishReason: response.StopReason,
+			FinishReason: stopReason,
 		})
 	}
 
 	// Record metrics
-	if c.metrics != nil {
-		c.metrics.RecordDuration("anthropic", c.model, duration)
-		c.metrics.RecordTokens("anthropic", c.model, response.TokensIn, response.TokensOut)
-		c.metrics.RecordCost("anthropic", c.model, cost)
+	if metrics := llmhttp.ScopedMetrics(ctx, c.metrics); metrics != nil {
+		metrics.RecordDuration("anthropic", c.model, duration)
+		metrics.RecordTokens("anthropic", c.model, tokensIn, tokensOut)
+		metrics.RecordCost("anthropic", c.model, cost)
 	}
 
-	return response, nil
+	return cost
 }
 
 // handleErrorResponse maps HTTP status codes to typed errors.
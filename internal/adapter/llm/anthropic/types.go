@@ -29,8 +29,54 @@ type MessagesResponse struct {
 
 // ContentBlock represents a content block in the response.
 type ContentBlock struct {
-	Type string `json:"type"` // "text"
+	Type string `json:"type"` // "text" or "tool_use"
 	Text string `json:"text"`
+
+	// tool_use fields
+	ID    string                 `json:"id,omitempty"`
+	Name  string                 `json:"name,omitempty"`
+	Input map[string]interface{} `json:"input,omitempty"`
+}
+
+// ToolMessagesRequest is a Messages API request with tools and a multi-turn history.
+type ToolMessagesRequest struct {
+	Model       string        `json:"model"`
+	Messages    []ToolMessage `json:"messages"`
+	System      string        `json:"system,omitempty"`
+	MaxTokens   int           `json:"max_tokens"`
+	Temperature float64       `json:"temperature,omitempty"`
+	Tools       []Tool        `json:"tools,omitempty"`
+}
+
+// ToolMessage is a message whose content is a list of blocks.
+type ToolMessage struct {
+	Role    string         `json:"role"` // "user" or "assistant"
+	Content []RequestBlock `json:"content"`
+}
+
+// RequestBlock is a content block sent to the API: text, tool_use (echoing the
+// assistant's earlier call) or tool_result.
+type RequestBlock struct {
+	Type string `json:"type"`
+
+	// text
+	Text string `json:"text,omitempty"`
+
+	// tool_use
+	ID    string                 `json:"id,omitempty"`
+	Name  string                 `json:"name,omitempty"`
+	Input map[string]interface{} `json:"input,omitempty"`
+
+	// tool_result
+	ToolUseID string `json:"tool_use_id,omitempty"`
+	Content   string `json:"content,omitempty"`
+}
+
+// Tool declares a tool the model may call.
+type Tool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"input_schema"`
 }
 
 // Usage represents token usage statistics.
@@ -0,0 +1,87 @@
+package llm
+
+// Chat roles for multi-turn conversations with tool calling.
+const (
+	ChatRoleUser      = "user"
+	ChatRoleAssistant = "assistant"
+	ChatRoleTool      = "tool"
+)
+
+// ToolInputParameter is the single string parameter every tool accepts.
+// Tools are declared to providers with one required "input" argument so the
+// same tools work with native tool calling and the text protocol.
+const ToolInputParameter = "input"
+
+// ChatMessage is a provider-neutral message in a multi-turn conversation.
+// Provider clients translate it to their native format (Anthropic tool_use
+// blocks, OpenAI tool_calls, Gemini functionCall parts).
+type ChatMessage struct {
+	Role       string     // ChatRoleUser, ChatRoleAssistant or ChatRoleTool
+	Content    string     // Text content, or the tool output for tool messages
+	ToolCalls  []ToolCall // Tools requested by an assistant message
+	ToolCallID string     // For tool messages: the ID of the call being answered
+	ToolName   string     // For tool messages: the name of the tool that ran
+}
+
+// ToolCall is a tool invocation requested by the model.
+type ToolCall struct {
+	ID    string // Provider-assigned call ID (synthesized for Gemini)
+	Name  string // Tool name
+	Input string // Value of the tool's input argument
+
+	// Signature is opaque provider metadata that must be sent back with the
+	// call in later turns (Gemini thought signatures).
+	Signature string
+}
+
+// ToolDefinition declares a tool to the model.
+type ToolDefinition struct {
+	Name        string
+	Description string
+}
+
+// ChatResponse is the result of one turn of a tool-calling conversation.
+// If ToolCalls is non-empty, the model expects their results before answering.
+type ChatResponse struct {
+	Text      string
+	ToolCalls []ToolCall
+	TokensIn  int
+	TokensOut int
+	Cost      float64 // Cost in USD
+}
+
+// ToolInputSchema returns the JSON schema shared by all tool declarations.
+func ToolInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			ToolInputParameter: map[string]interface{}{
+				"type":        "string",
+				"description": "The tool input, as described in the tool description",
+			},
+		},
+		"required": []string{ToolInputParameter},
+	}
+}
+
+// ToolArguments wraps a tool input in the argument object sent back to providers.
+func ToolArguments(input string) map[string]interface{} {
+	return map[string]interface{}{ToolInputParameter: input}
+}
+
+// ToolInput extracts the input argument from a model's tool call arguments.
+// Non-string values are ignored.
+func ToolInput(args map[string]interface{}) string {
+	input, _ := args[ToolInputParameter].(string)
+	return input
+}
+
+// ContentLength returns the total content length of a conversation, used for
+// request logging.
+func ContentLength(messages []ChatMessage) int {
+	var n int
+	for _, m := range messages {
+		n += len(m.Content)
+	}
+	return n
+}
@@ -0,0 +1,114 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/review"
+)
+
+// ModelProvider is a review provider bound to one model.
+type ModelProvider struct {
+	Model    string
+	Provider review.Provider
+}
+
+// FallbackProvider reviews with the first model of a chain and falls back
+// to the next one when a model fails, e.g. because it is unavailable or
+// has been retired. Authentication errors are not retried with another
+// model since every model of the provider shares the same credentials.
+type FallbackProvider struct {
+	name   string
+	models []ModelProvider
+}
+
+// NewFallbackProvider creates a provider trying models in order. name is
+// the provider name used in logs.
+func NewFallbackProvider(name string, models ...ModelProvider) *FallbackProvider {
+	return &FallbackProvider{name: name, models: models}
+}
+
+// Review runs the review with each model in turn until one succeeds. A
+// review produced by a fallback model records the failed models in
+// FallbackFrom and notes the fallback at the start of its summary.
+func (p *FallbackProvider) Review(ctx context.Context, req review.ProviderRequest) (domain.Review, error) {
+	if len(p.models) == 0 {
+		return domain.Review{}, fmt.Errorf("%s: no models configured", p.name)
+	}
+
+	var failed []string
+	var reasons []string
+	var errs []error
+	for i, m := range p.models {
+		result, err := m.Provider.Review(ctx, req)
+		if err == nil {
+			if len(failed) > 0 {
+				if result.ModelName == "" {
+					result.ModelName = m.Model
+				}
+				result.FallbackFrom = failed
+				result.Summary = fallbackNote(result.ModelName, failed, reasons) + result.Summary
+			}
+			return result, nil
+		}
+
+		errs = append(errs, fmt.Errorf("model %s: %w", m.Model, err))
+		if !canFallback(ctx, err) {
+			break
+		}
+		failed = append(failed, m.Model)
+		reasons = append(reasons, failureReason(err))
+		if i+1 < len(p.models) {
+			log.Printf("[%s] model %s failed (%s), falling back to %s", p.name, m.Model, failureReason(err), p.models[i+1].Model)
+		}
+	}
+
+	if len(errs) == 1 {
+		return domain.Review{}, errors.Unwrap(errs[0])
+	}
+	return domain.Review{}, errors.Join(errs...)
+}
+
+// EstimateTokens delegates to the primary model's provider.
+func (p *FallbackProvider) EstimateTokens(text string) int {
+	if len(p.models) == 0 {
+		return EstimateTokens(text)
+	}
+	return p.models[0].Provider.EstimateTokens(text)
+}
+
+// canFallback reports whether another model might succeed after err.
+func canFallback(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var httpErr *llmhttp.Error
+	if errors.As(err, &httpErr) && httpErr.Type == llmhttp.ErrTypeAuthentication {
+		return false
+	}
+	return true
+}
+
+// failureReason summarizes err for logs and the review summary.
+func failureReason(err error) string {
+	var httpErr *llmhttp.Error
+	if errors.As(err, &httpErr) {
+		return httpErr.Type.String()
+	}
+	return err.Error()
+}
+
+// fallbackNote explains which models failed before model produced the
+// review.
+func fallbackNote(model string, failed, reasons []string) string {
+	parts := make([]string, len(failed))
+	for i := range failed {
+		parts[i] = fmt.Sprintf("%s (%s)", failed[i], reasons[i])
+	}
+	return fmt.Sprintf("_Reviewed with fallback model %s after %s failed._\n\n", model, strings.Join(parts, ", "))
+}
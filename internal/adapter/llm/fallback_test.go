@@ -0,0 +1,108 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/review"
+)
+
+// stubProvider returns a fixed review or error and counts its calls.
+type stubProvider struct {
+	model string
+	err   error
+	calls int
+}
+
+func (s *stubProvider) Review(ctx context.Context, req review.ProviderRequest) (domain.Review, error) {
+	s.calls++
+	if s.err != nil {
+		return domain.Review{}, s.err
+	}
+	return domain.Review{ProviderName: "anthropic", ModelName: s.model, Summary: "Looks good."}, nil
+}
+
+func (s *stubProvider) EstimateTokens(text string) int {
+	return len(text)
+}
+
+func TestFallbackProvider_PrimarySucceeds(t *testing.T) {
+	primary := &stubProvider{model: "claude-sonnet-4-5"}
+	fallback := &stubProvider{model: "claude-haiku-4-5"}
+	provider := NewFallbackProvider("anthropic",
+		ModelProvider{Model: "claude-sonnet-4-5", Provider: primary},
+		ModelProvider{Model: "claude-haiku-4-5", Provider: fallback})
+
+	result, err := provider.Review(context.Background(), review.ProviderRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ModelName != "claude-sonnet-4-5" || len(result.FallbackFrom) != 0 || result.Summary != "Looks good." {
+		t.Errorf("expected the primary review unchanged, got %+v", result)
+	}
+	if fallback.calls != 0 {
+		t.Errorf("fallback should not be called, got %d calls", fallback.calls)
+	}
+}
+
+func TestFallbackProvider_FallsBackInOrder(t *testing.T) {
+	provider := NewFallbackProvider("anthropic",
+		ModelProvider{Model: "claude-sonnet-4-5", Provider: &stubProvider{err: llmhttp.NewServiceUnavailableError("anthropic", "overloaded")}},
+		ModelProvider{Model: "claude-sonnet-4-0", Provider: &stubProvider{err: llmhttp.NewModelNotFoundError("anthropic", "retired")}},
+		ModelProvider{Model: "claude-haiku-4-5", Provider: &stubProvider{model: "claude-haiku-4-5"}})
+
+	result, err := provider.Review(context.Background(), review.ProviderRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ModelName != "claude-haiku-4-5" {
+		t.Errorf("expected the fallback model, got %q", result.ModelName)
+	}
+	if strings.Join(result.FallbackFrom, ",") != "claude-sonnet-4-5,claude-sonnet-4-0" {
+		t.Errorf("unexpected FallbackFrom: %v", result.FallbackFrom)
+	}
+	for _, want := range []string{"fallback model claude-haiku-4-5", "claude-sonnet-4-5 (service unavailable)", "claude-sonnet-4-0 (model not found)"} {
+		if !strings.Contains(result.Summary, want) {
+			t.Errorf("summary should mention %q, got %q", want, result.Summary)
+		}
+	}
+	if !strings.HasSuffix(result.Summary, "Looks good.") {
+		t.Errorf("summary should keep the review's own summary, got %q", result.Summary)
+	}
+}
+
+func TestFallbackProvider_StopsOnAuthenticationError(t *testing.T) {
+	authErr := llmhttp.NewAuthenticationError("anthropic", "invalid key")
+	fallback := &stubProvider{model: "claude-haiku-4-5"}
+	provider := NewFallbackProvider("anthropic",
+		ModelProvider{Model: "claude-sonnet-4-5", Provider: &stubProvider{err: authErr}},
+		ModelProvider{Model: "claude-haiku-4-5", Provider: fallback})
+
+	_, err := provider.Review(context.Background(), review.ProviderRequest{})
+	if !errors.Is(err, authErr) {
+		t.Errorf("expected the authentication error, got %v", err)
+	}
+	if fallback.calls != 0 {
+		t.Errorf("fallback should not be called after an authentication error")
+	}
+}
+
+func TestFallbackProvider_AllModelsFail(t *testing.T) {
+	provider := NewFallbackProvider("anthropic",
+		ModelProvider{Model: "claude-sonnet-4-5", Provider: &stubProvider{err: llmhttp.NewServiceUnavailableError("anthropic", "overloaded")}},
+		ModelProvider{Model: "claude-haiku-4-5", Provider: &stubProvider{err: llmhttp.NewTimeoutError("anthropic", "timed out")}})
+
+	_, err := provider.Review(context.Background(), review.ProviderRequest{})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{"model claude-sonnet-4-5", "model claude-haiku-4-5"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error should mention %q, got %v", want, err)
+		}
+	}
+}
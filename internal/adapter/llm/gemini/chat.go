@@ -0,0 +1,96 @@
+package gemini
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/llm"
+)
+
+// Chat sends a multi-turn conversation with native function declarations to
+// the generateContent API. Tool calls are returned as functionCall parts and
+// their results are sent back as functionResponse parts.
+func (c *HTTPClient) Chat(ctx context.Context, messages []llm.ChatMessage, tools []llm.ToolDefinition, options CallOptions) (*llm.ChatResponse, error) {
+	reqBody := GenerateContentRequest{
+		SystemInstruction: systemInstructionContent(options.SystemInstruction),
+		Contents:          toContents(messages),
+	}
+	applyOptions(&reqBody, options)
+	if len(tools) > 0 {
+		declarations := make([]FunctionDeclaration, len(tools))
+		for i, t := range tools {
+			declarations[i] = FunctionDeclaration{
+				Name:        t.Name,
+				Description: t.Description,
+				Parameters:  llm.ToolInputSchema(),
+			}
+		}
+		reqBody.Tools = []Tool{{FunctionDeclarations: declarations}}
+	}
+
+	genResp, _, duration, err := c.send(ctx, reqBody, llm.ContentLength(messages))
+	if err != nil {
+		return nil, err
+	}
+	candidate := genResp.Candidates[0]
+
+	response := &llm.ChatResponse{
+		TokensIn:  genResp.UsageMetadata.PromptTokenCount,
+		TokensOut: genResp.UsageMetadata.CandidatesTokenCount,
+	}
+
+	var textParts []string
+	for _, part := range candidate.Content.Parts {
+		if part.FunctionCall != nil {
+			// Gemini does not assign call IDs; calls are matched by name and order
+			response.ToolCalls = append(response.ToolCalls, llm.ToolCall{
+				ID:        fmt.Sprintf("%s-%d", part.FunctionCall.Name, len(response.ToolCalls)),
+				Name:      part.FunctionCall.Name,
+				Input:     llm.ToolInput(part.FunctionCall.Args),
+				Signature: part.ThoughtSignature,
+			})
+			continue
+		}
+		textParts = append(textParts, part.Text)
+	}
+	response.Text = strings.Join(textParts, "")
+	response.Cost = c.record(ctx, duration, response.TokensIn, response.TokensOut, candidate.FinishReason)
+
+	return response, nil
+}
+
+// toContents converts the neutral history to Gemini contents. Consecutive tool
+// results are combined into a single user turn of functionResponse parts.
+func toContents(messages []llm.ChatMessage) []Content {
+	var out []Content
+	for _, m := range messages {
+		switch m.Role {
+		case llm.ChatRoleAssistant:
+			var parts []Part
+			if m.Content != "" {
+				parts = append(parts, Part{Text: m.Content})
+			}
+			for _, call := range m.ToolCalls {
+				parts = append(parts, Part{
+					FunctionCall:     &FunctionCall{Name: call.Name, Args: llm.ToolArguments(call.Input)},
+					ThoughtSignature: call.Signature,
+				})
+			}
+			out = append(out, Content{Role: "model", Parts: parts})
+		case llm.ChatRoleTool:
+			part := Part{FunctionResponse: &FunctionResponse{
+				Name:     m.ToolName,
+				Response: map[string]interface{}{"output": m.Content},
+			}}
+			if n := len(out); n > 0 && out[n-1].Role == "user" && out[n-1].Parts[0].FunctionResponse != nil {
+				out[n-1].Parts = append(out[n-1].Parts, part)
+				continue
+			}
+			out = append(out, Content{Role: "user", Parts: []Part{part}})
+		default:
+			out = append(out, Content{Role: "user", Parts: []Part{{Text: m.Content}}})
+		}
+	}
+	return out
+}
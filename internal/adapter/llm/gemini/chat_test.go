@@ -0,0 +1,77 @@
+package gemini_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/llm"
+	"github.com/bkyoung/code-reviewer/internal/adapter/llm/gemini"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPClient_Chat_SendsHistoryAndParsesFunctionCalls(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req gemini.GenerateContentRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		require.NotNil(t, req.SystemInstruction)
+		assert.Equal(t, "verify things", req.SystemInstruction.Parts[0].Text)
+		require.Len(t, req.Tools, 1)
+		require.Len(t, req.Tools[0].FunctionDeclarations, 1)
+		assert.Equal(t, "read_file", req.Tools[0].FunctionDeclarations[0].Name)
+
+		// user, model function call, user function responses (combined)
+		require.Len(t, req.Contents, 3)
+		assert.Equal(t, "user", req.Contents[0].Role)
+		assert.Equal(t, "model", req.Contents[1].Role)
+		require.Len(t, req.Contents[1].Parts, 2)
+		require.NotNil(t, req.Contents[1].Parts[0].FunctionCall)
+		assert.Equal(t, "main.go", req.Contents[1].Parts[0].FunctionCall.Args["input"])
+		assert.Equal(t, "sig-1", req.Contents[1].Parts[0].ThoughtSignature)
+		assert.Equal(t, "user", req.Contents[2].Role)
+		require.Len(t, req.Contents[2].Parts, 2)
+		require.NotNil(t, req.Contents[2].Parts[0].FunctionResponse)
+		assert.Equal(t, "read_file", req.Contents[2].Parts[0].FunctionResponse.Name)
+		assert.Equal(t, "package main", req.Contents[2].Parts[0].FunctionResponse.Response["output"])
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(gemini.GenerateContentResponse{
+			Candidates: []gemini.Candidate{{
+				Content: gemini.Content{Role: "model", Parts: []gemini.Part{
+					{FunctionCall: &gemini.FunctionCall{Name: "grep", Args: map[string]interface{}{"input": "func main"}}, ThoughtSignature: "sig-2"},
+				}},
+				FinishReason: "STOP",
+			}},
+			UsageMetadata: gemini.UsageMetadata{PromptTokenCount: 100, CandidatesTokenCount: 20},
+		})
+	}))
+	defer server.Close()
+
+	client := gemini.NewHTTPClient("test-api-key", "gemini-1.5-pro", testProviderConfig(), testHTTPConfig())
+	client.SetBaseURL(server.URL)
+
+	system := "verify things"
+	messages := []llm.ChatMessage{
+		{Role: llm.ChatRoleUser, Content: "candidate"},
+		{Role: llm.ChatRoleAssistant, ToolCalls: []llm.ToolCall{
+			{ID: "read_file-0", Name: "read_file", Input: "main.go", Signature: "sig-1"},
+			{ID: "read_file-1", Name: "read_file", Input: "util.go"},
+		}},
+		{Role: llm.ChatRoleTool, ToolCallID: "read_file-0", ToolName: "read_file", Content: "package main"},
+		{Role: llm.ChatRoleTool, ToolCallID: "read_file-1", ToolName: "read_file", Content: "package util"},
+	}
+	tools := []llm.ToolDefinition{{Name: "read_file", Description: "Read a file"}}
+
+	resp, err := client.Chat(context.Background(), messages, tools, gemini.CallOptions{SystemInstruction: &system})
+	require.NoError(t, err)
+
+	assert.Empty(t, resp.Text)
+	require.Len(t, resp.ToolCalls, 1)
+	assert.Equal(t, llm.ToolCall{ID: "grep-0", Name: "grep", Input: "func main", Signature: "sig-2"}, resp.ToolCalls[0])
+	assert.Equal(t, 100, resp.TokensIn)
+	assert.Equal(t, 20, resp.TokensOut)
+}
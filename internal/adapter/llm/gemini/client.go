@@ -55,6 +55,9 @@ type HTTPClient struct {
 	logger  llmhttp.Logger
 	metrics llmhttp.Metrics
 	pricing llmhttp.Pricing
+
+	// Client-side rate limiting and circuit breaking, shared per provider
+	guard *llmhttp.Guard
 }
 
 // NewHTTPClient creates a new Gemini HTTP client.
@@ -93,6 +96,11 @@ func (c *HTTPClient) SetMetrics(metrics llmhttp.Metrics) {
 	c.metrics = metrics
 }
 
+// SetGuard sets the rate limiter and circuit breaker for this client.
+func (c *HTTPClient) SetGuard(guard *llmhttp.Guard) {
+	c.guard = guard
+}
+
 // SetPricing sets the pricing calculator for this client.
 func (c *HTTPClient) SetPricing(pricing llmhttp.Pricing) {
 	c.pricing = pricing
@@ -116,42 +124,9 @@ type APIResponse struct {
 
 // Call makes a request to the Gemini generateContent API.
 func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOptions) (*APIResponse, error) {
-	startTime := time.Now()
-
-	// Log request (if logger configured)
-	if c.logger != nil {
-		c.logger.LogRequest(ctx, llmhttp.RequestLog{
-			Provider:    "gemini",
-			Model:       c.model,
-			Timestamp:   startTime,
-			PromptChars: len(prompt),
-			APIKey:      c.apiKey,
-		})
-	}
-
-	// Record request metric
-	if c.metrics != nil {
-		c.metrics.RecordRequest("gemini", c.model)
-	}
-
-	// Determine system instruction: nil=use default, empty=""=disable, non-empty=override
-	var sysInstrContent *Content
-	if options.SystemInstruction == nil {
-		// Use default system instruction
-		sysInstrContent = &Content{
-			Parts: []Part{{Text: systemInstruction}},
-		}
-	} else if *options.SystemInstruction != "" {
-		// Use provided override
-		sysInstrContent = &Content{
-			Parts: []Part{{Text: *options.SystemInstruction}},
-		}
-	}
-	// If options.SystemInstruction is empty string pointer, sysInstrContent stays nil (disabled)
-
 	// Build request
 	reqBody := GenerateContentRequest{
-		SystemInstruction: sysInstrContent,
+		SystemInstruction: systemInstructionContent(options.SystemInstruction),
 		Contents: []Content{
 			{
 				Parts: []Part{
@@ -160,7 +135,63 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 			},
 		},
 	}
+	applyOptions(&reqBody, options)
+
+	genResp, bodyBytes, duration, err := c.send(ctx, reqBody, len(prompt))
+	if err != nil {
+		return nil, err
+	}
+	candidate := genResp.Candidates[0]
+
+	// Extract text from parts
+	var textParts []string
+	for _, part := range candidate.Content.Parts {
+		textParts = append(textParts, part.Text)
+	}
+
+	responseText := strings.Join(textParts, "")
+
+	// Log if we got an empty response for debugging
+	if c.logger != nil && responseText == "" {
+		c.logger.LogWarning(ctx, "Gemini returned empty response", map[string]interface{}{
+			"finishReason":        candidate.FinishReason,
+			"numParts":            len(candidate.Content.Parts),
+			"numCandidates":       len(genResp.Candidates),
+			"tokensOut":           genResp.UsageMetadata.CandidatesTokenCount,
+			"responsePreview":     llmhttp.SafeLogResponse(string(bodyBytes)),
+			"responseLengthBytes": len(bodyBytes),
+		})
+	}
+
+	response := &APIResponse{
+		Text:         responseText,
+		TokensIn:     genResp.UsageMetadata.PromptTokenCount,
+		TokensOut:    genResp.UsageMetadata.CandidatesTokenCount,
+		FinishReason: candidate.FinishReason,
+	}
+	response.Cost = c.record(ctx, duration, response.TokensIn, response.TokensOut, response.FinishReason)
+
+	return response, nil
+}
 
+// systemInstructionContent resolves the system instruction option:
+// nil uses the default, an empty string disables it, anything else overrides it.
+func systemInstructionContent(override *string) *Content {
+	if override == nil {
+		return &Content{
+			Parts: []Part{{Text: systemInstruction}},
+		}
+	}
+	if *override != "" {
+		return &Content{
+			Parts: []Part{{Text: *override}},
+		}
+	}
+	return nil
+}
+
+// applyOptions adds the generation config and default safety settings.
+func applyOptions(reqBody *GenerateContentRequest, options CallOptions) {
 	// Add generation config if options provided
 	if options.Temperature > 0 || options.MaxTokens > 0 {
 		reqBody.GenerationConfig = &GenerationConfig{}
@@ -180,11 +211,34 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 		{Category: "HARM_CATEGORY_HARASSMENT", Threshold: "BLOCK_ONLY_HIGH"},
 		{Category: "HARM_CATEGORY_SEXUALLY_EXPLICIT", Threshold: "BLOCK_ONLY_HIGH"},
 	}
+}
+
+// send posts a generateContent request with retry and returns the parsed
+// response (guaranteed to contain a candidate that was not safety-filtered)
+// and the raw body. The request and any failure are logged and recorded.
+func (c *HTTPClient) send(ctx context.Context, reqBody GenerateContentRequest, promptChars int) (*GenerateContentResponse, []byte, time.Duration, error) {
+	startTime := time.Now()
+
+	// Log request (if logger configured)
+	if c.logger != nil {
+		c.logger.LogRequest(ctx, llmhttp.RequestLog{
+			Provider:    "gemini",
+			Model:       c.model,
+			Timestamp:   startTime,
+			PromptChars: promptChars,
+			APIKey:      c.apiKey,
+		})
+	}
+
+	// Record request metric
+	if metrics := llmhttp.ScopedMetrics(ctx, c.metrics); metrics != nil {
+		metrics.RecordRequest("gemini", c.model)
+	}
 
 	// Marshal request
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return nil, nil, 0, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	// Create URL with API key
@@ -193,7 +247,7 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 	// Execute request with retry logic (using configured retry settings)
 	var resp *http.Response
 
-	err = llmhttp.RetryWithBackoff(ctx, func(ctx context.Context) error {
+	operation := func(ctx context.Context) error {
 		// Recreate request for each retry
 		retryReq, reqErr := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 		if reqErr != nil {
@@ -222,11 +276,16 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 		if resp.StatusCode >= 400 {
 			bodyBytes, _ := io.ReadAll(resp.Body)
 			resp.Body.Close()
-			return c.handleErrorResponse(resp.StatusCode, bodyBytes)
+			return llmhttp.WithRetryAfter(c.handleErrorResponse(resp.StatusCode, bodyBytes), resp.Header.Get("Retry-After"))
 		}
 
 		return nil
-	}, c.retryConf)
+	}
+
+	if c.guard != nil {
+		operation = c.guard.Wrap(operation, c.model, promptChars)
+	}
+	err = llmhttp.RetryWithBackoff(ctx, operation, c.retryConf)
 
 	duration := time.Since(startTime)
 
@@ -248,37 +307,35 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 			}
 		}
 		// Record error metric
-		if c.metrics != nil {
+		if metrics := llmhttp.ScopedMetrics(ctx, c.metrics); metrics != nil {
 			var httpErr *llmhttp.Error
 			if errors.As(err, &httpErr) {
-				c.metrics.RecordError("gemini", c.model, httpErr.Type)
+				metrics.RecordError("gemini", c.model, httpErr.Type)
 			}
 		}
-		return nil, err
+		return nil, nil, duration, err
 	}
 	defer resp.Body.Close()
 
 	// Parse response
 	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, nil, duration, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	var genResp GenerateContentResponse
 	if err := json.Unmarshal(bodyBytes, &genResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, nil, duration, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	// Validate response
 	if len(genResp.Candidates) == 0 {
-		return nil, fmt.Errorf("no candidates in response")
+		return nil, nil, duration, fmt.Errorf("no candidates in response")
 	}
 
-	candidate := genResp.Candidates[0]
-
 	// Check for content filtering
-	if candidate.FinishReason == "SAFETY" {
-		return nil, &llmhttp.Error{
+	if genResp.Candidates[0].FinishReason == "SAFETY" {
+		return nil, nil, duration, &llmhttp.Error{
 			Type:      llmhttp.ErrTypeContentFiltered,
 			Message:   "Content blocked by safety filters",
 			Retryable: false,
@@ -286,38 +343,16 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 		}
 	}
 
-	// Extract text from parts
-	var textParts []string
-	for _, part := range candidate.Content.Parts {
-		textParts = append(textParts, part.Text)
-	}
-
-	responseText := strings.Join(textParts, "")
-
-	// Log if we got an empty response for debugging
-	if c.logger != nil && responseText == "" {
-		c.logger.LogWarning(ctx, "Gemini returned empty response", map[string]interface{}{
-			"finishReason":        candidate.FinishReason,
-			"numParts":            len(candidate.Content.Parts),
-			"numCandidates":       len(genResp.Candidates),
-			"tokensOut":           genResp.UsageMetadata.CandidatesTokenCount,
-			"responsePreview":     llmhttp.SafeLogResponse(string(bodyBytes)),
-			"responseLengthBytes": len(bodyBytes),
-		})
-	}
-
-	response := &APIResponse{
-		Text:         responseText,
-		TokensIn:     genResp.UsageMetadata.PromptTokenCount,
-		TokensOut:    genResp.UsageMetadata.CandidatesTokenCount,
-		FinishReason: candidate.FinishReason,
-	}
+	return &genResp, bodyBytes, duration, nil
+}
 
+// record calculates the cost of a successful call, logs the response and
+// records metrics. It returns the cost in USD.
+func (c *HTTPClient) record(ctx context.Context, duration time.Duration, tokensIn, tokensOut int, finishReason string) float64 {
 	// Calculate cost
 	var cost float64
 	if c.pricing != nil {
-		cost = c.pricing.GetCost("gemini", c.model, response.TokensIn, response.TokensOut)
-		response.Cost = cost
+		cost = c.pricing.GetCost("gemini", c.model, tokensIn, tokensOut)
 	}
 
 	// Log response
@@ -327,22 +362,22 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 			Model:        c.model,
 			Timestamp:    time.Now(),
 			Duration:     duration,
-			TokensIn:     response.TokensIn,
-			TokensOut:    response.TokensOut,
+			TokensIn:     tokensIn,
+			TokensOut:    tokensOut,
 			Cost:         cost,
 			StatusCode:   200,
-			FinishReason: response.FinishReason,
+			FinishReason: finishReason,
 		})
 	}
 
 	// Record metrics
-	if c.metrics != nil {
-		c.metrics.RecordDuration("gemini", c.model, duration)
-		c.metrics.RecordTokens("gemini", c.model, response.TokensIn, response.TokensOut)
-		c.metrics.RecordCost("gemini", c.model, cost)
+	if metrics := llmhttp.ScopedMetrics(ctx, c.metrics); metrics != nil {
+		metrics.RecordDuration("gemini", c.model, duration)
+		metrics.RecordTokens("gemini", c.model, tokensIn, tokensOut)
+		metrics.RecordCost("gemini", c.model, cost)
 	}
 
-	return response, nil
+	return cost
 }
 
 // handleErrorResponse maps HTTP status codes to typed errors.
@@ -6,6 +6,7 @@ type GenerateContentRequest struct {
 	SystemInstruction *Content          `json:"systemInstruction,omitempty"`
 	GenerationConfig  *GenerationConfig `json:"generationConfig,omitempty"`
 	SafetySettings    []SafetySetting   `json:"safetySettings,omitempty"`
+	Tools             []Tool            `json:"tools,omitempty"`
 }
 
 // Content represents content in the request/response.
@@ -16,7 +17,36 @@ type Content struct {
 
 // Part represents a part of the content.
 type Part struct {
-	Text string `json:"text"`
+	Text             string            `json:"text,omitempty"`
+	FunctionCall     *FunctionCall     `json:"functionCall,omitempty"`
+	FunctionResponse *FunctionResponse `json:"functionResponse,omitempty"`
+
+	// ThoughtSignature must be sent back with the part it arrived on in later turns.
+	ThoughtSignature string `json:"thoughtSignature,omitempty"`
+}
+
+// FunctionCall is a function call requested by the model.
+type FunctionCall struct {
+	Name string                 `json:"name"`
+	Args map[string]interface{} `json:"args"`
+}
+
+// FunctionResponse carries a function's result back to the model.
+type FunctionResponse struct {
+	Name     string                 `json:"name"`
+	Response map[string]interface{} `json:"response"`
+}
+
+// Tool declares the functions the model may call.
+type Tool struct {
+	FunctionDeclarations []FunctionDeclaration `json:"functionDeclarations"`
+}
+
+// FunctionDeclaration describes a callable function and its parameter schema.
+type FunctionDeclaration struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Parameters  map[string]interface{} `json:"parameters"`
 }
 
 // GenerationConfig controls generation parameters.
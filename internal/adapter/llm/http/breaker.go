@@ -0,0 +1,152 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// BreakerState is the state of a circuit breaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets calls through.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen rejects calls until the cooldown has passed.
+	BreakerOpen
+	// BreakerHalfOpen lets a single trial call through after the cooldown.
+	BreakerHalfOpen
+)
+
+// String returns the state name used in metrics.
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreaker fails fast after a run of consecutive failures, so a
+// provider that is down is not retried on every call. After the cooldown
+// one trial call is let through: success closes the breaker, failure
+// opens it again.
+type CircuitBreaker struct {
+	mu sync.Mutex
+
+	threshold int
+	cooldown  time.Duration
+
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	trial    bool // A half-open trial call is in flight
+
+	onChange func(BreakerState)
+	now      func() time.Time
+}
+
+// NewCircuitBreaker creates a breaker that opens after threshold
+// consecutive failures and stays open for cooldown. onChange, when not
+// nil, is called with each new state.
+func NewCircuitBreaker(threshold int, cooldown time.Duration, onChange func(BreakerState)) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		onChange:  onChange,
+		now:       time.Now,
+	}
+}
+
+// Allow reports whether a call may proceed. When it may not, it returns
+// how long until the next trial call is allowed.
+func (b *CircuitBreaker) Allow() (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		remaining := b.cooldown - b.now().Sub(b.openedAt)
+		if remaining > 0 {
+			return false, remaining
+		}
+		b.setState(BreakerHalfOpen)
+		b.trial = true
+		return true, 0
+	case BreakerHalfOpen:
+		if b.trial {
+			return false, b.cooldown
+		}
+		b.trial = true
+		return true, 0
+	default:
+		return true, 0
+	}
+}
+
+// Record records the outcome of an allowed call. Only failures that
+// suggest the provider is unavailable count towards opening the breaker;
+// rejected requests and rate limiting do not.
+func (b *CircuitBreaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return // Says nothing about the provider
+	}
+	if !countsAsFailure(err) {
+		b.failures = 0
+		if b.state != BreakerClosed {
+			b.setState(BreakerClosed)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.openedAt = b.now()
+		b.setState(BreakerOpen)
+	}
+}
+
+// State returns the current state.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+func (b *CircuitBreaker) setState(state BreakerState) {
+	b.state = state
+	if b.onChange != nil {
+		b.onChange(state)
+	}
+}
+
+// countsAsFailure reports whether err indicates the provider is down:
+// server errors and timeouts. Local failures such as an unparseable
+// response carry no *Error and do not count.
+func countsAsFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	var httpErr *Error
+	if !errors.As(err, &httpErr) {
+		return false
+	}
+	switch httpErr.Type {
+	case ErrTypeServiceUnavailable, ErrTypeTimeout:
+		return true
+	case ErrTypeUnknown:
+		return httpErr.StatusCode >= 500
+	default:
+		return false
+	}
+}
@@ -0,0 +1,70 @@
+package http_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	var states []llmhttp.BreakerState
+	breaker := llmhttp.NewCircuitBreaker(2, time.Minute, func(s llmhttp.BreakerState) { states = append(states, s) })
+
+	breaker.Record(llmhttp.NewServiceUnavailableError("openai", "down"))
+	assert.Equal(t, llmhttp.BreakerClosed, breaker.State())
+
+	breaker.Record(llmhttp.NewTimeoutError("openai", "timed out"))
+	assert.Equal(t, llmhttp.BreakerOpen, breaker.State())
+
+	allowed, retryIn := breaker.Allow()
+	assert.False(t, allowed)
+	assert.Greater(t, retryIn, 59*time.Second)
+	assert.Equal(t, []llmhttp.BreakerState{llmhttp.BreakerOpen}, states)
+}
+
+func TestCircuitBreaker_IgnoresClientErrors(t *testing.T) {
+	breaker := llmhttp.NewCircuitBreaker(2, time.Minute, nil)
+
+	breaker.Record(llmhttp.NewServiceUnavailableError("openai", "down"))
+	breaker.Record(llmhttp.NewRateLimitError("openai", "slow down")) // The provider is up
+	breaker.Record(llmhttp.NewServiceUnavailableError("openai", "down"))
+	breaker.Record(context.Canceled)
+	breaker.Record(llmhttp.NewInvalidRequestError("openai", "bad request"))
+	breaker.Record(llmhttp.NewServiceUnavailableError("openai", "down"))
+
+	assert.Equal(t, llmhttp.BreakerClosed, breaker.State())
+}
+
+func TestCircuitBreaker_HalfOpenTrial(t *testing.T) {
+	breaker := llmhttp.NewCircuitBreaker(1, 20*time.Millisecond, nil)
+	breaker.Record(llmhttp.NewServiceUnavailableError("openai", "down"))
+	assert.Equal(t, llmhttp.BreakerOpen, breaker.State())
+
+	time.Sleep(30 * time.Millisecond)
+
+	// Only one trial call is let through
+	allowed, _ := breaker.Allow()
+	assert.True(t, allowed)
+	assert.Equal(t, llmhttp.BreakerHalfOpen, breaker.State())
+	allowed, _ = breaker.Allow()
+	assert.False(t, allowed)
+
+	// A failed trial opens the breaker again
+	breaker.Record(llmhttp.NewServiceUnavailableError("openai", "down"))
+	assert.Equal(t, llmhttp.BreakerOpen, breaker.State())
+
+	time.Sleep(30 * time.Millisecond)
+	allowed, _ = breaker.Allow()
+	assert.True(t, allowed)
+	breaker.Record(nil)
+	assert.Equal(t, llmhttp.BreakerClosed, breaker.State())
+}
+
+func TestBreakerState_String(t *testing.T) {
+	assert.Equal(t, "closed", llmhttp.BreakerClosed.String())
+	assert.Equal(t, "open", llmhttp.BreakerOpen.String())
+	assert.Equal(t, "half-open", llmhttp.BreakerHalfOpen.String())
+}
@@ -52,6 +52,29 @@ func BuildRetryConfig(provider config.ProviderConfig, httpCfg config.HTTPConfig)
 	}
 }
 
+// BuildGuardConfig creates GuardConfig from provider + global HTTP config.
+// Rate limits: provider override > global. The circuit breaker is global.
+// Retry-After pauses are capped at the same max backoff as the retries.
+func BuildGuardConfig(provider config.ProviderConfig, httpCfg config.HTTPConfig) GuardConfig {
+	requestsPerMinute := httpCfg.RequestsPerMinute
+	if provider.RequestsPerMinute != nil {
+		requestsPerMinute = *provider.RequestsPerMinute
+	}
+
+	tokensPerMinute := httpCfg.TokensPerMinute
+	if provider.TokensPerMinute != nil {
+		tokensPerMinute = *provider.TokensPerMinute
+	}
+
+	return GuardConfig{
+		RequestsPerMinute: requestsPerMinute,
+		TokensPerMinute:   tokensPerMinute,
+		FailureThreshold:  httpCfg.CircuitBreaker.FailureThreshold,
+		Cooldown:          parseDuration(nil, httpCfg.CircuitBreaker.Cooldown, 30*time.Second),
+		MaxPause:          parseDuration(provider.MaxBackoff, httpCfg.MaxBackoff, 32*time.Second),
+	}
+}
+
 // parseDuration parses duration with fallback chain.
 // Negative durations are rejected to prevent invalid backoff values.
 func parseDuration(override *string, global string, defaultVal time.Duration) time.Duration {
@@ -260,3 +260,39 @@ func TestBuildRetryConfig_EmptyStringProviderOverrides(t *testing.T) {
 	assert.Equal(t, 3*time.Second, result.InitialBackoff, "Empty string override should fall back to global")
 	assert.Equal(t, 40*time.Second, result.MaxBackoff, "Empty string override should fall back to global")
 }
+
+func TestBuildGuardConfig_ProviderOverrides(t *testing.T) {
+	provider := config.ProviderConfig{
+		RequestsPerMinute: intPtr(50),
+		TokensPerMinute:   intPtr(0), // Explicitly unlimited
+	}
+	httpCfg := config.HTTPConfig{
+		RequestsPerMinute: 500,
+		TokensPerMinute:   100000,
+		CircuitBreaker:    config.CircuitBreakerConfig{FailureThreshold: 3, Cooldown: "1m"},
+	}
+
+	result := llmhttp.BuildGuardConfig(provider, httpCfg)
+
+	assert.Equal(t, 50, result.RequestsPerMinute)
+	assert.Equal(t, 0, result.TokensPerMinute)
+	assert.Equal(t, 3, result.FailureThreshold)
+	assert.Equal(t, time.Minute, result.Cooldown)
+	assert.Equal(t, 32*time.Second, result.MaxPause)
+}
+
+func TestBuildGuardConfig_GlobalFallbacks(t *testing.T) {
+	httpCfg := config.HTTPConfig{
+		RequestsPerMinute: 500,
+		TokensPerMinute:   100000,
+		MaxBackoff:        "10s",
+		CircuitBreaker:    config.CircuitBreakerConfig{FailureThreshold: 5, Cooldown: "invalid"},
+	}
+
+	result := llmhttp.BuildGuardConfig(config.ProviderConfig{}, httpCfg)
+	assert.Equal(t, 10*time.Second, result.MaxPause)
+
+	assert.Equal(t, 500, result.RequestsPerMinute)
+	assert.Equal(t, 100000, result.TokensPerMinute)
+	assert.Equal(t, 30*time.Second, result.Cooldown)
+}
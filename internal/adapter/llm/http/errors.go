@@ -1,6 +1,12 @@
 package http
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // ErrorType represents the category of error that occurred.
 type ErrorType int
@@ -13,6 +19,7 @@ const (
 	ErrTypeTimeout
 	ErrTypeModelNotFound
 	ErrTypeContentFiltered
+	ErrTypeCircuitOpen
 	ErrTypeUnknown
 )
 
@@ -33,6 +40,8 @@ func (e ErrorType) String() string {
 		return "model not found"
 	case ErrTypeContentFiltered:
 		return "content filtered"
+	case ErrTypeCircuitOpen:
+		return "circuit open"
 	case ErrTypeUnknown:
 		return "unknown error"
 	default:
@@ -47,6 +56,10 @@ type Error struct {
 	StatusCode int
 	Retryable  bool
 	Provider   string
+
+	// RetryAfter is how long the server asked clients to wait before
+	// retrying (from the Retry-After header). Zero when not given.
+	RetryAfter time.Duration
 }
 
 // Error implements the error interface.
@@ -144,3 +157,49 @@ func NewContentFilteredError(provider, message string) *Error {
 		Provider:   provider,
 	}
 }
+
+// NewCircuitOpenError creates an error for a call rejected by an open
+// circuit breaker. It is not retryable so callers fail fast.
+func NewCircuitOpenError(provider string, retryIn time.Duration) *Error {
+	return &Error{
+		Type:       ErrTypeCircuitOpen,
+		Message:    fmt.Sprintf("too many consecutive failures, next attempt allowed in %s", retryIn.Round(time.Second)),
+		StatusCode: 0,
+		Retryable:  false,
+		Provider:   provider,
+	}
+}
+
+// WithRetryAfter sets RetryAfter on err from a Retry-After header value.
+// Errors that are not *Error, and unparseable values, are left unchanged.
+func WithRetryAfter(err error, header string) error {
+	var httpErr *Error
+	if errors.As(err, &httpErr) {
+		if d := ParseRetryAfter(header, time.Now()); d > 0 {
+			httpErr.RetryAfter = d
+		}
+	}
+	return err
+}
+
+// ParseRetryAfter parses a Retry-After header value, given either as
+// delay seconds or as an HTTP date. It returns zero when the value is
+// empty, invalid or in the past.
+func ParseRetryAfter(header string, now time.Time) time.Duration {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := time.Parse(time.RFC1123, header); err == nil {
+		if d := date.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
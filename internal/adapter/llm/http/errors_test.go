@@ -3,9 +3,11 @@ package http_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestError_Error(t *testing.T) {
@@ -141,6 +143,7 @@ func TestErrorTypeString(t *testing.T) {
 		{llmhttp.ErrTypeTimeout, "timeout"},
 		{llmhttp.ErrTypeModelNotFound, "model not found"},
 		{llmhttp.ErrTypeContentFiltered, "content filtered"},
+		{llmhttp.ErrTypeCircuitOpen, "circuit open"},
 		{llmhttp.ErrTypeUnknown, "unknown error"},
 	}
 
@@ -150,3 +153,39 @@ func TestErrorTypeString(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 10, 20, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		header   string
+		expected time.Duration
+	}{
+		{"seconds", "30", 30 * time.Second},
+		{"padded seconds", " 5 ", 5 * time.Second},
+		{"http date", "Mon, 20 Oct 2025 12:01:00 GMT", time.Minute},
+		{"date in the past", "Mon, 20 Oct 2025 11:59:00 GMT", 0},
+		{"empty", "", 0},
+		{"negative", "-1", 0},
+		{"garbage", "soon", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, llmhttp.ParseRetryAfter(tt.header, now))
+		})
+	}
+}
+
+func TestWithRetryAfter(t *testing.T) {
+	err := llmhttp.WithRetryAfter(llmhttp.NewRateLimitError("openai", "slow down"), "12")
+
+	var httpErr *llmhttp.Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, 12*time.Second, httpErr.RetryAfter)
+
+	// Untyped errors pass through unchanged
+	plain := errors.New("boom")
+	assert.Equal(t, plain, llmhttp.WithRetryAfter(plain, "12"))
+}
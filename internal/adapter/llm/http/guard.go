@@ -0,0 +1,155 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/bkyoung/code-reviewer/internal/config"
+)
+
+// GuardConfig configures the client-side protection of a provider.
+type GuardConfig struct {
+	RequestsPerMinute int // Zero means unlimited
+	TokensPerMinute   int // Zero means unlimited
+	FailureThreshold  int // Consecutive failures that open the breaker; zero disables it
+	Cooldown          time.Duration
+	MaxPause          time.Duration // Caps the limiter pause on Retry-After; zero means uncapped
+}
+
+// Guard rate limits and circuit-breaks the calls to one provider. Every
+// client of the provider shares the same Guard. The rate limiter covers
+// the whole provider; each model has its own breaker, so an outage of one
+// model does not block falling back to another.
+type Guard struct {
+	provider string
+	cfg      GuardConfig
+	limiter  *RateLimiter
+	metrics  Metrics
+
+	mu       sync.Mutex
+	breakers map[string]*CircuitBreaker
+}
+
+// NewGuard creates a guard for a provider. metrics may be nil.
+func NewGuard(provider string, cfg GuardConfig, metrics Metrics) *Guard {
+	return &Guard{
+		provider: provider,
+		cfg:      cfg,
+		limiter:  NewRateLimiter(cfg.RequestsPerMinute, cfg.TokensPerMinute),
+		metrics:  metrics,
+		breakers: make(map[string]*CircuitBreaker),
+	}
+}
+
+// breaker returns the model's circuit breaker, creating it on first use.
+// It returns nil when the breaker is disabled.
+func (g *Guard) breaker(model string) *CircuitBreaker {
+	if g.cfg.FailureThreshold <= 0 {
+		return nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	b, ok := g.breakers[model]
+	if !ok {
+		var onChange func(BreakerState)
+		if g.metrics != nil {
+			onChange = func(state BreakerState) { g.metrics.RecordBreakerState(g.provider, model, state) }
+			g.metrics.RecordBreakerState(g.provider, model, BreakerClosed)
+		}
+		b = NewCircuitBreaker(g.cfg.FailureThreshold, g.cfg.Cooldown, onChange)
+		g.breakers[model] = b
+	}
+	return b
+}
+
+// Wrap returns an operation that runs op, a call to model, under the
+// guard: it fails fast while the model's breaker is open, waits for the
+// rate limiter, records the outcome with the breaker and pauses the
+// limiter (for at most MaxPause) when the provider answers with a
+// Retry-After. promptChars sizes the token estimate.
+//
+// Wrap the operation passed to RetryWithBackoff so each attempt is
+// guarded separately.
+func (g *Guard) Wrap(op Operation, model string, promptChars int) Operation {
+	tokens := estimateTokens(promptChars)
+	breaker := g.breaker(model)
+	return func(ctx context.Context) error {
+		if breaker != nil {
+			if ok, retryIn := breaker.Allow(); !ok {
+				return NewCircuitOpenError(g.provider, retryIn)
+			}
+		}
+
+		wait, err := g.limiter.Wait(ctx, tokens)
+		if metrics := ScopedMetrics(ctx, g.metrics); wait > 0 && metrics != nil {
+			metrics.RecordThrottle(g.provider, wait)
+		}
+		if err == nil {
+			err = op(ctx)
+		}
+
+		if breaker != nil {
+			breaker.Record(err)
+		}
+		var httpErr *Error
+		if errors.As(err, &httpErr) && httpErr.Type == ErrTypeRateLimit && httpErr.RetryAfter > 0 {
+			pause := httpErr.RetryAfter
+			if g.cfg.MaxPause > 0 && pause > g.cfg.MaxPause {
+				pause = g.cfg.MaxPause
+			}
+			g.limiter.Pause(pause)
+		}
+		return err
+	}
+}
+
+// State returns the state of the model's breaker; BreakerClosed when it
+// is disabled.
+func (g *Guard) State(model string) BreakerState {
+	if b := g.breaker(model); b != nil {
+		return b.State()
+	}
+	return BreakerClosed
+}
+
+// estimateTokens approximates the tokens of a prompt at four characters
+// per token, which is close enough for rate limiting.
+func estimateTokens(promptChars int) int {
+	return promptChars / 4
+}
+
+// Guards holds one Guard per provider.
+type Guards struct {
+	mu      sync.Mutex
+	metrics Metrics
+	guards  map[string]*Guard
+}
+
+// NewGuards creates an empty set of guards reporting to metrics, which
+// may be nil.
+func NewGuards(metrics Metrics) *Guards {
+	return &Guards{metrics: metrics, guards: make(map[string]*Guard)}
+}
+
+// For returns the provider's guard, creating it from the provider and
+// global HTTP config on first use. A nil Guards returns nil, which
+// leaves clients unguarded.
+func (g *Guards) For(provider string, providerCfg config.ProviderConfig, httpCfg config.HTTPConfig) *Guard {
+	if g == nil {
+		return nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	guard, ok := g.guards[provider]
+	if !ok {
+		guard = NewGuard(provider, BuildGuardConfig(providerCfg, httpCfg), g.metrics)
+		g.guards[provider] = guard
+	}
+	return guard
+}
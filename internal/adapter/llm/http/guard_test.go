@@ -0,0 +1,129 @@
+package http_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+	"github.com/bkyoung/code-reviewer/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fastRetryConfig() llmhttp.RetryConfig {
+	return llmhttp.RetryConfig{
+		MaxRetries:     5,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Multiplier:     1,
+	}
+}
+
+func TestGuard_FailsFastWhenBreakerOpens(t *testing.T) {
+	metrics := llmhttp.NewDefaultMetrics()
+	guard := llmhttp.NewGuard("openai", llmhttp.GuardConfig{FailureThreshold: 2, Cooldown: time.Minute}, metrics)
+
+	calls := 0
+	operation := func(ctx context.Context) error {
+		calls++
+		return llmhttp.NewServiceUnavailableError("openai", "down")
+	}
+
+	err := llmhttp.RetryWithBackoff(context.Background(), guard.Wrap(operation, "gpt-4o", 100), fastRetryConfig())
+	var httpErr *llmhttp.Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, llmhttp.ErrTypeCircuitOpen, httpErr.Type)
+	assert.Equal(t, 2, calls, "the breaker should stop the retries")
+
+	// Later calls are rejected without reaching the provider
+	err = llmhttp.RetryWithBackoff(context.Background(), guard.Wrap(operation, "gpt-4o", 100), fastRetryConfig())
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, llmhttp.ErrTypeCircuitOpen, httpErr.Type)
+	assert.Equal(t, 2, calls)
+
+	stats := metrics.GetStats().ByProvider["openai"]
+	assert.Equal(t, llmhttp.BreakerStats{State: "open", Trips: 1}, stats.Breakers["gpt-4o"])
+	assert.Equal(t, llmhttp.BreakerOpen, guard.State("gpt-4o"))
+
+	// Other models of the provider are unaffected
+	fallback := guard.Wrap(func(ctx context.Context) error { return nil }, "gpt-4o-mini", 100)
+	require.NoError(t, fallback(context.Background()))
+	assert.Equal(t, llmhttp.BreakerClosed, guard.State("gpt-4o-mini"))
+}
+
+func TestGuard_HonorsRetryAfter(t *testing.T) {
+	guard := llmhttp.NewGuard("openai", llmhttp.GuardConfig{}, nil)
+
+	calls := 0
+	operation := func(ctx context.Context) error {
+		calls++
+		if calls == 1 {
+			err := llmhttp.NewRateLimitError("openai", "slow down")
+			err.RetryAfter = 50 * time.Millisecond
+			return err
+		}
+		return nil
+	}
+
+	retryConfig := fastRetryConfig()
+	retryConfig.MaxBackoff = time.Second
+
+	start := time.Now()
+	err := llmhttp.RetryWithBackoff(context.Background(), guard.Wrap(operation, "gpt-4o", 100), retryConfig)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+}
+
+func TestGuard_CapsRetryAfterPause(t *testing.T) {
+	guard := llmhttp.NewGuard("openai", llmhttp.GuardConfig{MaxPause: 20 * time.Millisecond}, nil)
+
+	failed := guard.Wrap(func(ctx context.Context) error {
+		err := llmhttp.NewRateLimitError("openai", "slow down")
+		err.RetryAfter = time.Hour
+		return err
+	}, "gpt-4o", 0)
+	require.Error(t, failed(context.Background()))
+
+	// Other callers are held back for MaxPause, not the hour the provider asked for
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	ok := guard.Wrap(func(ctx context.Context) error { return nil }, "gpt-4o", 0)
+	require.NoError(t, ok(ctx))
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+}
+
+func TestGuard_RecordsThrottling(t *testing.T) {
+	metrics := llmhttp.NewDefaultMetrics()
+	guard := llmhttp.NewGuard("openai", llmhttp.GuardConfig{}, metrics)
+
+	// Another caller's Retry-After holds this one back too
+	failed := guard.Wrap(func(ctx context.Context) error {
+		err := llmhttp.NewRateLimitError("openai", "slow down")
+		err.RetryAfter = 20 * time.Millisecond
+		return err
+	}, "gpt-4o", 0)
+	require.Error(t, failed(context.Background()))
+
+	ok := guard.Wrap(func(ctx context.Context) error { return nil }, "gpt-4o-mini", 0)
+	require.NoError(t, ok(context.Background()))
+
+	stats := metrics.GetStats().ByProvider["openai"]
+	assert.Equal(t, 1, stats.Throttled)
+	assert.Greater(t, stats.ThrottleWait, time.Duration(0))
+	assert.Empty(t, stats.Breakers, "no breaker is configured")
+}
+
+func TestGuards_SharedPerProvider(t *testing.T) {
+	guards := llmhttp.NewGuards(nil)
+	httpCfg := config.HTTPConfig{CircuitBreaker: config.CircuitBreakerConfig{FailureThreshold: 5}}
+
+	first := guards.For("openai", config.ProviderConfig{}, httpCfg)
+	assert.Same(t, first, guards.For("openai", config.ProviderConfig{}, httpCfg))
+	assert.NotSame(t, first, guards.For("anthropic", config.ProviderConfig{}, httpCfg))
+
+	var none *llmhttp.Guards
+	assert.Nil(t, none.For("openai", config.ProviderConfig{}, httpCfg))
+}
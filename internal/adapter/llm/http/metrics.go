@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"sync"
 	"time"
 )
@@ -22,6 +23,12 @@ type Metrics interface {
 	// RecordError records an error
 	RecordError(provider, model string, errType ErrorType)
 
+	// RecordThrottle records time a request waited for the rate limiter
+	RecordThrottle(provider string, wait time.Duration)
+
+	// RecordBreakerState records a circuit breaker state change
+	RecordBreakerState(provider, model string, state BreakerState)
+
 	// GetStats returns current statistics
 	GetStats() Stats
 }
@@ -45,6 +52,18 @@ type ProviderStats struct {
 	Cost      float64
 	Duration  time.Duration
 	Errors    int
+
+	// Client-side protection: requests held back by the rate limiter,
+	// the total time they waited, and the circuit breakers by model.
+	Throttled    int
+	ThrottleWait time.Duration
+	Breakers     map[string]BreakerStats
+}
+
+// BreakerStats describes the circuit breaker of one model.
+type BreakerStats struct {
+	State string // Current state, e.g. "closed" or "open"
+	Trips int    // Times the breaker opened
 }
 
 // DefaultMetrics provides in-memory metrics tracking.
@@ -124,6 +143,35 @@ func (m *DefaultMetrics) RecordError(provider, model string, errType ErrorType)
 	m.stats.ByProvider[provider] = ps
 }
 
+// RecordThrottle records a rate limiter wait.
+func (m *DefaultMetrics) RecordThrottle(provider string, wait time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ps := m.stats.ByProvider[provider]
+	ps.Throttled++
+	ps.ThrottleWait += wait
+	m.stats.ByProvider[provider] = ps
+}
+
+// RecordBreakerState records a circuit breaker state change.
+func (m *DefaultMetrics) RecordBreakerState(provider, model string, state BreakerState) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ps := m.stats.ByProvider[provider]
+	if ps.Breakers == nil {
+		ps.Breakers = make(map[string]BreakerStats)
+	}
+	bs := ps.Breakers[model]
+	bs.State = state.String()
+	if state == BreakerOpen {
+		bs.Trips++
+	}
+	ps.Breakers[model] = bs
+	m.stats.ByProvider[provider] = ps
+}
+
 // GetStats returns a copy of current statistics.
 func (m *DefaultMetrics) GetStats() Stats {
 	m.mu.RLock()
@@ -141,8 +189,91 @@ func (m *DefaultMetrics) GetStats() Stats {
 	}
 
 	for k, v := range m.stats.ByProvider {
+		if v.Breakers != nil {
+			breakers := make(map[string]BreakerStats, len(v.Breakers))
+			for model, bs := range v.Breakers {
+				breakers[model] = bs
+			}
+			v.Breakers = breakers
+		}
 		statsCopy.ByProvider[k] = v
 	}
 
 	return statsCopy
 }
+
+type runMetricsKey struct{}
+
+// WithRunMetrics returns a context in which LLM clients and guards also
+// record into the returned metrics. Runs that share the process-wide
+// metrics (e.g. concurrent jobs of cr serve) can so report their own calls.
+func WithRunMetrics(ctx context.Context) (context.Context, *DefaultMetrics) {
+	run := NewDefaultMetrics()
+	return context.WithValue(ctx, runMetricsKey{}, run), run
+}
+
+// RunMetricsFrom returns the metrics set by WithRunMetrics, if any.
+func RunMetricsFrom(ctx context.Context) (*DefaultMetrics, bool) {
+	run, ok := ctx.Value(runMetricsKey{}).(*DefaultMetrics)
+	return run, ok
+}
+
+// ScopedMetrics returns the metrics a call made under ctx records into:
+// m, the run metrics of ctx, or both. It returns nil when there are none.
+func ScopedMetrics(ctx context.Context, m Metrics) Metrics {
+	run, ok := RunMetricsFrom(ctx)
+	switch {
+	case !ok:
+		return m
+	case m == nil:
+		return run
+	default:
+		return teeMetrics{primary: m, run: run}
+	}
+}
+
+// teeMetrics records into the process-wide and the run metrics. GetStats
+// reports the process-wide ones.
+type teeMetrics struct {
+	primary Metrics
+	run     Metrics
+}
+
+func (t teeMetrics) RecordRequest(provider, model string) {
+	t.primary.RecordRequest(provider, model)
+	t.run.RecordRequest(provider, model)
+}
+
+func (t teeMetrics) RecordDuration(provider, model string, duration time.Duration) {
+	t.primary.RecordDuration(provider, model, duration)
+	t.run.RecordDuration(provider, model, duration)
+}
+
+func (t teeMetrics) RecordTokens(provider, model string, tokensIn, tokensOut int) {
+	t.primary.RecordTokens(provider, model, tokensIn, tokensOut)
+	t.run.RecordTokens(provider, model, tokensIn, tokensOut)
+}
+
+func (t teeMetrics) RecordCost(provider, model string, cost float64) {
+	t.primary.RecordCost(provider, model, cost)
+	t.run.RecordCost(provider, model, cost)
+}
+
+func (t teeMetrics) RecordError(provider, model string, errType ErrorType) {
+	t.primary.RecordError(provider, model, errType)
+	t.run.RecordError(provider, model, errType)
+}
+
+func (t teeMetrics) RecordThrottle(provider string, wait time.Duration) {
+	t.primary.RecordThrottle(provider, wait)
+	t.run.RecordThrottle(provider, wait)
+}
+
+func (t teeMetrics) RecordBreakerState(provider, model string, state BreakerState) {
+	t.primary.RecordBreakerState(provider, model, state)
+	t.run.RecordBreakerState(provider, model, state)
+}
+
+func (t teeMetrics) GetStats() Stats {
+	return t.primary.GetStats()
+}
@@ -1,6 +1,7 @@
 package http_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -167,3 +168,28 @@ func TestDefaultMetrics_GetStats_ThreadSafe(t *testing.T) {
 	stats1.TotalRequests = 999
 	assert.NotEqual(t, stats1.TotalRequests, stats2.TotalRequests)
 }
+
+func TestScopedMetrics(t *testing.T) {
+	shared := http.NewDefaultMetrics()
+
+	// Outside a run only the shared metrics record
+	assert.Same(t, shared, http.ScopedMetrics(context.Background(), shared))
+	assert.Nil(t, http.ScopedMetrics(context.Background(), nil))
+
+	ctxA, runA := http.WithRunMetrics(context.Background())
+	ctxB, runB := http.WithRunMetrics(context.Background())
+	http.ScopedMetrics(ctxA, shared).RecordRequest("openai", "gpt-4o")
+	http.ScopedMetrics(ctxA, shared).RecordCost("openai", "gpt-4o", 0.5)
+	http.ScopedMetrics(ctxB, shared).RecordRequest("openai", "gpt-4o")
+	http.ScopedMetrics(ctxB, nil).RecordRequest("gemini", "gemini-2.5-pro")
+
+	assert.Equal(t, 2, shared.GetStats().TotalRequests)
+	assert.Equal(t, 1, runA.GetStats().TotalRequests)
+	assert.Equal(t, 0.5, runA.GetStats().TotalCost)
+	assert.Equal(t, 2, runB.GetStats().TotalRequests)
+	assert.Equal(t, 0.0, runB.GetStats().TotalCost)
+
+	found, ok := http.RunMetricsFrom(ctxA)
+	assert.True(t, ok)
+	assert.Same(t, runA, found)
+}
@@ -0,0 +1,127 @@
+package http
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a client-side token bucket limiting requests and tokens
+// per minute. It is shared by every client of a provider so concurrent
+// reviews, verification and synthesis calls stay under the vendor limits.
+type RateLimiter struct {
+	mu sync.Mutex
+
+	requests *bucket
+	tokens   *bucket
+
+	// pausedUntil holds every caller back after a Retry-After response.
+	pausedUntil time.Time
+
+	now func() time.Time
+}
+
+// NewRateLimiter creates a rate limiter. A limit of zero or less disables
+// that bucket; Pause still applies.
+func NewRateLimiter(requestsPerMinute, tokensPerMinute int) *RateLimiter {
+	l := &RateLimiter{now: time.Now}
+	start := l.now()
+	if requestsPerMinute > 0 {
+		l.requests = newBucket(float64(requestsPerMinute), start)
+	}
+	if tokensPerMinute > 0 {
+		l.tokens = newBucket(float64(tokensPerMinute), start)
+	}
+	return l
+}
+
+// Wait blocks until a request using the given number of tokens may be
+// sent, then takes it from the buckets. Requests larger than the token
+// bucket wait for a full bucket rather than forever. It returns the time
+// spent waiting, or the context error if the context ends first.
+func (l *RateLimiter) Wait(ctx context.Context, tokens int) (time.Duration, error) {
+	var waited time.Duration
+	for {
+		delay := l.reserve(float64(tokens))
+		if delay <= 0 {
+			return waited, nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+			waited += delay
+		case <-ctx.Done():
+			timer.Stop()
+			return waited, ctx.Err()
+		}
+	}
+}
+
+// Pause holds back every caller for d, e.g. after a 429 response with a
+// Retry-After header. A shorter pause never cuts a longer one short.
+func (l *RateLimiter) Pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := l.now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// reserve takes a request and tokens from the buckets when both have
+// enough, returning zero. Otherwise nothing is taken and it returns how
+// long to wait before trying again.
+func (l *RateLimiter) reserve(tokens float64) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+
+	var delay time.Duration
+	if l.requests != nil {
+		delay = max(delay, l.requests.delay(1, now))
+	}
+	if l.tokens != nil {
+		delay = max(delay, l.tokens.delay(min(tokens, l.tokens.capacity), now))
+	}
+	if delay > 0 {
+		return delay
+	}
+
+	if l.requests != nil {
+		l.requests.available--
+	}
+	if l.tokens != nil {
+		l.tokens.available -= min(tokens, l.tokens.capacity)
+	}
+	return 0
+}
+
+// bucket is a token bucket refilled continuously at capacity per minute.
+type bucket struct {
+	capacity  float64
+	available float64
+	updated   time.Time
+}
+
+func newBucket(perMinute float64, now time.Time) *bucket {
+	return &bucket{capacity: perMinute, available: perMinute, updated: now}
+}
+
+// delay refills the bucket and returns how long until it holds n.
+func (b *bucket) delay(n float64, now time.Time) time.Duration {
+	elapsed := now.Sub(b.updated)
+	if elapsed > 0 {
+		b.available = min(b.capacity, b.available+elapsed.Minutes()*b.capacity)
+		b.updated = now
+	}
+	if b.available >= n {
+		return 0
+	}
+	missing := n - b.available
+	return time.Duration(missing / b.capacity * float64(time.Minute))
+}
@@ -0,0 +1,61 @@
+package http_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_AllowsBurstUpToLimit(t *testing.T) {
+	limiter := llmhttp.NewRateLimiter(3, 0)
+
+	for i := 0; i < 3; i++ {
+		waited, err := limiter.Wait(context.Background(), 0)
+		require.NoError(t, err)
+		assert.Zero(t, waited)
+	}
+
+	// The bucket is empty; the next request needs 20s of refill
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := limiter.Wait(ctx, 0)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRateLimiter_LimitsTokens(t *testing.T) {
+	limiter := llmhttp.NewRateLimiter(0, 1000)
+
+	waited, err := limiter.Wait(context.Background(), 800)
+	require.NoError(t, err)
+	assert.Zero(t, waited)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = limiter.Wait(ctx, 800)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRateLimiter_OversizedRequestWaitsForFullBucket(t *testing.T) {
+	limiter := llmhttp.NewRateLimiter(0, 1000)
+
+	// Larger than the bucket, but must not block forever
+	waited, err := limiter.Wait(context.Background(), 5000)
+	require.NoError(t, err)
+	assert.Zero(t, waited)
+}
+
+func TestRateLimiter_Pause(t *testing.T) {
+	limiter := llmhttp.NewRateLimiter(0, 0)
+	limiter.Pause(50 * time.Millisecond)
+	limiter.Pause(time.Millisecond) // A shorter pause does not cut it short
+
+	start := time.Now()
+	waited, err := limiter.Wait(context.Background(), 0)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+	assert.Greater(t, waited, time.Duration(0))
+}
@@ -5,7 +5,11 @@ import (
 	"errors"
 	"math"
 	"math/rand"
+	"sync/atomic"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // RetryConfig holds configuration for retry logic.
@@ -74,7 +78,33 @@ func ShouldRetry(err error) bool {
 // Operation is a function that can be retried.
 type Operation func(ctx context.Context) error
 
+type retryCounterKey struct{}
+
+// WithRetryCounter returns a context in which RetryWithBackoff adds each
+// retry to the returned counter. A counter set further down the context
+// chain replaces this one, so each counter sees only its own scope.
+func WithRetryCounter(ctx context.Context) (context.Context, *atomic.Int64) {
+	counter := new(atomic.Int64)
+	return context.WithValue(ctx, retryCounterKey{}, counter), counter
+}
+
+// recordRetry counts a retry in the context's counter and adds a "retry"
+// event to the current trace span, if any.
+func recordRetry(ctx context.Context, attempt int, err error, backoff time.Duration) {
+	if counter, ok := ctx.Value(retryCounterKey{}).(*atomic.Int64); ok {
+		counter.Add(1)
+	}
+	trace.SpanFromContext(ctx).AddEvent("retry", trace.WithAttributes(
+		attribute.Int("attempt", attempt+1),
+		attribute.String("error", err.Error()),
+		attribute.String("backoff", backoff.String()),
+	))
+}
+
 // RetryWithBackoff executes an operation with exponential backoff retry logic.
+// A Retry-After given by the server overrides a shorter backoff; one longer
+// than MaxBackoff is not waited out and the error is returned immediately.
+// Each retry is recorded with recordRetry.
 func RetryWithBackoff(ctx context.Context, operation Operation, config RetryConfig) error {
 	var lastErr error
 
@@ -102,8 +132,16 @@ func RetryWithBackoff(ctx context.Context, operation Operation, config RetryConf
 			return err // Return the last error
 		}
 
-		// Calculate backoff and wait
+		// Calculate backoff, waiting at least as long as the server asked
 		backoff := ExponentialBackoff(attempt, config)
+		var httpErr *Error
+		if errors.As(err, &httpErr) && httpErr.RetryAfter > backoff {
+			if httpErr.RetryAfter > config.MaxBackoff {
+				return err // Waiting that long would stall the review
+			}
+			backoff = httpErr.RetryAfter
+		}
+		recordRetry(ctx, attempt, err, backoff)
 
 		// Wait with context cancellation support
 		select {
@@ -161,6 +161,61 @@ func TestRetryWithBackoff_RetryableError(t *testing.T) {
 	assert.GreaterOrEqual(t, duration, 10*time.Millisecond, "should have backoff delays")
 }
 
+func TestRetryWithBackoff_FailsFastOnLongRetryAfter(t *testing.T) {
+	attempts := 0
+	operation := func(ctx context.Context) error {
+		attempts++
+		err := llmhttp.NewRateLimitError("test", "quota exhausted")
+		err.RetryAfter = time.Hour
+		return err
+	}
+
+	config := llmhttp.RetryConfig{
+		MaxRetries:     5,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     100 * time.Millisecond,
+		Multiplier:     2.0,
+	}
+
+	start := time.Now()
+	err := llmhttp.RetryWithBackoff(context.Background(), operation, config)
+
+	var httpErr *llmhttp.Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, llmhttp.ErrTypeRateLimit, httpErr.Type)
+	assert.Equal(t, 1, attempts, "a Retry-After beyond MaxBackoff is not waited out")
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRetryWithBackoff_CountsRetries(t *testing.T) {
+	attempts := 0
+	operation := func(ctx context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return llmhttp.NewRateLimitError("test", "rate limited")
+		}
+		return nil
+	}
+
+	config := llmhttp.RetryConfig{
+		MaxRetries:     5,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		Multiplier:     2.0,
+	}
+
+	ctx, counter := llmhttp.WithRetryCounter(context.Background())
+	require.NoError(t, llmhttp.RetryWithBackoff(ctx, operation, config))
+	assert.Equal(t, int64(2), counter.Load())
+
+	// A nested counter takes the retries of its own scope
+	innerCtx, inner := llmhttp.WithRetryCounter(ctx)
+	attempts = 0
+	require.NoError(t, llmhttp.RetryWithBackoff(innerCtx, operation, config))
+	assert.Equal(t, int64(2), inner.Load())
+	assert.Equal(t, int64(2), counter.Load())
+}
+
 func TestRetryWithBackoff_NonRetryableError(t *testing.T) {
 	attempts := 0
 	operation := func(ctx context.Context) error {
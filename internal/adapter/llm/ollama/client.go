@@ -33,6 +33,9 @@ type HTTPClient struct {
 	logger  llmhttp.Logger
 	metrics llmhttp.Metrics
 	pricing llmhttp.Pricing
+
+	// Client-side rate limiting and circuit breaking, shared per provider
+	guard *llmhttp.Guard
 }
 
 // NewHTTPClient creates a new Ollama HTTP client.
@@ -65,6 +68,11 @@ func (c *HTTPClient) SetMetrics(metrics llmhttp.Metrics) {
 	c.metrics = metrics
 }
 
+// SetGuard sets the rate limiter and circuit breaker for this client.
+func (c *HTTPClient) SetGuard(guard *llmhttp.Guard) {
+	c.guard = guard
+}
+
 // SetPricing sets the pricing calculator for this client.
 func (c *HTTPClient) SetPricing(pricing llmhttp.Pricing) {
 	c.pricing = pricing
@@ -101,8 +109,8 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 	}
 
 	// Record request metric
-	if c.metrics != nil {
-		c.metrics.RecordRequest("ollama", c.model)
+	if metrics := llmhttp.ScopedMetrics(ctx, c.metrics); metrics != nil {
+		metrics.RecordRequest("ollama", c.model)
 	}
 
 	// Build request
@@ -142,7 +150,7 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 	// Execute request with retry logic (using configured retry settings)
 	var resp *http.Response
 
-	err = llmhttp.RetryWithBackoff(ctx, func(ctx context.Context) error {
+	operation := func(ctx context.Context) error {
 		// Recreate request for each retry
 		retryReq, reqErr := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 		if reqErr != nil {
@@ -180,11 +188,16 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 		if resp.StatusCode >= 400 {
 			bodyBytes, _ := io.ReadAll(resp.Body)
 			resp.Body.Close()
-			return c.handleErrorResponse(resp.StatusCode, bodyBytes)
+			return llmhttp.WithRetryAfter(c.handleErrorResponse(resp.StatusCode, bodyBytes), resp.Header.Get("Retry-After"))
 		}
 
 		return nil
-	}, c.retryConf)
+	}
+
+	if c.guard != nil {
+		operation = c.guard.Wrap(operation, c.model, len(prompt))
+	}
+	err = llmhttp.RetryWithBackoff(ctx, operation, c.retryConf)
 
 	duration := time.Since(startTime)
 
@@ -206,10 +219,10 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 			}
 		}
 		// Record error metric
-		if c.metrics != nil {
+		if metrics := llmhttp.ScopedMetrics(ctx, c.metrics); metrics != nil {
 			var httpErr *llmhttp.Error
 			if errors.As(err, &httpErr) {
-				c.metrics.RecordError("ollama", c.model, httpErr.Type)
+				metrics.RecordError("ollama", c.model, httpErr.Type)
 			}
 		}
 		return nil, err
@@ -260,10 +273,10 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 	}
 
 	// Record metrics
-	if c.metrics != nil {
-		c.metrics.RecordDuration("ollama", c.model, duration)
-		c.metrics.RecordTokens("ollama", c.model, response.TokensIn, response.TokensOut)
-		c.metrics.RecordCost("ollama", c.model, 0.0)
+	if metrics := llmhttp.ScopedMetrics(ctx, c.metrics); metrics != nil {
+		metrics.RecordDuration("ollama", c.model, duration)
+		metrics.RecordTokens("ollama", c.model, response.TokensIn, response.TokensOut)
+		metrics.RecordCost("ollama", c.model, 0.0)
 	}
 
 	return response, nil
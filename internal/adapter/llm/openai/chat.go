@@ -0,0 +1,83 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/llm"
+)
+
+// Chat sends a multi-turn conversation with native function declarations to
+// the Chat Completion API. Tool calls are returned from the assistant message's
+// tool_calls and their results are sent back as "tool" role messages.
+func (c *HTTPClient) Chat(ctx context.Context, messages []llm.ChatMessage, tools []llm.ToolDefinition, options CallOptions) (*llm.ChatResponse, error) {
+	reqBody := ChatCompletionRequest{
+		Model:    c.model,
+		Messages: toMessages(options.System, messages),
+	}
+	c.applyOptions(&reqBody, options)
+	for _, t := range tools {
+		reqBody.Tools = append(reqBody.Tools, Tool{
+			Type: "function",
+			Function: FunctionDefinition{
+				Name:        t.Name,
+				Description: t.Description,
+				Parameters:  llm.ToolInputSchema(),
+			},
+		})
+	}
+
+	chatResp, duration, err := c.send(ctx, reqBody, llm.ContentLength(messages))
+	if err != nil {
+		return nil, err
+	}
+
+	choice := chatResp.Choices[0]
+	response := &llm.ChatResponse{
+		Text:      choice.Message.Content,
+		TokensIn:  chatResp.Usage.PromptTokens,
+		TokensOut: chatResp.Usage.CompletionTokens,
+	}
+	for _, call := range choice.Message.ToolCalls {
+		var args map[string]interface{}
+		// Malformed arguments yield an empty input; the tool reports the error
+		_ = json.Unmarshal([]byte(call.Function.Arguments), &args)
+		response.ToolCalls = append(response.ToolCalls, llm.ToolCall{
+			ID:    call.ID,
+			Name:  call.Function.Name,
+			Input: llm.ToolInput(args),
+		})
+	}
+	response.Cost = c.record(ctx, duration, response.TokensIn, response.TokensOut, choice.FinishReason)
+
+	return response, nil
+}
+
+// toMessages converts the neutral history to Chat Completion messages,
+// prepending the system prompt.
+func toMessages(system string, messages []llm.ChatMessage) []Message {
+	if system == "" {
+		system = defaultSystemPrompt
+	}
+	out := []Message{{Role: "system", Content: system}}
+	for _, m := range messages {
+		switch m.Role {
+		case llm.ChatRoleAssistant:
+			msg := Message{Role: "assistant", Content: m.Content}
+			for _, call := range m.ToolCalls {
+				args, _ := json.Marshal(llm.ToolArguments(call.Input))
+				msg.ToolCalls = append(msg.ToolCalls, ToolCall{
+					ID:       call.ID,
+					Type:     "function",
+					Function: FunctionCall{Name: call.Name, Arguments: string(args)},
+				})
+			}
+			out = append(out, msg)
+		case llm.ChatRoleTool:
+			out = append(out, Message{Role: "tool", Content: m.Content, ToolCallID: m.ToolCallID})
+		default:
+			out = append(out, Message{Role: "user", Content: m.Content})
+		}
+	}
+	return out
+}
@@ -0,0 +1,77 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/llm"
+	"github.com/bkyoung/code-reviewer/internal/adapter/llm/openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPClient_Chat_SendsHistoryAndParsesToolCalls(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+
+		var req openai.ChatCompletionRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		require.Len(t, req.Tools, 1)
+		assert.Equal(t, "function", req.Tools[0].Type)
+		assert.Equal(t, "read_file", req.Tools[0].Function.Name)
+
+		// system, user, assistant with tool_calls, tool result
+		require.Len(t, req.Messages, 4)
+		assert.Equal(t, "system", req.Messages[0].Role)
+		assert.Equal(t, "verify things", req.Messages[0].Content)
+		assert.Equal(t, "user", req.Messages[1].Role)
+		assert.Equal(t, "assistant", req.Messages[2].Role)
+		require.Len(t, req.Messages[2].ToolCalls, 1)
+		assert.Equal(t, "call_1", req.Messages[2].ToolCalls[0].ID)
+		assert.JSONEq(t, `{"input":"main.go"}`, req.Messages[2].ToolCalls[0].Function.Arguments)
+		assert.Equal(t, "tool", req.Messages[3].Role)
+		assert.Equal(t, "call_1", req.Messages[3].ToolCallID)
+		assert.Equal(t, "package main", req.Messages[3].Content)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openai.ChatCompletionResponse{
+			Model: "gpt-4o-mini",
+			Choices: []openai.Choice{{
+				Message: openai.Message{
+					Role: "assistant",
+					ToolCalls: []openai.ToolCall{{
+						ID:       "call_2",
+						Type:     "function",
+						Function: openai.FunctionCall{Name: "grep", Arguments: `{"input":"func main"}`},
+					}},
+				},
+				FinishReason: "tool_calls",
+			}},
+			Usage: openai.Usage{PromptTokens: 100, CompletionTokens: 20},
+		})
+	}))
+	defer server.Close()
+
+	client := openai.NewHTTPClient("test-api-key", "gpt-4o-mini", testProviderConfig(), testHTTPConfig())
+	client.SetBaseURL(server.URL)
+
+	messages := []llm.ChatMessage{
+		{Role: llm.ChatRoleUser, Content: "candidate"},
+		{Role: llm.ChatRoleAssistant, ToolCalls: []llm.ToolCall{{ID: "call_1", Name: "read_file", Input: "main.go"}}},
+		{Role: llm.ChatRoleTool, ToolCallID: "call_1", ToolName: "read_file", Content: "package main"},
+	}
+	tools := []llm.ToolDefinition{{Name: "read_file", Description: "Read a file"}}
+
+	resp, err := client.Chat(context.Background(), messages, tools, openai.CallOptions{System: "verify things"})
+	require.NoError(t, err)
+
+	assert.Empty(t, resp.Text)
+	require.Len(t, resp.ToolCalls, 1)
+	assert.Equal(t, llm.ToolCall{ID: "call_2", Name: "grep", Input: "func main"}, resp.ToolCalls[0])
+	assert.Equal(t, 100, resp.TokensIn)
+	assert.Equal(t, 20, resp.TokensOut)
+}
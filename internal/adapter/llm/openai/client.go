@@ -67,6 +67,9 @@ type HTTPClient struct {
 	logger  llmhttp.Logger
 	metrics llmhttp.Metrics
 	pricing llmhttp.Pricing
+
+	// Client-side rate limiting and circuit breaking, shared per provider
+	guard *llmhttp.Guard
 }
 
 // NewHTTPClient creates a new OpenAI HTTP client.
@@ -105,6 +108,11 @@ func (c *HTTPClient) SetMetrics(metrics llmhttp.Metrics) {
 	c.metrics = metrics
 }
 
+// SetGuard sets the rate limiter and circuit breaker for this client.
+func (c *HTTPClient) SetGuard(guard *llmhttp.Guard) {
+	c.guard = guard
+}
+
 // SetPricing sets the pricing calculator for this client.
 func (c *HTTPClient) SetPricing(pricing llmhttp.Pricing) {
 	c.pricing = pricing
@@ -130,24 +138,6 @@ type APIResponse struct {
 
 // Call makes a request to the OpenAI Chat Completion API.
 func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOptions) (*APIResponse, error) {
-	startTime := time.Now()
-
-	// Log request (if logger configured)
-	if c.logger != nil {
-		c.logger.LogRequest(ctx, llmhttp.RequestLog{
-			Provider:    "openai",
-			Model:       c.model,
-			Timestamp:   startTime,
-			PromptChars: len(prompt),
-			APIKey:      c.apiKey,
-		})
-	}
-
-	// Record request metric
-	if c.metrics != nil {
-		c.metrics.RecordRequest("openai", c.model)
-	}
-
 	// Determine system prompt (use override if provided, otherwise use default)
 	systemPrompt := defaultSystemPrompt
 	if options.System != "" {
@@ -168,10 +158,28 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 			},
 		},
 	}
+	c.applyOptions(&reqBody, options)
+
+	chatResp, duration, err := c.send(ctx, reqBody, len(prompt))
+	if err != nil {
+		return nil, err
+	}
 
-	// o1-series models have different API requirements
-	isO1 := isO1Model(c.model)
+	// Extract response data
+	response := &APIResponse{
+		Text:         chatResp.Choices[0].Message.Content,
+		TokensIn:     chatResp.Usage.PromptTokens,
+		TokensOut:    chatResp.Usage.CompletionTokens,
+		Model:        chatResp.Model,
+		FinishReason: chatResp.Choices[0].FinishReason,
+	}
+	response.Cost = c.record(ctx, duration, response.TokensIn, response.TokensOut, response.FinishReason)
+
+	return response, nil
+}
 
+// applyOptions sets token limits, temperature and seed as supported by the model.
+func (c *HTTPClient) applyOptions(reqBody *ChatCompletionRequest, options CallOptions) {
 	// Set token limits - newer models (o1+, gpt-5+) use max_completion_tokens
 	if options.MaxTokens > 0 {
 		if usesMaxCompletionTokens(c.model) {
@@ -182,20 +190,43 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 	}
 
 	// o1 models don't support temperature, seed, or response_format
-	if !isO1 {
+	if !isO1Model(c.model) {
 		reqBody.Temperature = options.Temperature
 		reqBody.Seed = options.Seed
 	}
+}
+
+// send posts a chat completion request with retry and returns the parsed
+// response, which is guaranteed to contain at least one choice. The request
+// and any failure are logged and recorded.
+func (c *HTTPClient) send(ctx context.Context, reqBody ChatCompletionRequest, promptChars int) (*ChatCompletionResponse, time.Duration, error) {
+	startTime := time.Now()
+
+	// Log request (if logger configured)
+	if c.logger != nil {
+		c.logger.LogRequest(ctx, llmhttp.RequestLog{
+			Provider:    "openai",
+			Model:       c.model,
+			Timestamp:   startTime,
+			PromptChars: promptChars,
+			APIKey:      c.apiKey,
+		})
+	}
+
+	// Record request metric
+	if metrics := llmhttp.ScopedMetrics(ctx, c.metrics); metrics != nil {
+		metrics.RecordRequest("openai", c.model)
+	}
 
 	// Marshal request
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return nil, 0, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	// Execute request with retry logic
 	url := c.baseURL + "/v1/chat/completions"
-	var response *APIResponse
+	var chatResp ChatCompletionResponse
 	operation := func(ctx context.Context) error {
 		// Recreate request for each retry with fresh body
 		req, reqErr := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
@@ -225,11 +256,10 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 
 		// Check for errors
 		if resp.StatusCode != http.StatusOK {
-			return c.handleErrorResponse(resp.StatusCode, body)
+			return llmhttp.WithRetryAfter(c.handleErrorResponse(resp.StatusCode, body), resp.Header.Get("Retry-After"))
 		}
 
 		// Parse success response
-		var chatResp ChatCompletionResponse
 		if err := json.Unmarshal(body, &chatResp); err != nil {
 			return fmt.Errorf("failed to parse response: %w", err)
 		}
@@ -239,18 +269,13 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 			return fmt.Errorf("no choices in response")
 		}
 
-		// Extract response data
-		response = &APIResponse{
-			Text:         chatResp.Choices[0].Message.Content,
-			TokensIn:     chatResp.Usage.PromptTokens,
-			TokensOut:    chatResp.Usage.CompletionTokens,
-			Model:        chatResp.Model,
-			FinishReason: chatResp.Choices[0].FinishReason,
-		}
-
 		return nil
 	}
 
+	if c.guard != nil {
+		operation = c.guard.Wrap(operation, c.model, promptChars)
+	}
+
 	// Execute with retry (using configured retry settings)
 	err = llmhttp.RetryWithBackoff(ctx, operation, c.retryConf)
 	duration := time.Since(startTime)
@@ -273,20 +298,25 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 			}
 		}
 		// Record error metric
-		if c.metrics != nil {
+		if metrics := llmhttp.ScopedMetrics(ctx, c.metrics); metrics != nil {
 			var httpErr *llmhttp.Error
 			if errors.As(err, &httpErr) {
-				c.metrics.RecordError("openai", c.model, httpErr.Type)
+				metrics.RecordError("openai", c.model, httpErr.Type)
 			}
 		}
-		return nil, err
+		return nil, duration, err
 	}
 
+	return &chatResp, duration, nil
+}
+
+// record calculates the cost of a successful call, logs the response and
+// records metrics. It returns the cost in USD.
+func (c *HTTPClient) record(ctx context.Context, duration time.Duration, tokensIn, tokensOut int, finishReason string) float64 {
 	// Calculate cost
 	var cost float64
 	if c.pricing != nil {
-		cost = c.pricing.GetCost("openai", c.model, response.TokensIn, response.TokensOut)
-		response.Cost = cost
+		cost = c.pricing.GetCost("openai", c.model, tokensIn, tokensOut)
 	}
 
 	// Log response
@@ -296,22 +326,22 @@ func (c *HTTPClient) Call(ctx context.Context, prompt string, options CallOption
 			Model:        c.model,
 			Timestamp:    time.Now(),
 			Duration:     duration,
-			TokensIn:     response.TokensIn,
-			TokensOut:    response.TokensOut,
+			TokensIn:     tokensIn,
+			TokensOut:    tokensOut,
 			Cost:         cost,
 			StatusCode:   200,
-			FinishReason: response.FinishReason,
+			FinishReason: finishReason,
 		})
 	}
 
 	// Record metrics
-	if c.metrics != nil {
-		c.metrics.RecordDuration("openai", c.model, duration)
-		c.metrics.RecordTokens("openai", c.model, response.TokensIn, response.TokensOut)
-		c.metrics.RecordCost("openai", c.model, cost)
+	if metrics := llmhttp.ScopedMetrics(ctx, c.metrics); metrics != nil {
+		metrics.RecordDuration("openai", c.model, duration)
+		metrics.RecordTokens("openai", c.model, tokensIn, tokensOut)
+		metrics.RecordCost("openai", c.model, cost)
 	}
 
-	return response, nil
+	return cost
 }
 
 // handleErrorResponse converts HTTP error responses to typed errors.
@@ -229,6 +229,32 @@ func TestHTTPClient_Call_ServiceUnavailable(t *testing.T) {
 	assert.Equal(t, 2, attempts)
 }
 
+func TestHTTPClient_Call_CircuitBreakerFailsFast(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	httpCfg := testHTTPConfig()
+	httpCfg.InitialBackoff = "1ms"
+	httpCfg.MaxBackoff = "1ms"
+	httpCfg.CircuitBreaker = config.CircuitBreakerConfig{FailureThreshold: 2, Cooldown: "1m"}
+
+	client := openai.NewHTTPClient("test-key", "gpt-4o-mini", testProviderConfig(), httpCfg)
+	client.SetBaseURL(server.URL)
+	client.SetGuard(llmhttp.NewGuards(nil).For("openai", testProviderConfig(), httpCfg))
+
+	_, err := client.Call(context.Background(), "test", openai.CallOptions{})
+
+	var httpErr *llmhttp.Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, llmhttp.ErrTypeCircuitOpen, httpErr.Type)
+	assert.Equal(t, 2, attempts, "the open breaker should stop further retries")
+}
+
 func TestHTTPClient_Call_InvalidRequest(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
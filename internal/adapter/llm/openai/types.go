@@ -9,12 +9,41 @@ type ChatCompletionRequest struct {
 	MaxTokens           int             `json:"max_tokens,omitempty"`
 	MaxCompletionTokens int             `json:"max_completion_tokens,omitempty"` // For o1 models
 	ResponseFormat      *ResponseFormat `json:"response_format,omitempty"`
+	Tools               []Tool          `json:"tools,omitempty"`
 }
 
 // Message represents a chat message in the conversation.
 type Message struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role       string     `json:"role"`
+	Content    string     `json:"content"`
+	ToolCalls  []ToolCall `json:"tool_calls,omitempty"`   // Assistant tool calls
+	ToolCallID string     `json:"tool_call_id,omitempty"` // For role "tool": the call being answered
+}
+
+// Tool declares a function the model may call.
+type Tool struct {
+	Type     string             `json:"type"` // "function"
+	Function FunctionDefinition `json:"function"`
+}
+
+// FunctionDefinition describes a callable function and its JSON schema parameters.
+type FunctionDefinition struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Parameters  map[string]interface{} `json:"parameters"`
+}
+
+// ToolCall is a function call requested by the model.
+type ToolCall struct {
+	ID       string       `json:"id"`
+	Type     string       `json:"type"` // "function"
+	Function FunctionCall `json:"function"`
+}
+
+// FunctionCall holds the function name and its JSON-encoded arguments.
+type FunctionCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
 }
 
 // ResponseFormat specifies the format of the model's output.
@@ -0,0 +1,210 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+	"github.com/bkyoung/code-reviewer/internal/usecase/review"
+)
+
+// instrumentationName identifies this tool's spans to OpenTelemetry.
+const instrumentationName = "github.com/bkyoung/code-reviewer"
+
+// Trace exporters supported by NewTracing.
+const (
+	ExporterOTLP = "otlp" // OTLP over HTTP to a collector
+	ExporterFile = "file" // One JSON span per line in a local file
+)
+
+// TracingConfig configures the OpenTelemetry trace exporter.
+type TracingConfig struct {
+	Exporter    string // ExporterOTLP or ExporterFile
+	Endpoint    string // OTLP/HTTP endpoint URL; empty uses the OTEL_EXPORTER_OTLP_* env vars
+	File        string // Output file for ExporterFile
+	ServiceName string
+	Version     string
+}
+
+// Tracing owns the OpenTelemetry tracer provider for a run.
+type Tracing struct {
+	provider *sdktrace.TracerProvider
+	file     *os.File
+}
+
+// NewTracing creates a tracer provider with the configured exporter and
+// installs it as the global OpenTelemetry provider, so Tracer, StartSpan and
+// spans from third-party instrumentation all export through it.
+// Call Shutdown before exiting to flush buffered spans.
+func NewTracing(ctx context.Context, cfg TracingConfig) (*Tracing, error) {
+	t := &Tracing{}
+
+	var exporter sdktrace.SpanExporter
+	switch cfg.Exporter {
+	case ExporterOTLP, "":
+		var opts []otlptracehttp.Option
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+		}
+		otlp, err := otlptracehttp.New(ctx, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("create OTLP exporter: %w", err)
+		}
+		exporter = otlp
+	case ExporterFile:
+		if cfg.File == "" {
+			return nil, errors.New("trace file is required for the file exporter")
+		}
+		if dir := filepath.Dir(cfg.File); dir != "." {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return nil, fmt.Errorf("create trace directory: %w", err)
+			}
+		}
+		file, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("open trace file: %w", err)
+		}
+		stdout, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("create file exporter: %w", err)
+		}
+		t.file = file
+		exporter = stdout
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q (must be %s or %s)", cfg.Exporter, ExporterOTLP, ExporterFile)
+	}
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = "code-reviewer"
+	}
+	serviceAttrs := []attribute.KeyValue{attribute.String("service.name", serviceName)}
+	if cfg.Version != "" {
+		serviceAttrs = append(serviceAttrs, attribute.String("service.version", cfg.Version))
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(serviceAttrs...))
+	if err != nil {
+		res = resource.NewSchemaless(serviceAttrs...)
+	}
+
+	t.provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(t.provider)
+	return t, nil
+}
+
+// Shutdown flushes pending spans and stops the exporter.
+func (t *Tracing) Shutdown(ctx context.Context) error {
+	err := t.provider.Shutdown(ctx)
+	if t.file != nil {
+		if closeErr := t.file.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// Tracer adapts the global OpenTelemetry tracer provider to review.Tracer.
+// Without NewTracing the global provider is a no-op, so spans cost nothing.
+type Tracer struct{}
+
+// NewTracer creates a review tracer backed by the global tracer provider.
+func NewTracer() review.Tracer {
+	return Tracer{}
+}
+
+// StartSpan starts a span as a child of the span in ctx.
+func (Tracer) StartSpan(ctx context.Context, name string, attributes map[string]interface{}) (context.Context, review.Span) {
+	return StartSpan(ctx, name, attributes)
+}
+
+// StartSpan starts a span as a child of the span in ctx. Adapters use it to
+// trace their own work (e.g. verifying one finding). Retries made by
+// llmhttp.RetryWithBackoff under the span are added to it as retryCount.
+func StartSpan(ctx context.Context, name string, attributes map[string]interface{}) (context.Context, review.Span) {
+	ctx, otelSpan := otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(toAttributes(attributes)...))
+	ctx, retries := llmhttp.WithRetryCounter(ctx)
+	return ctx, &span{span: otelSpan, retries: retries}
+}
+
+// span implements review.Span over an OpenTelemetry span.
+type span struct {
+	span    trace.Span
+	retries *atomic.Int64
+}
+
+func (s *span) SetAttributes(attributes map[string]interface{}) {
+	s.span.SetAttributes(toAttributes(attributes)...)
+}
+
+func (s *span) RecordError(err error) {
+	if err == nil {
+		return
+	}
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s *span) End() {
+	if n := s.retries.Load(); n > 0 {
+		s.span.SetAttributes(attribute.Int64("retryCount", n))
+	}
+	s.span.End()
+}
+
+// toAttributes converts structured fields to OpenTelemetry attributes,
+// sorted by key. Unsupported types are recorded with fmt.Sprint.
+func toAttributes(fields map[string]interface{}) []attribute.KeyValue {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]attribute.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		switch v := fields[k].(type) {
+		case string:
+			attrs = append(attrs, attribute.String(k, v))
+		case bool:
+			attrs = append(attrs, attribute.Bool(k, v))
+		case int:
+			attrs = append(attrs, attribute.Int(k, v))
+		case int64:
+			attrs = append(attrs, attribute.Int64(k, v))
+		case float64:
+			attrs = append(attrs, attribute.Float64(k, v))
+		case []string:
+			attrs = append(attrs, attribute.StringSlice(k, v))
+		case time.Duration:
+			attrs = append(attrs, attribute.String(k, v.String()))
+		case error:
+			attrs = append(attrs, attribute.String(k, v.Error()))
+		default:
+			attrs = append(attrs, attribute.String(k, fmt.Sprint(v)))
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,121 @@
+package observability_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+	"github.com/bkyoung/code-reviewer/internal/adapter/observability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exportedSpan is the subset of the stdouttrace JSON used by the tests.
+type exportedSpan struct {
+	Name        string
+	SpanContext struct{ TraceID, SpanID string }
+	Parent      struct{ SpanID string }
+	Attributes  []struct {
+		Key   string
+		Value struct{ Value interface{} }
+	}
+	Events []struct{ Name string }
+	Status struct{ Code string }
+}
+
+func (s exportedSpan) attribute(key string) interface{} {
+	for _, a := range s.Attributes {
+		if a.Key == key {
+			return a.Value.Value
+		}
+	}
+	return nil
+}
+
+func readSpans(t *testing.T, file string) map[string]exportedSpan {
+	t.Helper()
+	f, err := os.Open(file)
+	require.NoError(t, err)
+	defer f.Close()
+
+	spans := make(map[string]exportedSpan)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		var span exportedSpan
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &span), "each line should be one JSON span")
+		spans[span.Name] = span
+	}
+	require.NoError(t, scanner.Err())
+	return spans
+}
+
+func TestTracing_FileExporter(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "traces", "review.jsonl")
+	tracing, err := observability.NewTracing(context.Background(), observability.TracingConfig{
+		Exporter:    observability.ExporterFile,
+		File:        file,
+		ServiceName: "cr-test",
+	})
+	require.NoError(t, err)
+
+	tracer := observability.NewTracer()
+	ctx, root := tracer.StartSpan(context.Background(), "review", map[string]interface{}{
+		"baseRef":   "main",
+		"providers": 2,
+	})
+
+	providerCtx, provider := tracer.StartSpan(ctx, "review.provider", map[string]interface{}{"provider": "openai"})
+	attempts := 0
+	retryErr := llmhttp.RetryWithBackoff(providerCtx, func(ctx context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return llmhttp.NewRateLimitError("openai", "rate limited")
+		}
+		return nil
+	}, llmhttp.RetryConfig{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Multiplier: 1})
+	require.NoError(t, retryErr)
+	provider.SetAttributes(map[string]interface{}{"model": "gpt-4o", "tokensIn": 1200, "cost": 0.25})
+	provider.End()
+
+	_, verify := observability.StartSpan(ctx, "verify.finding", nil)
+	verify.RecordError(errors.New("agent failed"))
+	verify.End()
+
+	root.End()
+	require.NoError(t, tracing.Shutdown(context.Background()))
+
+	spans := readSpans(t, file)
+	require.Len(t, spans, 3)
+
+	rootSpan := spans["review"]
+	assert.Equal(t, "main", rootSpan.attribute("baseRef"))
+	assert.EqualValues(t, 2, rootSpan.attribute("providers"))
+
+	providerSpan := spans["review.provider"]
+	assert.Equal(t, rootSpan.SpanContext.TraceID, providerSpan.SpanContext.TraceID)
+	assert.Equal(t, rootSpan.SpanContext.SpanID, providerSpan.Parent.SpanID, "provider span should be a child of the review span")
+	assert.Equal(t, "gpt-4o", providerSpan.attribute("model"))
+	assert.EqualValues(t, 1200, providerSpan.attribute("tokensIn"))
+	assert.EqualValues(t, 0.25, providerSpan.attribute("cost"))
+	assert.EqualValues(t, 2, providerSpan.attribute("retryCount"))
+	require.Len(t, providerSpan.Events, 2)
+	assert.Equal(t, "retry", providerSpan.Events[0].Name)
+	assert.Nil(t, rootSpan.attribute("retryCount"), "retries belong to the innermost span")
+
+	assert.Equal(t, "Error", spans["verify.finding"].Status.Code)
+}
+
+func TestNewTracing_InvalidConfig(t *testing.T) {
+	_, err := observability.NewTracing(context.Background(), observability.TracingConfig{Exporter: "zipkin"})
+	assert.ErrorContains(t, err, "unknown trace exporter")
+
+	_, err = observability.NewTracing(context.Background(), observability.TracingConfig{Exporter: observability.ExporterFile})
+	assert.ErrorContains(t, err, "trace file is required")
+}
@@ -0,0 +1,3 @@
+// Package codeclimate provides a writer that outputs reviews in the Code
+// Climate issue format used by GitLab Code Quality reports.
+package codeclimate
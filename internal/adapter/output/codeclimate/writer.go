@@ -0,0 +1,194 @@
+package codeclimate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/review"
+)
+
+// Writer implements the review.ReportWriter interface.
+type Writer struct {
+	now func() string
+}
+
+// NewWriter creates a new Code Climate writer.
+func NewWriter(now func() string) *Writer {
+	return &Writer{now: now}
+}
+
+// Issue is a Code Climate issue. GitLab reads description, check_name,
+// fingerprint, severity and location; the rest follows the Code Climate
+// spec for other consumers.
+type Issue struct {
+	Type        string   `json:"type"`
+	CheckName   string   `json:"check_name"`
+	Description string   `json:"description"`
+	Content     *Content `json:"content,omitempty"`
+	Categories  []string `json:"categories"`
+	Location    Location `json:"location"`
+	Severity    string   `json:"severity"`
+	Fingerprint string   `json:"fingerprint"`
+}
+
+// Content holds the Markdown body of an issue.
+type Content struct {
+	Body string `json:"body"`
+}
+
+// Location is the file and lines of an issue.
+type Location struct {
+	Path  string `json:"path"`
+	Lines Lines  `json:"lines"`
+}
+
+// Lines is a range of lines, both inclusive.
+type Lines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end"`
+}
+
+// Write persists a review to disk as a Code Climate JSON file.
+func (w *Writer) Write(ctx context.Context, artifact review.ReportArtifact) (string, error) {
+	outputDir := filepath.Join(artifact.OutputDir, fmt.Sprintf("%s_%s", artifact.Repository, artifact.TargetRef), w.now())
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	filePath := filepath.Join(outputDir, fmt.Sprintf("review-%s.codeclimate.json", artifact.ProviderName))
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create codeclimate file: %w", err)
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(convertToIssues(artifact.Review)); err != nil {
+		return "", fmt.Errorf("failed to encode review to codeclimate: %w", err)
+	}
+
+	return filePath, nil
+}
+
+// convertToIssues converts a review's findings to Code Climate issues.
+// The result is never nil, so an empty review encodes as [].
+func convertToIssues(r domain.Review) []Issue {
+	issues := make([]Issue, 0, len(r.Findings))
+	fingerprints := fingerprints(r.Findings)
+	for i, finding := range r.Findings {
+		description := finding.Description
+		if description == "" {
+			description = "No description provided"
+		}
+
+		checkName := finding.Category
+		if checkName == "" {
+			checkName = "code-review"
+		}
+
+		// GitLab requires a path and a begin line; project-level findings
+		// are attached to the repository root
+		path := finding.File
+		if path == "" {
+			path = "."
+		}
+		begin := max(finding.LineStart, 1)
+
+		issue := Issue{
+			Type:        "issue",
+			CheckName:   checkName,
+			Description: description,
+			Categories:  []string{category(finding.Category)},
+			Location:    Location{Path: path, Lines: Lines{Begin: begin, End: max(finding.LineEnd, begin)}},
+			Severity:    severity(finding.Severity),
+			Fingerprint: fingerprints[i],
+		}
+		if finding.Suggestion != "" {
+			issue.Content = &Content{Body: finding.Suggestion}
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// fingerprints returns a unique fingerprint per finding. The domain
+// fingerprint leaves out line numbers so it survives unrelated code shifts,
+// which makes repeats of a finding in one file collide, and GitLab shows only
+// one issue per fingerprint. Repeats are therefore numbered in line order and
+// the number is mixed in; the first occurrence keeps the domain fingerprint.
+func fingerprints(findings []domain.Finding) []string {
+	groups := make(map[domain.FindingFingerprint][]int)
+	for i, f := range findings {
+		fp := domain.FingerprintFromFinding(f)
+		groups[fp] = append(groups[fp], i)
+	}
+
+	result := make([]string, len(findings))
+	for fp, indexes := range groups {
+		sort.SliceStable(indexes, func(a, b int) bool {
+			fa, fb := findings[indexes[a]], findings[indexes[b]]
+			if fa.LineStart != fb.LineStart {
+				return fa.LineStart < fb.LineStart
+			}
+			return fa.LineEnd < fb.LineEnd
+		})
+		for occurrence, i := range indexes {
+			if occurrence == 0 {
+				result[i] = string(fp)
+				continue
+			}
+			sum := sha256.Sum256([]byte(fmt.Sprintf("%s#%d", fp, occurrence)))
+			result[i] = hex.EncodeToString(sum[:16])
+		}
+	}
+	return result
+}
+
+// severity maps our severities to Code Climate's info, minor, major,
+// critical and blocker.
+func severity(s string) string {
+	switch strings.ToLower(s) {
+	case "critical":
+		return "blocker"
+	case "high":
+		return "critical"
+	case "medium":
+		return "major"
+	case "low":
+		return "minor"
+	default:
+		return "info"
+	}
+}
+
+// categories maps finding categories to Code Climate's fixed category list.
+var categories = map[string]string{
+	"security":        "Security",
+	"bug":             "Bug Risk",
+	"error_handling":  "Bug Risk",
+	"test_coverage":   "Bug Risk",
+	"performance":     "Performance",
+	"maintainability": "Clarity",
+	"architecture":    "Complexity",
+	"style":           "Style",
+	"duplication":     "Duplication",
+	"compatibility":   "Compatibility",
+}
+
+func category(c string) string {
+	if mapped, ok := categories[strings.ToLower(c)]; ok {
+		return mapped
+	}
+	return "Bug Risk"
+}
@@ -0,0 +1,90 @@
+package codeclimate_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/output/codeclimate"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/review"
+)
+
+func writeIssues(t *testing.T, findings []domain.Finding) (string, []byte) {
+	t.Helper()
+	writer := codeclimate.NewWriter(func() string { return "20251020T120000Z" })
+	path, err := writer.Write(context.Background(), review.ReportArtifact{
+		OutputDir:    t.TempDir(),
+		Repository:   "repo",
+		TargetRef:    "feature",
+		Review:       domain.Review{Findings: findings},
+		ProviderName: "merged",
+	})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return path, content
+}
+
+func TestWriter_Write_ConvertsFindingsToIssues(t *testing.T) {
+	finding := domain.Finding{File: "db.go", LineStart: 10, LineEnd: 12, Severity: "critical", Category: "security", Description: "SQL injection", Suggestion: "Use parameters"}
+	path, content := writeIssues(t, []domain.Finding{
+		finding,
+		{Severity: "low", Description: "Missing changelog entry"},
+	})
+	assert.Equal(t, "review-merged.codeclimate.json", filepath.Base(path))
+
+	var issues []codeclimate.Issue
+	require.NoError(t, json.Unmarshal(content, &issues))
+	require.Len(t, issues, 2)
+
+	assert.Equal(t, codeclimate.Issue{
+		Type:        "issue",
+		CheckName:   "security",
+		Description: "SQL injection",
+		Content:     &codeclimate.Content{Body: "Use parameters"},
+		Categories:  []string{"Security"},
+		Location:    codeclimate.Location{Path: "db.go", Lines: codeclimate.Lines{Begin: 10, End: 12}},
+		Severity:    "blocker",
+		Fingerprint: string(domain.FingerprintFromFinding(finding)),
+	}, issues[0])
+
+	// Project-level findings still have the location GitLab requires
+	assert.Equal(t, codeclimate.Location{Path: ".", Lines: codeclimate.Lines{Begin: 1, End: 1}}, issues[1].Location)
+	assert.Equal(t, "code-review", issues[1].CheckName)
+	assert.Equal(t, "minor", issues[1].Severity)
+}
+
+func TestWriter_Write_RepeatedFindingsGetDistinctFingerprints(t *testing.T) {
+	repeat := func(line int) domain.Finding {
+		return domain.Finding{File: "db.go", LineStart: line, Severity: "high", Category: "security", Description: "SQL injection"}
+	}
+	// Reported out of line order; the numbering follows the lines
+	_, content := writeIssues(t, []domain.Finding{repeat(40), repeat(10)})
+
+	var issues []codeclimate.Issue
+	require.NoError(t, json.Unmarshal(content, &issues))
+	require.Len(t, issues, 2)
+
+	assert.NotEqual(t, issues[0].Fingerprint, issues[1].Fingerprint)
+	assert.Equal(t, string(domain.FingerprintFromFinding(repeat(10))), issues[1].Fingerprint, "the first occurrence keeps the domain fingerprint")
+	assert.Len(t, issues[0].Fingerprint, 32)
+
+	// Fingerprints are stable across runs
+	_, again := writeIssues(t, []domain.Finding{repeat(10), repeat(40)})
+	var rerun []codeclimate.Issue
+	require.NoError(t, json.Unmarshal(again, &rerun))
+	assert.Equal(t, issues[1].Fingerprint, rerun[0].Fingerprint)
+	assert.Equal(t, issues[0].Fingerprint, rerun[1].Fingerprint)
+}
+
+func TestWriter_Write_EmptyReviewIsEmptyArray(t *testing.T) {
+	_, content := writeIssues(t, nil)
+	assert.JSONEq(t, "[]", string(content))
+}
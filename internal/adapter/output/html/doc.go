@@ -0,0 +1,3 @@
+// Package html provides a writer that renders a review run as a single
+// self-contained HTML report, with findings shown inline in the diff.
+package html
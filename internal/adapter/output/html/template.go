@@ -0,0 +1,127 @@
+package html
+
+// reportHTML is the report template. Styles and scripts are inline so the
+// report is a single file that opens anywhere, including offline.
+const reportHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Code review: {{.Repository}} {{.BaseRef}}...{{.TargetRef}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0; color: #1f2328; background: #f6f8fa; }
+header { padding: 16px 24px; background: #fff; border-bottom: 1px solid #d0d7de; }
+header h1 { font-size: 20px; margin: 0 0 4px; }
+main { padding: 16px 24px; }
+.meta { color: #59636e; font-size: 13px; }
+.controls { display: flex; flex-wrap: wrap; gap: 16px; align-items: center; margin-bottom: 16px; }
+.tabs button { border: 1px solid #d0d7de; background: #fff; padding: 6px 12px; cursor: pointer; border-radius: 6px; margin-right: 4px; }
+.tabs button.active { background: #0969da; color: #fff; border-color: #0969da; }
+.filters label { margin-right: 10px; font-size: 13px; }
+section.file { background: #fff; border: 1px solid #d0d7de; border-radius: 6px; margin-bottom: 16px; overflow: hidden; }
+section.file h2 { font-size: 14px; font-family: ui-monospace, SFMono-Regular, Menlo, monospace; margin: 0; padding: 8px 12px; background: #f6f8fa; border-bottom: 1px solid #d0d7de; }
+.status { font-weight: normal; color: #59636e; }
+.note { padding: 8px 12px; color: #59636e; font-style: italic; }
+table.diff { border-collapse: collapse; width: 100%; font-family: ui-monospace, SFMono-Regular, Menlo, monospace; font-size: 12px; }
+table.diff td { padding: 0 8px; vertical-align: top; white-space: pre-wrap; word-break: break-all; }
+td.num { width: 1%; min-width: 40px; text-align: right; color: #59636e; user-select: none; }
+tr.hunk td { background: #ddf4ff; color: #59636e; }
+tr.add td.code { background: #e6ffec; }
+tr.del td.code { background: #ffebe9; }
+tr.findings td { padding: 4px 8px; background: #f6f8fa; white-space: normal; font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; }
+.finding { border: 1px solid #d0d7de; border-left-width: 4px; border-radius: 6px; background: #fff; padding: 8px 12px; margin: 4px 0; font-size: 13px; }
+.finding.critical { border-left-color: #82071e; }
+.finding.high { border-left-color: #cf222e; }
+.finding.medium { border-left-color: #bf8700; }
+.finding.low { border-left-color: #0969da; }
+.finding.unreported { opacity: 0.7; }
+.badge { display: inline-block; padding: 0 6px; border-radius: 10px; font-size: 11px; background: #eaeef2; margin-right: 4px; }
+.badge.critical { background: #82071e; color: #fff; }
+.badge.high { background: #cf222e; color: #fff; }
+.badge.medium { background: #bf8700; color: #fff; }
+.badge.low { background: #0969da; color: #fff; }
+.finding p { margin: 6px 0; }
+.suggestion { color: #1a7f37; }
+.evidence { background: #f6f8fa; padding: 6px 8px; border-radius: 4px; }
+details pre { background: #f6f8fa; padding: 6px 8px; overflow-x: auto; font-size: 12px; }
+.unanchored { padding: 8px 12px; border-top: 1px solid #d0d7de; }
+table.usage { border-collapse: collapse; background: #fff; font-size: 13px; }
+table.usage th, table.usage td { border: 1px solid #d0d7de; padding: 4px 10px; text-align: left; }
+table.usage td.n { text-align: right; }
+table.usage tr.total td { font-weight: bold; }
+.hidden { display: none !important; }
+</style>
+</head>
+<body>
+<header>
+<h1>Code review: {{.Repository}}</h1>
+<div class="meta">{{.BaseRef}}...{{.TargetRef}} &middot; generated {{.GeneratedAt}}</div>
+</header>
+<main>
+<div class="controls">
+<div class="tabs">{{range $i, $t := .Tabs}}<button type="button" data-tab="{{$t.ID}}"{{if eq $i 0}} class="active"{{end}}>{{$t.Label}} ({{$t.Count}})</button>{{end}}</div>
+<div class="filters">{{range .Severities}}<label><input type="checkbox" class="severity" value="{{.}}" checked> {{.}}</label>{{end}}{{if .Verified}}<label><input type="checkbox" id="show-unreported"> show unreported</label>{{end}}</div>
+</div>
+{{range .Files}}
+<section class="file">
+<h2>{{.Path}} <span class="status">{{.Status}}{{if .OldPath}} from {{.OldPath}}{{end}}</span></h2>
+{{if .Note}}<div class="note">{{.Note}}</div>{{end}}
+{{if .Hunks}}<table class="diff">
+{{range .Hunks}}<tr class="hunk"><td class="num"></td><td class="num"></td><td>{{.Header}}</td></tr>
+{{range .Rows}}<tr class="{{.Kind}}"><td class="num">{{.OldLine}}</td><td class="num">{{.NewLine}}</td><td class="code">{{.Content}}</td></tr>
+{{if .Findings}}<tr class="findings"><td colspan="3">{{range .Findings}}{{template "finding" .}}{{end}}</td></tr>
+{{end}}{{end}}{{end}}</table>{{end}}
+{{if .Unanchored}}<div class="unanchored findings">{{range .Unanchored}}{{template "finding" .}}{{end}}</div>{{end}}
+</section>
+{{end}}
+{{if .Other}}
+<section class="file">
+<h2>Other findings</h2>
+<div class="unanchored findings">{{range .Other}}{{template "finding" .}}{{end}}</div>
+</section>
+{{end}}
+<h2>Usage</h2>
+<table class="usage">
+<tr><th>Stage</th><th>Model</th><th>Fallback from</th><th>Tokens in</th><th>Tokens out</th><th>Cost</th><th>Findings</th></tr>
+{{range .Usage}}<tr><td>{{.Name}}</td><td>{{.Model}}</td><td>{{.FallbackFrom}}</td><td class="n">{{.TokensIn}}</td><td class="n">{{.TokensOut}}</td><td class="n">{{cost .Cost}}</td><td class="n">{{.Findings}}</td></tr>
+{{end}}{{with .Total}}<tr class="total"><td>{{.Name}}</td><td></td><td></td><td class="n">{{.TokensIn}}</td><td class="n">{{.TokensOut}}</td><td class="n">{{cost .Cost}}</td><td class="n">{{.Findings}}</td></tr>{{end}}
+</table>
+</main>
+<script>
+(function () {
+  var tab = "merged";
+  function update() {
+    var severities = {};
+    document.querySelectorAll("input.severity").forEach(function (box) { severities[box.value] = box.checked; });
+    var unreported = document.getElementById("show-unreported");
+    var showUnreported = unreported !== null && unreported.checked;
+    document.querySelectorAll(".finding").forEach(function (el) {
+      var visible = el.dataset.tab === tab && severities[el.dataset.severity] !== false &&
+        (el.dataset.unreported !== "true" || showUnreported);
+      el.classList.toggle("hidden", !visible);
+    });
+    document.querySelectorAll(".findings").forEach(function (el) {
+      el.classList.toggle("hidden", el.querySelector(".finding:not(.hidden)") === null);
+    });
+  }
+  document.querySelectorAll(".tabs button").forEach(function (button) {
+    button.addEventListener("click", function () {
+      tab = button.dataset.tab;
+      document.querySelectorAll(".tabs button").forEach(function (b) { b.classList.toggle("active", b === button); });
+      update();
+    });
+  });
+  document.querySelectorAll(".filters input").forEach(function (box) { box.addEventListener("change", update); });
+  update();
+})();
+</script>
+</body>
+</html>
+{{define "finding"}}<div class="finding {{.Severity}}{{if .Hidden}} unreported{{end}}" data-tab="{{.Tab}}" data-severity="{{.Severity}}" data-unreported="{{.Hidden}}">
+<div><span class="badge {{.Severity}}">{{.Severity}}</span>{{if .Category}}<span class="badge">{{.Category}}</span>{{end}}{{if .Status}}<span class="badge">{{.Status}}</span>{{end}}<strong>{{.File}}:{{.Lines}}</strong></div>
+<p>{{.Description}}</p>
+{{if .Suggestion}}<p class="suggestion">Suggestion: {{.Suggestion}}</p>{{end}}
+{{if .Verified}}<p class="evidence">{{if .Classification}}<span class="badge">{{.Classification}}</span>{{end}}<span class="badge">confidence {{.Confidence}}%</span> {{.Evidence}}</p>
+{{if .Log}}<details><summary>Verification log ({{len .Log}} steps)</summary>{{range .Log}}<pre>{{.Tool}} {{.Input}}
+{{.Output}}</pre>{{end}}</details>{{end}}{{end}}
+</div>{{end}}`
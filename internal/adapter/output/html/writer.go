@@ -0,0 +1,357 @@
+package html
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bkyoung/code-reviewer/internal/diff"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/review"
+)
+
+// mergedTab is the tab showing the merged (and verified) findings.
+const mergedTab = "merged"
+
+// severities lists the severities offered as filters, most severe first.
+var severities = []string{"critical", "high", "medium", "low"}
+
+// Finding statuses shown for verified findings.
+const (
+	statusReportable     = "reportable"
+	statusBelowThreshold = "below threshold"
+	statusRejected       = "rejected"
+)
+
+// Writer implements the review.RunWriter interface.
+type Writer struct {
+	now func() string
+}
+
+// NewWriter creates a new HTML writer.
+func NewWriter(now func() string) *Writer {
+	return &Writer{now: now}
+}
+
+// Write renders the review run as a single self-contained HTML file.
+func (w *Writer) Write(ctx context.Context, artifact review.RunArtifact) (string, error) {
+	outputDir := filepath.Join(artifact.OutputDir, fmt.Sprintf("%s_%s", artifact.Repository, artifact.TargetRef), w.now())
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	filePath := filepath.Join(outputDir, "report.html")
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create html file: %w", err)
+	}
+	defer file.Close()
+
+	if err := reportTemplate.Execute(file, buildReport(artifact, w.now())); err != nil {
+		return "", fmt.Errorf("failed to render html report: %w", err)
+	}
+
+	return filePath, nil
+}
+
+// report is the view model of the HTML template.
+type report struct {
+	Repository  string
+	BaseRef     string
+	TargetRef   string
+	GeneratedAt string
+	Severities  []string
+	Tabs        []tab
+	Files       []fileView
+	Other       []findingView // Findings on files that are not in the diff
+	Usage       []usageRow
+	Total       usageRow
+	Verified    bool
+}
+
+type tab struct {
+	ID    string
+	Label string
+	Count int
+}
+
+type fileView struct {
+	Path       string
+	OldPath    string
+	Status     string
+	Note       string
+	Hunks      []hunkView
+	Unanchored []findingView // Findings on lines the diff does not show
+}
+
+type hunkView struct {
+	Header string
+	Rows   []rowView
+}
+
+type rowView struct {
+	Kind     string // add, del or ctx
+	OldLine  string
+	NewLine  string
+	Content  string
+	Findings []findingView
+}
+
+type findingView struct {
+	Tab            string
+	Severity       string
+	Category       string
+	Description    string
+	Suggestion     string
+	File           string
+	Lines          string
+	Status         string
+	Classification string
+	Confidence     int
+	Evidence       string
+	Log            []domain.VerificationAction
+	Verified       bool // The finding went through verification
+	Hidden         bool // Not reported; shown only on request
+
+	anchor int // The new-side line the finding is shown under: its last line
+}
+
+type usageRow struct {
+	Name         string
+	Model        string
+	FallbackFrom string
+	TokensIn     int
+	TokensOut    int
+	Cost         float64
+	Findings     int
+}
+
+// buildReport turns the artifact into the template's view model.
+func buildReport(artifact review.RunArtifact, generatedAt string) report {
+	r := report{
+		Repository:  artifact.Repository,
+		BaseRef:     artifact.BaseRef,
+		TargetRef:   artifact.TargetRef,
+		GeneratedAt: generatedAt,
+		Severities:  severities,
+		Verified:    len(artifact.Merged.VerifiedFindings) > 0,
+	}
+
+	reviews := append([]domain.Review(nil), artifact.Reviews...)
+	sort.SliceStable(reviews, func(i, j int) bool { return reviews[i].ProviderName < reviews[j].ProviderName })
+
+	findings := mergedFindings(artifact.Merged)
+	r.Tabs = append(r.Tabs, tab{ID: mergedTab, Label: "Merged", Count: countShown(findings)})
+	for _, rv := range reviews {
+		providerFindings := make([]findingView, 0, len(rv.Findings))
+		for _, f := range rv.Findings {
+			providerFindings = append(providerFindings, newFindingView(rv.ProviderName, f))
+		}
+		r.Tabs = append(r.Tabs, tab{ID: rv.ProviderName, Label: rv.ProviderName, Count: len(providerFindings)})
+		findings = append(findings, providerFindings...)
+	}
+
+	r.Files, r.Other = anchorFindings(artifact.Diff, findings)
+	r.Usage, r.Total = buildUsage(artifact.Merged, reviews)
+	return r
+}
+
+// mergedFindings returns the merged tab's findings. With verification the
+// rejected and below-threshold findings are included but hidden, so the
+// reader can see why they were not reported.
+func mergedFindings(merged domain.Review) []findingView {
+	if len(merged.VerifiedFindings) == 0 {
+		views := make([]findingView, 0, len(merged.Findings))
+		for _, f := range merged.Findings {
+			views = append(views, newFindingView(mergedTab, f))
+		}
+		return views
+	}
+
+	reportable := make(map[string]bool, len(merged.ReportableFindings))
+	for _, vf := range merged.ReportableFindings {
+		reportable[vf.Finding.ID] = true
+	}
+
+	views := make([]findingView, 0, len(merged.VerifiedFindings))
+	for _, vf := range merged.VerifiedFindings {
+		view := newFindingView(mergedTab, vf.Finding)
+		view.Verified = true
+		view.Classification = string(vf.Classification)
+		view.Confidence = vf.Confidence
+		view.Evidence = vf.Evidence
+		view.Log = vf.VerificationLog
+		switch {
+		case reportable[vf.Finding.ID]:
+			view.Status = statusReportable
+		case vf.Verified:
+			view.Status = statusBelowThreshold
+			view.Hidden = true
+		default:
+			view.Status = statusRejected
+			view.Hidden = true
+		}
+		views = append(views, view)
+	}
+	return views
+}
+
+func newFindingView(tabID string, f domain.Finding) findingView {
+	lines, anchor := fmt.Sprintf("%d", f.LineStart), f.LineStart
+	if f.LineEnd > f.LineStart {
+		lines, anchor = fmt.Sprintf("%d-%d", f.LineStart, f.LineEnd), f.LineEnd
+	}
+	return findingView{
+		Tab:         tabID,
+		Severity:    strings.ToLower(f.Severity),
+		Category:    f.Category,
+		Description: f.Description,
+		Suggestion:  f.Suggestion,
+		File:        f.File,
+		Lines:       lines,
+		anchor:      anchor,
+	}
+}
+
+func countShown(findings []findingView) int {
+	n := 0
+	for _, f := range findings {
+		if !f.Hidden {
+			n++
+		}
+	}
+	return n
+}
+
+// anchorFindings renders the diff and places each finding after the diff
+// line it ends on. Findings on lines the diff does not show are listed
+// under their file; findings on files outside the diff are returned
+// separately.
+func anchorFindings(d domain.Diff, findings []findingView) ([]fileView, []findingView) {
+	byFile := make(map[string][]findingView)
+	for _, f := range findings {
+		byFile[f.File] = append(byFile[f.File], f)
+	}
+
+	files := make([]fileView, 0, len(d.Files))
+	for _, fd := range d.Files {
+		view := fileView{Path: fd.Path, OldPath: fd.OldPath, Status: fd.Status}
+		pending := byFile[fd.Path]
+		delete(byFile, fd.Path)
+
+		switch {
+		case fd.IsBinary:
+			view.Note = "Binary file not shown."
+		case fd.Generated != "":
+			view.Note = fmt.Sprintf("Excluded from review (%s).", fd.Generated)
+		default:
+			parsed, err := diff.Parse(fd.Patch)
+			if err != nil {
+				view.Note = fmt.Sprintf("Diff could not be parsed: %v", err)
+				break
+			}
+			view.Hunks, pending = renderHunks(parsed, pending)
+		}
+
+		view.Unanchored = pending
+		files = append(files, view)
+	}
+
+	paths := make([]string, 0, len(byFile))
+	for path := range byFile {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var other []findingView
+	for _, path := range paths {
+		other = append(other, byFile[path]...)
+	}
+	return files, other
+}
+
+// renderHunks converts the parsed hunks to rows, attaching the findings
+// anchored on their new-side lines. It returns the findings left over.
+func renderHunks(parsed diff.ParsedDiff, findings []findingView) ([]hunkView, []findingView) {
+	byLine := make(map[int][]findingView)
+	for _, f := range findings {
+		byLine[f.anchor] = append(byLine[f.anchor], f)
+	}
+
+	hunks := make([]hunkView, 0, len(parsed.Hunks))
+	for _, h := range parsed.Hunks {
+		hv := hunkView{Header: fmt.Sprintf("@@ -%d,%d +%d,%d @@", h.OldStart, h.OldLines, h.NewStart, h.NewLines)}
+		for _, line := range h.Lines {
+			row := rowView{Content: line.Content}
+			switch line.Type {
+			case diff.LineAddition:
+				row.Kind = "add"
+			case diff.LineDeletion:
+				row.Kind = "del"
+			default:
+				row.Kind = "ctx"
+			}
+			if line.OldLine != nil {
+				row.OldLine = fmt.Sprintf("%d", *line.OldLine)
+			}
+			if line.NewLine != nil {
+				row.NewLine = fmt.Sprintf("%d", *line.NewLine)
+				row.Findings = byLine[*line.NewLine]
+				delete(byLine, *line.NewLine)
+			}
+			hv.Rows = append(hv.Rows, row)
+		}
+		hunks = append(hunks, hv)
+	}
+
+	var rest []findingView
+	for _, f := range findings {
+		if _, ok := byLine[f.anchor]; ok {
+			rest = append(rest, f)
+		}
+	}
+	return hunks, rest
+}
+
+// buildUsage returns a usage row per provider, one for verification when
+// it ran, and the total.
+func buildUsage(merged domain.Review, reviews []domain.Review) ([]usageRow, usageRow) {
+	rows := make([]usageRow, 0, len(reviews)+1)
+	for _, rv := range reviews {
+		rows = append(rows, usageRow{
+			Name:         rv.ProviderName,
+			Model:        rv.ModelName,
+			FallbackFrom: strings.Join(rv.FallbackFrom, ", "),
+			TokensIn:     rv.TokensIn,
+			TokensOut:    rv.TokensOut,
+			Cost:         rv.Cost,
+			Findings:     len(rv.Findings),
+		})
+	}
+
+	if len(merged.VerifiedFindings) > 0 {
+		verification := usageRow{Name: "verification", Model: merged.VerificationMode, Cost: merged.VerificationCost, Findings: len(merged.ReportableFindings)}
+		for _, vf := range merged.VerifiedFindings {
+			verification.TokensIn += vf.TokensIn
+			verification.TokensOut += vf.TokensOut
+		}
+		rows = append(rows, verification)
+	}
+
+	total := usageRow{Name: "total", Findings: len(merged.Findings)}
+	for _, row := range rows {
+		total.TokensIn += row.TokensIn
+		total.TokensOut += row.TokensOut
+		total.Cost += row.Cost
+	}
+	return rows, total
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"cost": func(c float64) string { return fmt.Sprintf("$%.4f", c) },
+}).Parse(reportHTML))
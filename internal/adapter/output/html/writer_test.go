@@ -0,0 +1,137 @@
+package html_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/output/html"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/review"
+)
+
+const testPatch = `@@ -1,3 +1,4 @@
+ package main
+-func old() {}
++func main() {
++	println("<hi>")
+ }
+`
+
+func testArtifact(outputDir string) review.RunArtifact {
+	reportable := domain.Finding{ID: "f1", File: "main.go", LineStart: 2, LineEnd: 3, Severity: "high", Category: "bug", Description: "Prints markup"}
+	rejected := domain.Finding{ID: "f2", File: "main.go", LineStart: 40, LineEnd: 40, Severity: "low", Description: "Unused import"}
+
+	return review.RunArtifact{
+		OutputDir:  outputDir,
+		Repository: "repo",
+		BaseRef:    "main",
+		TargetRef:  "feature",
+		Diff: domain.Diff{Files: []domain.FileDiff{
+			{Path: "main.go", Status: domain.FileStatusModified, Patch: testPatch},
+			{Path: "go.sum", Status: domain.FileStatusModified, Generated: domain.GeneratedLockfile},
+		}},
+		Merged: domain.Review{
+			ProviderName: "merged",
+			Findings:     []domain.Finding{reportable},
+			VerifiedFindings: []domain.VerifiedFinding{
+				{Finding: reportable, Verified: true, Classification: domain.ClassBlockingBug, Confidence: 90, Evidence: "Confirmed in main.go",
+					VerificationLog: []domain.VerificationAction{{Tool: "read", Input: "main.go", Output: "package main"}}, TokensIn: 100, TokensOut: 10},
+				{Finding: rejected, Verified: false, Confidence: 20, Evidence: "No such import"},
+			},
+			ReportableFindings: []domain.VerifiedFinding{{Finding: reportable}},
+			VerificationMode:   domain.VerificationModeAgent,
+			VerificationCost:   0.02,
+		},
+		Reviews: []domain.Review{
+			{ProviderName: "openai", ModelName: "gpt-4o", FallbackFrom: []string{"gpt-5"}, TokensIn: 1000, TokensOut: 200, Cost: 0.5,
+				Findings: []domain.Finding{reportable, {File: "docs/README.md", LineStart: 1, Severity: "medium", Description: "Outdated"}}},
+			{ProviderName: "anthropic", ModelName: "claude", TokensIn: 900, TokensOut: 100, Cost: 0.3,
+				Findings: []domain.Finding{rejected}},
+		},
+	}
+}
+
+func writeReport(t *testing.T) (string, string) {
+	t.Helper()
+	writer := html.NewWriter(func() string { return "20251020T120000Z" })
+	path, err := writer.Write(context.Background(), testArtifact(t.TempDir()))
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return path, string(content)
+}
+
+func TestWriter_WritesReport(t *testing.T) {
+	path, content := writeReport(t)
+	assert.Equal(t, "report.html", filepath.Base(path))
+	assert.Contains(t, path, filepath.Join("repo_feature", "20251020T120000Z"))
+	assert.True(t, strings.HasPrefix(content, "<!DOCTYPE html>"))
+}
+
+func TestWriter_AnchorsFindingsInDiff(t *testing.T) {
+	_, content := writeReport(t)
+
+	// The finding on lines 2-3 follows new line 3, before the closing brace
+	line3 := strings.Index(content, `&#34;&lt;hi&gt;&#34;`)
+	finding := strings.Index(content, "Prints markup")
+	closing := strings.LastIndex(content, `<td class="num">4</td>`)
+	require.NotEqual(t, -1, line3)
+	assert.Less(t, line3, finding)
+	assert.Less(t, finding, closing)
+
+	// Diff content is escaped
+	assert.NotContains(t, content, `println("<hi>")`)
+
+	// Findings off the diff lines or outside the diff are still shown
+	assert.Contains(t, content, "Unused import")
+	assert.Contains(t, content, "Other findings")
+	assert.Contains(t, content, "Outdated")
+	assert.Contains(t, content, "Excluded from review (lockfile).")
+}
+
+func TestWriter_RendersTabsFiltersAndVerification(t *testing.T) {
+	_, content := writeReport(t)
+
+	// Merged first, then providers by name
+	merged := strings.Index(content, `data-tab="merged" class="active">Merged (1)`)
+	anthropic := strings.Index(content, `data-tab="anthropic">anthropic (1)`)
+	openai := strings.Index(content, `data-tab="openai">openai (2)`)
+	require.NotEqual(t, -1, merged)
+	assert.Less(t, merged, anthropic)
+	assert.Less(t, anthropic, openai)
+
+	for _, severity := range []string{"critical", "high", "medium", "low"} {
+		assert.Contains(t, content, `class="severity" value="`+severity+`"`)
+	}
+	assert.Contains(t, content, `id="show-unreported"`)
+	assert.Contains(t, content, `data-tab="merged" data-severity="low" data-unreported="true"`)
+
+	assert.Contains(t, content, "reportable")
+	assert.Contains(t, content, "rejected")
+	assert.Contains(t, content, "Confirmed in main.go")
+	assert.Contains(t, content, "confidence 90%")
+	assert.Contains(t, content, "Verification log (1 steps)")
+}
+
+func TestWriter_RendersUsageTable(t *testing.T) {
+	_, content := writeReport(t)
+
+	assert.Contains(t, content, `<td>openai</td><td>gpt-4o</td><td>gpt-5</td><td class="n">1000</td><td class="n">200</td><td class="n">$0.5000</td><td class="n">2</td>`)
+	assert.Contains(t, content, `<td>verification</td><td>agent</td><td></td><td class="n">100</td><td class="n">10</td><td class="n">$0.0200</td><td class="n">1</td>`)
+	assert.Contains(t, content, `<td class="n">2000</td><td class="n">310</td><td class="n">$0.8200</td>`)
+}
+
+func TestWriter_HasNoExternalAssets(t *testing.T) {
+	_, content := writeReport(t)
+
+	for _, ref := range []string{"<link", "src=", "@import", "http://", "https://"} {
+		assert.NotContains(t, content, ref)
+	}
+}
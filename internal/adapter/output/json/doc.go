@@ -1,2 +1,5 @@
 // Package json provides a writer that outputs reviews in JSON format.
+//
+// The document is the versioned Output envelope; Schema returns its JSON
+// Schema, which `cr schema output` prints.
 package json
@@ -0,0 +1,226 @@
+package json
+
+import (
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/jsonschema"
+	"github.com/bkyoung/code-reviewer/internal/version"
+)
+
+// SchemaVersion is the version of the JSON output format. The major
+// version changes when fields are removed, renamed or change meaning; the
+// minor version when fields are added.
+const SchemaVersion = "1.0"
+
+// SchemaID identifies the output schema printed by `cr schema output`.
+const SchemaID = "https://github.com/bkyoung/code-reviewer/schemas/output-v1.json"
+
+// Finding verification statuses.
+const (
+	StatusReportable     = "reportable"
+	StatusBelowThreshold = "below_threshold"
+	StatusRejected       = "rejected"
+)
+
+// Output is the document the JSON writer produces for one review.
+type Output struct {
+	SchemaVersion string    `json:"schemaVersion" description:"Version of this format: the major version changes on breaking changes, the minor version on additions"`
+	Tool          Tool      `json:"tool"`
+	Run           Run       `json:"run"`
+	Review        Review    `json:"review"`
+	Findings      []Finding `json:"findings" description:"The reported findings"`
+
+	// FilteredFindings are verified findings that were not reported.
+	FilteredFindings []Finding `json:"filteredFindings,omitempty" description:"Findings verification rejected or scored below the confidence threshold"`
+}
+
+// Tool identifies the program that wrote the output.
+type Tool struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Run describes what was reviewed.
+type Run struct {
+	Timestamp  string `json:"timestamp" description:"When the output was written, UTC, as YYYYMMDDTHHMMSSZ"`
+	Repository string `json:"repository"`
+	BaseRef    string `json:"baseRef"`
+	TargetRef  string `json:"targetRef"`
+	BaseSHA    string `json:"baseSha" description:"Commit the diff starts from; empty for reviewed patches"`
+	TargetSHA  string `json:"targetSha" description:"Commit the diff ends at; empty for reviewed patches and uncommitted changes"`
+	ConfigHash string `json:"configHash" description:"Hash of the review settings, to tell apart runs of the same refs"`
+}
+
+// Review describes the review that produced the findings.
+type Review struct {
+	Provider     string        `json:"provider" description:"Provider name, or merged for the merged review"`
+	Model        string        `json:"model"`
+	FallbackFrom []string      `json:"fallbackFrom,omitempty" description:"Models that failed, in order, before model produced the review"`
+	Summary      string        `json:"summary"`
+	TokensIn     int           `json:"tokensIn" minimum:"0"`
+	TokensOut    int           `json:"tokensOut" minimum:"0"`
+	Cost         float64       `json:"cost" minimum:"0" description:"Cost in USD"`
+	Truncation   *Truncation   `json:"truncation,omitempty" description:"Set when files were left out of the review to fit the size limit"`
+	Verification *Verification `json:"verification,omitempty" description:"Set when the findings were verified"`
+}
+
+// Truncation lists the files left out of a review.
+type Truncation struct {
+	Files   []string `json:"files"`
+	Warning string   `json:"warning"`
+}
+
+// Verification summarizes the verification of a review's findings.
+type Verification struct {
+	Mode       string  `json:"mode" description:"batch, agent or hybrid"`
+	Candidates int     `json:"candidates" minimum:"0"`
+	Verified   int     `json:"verified" minimum:"0"`
+	Reportable int     `json:"reportable" minimum:"0"`
+	Cost       float64 `json:"cost" minimum:"0" description:"Cost in USD"`
+}
+
+// Finding is one issue found by the review.
+type Finding struct {
+	ID           string               `json:"id"`
+	Fingerprint  string               `json:"fingerprint" description:"Stable across runs and line shifts: derived from the file, category, severity and description"`
+	File         string               `json:"file"`
+	LineStart    int                  `json:"lineStart" minimum:"0"`
+	LineEnd      int                  `json:"lineEnd" minimum:"0"`
+	Severity     string               `json:"severity" description:"critical, high, medium or low"`
+	Category     string               `json:"category"`
+	Description  string               `json:"description"`
+	Suggestion   string               `json:"suggestion"`
+	Evidence     bool                 `json:"evidence"`
+	Verification *FindingVerification `json:"verification,omitempty" description:"Set when the finding was verified"`
+}
+
+// FindingVerification is the verification result of one finding.
+type FindingVerification struct {
+	Status          string   `json:"status" enum:"reportable,below_threshold,rejected"`
+	Verified        bool     `json:"verified" description:"Whether verification confirmed the issue exists"`
+	Classification  string   `json:"classification"`
+	Confidence      int      `json:"confidence" minimum:"0" maximum:"100"`
+	Evidence        string   `json:"evidence"`
+	BlocksOperation bool     `json:"blocksOperation"`
+	Path            string   `json:"path,omitempty" description:"How the finding was verified: batch, agent or batch+agent"`
+	Log             []Action `json:"log,omitempty" description:"The verification agent's tool calls"`
+}
+
+// Action is one tool call of the verification agent.
+type Action struct {
+	Tool   string `json:"tool"`
+	Input  string `json:"input"`
+	Output string `json:"output"`
+}
+
+// Schema returns the JSON Schema of Output.
+func Schema() *jsonschema.Schema {
+	schema := jsonschema.Generate(Output{})
+	schema.ID = SchemaID
+	schema.Title = "code-reviewer review output"
+	schema.Description = "A review written by cr in the json format, version " + SchemaVersion
+	return schema
+}
+
+// NewOutput builds the output document of a review.
+func NewOutput(artifact domain.JSONArtifact, timestamp string) Output {
+	r := artifact.Review
+	out := Output{
+		SchemaVersion: SchemaVersion,
+		Tool:          Tool{Name: "cr", Version: version.Value()},
+		Run: Run{
+			Timestamp:  timestamp,
+			Repository: artifact.Repository,
+			BaseRef:    artifact.BaseRef,
+			TargetRef:  artifact.TargetRef,
+			BaseSHA:    artifact.Diff.FromCommitHash,
+			TargetSHA:  artifact.Diff.ToCommitHash,
+			ConfigHash: artifact.ConfigHash,
+		},
+		Review: Review{
+			Provider:     artifact.ProviderName,
+			Model:        r.ModelName,
+			FallbackFrom: r.FallbackFrom,
+			Summary:      r.Summary,
+			TokensIn:     r.TokensIn,
+			TokensOut:    r.TokensOut,
+			Cost:         r.Cost,
+		},
+		Findings: make([]Finding, 0, len(r.Findings)),
+	}
+
+	if r.WasTruncated {
+		out.Review.Truncation = &Truncation{Files: append([]string{}, r.TruncatedFiles...), Warning: r.TruncationWarning}
+	}
+	if len(r.VerifiedFindings) > 0 {
+		out.Review.Verification = &Verification{
+			Mode:       r.VerificationMode,
+			Candidates: len(r.DiscoveryFindings),
+			Verified:   countVerified(r.VerifiedFindings),
+			Reportable: len(r.ReportableFindings),
+			Cost:       r.VerificationCost,
+		}
+	}
+
+	// Verification results are matched to the reported findings by ID
+	verified := make(map[string]domain.VerifiedFinding, len(r.VerifiedFindings))
+	for _, vf := range r.VerifiedFindings {
+		if vf.Finding.ID != "" {
+			verified[vf.Finding.ID] = vf
+		}
+	}
+	reported := make(map[string]bool, len(r.Findings))
+	for _, f := range r.Findings {
+		finding := newFinding(f)
+		if vf, ok := verified[f.ID]; ok {
+			finding.Verification = newFindingVerification(vf, StatusReportable)
+			reported[f.ID] = true
+		}
+		out.Findings = append(out.Findings, finding)
+	}
+
+	for _, vf := range r.VerifiedFindings {
+		if vf.Finding.ID != "" && reported[vf.Finding.ID] {
+			continue
+		}
+		status := StatusRejected
+		if vf.Verified {
+			status = StatusBelowThreshold
+		}
+		finding := newFinding(vf.Finding)
+		finding.Verification = newFindingVerification(vf, status)
+		out.FilteredFindings = append(out.FilteredFindings, finding)
+	}
+
+	return out
+}
+
+func newFinding(f domain.Finding) Finding {
+	return Finding{
+		ID:          f.ID,
+		Fingerprint: string(domain.FingerprintFromFinding(f)),
+		File:        f.File,
+		LineStart:   f.LineStart,
+		LineEnd:     f.LineEnd,
+		Severity:    f.Severity,
+		Category:    f.Category,
+		Description: f.Description,
+		Suggestion:  f.Suggestion,
+		Evidence:    f.Evidence,
+	}
+}
+
+func newFindingVerification(vf domain.VerifiedFinding, status string) *FindingVerification {
+	v := &FindingVerification{
+		Status:          status,
+		Verified:        vf.Verified,
+		Classification:  string(vf.Classification),
+		Confidence:      vf.Confidence,
+		Evidence:        vf.Evidence,
+		BlocksOperation: vf.BlocksOperation,
+		Path:            vf.VerificationPath,
+	}
+	for _, action := range vf.VerificationLog {
+		v.Log = append(v.Log, Action{Tool: action.Tool, Input: action.Input, Output: action.Output})
+	}
+	return v
+}
@@ -20,9 +20,10 @@ func NewWriter(now func() string) *Writer {
 	return &Writer{now: now}
 }
 
-// Write persists a review to disk as a JSON file.
+// Write persists a review to disk as a JSON file in the Output format.
 func (w *Writer) Write(ctx context.Context, artifact domain.JSONArtifact) (string, error) {
-	outputDir := filepath.Join(artifact.OutputDir, fmt.Sprintf("%s_%s", artifact.Repository, artifact.TargetRef), w.now())
+	timestamp := w.now()
+	outputDir := filepath.Join(artifact.OutputDir, fmt.Sprintf("%s_%s", artifact.Repository, artifact.TargetRef), timestamp)
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		return "", fmt.Errorf("failed to create output directory: %w", err)
 	}
@@ -38,7 +39,7 @@ func (w *Writer) Write(ctx context.Context, artifact domain.JSONArtifact) (strin
 	encoder := json.NewEncoder(file)
 	encoder.SetIndent("", "  ")
 
-	if err := encoder.Encode(artifact.Review); err != nil {
+	if err := encoder.Encode(NewOutput(artifact, timestamp)); err != nil {
 		return "", fmt.Errorf("failed to encode review to json: %w", err)
 	}
 
@@ -1,6 +1,7 @@
 package json_test
 
 import (
+	"bytes"
 	"context"
 	stdjson "encoding/json"
 	"os"
@@ -9,7 +10,9 @@ import (
 
 	"github.com/bkyoung/code-reviewer/internal/adapter/output/json"
 	"github.com/bkyoung/code-reviewer/internal/domain"
+	validator "github.com/santhosh-tekuri/jsonschema/v6"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWriter_Write(t *testing.T) {
@@ -52,10 +55,17 @@ func TestWriter_Write(t *testing.T) {
 	content, err := os.ReadFile(path)
 	assert.NoError(t, err)
 
-	var writtenReview domain.Review
-	err = stdjson.Unmarshal(content, &writtenReview)
+	var output json.Output
+	err = stdjson.Unmarshal(content, &output)
 	assert.NoError(t, err)
-	assert.Equal(t, review, writtenReview)
+	assert.Equal(t, json.SchemaVersion, output.SchemaVersion)
+	assert.Equal(t, "test-provider", output.Review.Provider)
+	assert.Equal(t, "test-model", output.Review.Model)
+	assert.Equal(t, "Test summary", output.Review.Summary)
+	assert.Equal(t, json.Run{Timestamp: "20251020T120000Z", Repository: "test-repo", BaseRef: "main", TargetRef: "feature"}, output.Run)
+	assert.Equal(t, []json.Finding{{
+		ID: "123", Fingerprint: string(review.Findings[0].Fingerprint()), File: "main.go", LineStart: 1, LineEnd: 5, Description: "Test finding",
+	}}, output.Findings)
 }
 
 func TestWriter_Write_IncludesCostField(t *testing.T) {
@@ -91,9 +101,132 @@ func TestWriter_Write_IncludesCostField(t *testing.T) {
 	content, err := os.ReadFile(path)
 	assert.NoError(t, err)
 
-	var writtenReview domain.Review
-	err = stdjson.Unmarshal(content, &writtenReview)
+	var output json.Output
+	err = stdjson.Unmarshal(content, &output)
 	assert.NoError(t, err)
-	assert.Equal(t, 0.0523, writtenReview.Cost)
-	assert.Equal(t, review, writtenReview)
+	assert.Equal(t, 0.0523, output.Review.Cost)
+	assert.Empty(t, output.Findings)
+}
+
+func verifiedArtifact(outputDir string) domain.JSONArtifact {
+	reported := domain.Finding{ID: "f1", File: "main.go", LineStart: 3, LineEnd: 4, Severity: "high", Category: "bug", Description: "Nil dereference"}
+	belowThreshold := domain.Finding{ID: "f2", File: "main.go", LineStart: 9, LineEnd: 9, Severity: "low", Category: "style", Description: "Long line"}
+	rejected := domain.Finding{ID: "f3", File: "util.go", LineStart: 1, LineEnd: 1, Severity: "medium", Category: "bug", Description: "Unused import"}
+
+	return domain.JSONArtifact{
+		OutputDir:  outputDir,
+		Repository: "repo",
+		BaseRef:    "main",
+		TargetRef:  "feature",
+		Diff:       domain.Diff{FromCommitHash: "aaa111", ToCommitHash: "bbb222"},
+		ConfigHash: "0123456789abcdef",
+		Review: domain.Review{
+			ProviderName: "merged",
+			ModelName:    "consensus",
+			Findings:     []domain.Finding{reported},
+			FallbackFrom: []string{"gpt-5"},
+			DiscoveryFindings: []domain.CandidateFinding{
+				{Finding: reported}, {Finding: belowThreshold}, {Finding: rejected},
+			},
+			VerifiedFindings: []domain.VerifiedFinding{
+				{Finding: reported, Verified: true, Classification: domain.ClassBlockingBug, Confidence: 90, Evidence: "Confirmed", BlocksOperation: true,
+					VerificationPath: "agent", VerificationLog: []domain.VerificationAction{{Tool: "read", Input: "main.go", Output: "package main"}}},
+				{Finding: belowThreshold, Verified: true, Confidence: 40, Evidence: "Minor"},
+				{Finding: rejected, Verified: false, Confidence: 10, Evidence: "Import is used"},
+			},
+			ReportableFindings: []domain.VerifiedFinding{{Finding: reported}},
+			VerificationMode:   domain.VerificationModeAgent,
+			VerificationCost:   0.02,
+			WasTruncated:       true,
+			TruncatedFiles:     []string{"big.go"},
+			TruncationWarning:  "1 file left out",
+		},
+		ProviderName: "merged",
+	}
+}
+
+func TestWriter_Write_Envelope(t *testing.T) {
+	writer := json.NewWriter(func() string { return "20251020T120000Z" })
+	path, err := writer.Write(context.Background(), verifiedArtifact(t.TempDir()))
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var output json.Output
+	require.NoError(t, stdjson.Unmarshal(content, &output))
+
+	assert.Equal(t, "aaa111", output.Run.BaseSHA)
+	assert.Equal(t, "bbb222", output.Run.TargetSHA)
+	assert.Equal(t, "0123456789abcdef", output.Run.ConfigHash)
+	assert.Equal(t, "cr", output.Tool.Name)
+	assert.Equal(t, &json.Truncation{Files: []string{"big.go"}, Warning: "1 file left out"}, output.Review.Truncation)
+	assert.Equal(t, &json.Verification{Mode: domain.VerificationModeAgent, Candidates: 3, Verified: 2, Reportable: 1, Cost: 0.02}, output.Review.Verification)
+
+	require.Len(t, output.Findings, 1)
+	finding := output.Findings[0]
+	assert.NotEmpty(t, finding.Fingerprint)
+	require.NotNil(t, finding.Verification)
+	assert.Equal(t, json.StatusReportable, finding.Verification.Status)
+	assert.Equal(t, 90, finding.Verification.Confidence)
+	assert.Equal(t, []json.Action{{Tool: "read", Input: "main.go", Output: "package main"}}, finding.Verification.Log)
+
+	require.Len(t, output.FilteredFindings, 2)
+	assert.Equal(t, json.StatusBelowThreshold, output.FilteredFindings[0].Verification.Status)
+	assert.Equal(t, json.StatusRejected, output.FilteredFindings[1].Verification.Status)
+}
+
+// compileSchema compiles the published output schema with an independent
+// draft 2020-12 validator.
+func compileSchema(t *testing.T) *validator.Schema {
+	t.Helper()
+	encoded, err := stdjson.Marshal(json.Schema())
+	require.NoError(t, err)
+	doc, err := validator.UnmarshalJSON(bytes.NewReader(encoded))
+	require.NoError(t, err)
+
+	compiler := validator.NewCompiler()
+	require.NoError(t, compiler.AddResource(json.SchemaID, doc))
+	schema, err := compiler.Compile(json.SchemaID)
+	require.NoError(t, err)
+	return schema
+}
+
+func validateDocument(schema *validator.Schema, document []byte) error {
+	instance, err := validator.UnmarshalJSON(bytes.NewReader(document))
+	if err != nil {
+		return err
+	}
+	return schema.Validate(instance)
+}
+
+func TestWriter_Write_MatchesSchema(t *testing.T) {
+	schema := compileSchema(t)
+	writer := json.NewWriter(func() string { return "20251020T120000Z" })
+
+	artifacts := map[string]domain.JSONArtifact{
+		"verified":    verifiedArtifact(t.TempDir()),
+		"no findings": {OutputDir: t.TempDir(), Repository: "repo", TargetRef: "feature", ProviderName: "openai"},
+	}
+	for name, artifact := range artifacts {
+		t.Run(name, func(t *testing.T) {
+			path, err := writer.Write(context.Background(), artifact)
+			require.NoError(t, err)
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.NoError(t, validateDocument(schema, content))
+		})
+	}
+}
+
+func TestSchema(t *testing.T) {
+	schema := json.Schema()
+	assert.Equal(t, json.SchemaID, schema.ID)
+	assert.Contains(t, schema.Required, "schemaVersion")
+	assert.Contains(t, schema.Defs, "FindingVerification")
+
+	// Documents of another shape are rejected
+	err := validateDocument(compileSchema(t), []byte(`{"providerName":"openai","findings":[]}`))
+	assert.ErrorContains(t, err, "schemaVersion")
 }
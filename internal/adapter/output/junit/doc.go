@@ -0,0 +1,3 @@
+// Package junit provides a writer that outputs reviews as JUnit XML, one
+// test case per finding, for CI servers such as Jenkins.
+package junit
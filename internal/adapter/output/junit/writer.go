@@ -0,0 +1,189 @@
+package junit
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/review"
+)
+
+// maxNameLength truncates the description in test case names, which CI
+// servers show in lists.
+const maxNameLength = 80
+
+// Layouts of the run timestamp passed to the writers and of the JUnit
+// timestamp attribute, which the schema defines as ISO 8601 without a zone.
+const (
+	runTimestampLayout   = "20060102T150405Z"
+	junitTimestampLayout = "2006-01-02T15:04:05"
+)
+
+// Writer implements the review.ReportWriter interface.
+type Writer struct {
+	now func() string
+}
+
+// NewWriter creates a new JUnit writer.
+func NewWriter(now func() string) *Writer {
+	return &Writer{now: now}
+}
+
+type testSuites struct {
+	XMLName  xml.Name    `xml:"testsuites"`
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Suites   []testSuite `xml:"testsuite"`
+}
+
+type testSuite struct {
+	Name      string     `xml:"name,attr"`
+	Tests     int        `xml:"tests,attr"`
+	Failures  int        `xml:"failures,attr"`
+	Timestamp string     `xml:"timestamp,attr,omitempty"`
+	TestCases []testCase `xml:"testcase"`
+}
+
+type testCase struct {
+	ClassName string   `xml:"classname,attr"`
+	Name      string   `xml:"name,attr"`
+	Failure   *failure `xml:"failure,omitempty"`
+	SystemOut string   `xml:"system-out,omitempty"`
+}
+
+type failure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// Write persists a review to disk as a JUnit XML file. Blocking findings
+// are failed test cases; the others pass with their details in the output.
+func (w *Writer) Write(ctx context.Context, artifact review.ReportArtifact) (string, error) {
+	stamp := w.now()
+	outputDir := filepath.Join(artifact.OutputDir, fmt.Sprintf("%s_%s", artifact.Repository, artifact.TargetRef), stamp)
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	filePath := filepath.Join(outputDir, fmt.Sprintf("review-%s.junit.xml", artifact.ProviderName))
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create junit file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(xml.Header); err != nil {
+		return "", fmt.Errorf("failed to write junit file: %w", err)
+	}
+	encoder := xml.NewEncoder(file)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(convertToJUnit(artifact, junitTimestamp(stamp))); err != nil {
+		return "", fmt.Errorf("failed to encode review to junit: %w", err)
+	}
+
+	return filePath, nil
+}
+
+// convertToJUnit converts a review to a single JUnit test suite. A review
+// without findings gets one passing test case, since CI servers treat an
+// empty report as a missing one.
+func convertToJUnit(artifact review.ReportArtifact, timestamp string) testSuites {
+	suite := testSuite{
+		Name:      fmt.Sprintf("code-review (%s)", artifact.ProviderName),
+		Timestamp: timestamp,
+	}
+
+	for _, finding := range artifact.Review.Findings {
+		tc := testCase{
+			ClassName: className(finding),
+			Name:      caseName(finding),
+		}
+		details := findingDetails(finding)
+		if artifact.Blocks(finding) {
+			tc.Failure = &failure{
+				Message: finding.Description,
+				Type:    finding.Severity,
+				Text:    details,
+			}
+			suite.Failures++
+		} else {
+			tc.SystemOut = details
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	if len(suite.TestCases) == 0 {
+		suite.TestCases = append(suite.TestCases, testCase{ClassName: "code-review", Name: "no findings"})
+	}
+	suite.Tests = len(suite.TestCases)
+
+	return testSuites{
+		Name:     "cr",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []testSuite{suite},
+	}
+}
+
+// junitTimestamp converts the run timestamp to the JUnit format in UTC.
+// A timestamp in an unexpected format is left out rather than written invalid.
+func junitTimestamp(stamp string) string {
+	t, err := time.Parse(runTimestampLayout, stamp)
+	if err != nil {
+		return ""
+	}
+	return t.UTC().Format(junitTimestampLayout)
+}
+
+// className groups findings by file, as test runners group by class.
+func className(f domain.Finding) string {
+	if f.File == "" {
+		return "code-review"
+	}
+	return f.File
+}
+
+// caseName identifies a finding by location, severity and description.
+func caseName(f domain.Finding) string {
+	description := f.Description
+	if runes := []rune(description); len(runes) > maxNameLength {
+		description = string(runes[:maxNameLength]) + "..."
+	}
+	return fmt.Sprintf("%s [%s] %s", location(f), f.Severity, description)
+}
+
+func location(f domain.Finding) string {
+	switch {
+	case f.File == "":
+		return "(project)"
+	case f.LineStart < 1:
+		return f.File
+	case f.LineEnd > f.LineStart:
+		return fmt.Sprintf("%s:%d-%d", f.File, f.LineStart, f.LineEnd)
+	default:
+		return fmt.Sprintf("%s:%d", f.File, f.LineStart)
+	}
+}
+
+// findingDetails renders the finding for the failure body or output.
+func findingDetails(f domain.Finding) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s\n", location(f))
+	fmt.Fprintf(&sb, "Severity: %s\n", f.Severity)
+	if f.Category != "" {
+		fmt.Fprintf(&sb, "Category: %s\n", f.Category)
+	}
+	fmt.Fprintf(&sb, "\n%s\n", f.Description)
+	if f.Suggestion != "" {
+		fmt.Fprintf(&sb, "\nSuggestion: %s\n", f.Suggestion)
+	}
+	return sb.String()
+}
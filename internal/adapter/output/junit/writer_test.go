@@ -0,0 +1,101 @@
+package junit_test
+
+import (
+	"context"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/output/junit"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/review"
+)
+
+type testSuites struct {
+	Tests    int `xml:"tests,attr"`
+	Failures int `xml:"failures,attr"`
+	Suites   []struct {
+		Name      string `xml:"name,attr"`
+		Tests     int    `xml:"tests,attr"`
+		Failures  int    `xml:"failures,attr"`
+		Timestamp string `xml:"timestamp,attr"`
+		TestCases []struct {
+			ClassName string `xml:"classname,attr"`
+			Name      string `xml:"name,attr"`
+			Failure   *struct {
+				Message string `xml:"message,attr"`
+				Type    string `xml:"type,attr"`
+				Text    string `xml:",chardata"`
+			} `xml:"failure"`
+			SystemOut string `xml:"system-out"`
+		} `xml:"testcase"`
+	} `xml:"testsuite"`
+}
+
+func writeJUnit(t *testing.T, artifact review.ReportArtifact) (string, testSuites) {
+	t.Helper()
+	artifact.OutputDir = t.TempDir()
+	artifact.Repository = "repo"
+	artifact.TargetRef = "feature"
+	if artifact.ProviderName == "" {
+		artifact.ProviderName = "merged"
+	}
+
+	writer := junit.NewWriter(func() string { return "20251020T120000Z" })
+	path, err := writer.Write(context.Background(), artifact)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(content), "<?xml"))
+
+	var suites testSuites
+	require.NoError(t, xml.Unmarshal(content, &suites))
+	return path, suites
+}
+
+func TestWriter_Write_FailsBlockingFindings(t *testing.T) {
+	path, suites := writeJUnit(t, review.ReportArtifact{
+		Review: domain.Review{Findings: []domain.Finding{
+			{File: "db.go", LineStart: 10, LineEnd: 12, Severity: "high", Category: "security", Description: "SQL injection", Suggestion: "Use parameters"},
+			{File: "util.go", LineStart: 3, Severity: "low", Category: "security", Description: "Weak hash"},
+			{File: "util.go", LineStart: 8, Severity: "medium", Category: "maintainability", Description: "Long function"},
+		}},
+		BlockingSeverities:    []string{"critical", "high"},
+		AlwaysBlockCategories: []string{"Security"},
+	})
+	assert.Equal(t, "review-merged.junit.xml", filepath.Base(path))
+
+	assert.Equal(t, 3, suites.Tests)
+	assert.Equal(t, 2, suites.Failures)
+	require.Len(t, suites.Suites, 1)
+	assert.Equal(t, "2025-10-20T12:00:00", suites.Suites[0].Timestamp, "ISO 8601 in UTC, as the JUnit schema requires")
+	cases := suites.Suites[0].TestCases
+	require.Len(t, cases, 3)
+
+	assert.Equal(t, "db.go", cases[0].ClassName)
+	assert.Equal(t, "db.go:10-12 [high] SQL injection", cases[0].Name)
+	require.NotNil(t, cases[0].Failure)
+	assert.Equal(t, "SQL injection", cases[0].Failure.Message)
+	assert.Equal(t, "high", cases[0].Failure.Type)
+	assert.Contains(t, cases[0].Failure.Text, "Suggestion: Use parameters")
+
+	// Blocked by category despite its severity
+	assert.NotNil(t, cases[1].Failure)
+
+	assert.Nil(t, cases[2].Failure)
+	assert.Contains(t, cases[2].SystemOut, "Long function")
+}
+
+func TestWriter_Write_PassesWithoutFindings(t *testing.T) {
+	_, suites := writeJUnit(t, review.ReportArtifact{BlockingSeverities: []string{"high"}})
+
+	assert.Equal(t, 1, suites.Tests)
+	assert.Equal(t, 0, suites.Failures)
+	assert.Equal(t, "no findings", suites.Suites[0].TestCases[0].Name)
+}
@@ -0,0 +1,3 @@
+// Package metrics provides a writer that outputs review run metrics as JSON
+// and in Prometheus text format.
+package metrics
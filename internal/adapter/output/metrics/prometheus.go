@@ -0,0 +1,205 @@
+package metrics
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+)
+
+// metricPrefix namespaces every exported metric.
+const metricPrefix = "cr_"
+
+// breakerStateNames lists the circuit breaker states exported as labels.
+var breakerStateNames = []string{
+	llmhttp.BreakerClosed.String(),
+	llmhttp.BreakerOpen.String(),
+	llmhttp.BreakerHalfOpen.String(),
+}
+
+// sample is one value of a metric with its label name/value pairs.
+type sample struct {
+	labels []string
+	value  float64
+}
+
+// promBuilder renders metrics in the Prometheus text exposition format.
+type promBuilder struct {
+	sb strings.Builder
+}
+
+// gauge writes a gauge with its HELP and TYPE lines. Metrics without
+// samples are left out.
+func (b *promBuilder) gauge(name, help string, samples ...sample) {
+	if len(samples) == 0 {
+		return
+	}
+	name = metricPrefix + name
+	fmt.Fprintf(&b.sb, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(&b.sb, "# TYPE %s gauge\n", name)
+	for _, s := range samples {
+		b.sb.WriteString(name)
+		if len(s.labels) > 0 {
+			b.sb.WriteByte('{')
+			for i := 0; i+1 < len(s.labels); i += 2 {
+				if i > 0 {
+					b.sb.WriteByte(',')
+				}
+				fmt.Fprintf(&b.sb, "%s=\"%s\"", s.labels[i], escapeLabelValue(s.labels[i+1]))
+			}
+			b.sb.WriteByte('}')
+		}
+		b.sb.WriteByte(' ')
+		b.sb.WriteString(strconv.FormatFloat(s.value, 'g', -1, 64))
+		b.sb.WriteByte('\n')
+	}
+}
+
+func value(v float64) sample {
+	return sample{value: v}
+}
+
+// escapeLabelValue escapes a label value as the text format requires.
+func escapeLabelValue(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+// renderPrometheus renders a report as gauges describing the last run.
+func renderPrometheus(report Report, now time.Time) string {
+	var b promBuilder
+
+	b.gauge("review_last_run_timestamp_seconds", "Unix time the last review finished.", value(float64(now.Unix())))
+	b.gauge("review_duration_seconds", "Wall time of the last review.", value(report.DurationSeconds))
+
+	providers := make([]string, 0, len(report.Providers))
+	for name := range report.Providers {
+		providers = append(providers, name)
+	}
+	sort.Strings(providers)
+
+	var durations, tokens, costs, findings, fallbacks []sample
+	for _, name := range providers {
+		p := report.Providers[name]
+		labels := []string{"provider", name, "model", p.Model}
+		durations = append(durations, sample{labels, p.DurationSeconds})
+		tokens = append(tokens,
+			sample{[]string{"provider", name, "model", p.Model, "direction", "in"}, float64(p.TokensIn)},
+			sample{[]string{"provider", name, "model", p.Model, "direction", "out"}, float64(p.TokensOut)})
+		costs = append(costs, sample{labels, p.Cost})
+		findings = append(findings, sample{labels, float64(p.Findings)})
+		fallbacks = append(fallbacks, sample{labels, float64(len(p.FallbackFrom))})
+	}
+	b.gauge("provider_review_duration_seconds", "Time a provider took to review the diff.", durations...)
+	b.gauge("provider_tokens", "Tokens used by a provider's review.", tokens...)
+	b.gauge("provider_cost_usd", "Cost of a provider's review in USD.", costs...)
+	b.gauge("provider_findings", "Findings reported by a provider.", findings...)
+	b.gauge("provider_fallbacks", "Models that failed before the reported model produced the review.", fallbacks...)
+
+	b.gauge("merge_input_findings", "Findings across all provider reviews before merging.", value(float64(report.Merge.InputFindings)))
+	b.gauge("merge_findings", "Findings after merging similar ones.", value(float64(report.Merge.Findings)))
+
+	if v := report.Verification; v != nil {
+		b.gauge("verification_candidates", "Findings submitted for verification.", value(float64(v.Candidates)))
+		b.gauge("verification_verified", "Findings confirmed by verification.", value(float64(v.Verified)))
+		b.gauge("verification_reportable", "Verified findings above the confidence threshold.", value(float64(v.Reportable)))
+		b.gauge("verification_tool_calls", "Tools run by the verification agent.", value(float64(v.ToolCalls)))
+		b.gauge("verification_tokens", "Tokens used by verification.",
+			sample{[]string{"direction", "in"}, float64(v.TokensIn)},
+			sample{[]string{"direction", "out"}, float64(v.TokensOut)})
+		b.gauge("verification_cost_usd", "Cost of verification in USD.", value(v.Cost))
+	}
+
+	if p := report.Publish; p != nil {
+		b.gauge("publish_comments_posted", "Inline comments posted to the pull request.", value(float64(p.CommentsPosted)))
+		b.gauge("publish_comments_skipped", "Findings not posted because they are outside the diff.", value(float64(p.CommentsSkipped)))
+		b.gauge("publish_duplicates_skipped", "Findings not posted because an earlier review reported them.", value(float64(p.DuplicatesSkipped)))
+	}
+
+	if h := report.HTTP; h != nil {
+		names := make([]string, 0, len(h.ByProvider))
+		for name := range h.ByProvider {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var requests, httpTokens, httpCosts, httpDurations, errs []sample
+		var throttled, throttleWaits, breakerStates, breakerTrips []sample
+		for _, name := range names {
+			ps := h.ByProvider[name]
+			labels := []string{"provider", name}
+			requests = append(requests, sample{labels, float64(ps.Requests)})
+			httpTokens = append(httpTokens,
+				sample{[]string{"provider", name, "direction", "in"}, float64(ps.TokensIn)},
+				sample{[]string{"provider", name, "direction", "out"}, float64(ps.TokensOut)})
+			httpCosts = append(httpCosts, sample{labels, ps.Cost})
+			httpDurations = append(httpDurations, sample{labels, ps.DurationSeconds})
+			errs = append(errs, sample{labels, float64(ps.Errors)})
+			throttled = append(throttled, sample{labels, float64(ps.Throttled)})
+			throttleWaits = append(throttleWaits, sample{labels, ps.ThrottleWaitSeconds})
+			models := make([]string, 0, len(ps.Breakers))
+			for model := range ps.Breakers {
+				models = append(models, model)
+			}
+			sort.Strings(models)
+			for _, model := range models {
+				bs := ps.Breakers[model]
+				for _, state := range breakerStateNames {
+					current := 0.0
+					if state == bs.State {
+						current = 1
+					}
+					breakerStates = append(breakerStates, sample{[]string{"provider", name, "model", model, "state", state}, current})
+				}
+				breakerTrips = append(breakerTrips, sample{[]string{"provider", name, "model", model}, float64(bs.Trips)})
+			}
+		}
+		b.gauge("llm_requests", "LLM API requests made during the last review.", requests...)
+		b.gauge("llm_tokens", "Tokens used by LLM API requests.", httpTokens...)
+		b.gauge("llm_cost_usd", "Cost of LLM API requests in USD.", httpCosts...)
+		b.gauge("llm_request_duration_seconds", "Total time spent in LLM API requests.", httpDurations...)
+		b.gauge("llm_errors", "LLM API requests that failed.", errs...)
+		b.gauge("llm_throttled_requests", "LLM API requests held back by the client-side rate limiter.", throttled...)
+		b.gauge("llm_throttle_wait_seconds", "Total time requests waited for the client-side rate limiter.", throttleWaits...)
+		b.gauge("llm_circuit_breaker_state", "Circuit breaker state of a model (1 for the current state).", breakerStates...)
+		b.gauge("llm_circuit_breaker_trips", "Times a model's circuit breaker opened.", breakerTrips...)
+	}
+
+	return b.sb.String()
+}
+
+// writePrometheusFile writes the report for the node_exporter textfile
+// collector. The file is replaced atomically so a scrape never sees a
+// partial write.
+func writePrometheusFile(path string, report Report, now time.Time) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create metrics file directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create metrics file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	if _, err := tmp.WriteString(renderPrometheus(report, now)); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace metrics file: %w", err)
+	}
+	return nil
+}
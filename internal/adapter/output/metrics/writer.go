@@ -0,0 +1,263 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+	"github.com/bkyoung/code-reviewer/internal/usecase/review"
+)
+
+// FileName is the name of the metrics artifact in the run's output directory.
+const FileName = "metrics.json"
+
+// Writer implements the review.MetricsWriter interface.
+type Writer struct {
+	now   func() string
+	stats llmhttp.Metrics
+}
+
+// NewWriter creates a new metrics writer. stats supplies the HTTP client
+// statistics; when nil, the http section is left out. Runs scoped with
+// Scope report their own calls rather than the totals in stats.
+func NewWriter(now func() string, stats llmhttp.Metrics) *Writer {
+	return &Writer{now: now, stats: stats}
+}
+
+// Report is the metrics.json document.
+type Report struct {
+	GeneratedAt     string                    `json:"generatedAt"`
+	Repository      string                    `json:"repository"`
+	BaseRef         string                    `json:"baseRef"`
+	TargetRef       string                    `json:"targetRef"`
+	DurationSeconds float64                   `json:"durationSeconds"`
+	HTTP            *HTTPReport               `json:"http,omitempty"`
+	Providers       map[string]ProviderReport `json:"providers"`
+	Merge           MergeReport               `json:"merge"`
+	Verification    *VerificationReport       `json:"verification,omitempty"`
+	Publish         *PublishReport            `json:"publish,omitempty"`
+}
+
+// HTTPReport holds the LLM API call statistics, including calls made for
+// synthesis, verification and deduplication.
+type HTTPReport struct {
+	Requests        int                           `json:"requests"`
+	TokensIn        int                           `json:"tokensIn"`
+	TokensOut       int                           `json:"tokensOut"`
+	Cost            float64                       `json:"cost"`
+	DurationSeconds float64                       `json:"durationSeconds"`
+	Errors          int                           `json:"errors"`
+	ByProvider      map[string]HTTPProviderReport `json:"byProvider"`
+}
+
+// HTTPProviderReport holds the LLM API call statistics of one provider,
+// including what the client-side rate limiter and circuit breaker did.
+type HTTPProviderReport struct {
+	Requests            int     `json:"requests"`
+	TokensIn            int     `json:"tokensIn"`
+	TokensOut           int     `json:"tokensOut"`
+	Cost                float64 `json:"cost"`
+	DurationSeconds     float64 `json:"durationSeconds"`
+	Errors              int     `json:"errors"`
+	Throttled           int     `json:"throttled"`
+	ThrottleWaitSeconds float64 `json:"throttleWaitSeconds"`
+	// Breakers holds the circuit breaker of each model called.
+	Breakers map[string]BreakerReport `json:"breakers,omitempty"`
+}
+
+// BreakerReport describes the circuit breaker of one model.
+type BreakerReport struct {
+	State string `json:"state"`
+	Trips int    `json:"trips"`
+}
+
+// ProviderReport describes one provider's review. FallbackFrom lists the
+// models that failed before Model produced the review.
+type ProviderReport struct {
+	Model           string   `json:"model"`
+	FallbackFrom    []string `json:"fallbackFrom,omitempty"`
+	DurationSeconds float64  `json:"durationSeconds"`
+	TokensIn        int      `json:"tokensIn"`
+	TokensOut       int      `json:"tokensOut"`
+	Cost            float64  `json:"cost"`
+	Findings        int      `json:"findings"`
+}
+
+// MergeReport describes the merge of the provider reviews.
+type MergeReport struct {
+	InputFindings int `json:"inputFindings"`
+	Findings      int `json:"findings"`
+}
+
+// VerificationReport describes the verification stage.
+type VerificationReport struct {
+	Candidates int     `json:"candidates"`
+	Verified   int     `json:"verified"`
+	Reportable int     `json:"reportable"`
+	ToolCalls  int     `json:"toolCalls"`
+	TokensIn   int     `json:"tokensIn"`
+	TokensOut  int     `json:"tokensOut"`
+	Cost       float64 `json:"cost"`
+}
+
+// PublishReport describes the review posted to the code host.
+type PublishReport struct {
+	CommentsPosted    int `json:"commentsPosted"`
+	CommentsSkipped   int `json:"commentsSkipped"`
+	DuplicatesSkipped int `json:"duplicatesSkipped"`
+}
+
+// Scope returns a context that collects the LLM calls made under it, which
+// Write then reports instead of the process-wide totals. This keeps the
+// reports of concurrent runs (cr serve jobs) apart.
+func (w *Writer) Scope(ctx context.Context) context.Context {
+	if w.stats == nil {
+		return ctx
+	}
+	ctx, _ = llmhttp.WithRunMetrics(ctx)
+	return ctx
+}
+
+// Write persists the run metrics to metrics.json, and to the artifact's
+// PrometheusFile when one is set. It returns the path of metrics.json.
+func (w *Writer) Write(ctx context.Context, artifact review.MetricsArtifact) (string, error) {
+	report := w.buildReport(ctx, artifact)
+
+	outputDir := filepath.Join(artifact.OutputDir, fmt.Sprintf("%s_%s", artifact.Repository, artifact.TargetRef), w.now())
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	filePath := filepath.Join(outputDir, FileName)
+	file, err := os.Create(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create metrics file: %w", err)
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return "", fmt.Errorf("failed to encode metrics to json: %w", err)
+	}
+
+	if artifact.PrometheusFile != "" {
+		if err := writePrometheusFile(artifact.PrometheusFile, report, time.Now()); err != nil {
+			return "", err
+		}
+	}
+
+	return filePath, nil
+}
+
+// buildReport combines the run metrics with the HTTP client statistics.
+func (w *Writer) buildReport(ctx context.Context, artifact review.MetricsArtifact) Report {
+	run := artifact.Metrics
+	report := Report{
+		GeneratedAt:     time.Now().UTC().Format(time.RFC3339),
+		Repository:      artifact.Repository,
+		BaseRef:         artifact.BaseRef,
+		TargetRef:       artifact.TargetRef,
+		DurationSeconds: run.Duration.Seconds(),
+		Providers:       make(map[string]ProviderReport, len(run.Providers)),
+		Merge: MergeReport{
+			InputFindings: run.Merge.InputFindings,
+			Findings:      run.Merge.Findings,
+		},
+	}
+
+	for name, p := range run.Providers {
+		report.Providers[name] = ProviderReport{
+			Model:           p.Model,
+			FallbackFrom:    p.FallbackFrom,
+			DurationSeconds: p.Duration.Seconds(),
+			TokensIn:        p.TokensIn,
+			TokensOut:       p.TokensOut,
+			Cost:            p.Cost,
+			Findings:        p.Findings,
+		}
+	}
+
+	if run.Verification.Enabled {
+		report.Verification = &VerificationReport{
+			Candidates: run.Verification.Candidates,
+			Verified:   run.Verification.Verified,
+			Reportable: run.Verification.Reportable,
+			ToolCalls:  run.Verification.ToolCalls,
+			TokensIn:   run.Verification.TokensIn,
+			TokensOut:  run.Verification.TokensOut,
+			Cost:       run.Verification.Cost,
+		}
+	}
+
+	if run.Publish.Enabled {
+		report.Publish = &PublishReport{
+			CommentsPosted:    run.Publish.CommentsPosted,
+			CommentsSkipped:   run.Publish.CommentsSkipped,
+			DuplicatesSkipped: run.Publish.DuplicatesSkipped,
+		}
+	}
+
+	if w.stats != nil {
+		stats := w.httpStats(ctx)
+		report.HTTP = &HTTPReport{
+			Requests:        stats.TotalRequests,
+			TokensIn:        stats.TotalTokensIn,
+			TokensOut:       stats.TotalTokensOut,
+			Cost:            stats.TotalCost,
+			DurationSeconds: stats.TotalDuration.Seconds(),
+			Errors:          stats.ErrorCount,
+			ByProvider:      make(map[string]HTTPProviderReport, len(stats.ByProvider)),
+		}
+		for name, ps := range stats.ByProvider {
+			report.HTTP.ByProvider[name] = HTTPProviderReport{
+				Requests:        ps.Requests,
+				TokensIn:        ps.TokensIn,
+				TokensOut:       ps.TokensOut,
+				Cost:            ps.Cost,
+				DurationSeconds: ps.Duration.Seconds(),
+				Errors:          ps.Errors,
+
+				Throttled:           ps.Throttled,
+				ThrottleWaitSeconds: ps.ThrottleWait.Seconds(),
+			}
+			if len(ps.Breakers) > 0 {
+				pr := report.HTTP.ByProvider[name]
+				pr.Breakers = make(map[string]BreakerReport, len(ps.Breakers))
+				for model, bs := range ps.Breakers {
+					pr.Breakers[model] = BreakerReport{State: bs.State, Trips: bs.Trips}
+				}
+				report.HTTP.ByProvider[name] = pr
+			}
+		}
+	}
+
+	return report
+}
+
+// httpStats returns the run's HTTP statistics when ctx was scoped, else the
+// process-wide ones. Breaker states are process-wide either way: they
+// describe the provider, not one run, so they are taken from the shared stats.
+func (w *Writer) httpStats(ctx context.Context) llmhttp.Stats {
+	shared := w.stats.GetStats()
+	run, ok := llmhttp.RunMetricsFrom(ctx)
+	if !ok {
+		return shared
+	}
+
+	stats := run.GetStats()
+	for name, ps := range shared.ByProvider {
+		if len(ps.Breakers) == 0 {
+			continue
+		}
+		if rs, called := stats.ByProvider[name]; called {
+			rs.Breakers = ps.Breakers
+			stats.ByProvider[name] = rs
+		}
+	}
+	return stats
+}
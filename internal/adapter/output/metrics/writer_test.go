@@ -0,0 +1,158 @@
+package metrics_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+	"github.com/bkyoung/code-reviewer/internal/adapter/output/metrics"
+	"github.com/bkyoung/code-reviewer/internal/usecase/review"
+)
+
+func testArtifact(outputDir string) review.MetricsArtifact {
+	return review.MetricsArtifact{
+		OutputDir:  outputDir,
+		Repository: "repo",
+		BaseRef:    "main",
+		TargetRef:  "feature",
+		Metrics: review.RunMetrics{
+			Duration: 90 * time.Second,
+			Providers: map[string]review.ProviderMetrics{
+				"openai": {Model: "gpt-4o", FallbackFrom: []string{"gpt-5"}, Duration: 30 * time.Second, TokensIn: 1000, TokensOut: 200, Cost: 0.5, Findings: 4},
+			},
+			Merge:        review.MergeMetrics{InputFindings: 4, Findings: 3},
+			Verification: review.VerificationMetrics{Enabled: true, Candidates: 3, Verified: 2, Reportable: 1, ToolCalls: 5, Cost: 0.1},
+			Publish:      review.PublishMetrics{Enabled: true, CommentsPosted: 1, DuplicatesSkipped: 2},
+		},
+	}
+}
+
+func TestWriter_WritesMetricsJSON(t *testing.T) {
+	stats := llmhttp.NewDefaultMetrics()
+	stats.RecordRequest("openai", "gpt-4o")
+	stats.RecordTokens("openai", "gpt-4o", 1000, 200)
+	stats.RecordCost("openai", "gpt-4o", 0.5)
+	stats.RecordError("openai", "gpt-4o", llmhttp.ErrTypeRateLimit)
+
+	writer := metrics.NewWriter(func() string { return "20251020T120000Z" }, stats)
+	path, err := writer.Write(context.Background(), testArtifact(t.TempDir()))
+	require.NoError(t, err)
+	assert.Equal(t, "metrics.json", filepath.Base(path))
+	assert.Contains(t, path, filepath.Join("repo_feature", "20251020T120000Z"))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var report metrics.Report
+	require.NoError(t, json.Unmarshal(content, &report))
+	assert.Equal(t, 90.0, report.DurationSeconds)
+	assert.Equal(t, "gpt-4o", report.Providers["openai"].Model)
+	assert.Equal(t, 30.0, report.Providers["openai"].DurationSeconds)
+	assert.Equal(t, []string{"gpt-5"}, report.Providers["openai"].FallbackFrom)
+	assert.Equal(t, metrics.MergeReport{InputFindings: 4, Findings: 3}, report.Merge)
+	require.NotNil(t, report.Verification)
+	assert.Equal(t, 2, report.Verification.Verified)
+	assert.Equal(t, 5, report.Verification.ToolCalls)
+	require.NotNil(t, report.Publish)
+	assert.Equal(t, 2, report.Publish.DuplicatesSkipped)
+	require.NotNil(t, report.HTTP)
+	assert.Equal(t, 1, report.HTTP.Requests)
+	assert.Equal(t, 1, report.HTTP.ByProvider["openai"].Errors)
+	assert.Empty(t, report.HTTP.ByProvider["openai"].Breakers)
+}
+
+func TestWriter_ScopedRunReportsOwnCalls(t *testing.T) {
+	shared := llmhttp.NewDefaultMetrics()
+	shared.RecordBreakerState("openai", "gpt-4o", llmhttp.BreakerOpen)
+	writer := metrics.NewWriter(func() string { return "20251020T120000Z" }, shared)
+
+	// Two runs share the process-wide metrics, e.g. concurrent serve jobs
+	ctxA := writer.Scope(context.Background())
+	ctxB := writer.Scope(context.Background())
+	llmhttp.ScopedMetrics(ctxA, shared).RecordRequest("openai", "gpt-4o")
+	llmhttp.ScopedMetrics(ctxB, shared).RecordRequest("openai", "gpt-4o")
+	llmhttp.ScopedMetrics(ctxB, shared).RecordRequest("openai", "gpt-4o")
+
+	path, err := writer.Write(ctxA, testArtifact(t.TempDir()))
+	require.NoError(t, err)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var report metrics.Report
+	require.NoError(t, json.Unmarshal(content, &report))
+	require.NotNil(t, report.HTTP)
+	assert.Equal(t, 1, report.HTTP.Requests, "only the run's own calls are reported")
+	assert.Equal(t, 1, report.HTTP.ByProvider["openai"].Requests)
+	assert.Equal(t, metrics.BreakerReport{State: "open", Trips: 1}, report.HTTP.ByProvider["openai"].Breakers["gpt-4o"],
+		"breaker state is shared by all runs")
+	assert.Equal(t, 3, shared.GetStats().TotalRequests)
+}
+
+func TestWriter_OmitsSkippedStages(t *testing.T) {
+	artifact := testArtifact(t.TempDir())
+	artifact.Metrics.Verification = review.VerificationMetrics{}
+	artifact.Metrics.Publish = review.PublishMetrics{}
+
+	writer := metrics.NewWriter(func() string { return "20251020T120000Z" }, nil)
+	path, err := writer.Write(context.Background(), artifact)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	for _, key := range []string{`"verification"`, `"publish"`, `"http"`} {
+		assert.NotContains(t, string(content), key)
+	}
+}
+
+func TestWriter_WritesPrometheusFile(t *testing.T) {
+	promDir := filepath.Join(t.TempDir(), "textfile")
+	artifact := testArtifact(t.TempDir())
+	artifact.PrometheusFile = filepath.Join(promDir, "cr.prom")
+
+	stats := llmhttp.NewDefaultMetrics()
+	stats.RecordRequest("openai", "gpt-4o")
+	stats.RecordThrottle("openai", 1500*time.Millisecond)
+	stats.RecordBreakerState("openai", "gpt-4o", llmhttp.BreakerOpen)
+
+	writer := metrics.NewWriter(func() string { return "20251020T120000Z" }, stats)
+	_, err := writer.Write(context.Background(), artifact)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(artifact.PrometheusFile)
+	require.NoError(t, err)
+	text := string(content)
+
+	for _, line := range []string{
+		"# TYPE cr_review_duration_seconds gauge",
+		"cr_review_duration_seconds 90",
+		`cr_provider_tokens{provider="openai",model="gpt-4o",direction="in"} 1000`,
+		`cr_provider_cost_usd{provider="openai",model="gpt-4o"} 0.5`,
+		`cr_provider_fallbacks{provider="openai",model="gpt-4o"} 1`,
+		"cr_merge_findings 3",
+		"cr_verification_verified 2",
+		"cr_verification_tool_calls 5",
+		"cr_publish_duplicates_skipped 2",
+		`cr_llm_requests{provider="openai"} 1`,
+		`cr_llm_throttle_wait_seconds{provider="openai"} 1.5`,
+		`cr_llm_circuit_breaker_state{provider="openai",model="gpt-4o",state="closed"} 0`,
+		`cr_llm_circuit_breaker_state{provider="openai",model="gpt-4o",state="open"} 1`,
+		`cr_llm_circuit_breaker_trips{provider="openai",model="gpt-4o"} 1`,
+	} {
+		assert.Contains(t, text, line+"\n")
+	}
+	assert.True(t, strings.HasSuffix(text, "\n"))
+
+	// Only the final file is left for the collector to read
+	entries, err := os.ReadDir(promDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "cr.prom", entries[0].Name())
+}
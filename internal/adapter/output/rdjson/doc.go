@@ -0,0 +1,3 @@
+// Package rdjson provides writers that output reviews in reviewdog's
+// Diagnostic Format, as a single rdjson document or as rdjsonl lines.
+package rdjson
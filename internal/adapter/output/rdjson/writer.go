@@ -0,0 +1,180 @@
+package rdjson
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/review"
+)
+
+// sourceName identifies the tool in reviewdog's output.
+const sourceName = "cr"
+
+// Writer implements the review.ReportWriter interface.
+type Writer struct {
+	now   func() string
+	lines bool // Write rdjsonl, one diagnostic per line
+}
+
+// NewWriter creates a writer producing a single rdjson document.
+func NewWriter(now func() string) *Writer {
+	return &Writer{now: now}
+}
+
+// NewLinesWriter creates a writer producing rdjsonl, one diagnostic per line.
+func NewLinesWriter(now func() string) *Writer {
+	return &Writer{now: now, lines: true}
+}
+
+// DiagnosticResult is an rdjson document.
+type DiagnosticResult struct {
+	Source      Source       `json:"source"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// Diagnostic is one finding in reviewdog's format.
+type Diagnostic struct {
+	Message     string       `json:"message"`
+	Location    Location     `json:"location"`
+	Severity    string       `json:"severity"`
+	Source      Source       `json:"source"`
+	Code        *Code        `json:"code,omitempty"`
+	Suggestions []Suggestion `json:"suggestions,omitempty"`
+}
+
+// Source names the tool that produced a diagnostic.
+type Source struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// Location is the file and range of a diagnostic.
+type Location struct {
+	Path  string `json:"path"`
+	Range *Range `json:"range,omitempty"`
+}
+
+// Range spans whole lines when columns are left out.
+type Range struct {
+	Start Position  `json:"start"`
+	End   *Position `json:"end,omitempty"`
+}
+
+// Position is a 1-based line and optional column.
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column,omitempty"`
+}
+
+// Code is the rule of a diagnostic.
+type Code struct {
+	Value string `json:"value"`
+}
+
+// Suggestion replaces Range with Text.
+type Suggestion struct {
+	Range Range  `json:"range"`
+	Text  string `json:"text"`
+}
+
+// Write persists a review to disk as rdjson or rdjsonl.
+func (w *Writer) Write(ctx context.Context, artifact review.ReportArtifact) (string, error) {
+	outputDir := filepath.Join(artifact.OutputDir, fmt.Sprintf("%s_%s", artifact.Repository, artifact.TargetRef), w.now())
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	ext := "rdjson"
+	if w.lines {
+		ext = "rdjsonl"
+	}
+	filePath := filepath.Join(outputDir, fmt.Sprintf("review-%s.%s", artifact.ProviderName, ext))
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s file: %w", ext, err)
+	}
+	defer file.Close()
+
+	diagnostics := convertToDiagnostics(artifact.Review)
+	encoder := json.NewEncoder(file)
+
+	if w.lines {
+		for _, d := range diagnostics {
+			if err := encoder.Encode(d); err != nil {
+				return "", fmt.Errorf("failed to encode review to %s: %w", ext, err)
+			}
+		}
+		return filePath, nil
+	}
+
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(DiagnosticResult{Source: source(), Diagnostics: diagnostics}); err != nil {
+		return "", fmt.Errorf("failed to encode review to %s: %w", ext, err)
+	}
+	return filePath, nil
+}
+
+func source() Source {
+	return Source{Name: sourceName, URL: "https://github.com/bkyoung/code-reviewer"}
+}
+
+// convertToDiagnostics converts a review's findings to diagnostics. The
+// result is never nil, so an empty review encodes as [].
+func convertToDiagnostics(r domain.Review) []Diagnostic {
+	diagnostics := make([]Diagnostic, 0, len(r.Findings))
+	for _, finding := range r.Findings {
+		message := finding.Description
+		if message == "" {
+			message = "No description provided"
+		}
+
+		d := Diagnostic{
+			Message:  message,
+			Location: Location{Path: finding.File},
+			Severity: severity(finding.Severity),
+			Source:   source(),
+		}
+		if finding.Category != "" {
+			d.Code = &Code{Value: finding.Category}
+		}
+
+		if finding.LineStart >= 1 {
+			lines := lineRange(finding)
+			d.Location.Range = &lines
+			if code, _, ok := finding.Replacement(); ok && finding.File != "" {
+				d.Suggestions = []Suggestion{{Range: lines, Text: strings.TrimSuffix(code, "\n")}}
+			}
+		}
+		diagnostics = append(diagnostics, d)
+	}
+	return diagnostics
+}
+
+// lineRange covers the finding's lines.
+func lineRange(f domain.Finding) Range {
+	r := Range{Start: Position{Line: f.LineStart}}
+	if f.LineEnd > f.LineStart {
+		r.End = &Position{Line: f.LineEnd}
+	}
+	return r
+}
+
+// severity maps our severities to reviewdog's ERROR, WARNING and INFO.
+func severity(s string) string {
+	switch strings.ToLower(s) {
+	case "critical", "high":
+		return "ERROR"
+	case "medium":
+		return "WARNING"
+	case "low":
+		return "INFO"
+	default:
+		return "WARNING"
+	}
+}
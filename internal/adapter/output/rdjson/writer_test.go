@@ -0,0 +1,79 @@
+package rdjson_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/output/rdjson"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/review"
+)
+
+var testFindings = []domain.Finding{
+	{File: "loop.go", LineStart: 3, LineEnd: 4, Severity: "high", Category: "bug", Description: "Off by one",
+		Suggestion: "Use an inclusive bound.\n```suggestion\nfor i := 0; i <= n; i++ {\n```"},
+	{File: "util.go", LineStart: 8, Severity: "low", Description: "Long function", Suggestion: "Split it"},
+}
+
+func write(t *testing.T, writer *rdjson.Writer) (string, []byte) {
+	t.Helper()
+	path, err := writer.Write(context.Background(), review.ReportArtifact{
+		OutputDir:    t.TempDir(),
+		Repository:   "repo",
+		TargetRef:    "feature",
+		Review:       domain.Review{Findings: testFindings},
+		ProviderName: "merged",
+	})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return path, content
+}
+
+func TestWriter_Write_RDJSON(t *testing.T) {
+	path, content := write(t, rdjson.NewWriter(func() string { return "20251020T120000Z" }))
+	assert.Equal(t, "review-merged.rdjson", filepath.Base(path))
+
+	var result rdjson.DiagnosticResult
+	require.NoError(t, json.Unmarshal(content, &result))
+	assert.Equal(t, "cr", result.Source.Name)
+	require.Len(t, result.Diagnostics, 2)
+
+	d := result.Diagnostics[0]
+	assert.Equal(t, "Off by one", d.Message)
+	assert.Equal(t, "ERROR", d.Severity)
+	assert.Equal(t, "bug", d.Code.Value)
+	lines := rdjson.Range{Start: rdjson.Position{Line: 3}, End: &rdjson.Position{Line: 4}}
+	assert.Equal(t, rdjson.Location{Path: "loop.go", Range: &lines}, d.Location)
+	assert.Equal(t, []rdjson.Suggestion{{Range: lines, Text: "for i := 0; i <= n; i++ {"}}, d.Suggestions)
+
+	// Prose-only suggestions have nothing for reviewdog to apply
+	assert.Empty(t, result.Diagnostics[1].Suggestions)
+	assert.Equal(t, "INFO", result.Diagnostics[1].Severity)
+	assert.Nil(t, result.Diagnostics[1].Code)
+}
+
+func TestWriter_Write_RDJSONL(t *testing.T) {
+	path, content := write(t, rdjson.NewLinesWriter(func() string { return "20251020T120000Z" }))
+	assert.Equal(t, "review-merged.rdjsonl", filepath.Base(path))
+
+	var diagnostics []rdjson.Diagnostic
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		var d rdjson.Diagnostic
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &d))
+		diagnostics = append(diagnostics, d)
+	}
+	require.Len(t, diagnostics, 2)
+	assert.Equal(t, "Off by one", diagnostics[0].Message)
+	assert.Equal(t, "Long function", diagnostics[1].Message)
+}
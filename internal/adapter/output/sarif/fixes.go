@@ -0,0 +1,40 @@
+package sarif
+
+import (
+	"github.com/bkyoung/code-reviewer/internal/domain"
+)
+
+// buildFixes returns the SARIF fixes of a finding: a replacement of its
+// lines when the suggestion carries concrete code, nil otherwise.
+func buildFixes(finding domain.Finding) []map[string]interface{} {
+	if finding.File == "" || finding.LineStart < 1 {
+		return nil
+	}
+	code, description, ok := finding.Replacement()
+	if !ok {
+		return nil
+	}
+	if description == "" {
+		description = "Apply the suggested change"
+	}
+
+	return []map[string]interface{}{
+		{
+			"description": map[string]interface{}{"text": description},
+			"artifactChanges": []map[string]interface{}{
+				{
+					"artifactLocation": map[string]interface{}{"uri": finding.File},
+					"replacements": []map[string]interface{}{
+						{
+							"deletedRegion": map[string]interface{}{
+								"startLine": finding.LineStart,
+								"endLine":   max(finding.LineEnd, finding.LineStart),
+							},
+							"insertedContent": map[string]interface{}{"text": code},
+						},
+					},
+				},
+			},
+		},
+	}
+}
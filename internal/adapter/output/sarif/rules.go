@@ -0,0 +1,102 @@
+package sarif
+
+import (
+	"sort"
+	"strings"
+)
+
+// defaultRuleID is the rule of findings without a category.
+const defaultRuleID = "code-review"
+
+// ruleDescription describes a finding category as a SARIF rule.
+type ruleDescription struct {
+	short string
+	full  string
+}
+
+// ruleDescriptions covers the categories the review prompt asks for.
+// Other categories get a generic description.
+var ruleDescriptions = map[string]ruleDescription{
+	"security": {
+		"Security vulnerability",
+		"Code that can be exploited, leaks secrets or weakens authentication, authorization or input validation.",
+	},
+	"bug": {
+		"Bug",
+		"Code that does not behave as intended, such as logic errors, nil dereferences, races or off-by-one errors.",
+	},
+	"performance": {
+		"Performance problem",
+		"Code that wastes time or memory, such as needless allocations, repeated work or unbounded growth.",
+	},
+	"maintainability": {
+		"Maintainability issue",
+		"Code that is hard to read, change or reuse, such as duplication, unclear naming or excessive complexity.",
+	},
+	"test_coverage": {
+		"Missing test coverage",
+		"Changed behavior that no test exercises.",
+	},
+	"error_handling": {
+		"Error handling issue",
+		"Errors that are ignored, lost, reported without context or handled in a way that hides failures.",
+	},
+	"architecture": {
+		"Architecture issue",
+		"Changes that break the layering, dependencies or boundaries of the codebase.",
+	},
+	defaultRuleID: {
+		"Code review finding",
+		"A finding from the multi-LLM code review that has no specific category.",
+	},
+}
+
+// ruleID returns the rule of a finding category.
+func ruleID(category string) string {
+	if category == "" {
+		return defaultRuleID
+	}
+	return category
+}
+
+// buildRules returns one rule per rule ID, sorted by ID, and the index of
+// each rule for the results' ruleIndex.
+func buildRules(ids map[string]bool) ([]map[string]interface{}, map[string]int) {
+	sorted := make([]string, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+
+	rules := make([]map[string]interface{}, 0, len(sorted))
+	indexes := make(map[string]int, len(sorted))
+	for i, id := range sorted {
+		desc, ok := ruleDescriptions[id]
+		if !ok {
+			desc = ruleDescription{
+				short: strings.ReplaceAll(id, "_", " ") + " finding",
+				full:  "Findings in the " + id + " category of the multi-LLM code review.",
+			}
+		}
+		rules = append(rules, map[string]interface{}{
+			"id":               id,
+			"name":             ruleName(id),
+			"shortDescription": map[string]interface{}{"text": desc.short},
+			"fullDescription":  map[string]interface{}{"text": desc.full},
+			"properties":       map[string]interface{}{"tags": []string{"code-review", id}},
+		})
+		indexes[id] = i
+	}
+	return rules, indexes
+}
+
+// ruleName converts a rule ID to the PascalCase name SARIF recommends,
+// e.g. error_handling becomes ErrorHandling.
+func ruleName(id string) string {
+	var sb strings.Builder
+	for _, word := range strings.FieldsFunc(id, func(r rune) bool { return r == '_' || r == '-' || r == ' ' }) {
+		sb.WriteString(strings.ToUpper(word[:1]))
+		sb.WriteString(word[1:])
+	}
+	return sb.String()
+}
@@ -7,11 +7,18 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/bkyoung/code-reviewer/internal/domain"
 	"github.com/bkyoung/code-reviewer/internal/usecase/review"
+	"github.com/bkyoung/code-reviewer/internal/version"
 )
 
+// fingerprintKey names the finding fingerprint in partialFingerprints. The
+// version suffix lets the fingerprint scheme change without clashing with
+// alerts tracked under the old one.
+const fingerprintKey = "findingFingerprint/v1"
+
 // Writer implements the review.SARIFWriter interface.
 type Writer struct {
 	now func() string
@@ -51,6 +58,13 @@ func (w *Writer) Write(ctx context.Context, artifact review.SARIFArtifact) (stri
 
 // convertToSARIF converts a domain.Review to SARIF format.
 func (w *Writer) convertToSARIF(artifact review.SARIFArtifact) map[string]interface{} {
+	ruleIDs := map[string]bool{}
+	for _, finding := range artifact.Review.Findings {
+		ruleIDs[ruleID(finding.Category)] = true
+	}
+	rules, ruleIndexes := buildRules(ruleIDs)
+	confidences := verificationConfidences(artifact.Review)
+
 	results := make([]map[string]interface{}, 0, len(artifact.Review.Findings))
 
 	for _, finding := range artifact.Review.Findings {
@@ -60,18 +74,19 @@ func (w *Writer) convertToSARIF(artifact review.SARIFArtifact) map[string]interf
 			messageText = "No description provided"
 		}
 
-		// Use a valid ruleId (category or fallback)
-		ruleID := finding.Category
-		if ruleID == "" {
-			ruleID = "code-review"
-		}
-
+		id := ruleID(finding.Category)
 		result := map[string]interface{}{
-			"ruleId": ruleID,
-			"level":  convertSeverity(finding.Severity),
+			"ruleId":    id,
+			"ruleIndex": ruleIndexes[id],
+			"level":     convertSeverity(finding.Severity),
 			"message": map[string]interface{}{
 				"text": messageText,
 			},
+			// Lets code scanning track the alert across runs even when
+			// unrelated changes move its lines
+			"partialFingerprints": map[string]interface{}{
+				fingerprintKey: string(domain.FingerprintFromFinding(finding)),
+			},
 		}
 
 		// Build location only if we have meaningful file info
@@ -102,7 +117,17 @@ func (w *Writer) convertToSARIF(artifact review.SARIFArtifact) map[string]interf
 			}
 		}
 
-		// Add suggestion as a property (fixes requires artifactChanges which we don't have)
+		// SARIF rank is a 0-100 priority, which verification confidence maps onto
+		if confidence, ok := confidences[finding.ID]; ok {
+			result["rank"] = float64(confidence)
+		}
+
+		if fixes := buildFixes(finding); fixes != nil {
+			result["fixes"] = fixes
+		}
+
+		// The suggestion text is kept as a property for consumers that do
+		// not read fixes, and for suggestions without concrete code
 		if finding.Suggestion != "" {
 			result["properties"] = map[string]interface{}{
 				"suggestion": finding.Suggestion,
@@ -121,16 +146,9 @@ func (w *Writer) convertToSARIF(artifact review.SARIFArtifact) map[string]interf
 					"driver": map[string]interface{}{
 						"name":            artifact.ProviderName,
 						"informationUri":  "https://github.com/bkyoung/code-reviewer",
-						"version":         "1.0.0",
-						"semanticVersion": "1.0.0",
-						"rules": []map[string]interface{}{
-							{
-								"id":               "code-review",
-								"name":             "CodeReview",
-								"shortDescription": map[string]interface{}{"text": "AI-powered code review findings"},
-								"fullDescription":  map[string]interface{}{"text": "Findings from multi-LLM code review analysis"},
-							},
-						},
+						"version":         version.Value(),
+						"semanticVersion": strings.TrimPrefix(version.Value(), "v"),
+						"rules":           rules,
 					},
 				},
 				"results":    results,
@@ -140,6 +158,19 @@ func (w *Writer) convertToSARIF(artifact review.SARIFArtifact) map[string]interf
 	}
 }
 
+// verificationConfidences maps finding IDs to their verification
+// confidence. It is empty when the review was not verified.
+func verificationConfidences(r domain.Review) map[string]int {
+	confidences := make(map[string]int, len(r.VerifiedFindings))
+	for _, vf := range r.VerifiedFindings {
+		if vf.Finding.ID == "" {
+			continue
+		}
+		confidences[vf.Finding.ID] = min(max(vf.Confidence, 0), 100)
+	}
+	return confidences
+}
+
 // buildProperties creates the properties map for SARIF run, validating cost.
 func buildProperties(review domain.Review) map[string]interface{} {
 	properties := map[string]interface{}{
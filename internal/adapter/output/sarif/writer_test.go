@@ -282,3 +282,109 @@ func TestWriter_Write_HandlesInvalidCost(t *testing.T) {
 		})
 	}
 }
+
+func writeSARIFRun(t *testing.T, r domain.Review) map[string]interface{} {
+	t.Helper()
+	writer := sarif.NewWriter(func() string { return "2025-10-20T12-00-00" })
+	path, err := writer.Write(context.Background(), review.SARIFArtifact{
+		OutputDir:    t.TempDir(),
+		Repository:   "test-repo",
+		BaseRef:      "main",
+		TargetRef:    "feature",
+		Review:       r,
+		ProviderName: "merged",
+	})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var sarifDoc map[string]interface{}
+	require.NoError(t, json.Unmarshal(content, &sarifDoc))
+	return sarifDoc["runs"].([]interface{})[0].(map[string]interface{})
+}
+
+func TestWriter_Write_DeclaresRulePerCategory(t *testing.T) {
+	run := writeSARIFRun(t, domain.Review{Findings: []domain.Finding{
+		{File: "a.go", LineStart: 1, Severity: "high", Category: "security", Description: "Injection"},
+		{File: "b.go", LineStart: 2, Severity: "low", Category: "error_handling", Description: "Ignored error"},
+		{File: "c.go", LineStart: 3, Severity: "low", Category: "security", Description: "Weak hash"},
+		{File: "d.go", LineStart: 4, Severity: "low", Description: "Uncategorized"},
+	}})
+
+	driver := run["tool"].(map[string]interface{})["driver"].(map[string]interface{})
+	assert.Equal(t, "v0.0.0", driver["version"])
+	assert.Equal(t, "0.0.0", driver["semanticVersion"])
+
+	rules := driver["rules"].([]interface{})
+	require.Len(t, rules, 3)
+	ids := make([]string, len(rules))
+	for i, r := range rules {
+		rule := r.(map[string]interface{})
+		ids[i] = rule["id"].(string)
+		assert.NotEmpty(t, rule["shortDescription"].(map[string]interface{})["text"])
+		assert.NotEmpty(t, rule["fullDescription"].(map[string]interface{})["text"])
+	}
+	assert.Equal(t, []string{"code-review", "error_handling", "security"}, ids)
+	assert.Equal(t, "ErrorHandling", rules[1].(map[string]interface{})["name"])
+
+	// Each result points at its rule
+	for _, r := range run["results"].([]interface{}) {
+		result := r.(map[string]interface{})
+		index := int(result["ruleIndex"].(float64))
+		assert.Equal(t, ids[index], result["ruleId"])
+	}
+}
+
+func TestWriter_Write_SetsPartialFingerprints(t *testing.T) {
+	finding := domain.Finding{File: "a.go", LineStart: 10, Severity: "high", Category: "bug", Description: "Nil dereference"}
+	moved := finding
+	moved.LineStart = 42
+
+	fingerprint := func(f domain.Finding) string {
+		result := writeSARIFRun(t, domain.Review{Findings: []domain.Finding{f}})["results"].([]interface{})[0].(map[string]interface{})
+		return result["partialFingerprints"].(map[string]interface{})["findingFingerprint/v1"].(string)
+	}
+
+	assert.Equal(t, string(domain.FingerprintFromFinding(finding)), fingerprint(finding))
+	assert.Equal(t, fingerprint(finding), fingerprint(moved), "fingerprint must survive line shifts")
+}
+
+func TestWriter_Write_EmitsFixesForConcreteReplacements(t *testing.T) {
+	run := writeSARIFRun(t, domain.Review{Findings: []domain.Finding{
+		{File: "a.go", LineStart: 3, LineEnd: 4, Severity: "medium", Category: "bug", Description: "Wrong bound",
+			Suggestion: "Use an inclusive bound.\n```suggestion\nfor i := 0; i <= n; i++ {\n```"},
+		{File: "b.go", LineStart: 7, Severity: "low", Category: "bug", Description: "Vague", Suggestion: "Consider refactoring"},
+	}})
+	results := run["results"].([]interface{})
+
+	withFix := results[0].(map[string]interface{})
+	fixes := withFix["fixes"].([]interface{})
+	require.Len(t, fixes, 1)
+	fix := fixes[0].(map[string]interface{})
+	assert.Equal(t, "Use an inclusive bound.", fix["description"].(map[string]interface{})["text"])
+
+	change := fix["artifactChanges"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "a.go", change["artifactLocation"].(map[string]interface{})["uri"])
+	replacement := change["replacements"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"startLine": float64(3), "endLine": float64(4)}, replacement["deletedRegion"])
+	assert.Equal(t, "for i := 0; i <= n; i++ {\n", replacement["insertedContent"].(map[string]interface{})["text"])
+
+	withoutFix := results[1].(map[string]interface{})
+	assert.NotContains(t, withoutFix, "fixes")
+	assert.Equal(t, "Consider refactoring", withoutFix["properties"].(map[string]interface{})["suggestion"])
+}
+
+func TestWriter_Write_RanksByVerificationConfidence(t *testing.T) {
+	verified := domain.NewFinding(domain.FindingInput{File: "a.go", LineStart: 1, Severity: "high", Category: "bug", Description: "Verified"})
+	unverified := domain.NewFinding(domain.FindingInput{File: "b.go", LineStart: 1, Severity: "high", Category: "bug", Description: "Unverified"})
+
+	run := writeSARIFRun(t, domain.Review{
+		Findings:         []domain.Finding{verified, unverified},
+		VerifiedFindings: []domain.VerifiedFinding{{Finding: verified, Verified: true, Confidence: 85}},
+	})
+	results := run["results"].([]interface{})
+
+	assert.Equal(t, 85.0, results[0].(map[string]interface{})["rank"])
+	assert.NotContains(t, results[1].(map[string]interface{}), "rank")
+}
@@ -2,6 +2,8 @@ package store_test
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"testing"
 	"time"
 
@@ -17,6 +19,7 @@ type mockStore struct {
 	runs     []store.Run
 	reviews  []store.ReviewRecord
 	findings []store.FindingRecord
+	jobs     []store.JobRecord
 	closed   bool
 }
 
@@ -85,6 +88,36 @@ func (m *mockStore) UpdatePrecisionPrior(ctx context.Context, provider, category
 	return nil
 }
 
+func (m *mockStore) SaveJob(ctx context.Context, job store.JobRecord) error {
+	for i := range m.jobs {
+		if m.jobs[i].JobID == job.JobID {
+			m.jobs[i] = job
+			return nil
+		}
+	}
+	m.jobs = append(m.jobs, job)
+	return nil
+}
+
+func (m *mockStore) GetJob(ctx context.Context, jobID string) (store.JobRecord, error) {
+	for _, job := range m.jobs {
+		if job.JobID == jobID {
+			return job, nil
+		}
+	}
+	return store.JobRecord{}, fmt.Errorf("%w: %s", store.ErrJobNotFound, jobID)
+}
+
+func (m *mockStore) ListJobs(ctx context.Context, statuses ...string) ([]store.JobRecord, error) {
+	var jobs []store.JobRecord
+	for _, job := range m.jobs {
+		if slices.Contains(statuses, job.Status) {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
 func (m *mockStore) Close() error {
 	m.closed = true
 	return nil
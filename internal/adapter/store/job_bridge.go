@@ -0,0 +1,105 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/bkyoung/code-reviewer/internal/store"
+	"github.com/bkyoung/code-reviewer/internal/usecase/serve"
+)
+
+// JobBridge adapts store.Store to the serve.JobStore interface, storing
+// each job's request and result as JSON.
+type JobBridge struct {
+	store store.Store
+}
+
+// NewJobBridge creates a new job store adapter.
+func NewJobBridge(s store.Store) *JobBridge {
+	return &JobBridge{store: s}
+}
+
+// SaveJob converts and saves a job.
+func (b *JobBridge) SaveJob(ctx context.Context, job serve.Job) error {
+	request, err := json.Marshal(job.Request)
+	if err != nil {
+		return fmt.Errorf("failed to encode job request: %w", err)
+	}
+
+	record := store.JobRecord{
+		JobID:      job.ID,
+		Status:     string(job.Status),
+		Request:    string(request),
+		Error:      job.Error,
+		CreatedAt:  job.CreatedAt,
+		StartedAt:  job.StartedAt,
+		FinishedAt: job.FinishedAt,
+	}
+	if job.Result != nil {
+		result, err := json.Marshal(job.Result)
+		if err != nil {
+			return fmt.Errorf("failed to encode job result: %w", err)
+		}
+		record.Result = string(result)
+	}
+
+	return b.store.SaveJob(ctx, record)
+}
+
+// GetJob retrieves and converts a job.
+func (b *JobBridge) GetJob(ctx context.Context, id string) (serve.Job, error) {
+	record, err := b.store.GetJob(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrJobNotFound) {
+			return serve.Job{}, serve.ErrJobNotFound
+		}
+		return serve.Job{}, err
+	}
+	return jobFromRecord(record)
+}
+
+// ListJobs retrieves and converts the jobs with any of the statuses.
+func (b *JobBridge) ListJobs(ctx context.Context, statuses ...serve.Status) ([]serve.Job, error) {
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = string(status)
+	}
+
+	records, err := b.store.ListJobs(ctx, names...)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]serve.Job, 0, len(records))
+	for _, record := range records {
+		job, err := jobFromRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func jobFromRecord(record store.JobRecord) (serve.Job, error) {
+	job := serve.Job{
+		ID:         record.JobID,
+		Status:     serve.Status(record.Status),
+		Error:      record.Error,
+		CreatedAt:  record.CreatedAt,
+		StartedAt:  record.StartedAt,
+		FinishedAt: record.FinishedAt,
+	}
+	if err := json.Unmarshal([]byte(record.Request), &job.Request); err != nil {
+		return serve.Job{}, fmt.Errorf("failed to decode request of job %s: %w", record.JobID, err)
+	}
+	if record.Result != "" {
+		job.Result = &serve.Result{}
+		if err := json.Unmarshal([]byte(record.Result), job.Result); err != nil {
+			return serve.Job{}, fmt.Errorf("failed to decode result of job %s: %w", record.JobID, err)
+		}
+	}
+	return job, nil
+}
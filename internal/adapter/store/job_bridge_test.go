@@ -0,0 +1,81 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storeAdapter "github.com/bkyoung/code-reviewer/internal/adapter/store"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/review"
+	"github.com/bkyoung/code-reviewer/internal/usecase/serve"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobBridge_RoundTrip(t *testing.T) {
+	mock := &mockStore{}
+	bridge := storeAdapter.NewJobBridge(mock)
+	ctx := context.Background()
+
+	now := time.Now()
+	job := serve.Job{
+		ID:     "job-1",
+		Status: serve.StatusSucceeded,
+		Request: review.BranchRequest{
+			BaseRef:   "patch",
+			TargetRef: "fix.patch",
+			Formats:   []string{"json"},
+			Diff:      &domain.Diff{Files: []domain.FileDiff{{Path: "main.go", Patch: "@@ -1 +1 @@"}}},
+		},
+		Result: &serve.Result{
+			Review:  domain.Review{ProviderName: "merged", Findings: []domain.Finding{{File: "main.go", Severity: "high"}}},
+			Paths:   map[string]map[string]string{"json": {"merged": "out/review.json"}},
+			BaseSHA: "abc",
+		},
+		CreatedAt:  now,
+		FinishedAt: now,
+	}
+	require.NoError(t, bridge.SaveJob(ctx, job))
+
+	require.Len(t, mock.jobs, 1)
+	assert.Equal(t, "succeeded", mock.jobs[0].Status)
+	assert.Contains(t, mock.jobs[0].Request, `"TargetRef":"fix.patch"`)
+
+	retrieved, err := bridge.GetJob(ctx, "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, job.Request, retrieved.Request)
+	require.NotNil(t, retrieved.Result)
+	assert.Equal(t, "merged", retrieved.Result.Review.ProviderName)
+	assert.Equal(t, "high", retrieved.Result.Review.Findings[0].Severity)
+	assert.Equal(t, "out/review.json", retrieved.Result.Paths["json"]["merged"])
+	assert.Equal(t, "abc", retrieved.Result.BaseSHA)
+}
+
+func TestJobBridge_GetJob_NotFound(t *testing.T) {
+	bridge := storeAdapter.NewJobBridge(&mockStore{})
+
+	_, err := bridge.GetJob(context.Background(), "job-missing")
+	assert.ErrorIs(t, err, serve.ErrJobNotFound)
+}
+
+func TestJobBridge_ListJobs(t *testing.T) {
+	mock := &mockStore{}
+	bridge := storeAdapter.NewJobBridge(mock)
+	ctx := context.Background()
+
+	for _, job := range []serve.Job{
+		{ID: "job-1", Status: serve.StatusRunning},
+		{ID: "job-2", Status: serve.StatusFailed, Error: "boom"},
+		{ID: "job-3", Status: serve.StatusQueued},
+	} {
+		require.NoError(t, bridge.SaveJob(ctx, job))
+	}
+
+	jobs, err := bridge.ListJobs(ctx, serve.StatusQueued, serve.StatusRunning)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, "job-1", jobs[0].ID)
+	assert.Nil(t, jobs[0].Result)
+	assert.Equal(t, "job-3", jobs[1].ID)
+}
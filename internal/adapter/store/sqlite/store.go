@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/bkyoung/code-reviewer/internal/store"
@@ -98,12 +99,25 @@ func (s *Store) createSchema() error {
 		UNIQUE(provider, category)
 	);
 
+	-- Review jobs submitted to the HTTP API
+	CREATE TABLE IF NOT EXISTS jobs (
+		job_id TEXT PRIMARY KEY,
+		status TEXT NOT NULL CHECK(status IN ('queued', 'running', 'succeeded', 'failed')),
+		request TEXT NOT NULL,
+		result TEXT,
+		error TEXT,
+		created_at INTEGER NOT NULL,
+		started_at INTEGER,
+		finished_at INTEGER
+	);
+
 	-- Indexes for performance
 	CREATE INDEX IF NOT EXISTS idx_findings_hash ON findings(finding_hash);
 	CREATE INDEX IF NOT EXISTS idx_findings_review ON findings(review_id);
 	CREATE INDEX IF NOT EXISTS idx_feedback_finding ON feedback(finding_id);
 	CREATE INDEX IF NOT EXISTS idx_precision_provider_category ON precision_priors(provider, category);
 	CREATE INDEX IF NOT EXISTS idx_runs_timestamp ON runs(timestamp DESC);
+	CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs(status);
 	`
 
 	_, err := s.db.Exec(schema)
@@ -588,6 +602,138 @@ func (s *Store) UpdatePrecisionPrior(ctx context.Context, provider, category str
 	return nil
 }
 
+// SaveJob creates a job or replaces the stored one with the same ID.
+func (s *Store) SaveJob(ctx context.Context, job store.JobRecord) error {
+	query := `
+		INSERT INTO jobs (job_id, status, request, result, error, created_at, started_at, finished_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(job_id) DO UPDATE SET
+			status = excluded.status,
+			request = excluded.request,
+			result = excluded.result,
+			error = excluded.error,
+			started_at = excluded.started_at,
+			finished_at = excluded.finished_at
+	`
+
+	_, err := s.db.ExecContext(ctx, query,
+		job.JobID,
+		job.Status,
+		job.Request,
+		job.Result,
+		job.Error,
+		job.CreatedAt.Unix(),
+		unixOrNull(job.StartedAt),
+		unixOrNull(job.FinishedAt),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save job: %w", err)
+	}
+
+	return nil
+}
+
+// GetJob retrieves a job by ID.
+func (s *Store) GetJob(ctx context.Context, jobID string) (store.JobRecord, error) {
+	query := `
+		SELECT job_id, status, request, result, error, created_at, started_at, finished_at
+		FROM jobs
+		WHERE job_id = ?
+	`
+
+	job, err := scanJob(s.db.QueryRowContext(ctx, query, jobID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return store.JobRecord{}, fmt.Errorf("%w: %s", store.ErrJobNotFound, jobID)
+		}
+		return store.JobRecord{}, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	return job, nil
+}
+
+// ListJobs retrieves the jobs with any of the given statuses, oldest first.
+func (s *Store) ListJobs(ctx context.Context, statuses ...string) ([]store.JobRecord, error) {
+	if len(statuses) == 0 {
+		return nil, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(statuses)), ", ")
+	query := `
+		SELECT job_id, status, request, result, error, created_at, started_at, finished_at
+		FROM jobs
+		WHERE status IN (` + placeholders + `)
+		ORDER BY created_at ASC, rowid ASC
+	`
+
+	args := make([]interface{}, len(statuses))
+	for i, status := range statuses {
+		args[i] = status
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []store.JobRecord
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// scanJob scans a row of the jobs table.
+func scanJob(row interface{ Scan(...interface{}) error }) (store.JobRecord, error) {
+	var job store.JobRecord
+	var result, errMsg sql.NullString
+	var createdAt int64
+	var startedAt, finishedAt sql.NullInt64
+
+	if err := row.Scan(
+		&job.JobID,
+		&job.Status,
+		&job.Request,
+		&result,
+		&errMsg,
+		&createdAt,
+		&startedAt,
+		&finishedAt,
+	); err != nil {
+		return store.JobRecord{}, err
+	}
+
+	job.Result = result.String
+	job.Error = errMsg.String
+	job.CreatedAt = time.Unix(createdAt, 0)
+	if startedAt.Valid {
+		job.StartedAt = time.Unix(startedAt.Int64, 0)
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = time.Unix(finishedAt.Int64, 0)
+	}
+	return job, nil
+}
+
+// unixOrNull stores zero times as NULL.
+func unixOrNull(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.Unix()
+}
+
 // Close closes the database connection.
 func (s *Store) Close() error {
 	return s.db.Close()
@@ -2,6 +2,7 @@ package sqlite_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -489,3 +490,74 @@ func TestStore_ForeignKeyConstraints(t *testing.T) {
 	err := s.SaveReview(ctx, review)
 	assert.Error(t, err, "should fail due to foreign key constraint")
 }
+
+func TestStore_SaveJob_GetJob(t *testing.T) {
+	s := setupTestStore(t)
+	ctx := context.Background()
+
+	created := time.Now().Truncate(time.Second)
+	job := store.JobRecord{
+		JobID:     "job-1",
+		Status:    "queued",
+		Request:   `{"TargetRef":"feature"}`,
+		CreatedAt: created,
+	}
+	require.NoError(t, s.SaveJob(ctx, job))
+
+	retrieved, err := s.GetJob(ctx, "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, "queued", retrieved.Status)
+	assert.Equal(t, job.Request, retrieved.Request)
+	assert.True(t, created.Equal(retrieved.CreatedAt))
+	assert.True(t, retrieved.StartedAt.IsZero(), "unset times should read back as zero")
+	assert.Empty(t, retrieved.Result)
+
+	// Saving again updates the job
+	job.Status = "succeeded"
+	job.Result = `{"BaseSHA":"abc"}`
+	job.StartedAt = created.Add(time.Second)
+	job.FinishedAt = created.Add(time.Minute)
+	require.NoError(t, s.SaveJob(ctx, job))
+
+	retrieved, err = s.GetJob(ctx, "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, "succeeded", retrieved.Status)
+	assert.Equal(t, job.Result, retrieved.Result)
+	assert.True(t, job.StartedAt.Equal(retrieved.StartedAt))
+	assert.True(t, job.FinishedAt.Equal(retrieved.FinishedAt))
+
+	_, err = s.GetJob(ctx, "job-missing")
+	assert.ErrorContains(t, err, "job not found")
+}
+
+func TestStore_ListJobs(t *testing.T) {
+	s := setupTestStore(t)
+	ctx := context.Background()
+
+	now := time.Now()
+	for i, status := range []string{"running", "succeeded", "queued", "failed"} {
+		require.NoError(t, s.SaveJob(ctx, store.JobRecord{
+			JobID:     fmt.Sprintf("job-%d", i),
+			Status:    status,
+			Request:   "{}",
+			CreatedAt: now.Add(time.Duration(i) * time.Second),
+		}))
+	}
+
+	jobs, err := s.ListJobs(ctx, "queued", "running")
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, "job-0", jobs[0].JobID, "jobs should be listed oldest first")
+	assert.Equal(t, "job-2", jobs[1].JobID)
+
+	jobs, err = s.ListJobs(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestStore_SaveJob_RejectsUnknownStatus(t *testing.T) {
+	s := setupTestStore(t)
+
+	err := s.SaveJob(context.Background(), store.JobRecord{JobID: "job-1", Status: "paused", Request: "{}", CreatedAt: time.Now()})
+	assert.Error(t, err)
+}
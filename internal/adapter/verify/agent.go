@@ -8,6 +8,8 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/bkyoung/code-reviewer/internal/adapter/llm"
+	"github.com/bkyoung/code-reviewer/internal/adapter/observability"
 	"github.com/bkyoung/code-reviewer/internal/config"
 	"github.com/bkyoung/code-reviewer/internal/domain"
 	usecaseverify "github.com/bkyoung/code-reviewer/internal/usecase/verify"
@@ -21,6 +23,15 @@ type LLMClient interface {
 	Call(ctx context.Context, systemPrompt, userPrompt string) (response string, tokensIn, tokensOut int, cost float64, err error)
 }
 
+// ToolCallingClient is implemented by LLM clients that support native tool
+// calling (Anthropic tool_use, OpenAI tools, Gemini functionDeclarations).
+// The AgentVerifier uses it instead of the text protocol when available.
+type ToolCallingClient interface {
+	// CallWithTools sends the conversation so far and returns the next turn,
+	// which either requests tool calls or contains the final answer.
+	CallWithTools(ctx context.Context, systemPrompt string, messages []llm.ChatMessage, tools []llm.ToolDefinition) (llm.ChatResponse, error)
+}
+
 // AgentConfig configures the verification agent behavior.
 type AgentConfig struct {
 	// MaxIterations limits the number of tool calls per verification.
@@ -32,8 +43,12 @@ type AgentConfig struct {
 	// Confidence thresholds per severity level.
 	Confidence config.ConfidenceThresholds
 
-	// Depth controls verification thoroughness: "quick", "medium", "deep".
+	// Depth controls verification thoroughness: "minimal", "medium", "thorough".
 	Depth string
+
+	// ExtraTools are offered in addition to the default tool registry, such as
+	// the opt-in sandboxed TestTool.
+	ExtraTools []Tool
 }
 
 // DefaultAgentConfig returns sensible defaults.
@@ -68,7 +83,7 @@ func NewAgentVerifier(
 	costTracker usecaseverify.CostTracker,
 	config AgentConfig,
 ) *AgentVerifier {
-	tools := NewToolRegistry(repo)
+	tools := append(NewToolRegistry(repo), config.ExtraTools...)
 	toolMap := make(map[string]Tool, len(tools))
 	for _, t := range tools {
 		toolMap[t.Name()] = t
@@ -85,9 +100,20 @@ func NewAgentVerifier(
 }
 
 // Verify checks a single candidate finding and returns the verification result.
+// Clients implementing ToolCallingClient use native tool calling; others use
+// the text protocol. Either way the full conversation is kept across turns.
 func (v *AgentVerifier) Verify(ctx context.Context, candidate domain.CandidateFinding) (domain.VerificationResult, error) {
+	ctx, span := observability.StartSpan(ctx, "verify.finding", map[string]interface{}{
+		"file":     candidate.Finding.File,
+		"line":     candidate.Finding.LineStart,
+		"severity": candidate.Finding.Severity,
+		"category": candidate.Finding.Category,
+	})
+	defer span.End()
+
 	// Check cost ceiling before starting
 	if v.costTracker != nil && v.costTracker.ExceedsCeiling() {
+		span.SetAttributes(map[string]interface{}{"skipped": "cost ceiling exceeded"})
 		return domain.VerificationResult{
 			Verified:   false,
 			Confidence: 0,
@@ -95,92 +121,219 @@ func (v *AgentVerifier) Verify(ctx context.Context, candidate domain.CandidateFi
 		}, nil
 	}
 
-	// Build prompts
-	systemPrompt := VerificationPrompt(v.tools)
-	userPrompt := CandidatePrompt(candidate)
+	var (
+		inv *investigation
+		err error
+	)
+	if tc, ok := v.llm.(ToolCallingClient); ok {
+		inv, err = v.investigateWithTools(ctx, tc, candidate)
+	} else {
+		inv, err = v.investigateWithText(ctx, candidate)
+	}
+	if err != nil {
+		span.RecordError(err)
+		return domain.VerificationResult{}, err
+	}
+
+	// Max iterations reached or no verdict parsed - try to extract verdict from last response
+	result, ok := v.parseVerdict(inv.lastResponse)
+	if !ok {
+		// Fallback: Unable to determine
+		result = domain.VerificationResult{
+			Verified:       false,
+			Classification: "",
+			Confidence:     0,
+			Evidence:       "Unable to determine verification status after investigation",
+		}
+	}
+	result.Actions = inv.actions
+	result.Path = domain.VerificationModeAgent
+	result.TokensIn = inv.tokensIn
+	result.TokensOut = inv.tokensOut
+	result.Cost = inv.cost
+
+	span.SetAttributes(map[string]interface{}{
+		"verified":       result.Verified,
+		"classification": string(result.Classification),
+		"confidence":     result.Confidence,
+		"actions":        len(result.Actions),
+		"tokensIn":       result.TokensIn,
+		"tokensOut":      result.TokensOut,
+		"cost":           result.Cost,
+	})
+	return result, nil
+}
 
-	var actions []domain.VerificationAction
-	var lastResponse string
+// investigation accumulates the state of one agent verification.
+type investigation struct {
+	actions      []domain.VerificationAction
+	steps        []toolStep
+	lastResponse string
+	tokensIn     int
+	tokensOut    int
+	cost         float64
+}
+
+// toolStep is one text-protocol turn: the model's response and the tool it ran.
+type toolStep struct {
+	response string
+	tool     string
+	input    string
+	output   string
+}
+
+// addUsage records the usage of one LLM call.
+func (v *AgentVerifier) addUsage(inv *investigation, tokensIn, tokensOut int, cost float64) {
+	inv.tokensIn += tokensIn
+	inv.tokensOut += tokensOut
+	inv.cost += cost
+	if v.costTracker != nil {
+		v.costTracker.AddCost(cost)
+	}
+}
+
+// investigateWithTools runs the agent loop with native tool calling, keeping
+// the whole message history so the model retains the finding and every
+// earlier tool call and result.
+func (v *AgentVerifier) investigateWithTools(ctx context.Context, client ToolCallingClient, candidate domain.CandidateFinding) (*investigation, error) {
+	systemPrompt := NativeToolVerificationPrompt(v.tools)
+	definitions := v.toolDefinitions()
+	messages := []llm.ChatMessage{{Role: llm.ChatRoleUser, Content: CandidatePrompt(candidate)}}
+	inv := &investigation{}
 
-	// Agent loop - let LLM use tools until it provides a verdict
 	for i := 0; i < v.config.MaxIterations; i++ {
-		// Check context cancellation
 		if ctx.Err() != nil {
-			return domain.VerificationResult{}, ctx.Err()
+			return nil, ctx.Err()
 		}
-
-		// Check cost ceiling
 		if v.costTracker != nil && v.costTracker.ExceedsCeiling() {
 			break
 		}
 
-		// Call LLM
-		response, tokensIn, tokensOut, cost, err := v.llm.Call(ctx, systemPrompt, userPrompt)
+		resp, err := client.CallWithTools(ctx, systemPrompt, messages, definitions)
 		if err != nil {
-			return domain.VerificationResult{}, fmt.Errorf("llm call: %w", err)
+			return nil, fmt.Errorf("llm call: %w", err)
 		}
+		v.addUsage(inv, resp.TokensIn, resp.TokensOut, resp.Cost)
+		inv.lastResponse = resp.Text
 
-		// Track cost
-		if v.costTracker != nil {
-			v.costTracker.AddCost(cost)
+		// A turn without tool calls is the final answer
+		if len(resp.ToolCalls) == 0 {
+			break
+		}
+
+		messages = append(messages, llm.ChatMessage{
+			Role:      llm.ChatRoleAssistant,
+			Content:   resp.Text,
+			ToolCalls: resp.ToolCalls,
+		})
+		for _, call := range resp.ToolCalls {
+			output := v.runTool(ctx, inv, call.Name, call.Input)
+			messages = append(messages, llm.ChatMessage{
+				Role:       llm.ChatRoleTool,
+				Content:    output,
+				ToolCallID: call.ID,
+				ToolName:   call.Name,
+			})
 		}
+	}
+
+	return inv, nil
+}
 
-		lastResponse = response
+// investigateWithText runs the agent loop with the text tool protocol for
+// clients without native tool calling. Each prompt replays the candidate and
+// every earlier response and tool result.
+func (v *AgentVerifier) investigateWithText(ctx context.Context, candidate domain.CandidateFinding) (*investigation, error) {
+	systemPrompt := VerificationPrompt(v.tools)
+	inv := &investigation{}
 
-		// Try to parse as final verdict
-		if result, ok := v.parseVerdict(response); ok {
-			result.Actions = actions
-			return result, nil
+	// Agent loop - let LLM use tools until it provides a verdict
+	for i := 0; i < v.config.MaxIterations; i++ {
+		// Check context cancellation
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
 		}
 
-		// Try to parse tool call
-		toolName, toolInput, ok := v.parseToolCall(response)
-		if !ok {
-			// No tool call and no verdict - treat as final response
+		// Check cost ceiling
+		if v.costTracker != nil && v.costTracker.ExceedsCeiling() {
 			break
 		}
 
-		// Execute tool
-		tool, exists := v.toolMap[toolName]
-		if !exists {
-			userPrompt = fmt.Sprintf("Unknown tool: %s. Available tools: %v", toolName, v.toolNames())
-			continue
+		response, tokensIn, tokensOut, cost, err := v.llm.Call(ctx, systemPrompt, transcriptPrompt(candidate, inv.steps))
+		if err != nil {
+			return nil, fmt.Errorf("llm call: %w", err)
 		}
+		v.addUsage(inv, tokensIn, tokensOut, cost)
+		inv.lastResponse = response
 
-		output, err := tool.Execute(ctx, toolInput)
-		if err != nil {
-			output = fmt.Sprintf("Error: %v", err)
+		// A verdict ends the investigation
+		if _, ok := v.parseVerdict(response); ok {
+			break
 		}
 
-		// Record action
-		actions = append(actions, domain.VerificationAction{
-			Tool:   toolName,
-			Input:  toolInput,
-			Output: truncateOutput(output),
+		// No tool call and no verdict - treat as final response
+		toolName, toolInput, ok := v.parseToolCall(response)
+		if !ok {
+			break
+		}
+
+		output := v.runTool(ctx, inv, toolName, toolInput)
+		inv.steps = append(inv.steps, toolStep{
+			response: response,
+			tool:     toolName,
+			input:    toolInput,
+			output:   output,
 		})
+	}
 
-		// Build next prompt with tool result
-		userPrompt = ToolResultPrompt(toolName, toolInput, output)
+	return inv, nil
+}
 
-		// For logging/debugging
-		_ = tokensIn
-		_ = tokensOut
+// runTool executes a tool, records the action and returns the output shown to
+// the model. Unknown tools and tool errors are reported as output.
+func (v *AgentVerifier) runTool(ctx context.Context, inv *investigation, toolName, toolInput string) string {
+	tool, exists := v.toolMap[toolName]
+	if !exists {
+		return fmt.Sprintf("Unknown tool: %s. Available tools: %v", toolName, v.toolNames())
 	}
 
-	// Max iterations reached or no verdict parsed - try to extract verdict from last response
-	if result, ok := v.parseVerdict(lastResponse); ok {
-		result.Actions = actions
-		return result, nil
+	output, err := tool.Execute(ctx, toolInput)
+	if err != nil {
+		output = fmt.Sprintf("Error: %v", err)
 	}
 
-	// Fallback: Unable to determine
-	return domain.VerificationResult{
-		Verified:       false,
-		Classification: "",
-		Confidence:     0,
-		Evidence:       "Unable to determine verification status after investigation",
-		Actions:        actions,
-	}, nil
+	inv.actions = append(inv.actions, domain.VerificationAction{
+		Tool:   toolName,
+		Input:  toolInput,
+		Output: truncateOutput(output),
+	})
+	return output
+}
+
+// transcriptPrompt builds the text-protocol user prompt: the candidate followed
+// by every earlier response and tool result.
+func transcriptPrompt(candidate domain.CandidateFinding, steps []toolStep) string {
+	var sb strings.Builder
+	sb.WriteString(CandidatePrompt(candidate))
+	if len(steps) == 0 {
+		return sb.String()
+	}
+
+	sb.WriteString("\n## Investigation So Far\n")
+	for i, step := range steps {
+		sb.WriteString(fmt.Sprintf("\n### Step %d\n\n**Your response**:\n%s\n\n", i+1, step.response))
+		sb.WriteString(ToolResultPrompt(step.tool, step.input, step.output))
+	}
+	return sb.String()
+}
+
+// toolDefinitions declares the registered tools for native tool calling.
+func (v *AgentVerifier) toolDefinitions() []llm.ToolDefinition {
+	definitions := make([]llm.ToolDefinition, len(v.tools))
+	for i, t := range v.tools {
+		definitions[i] = llm.ToolDefinition{Name: t.Name(), Description: t.Description()}
+	}
+	return definitions
 }
 
 // VerifyBatch verifies multiple candidates, potentially in parallel.
@@ -296,9 +449,16 @@ func (v *AgentVerifier) parseVerdict(response string) (domain.VerificationResult
 // toolCallPattern matches tool invocations like "TOOL: read_file\nINPUT: main.go"
 var toolCallPattern = regexp.MustCompile(`(?s)TOOL:\s*(\w+)\s*\nINPUT:\s*(.+?)(?:\n|$)`)
 
+// fencedToolCallPattern matches a tool invocation in a ```tool block, whose
+// input runs to the closing fence and may span lines (test reproducers).
+var fencedToolCallPattern = regexp.MustCompile("(?s)```tool\\s*\\nTOOL:\\s*(\\w+)\\s*\\nINPUT:[ \\t]*(.+?)\\n```") //nolint:gocritic // Using double quotes for backticks in pattern
+
 // parseToolCall attempts to extract a tool call from the response.
 func (v *AgentVerifier) parseToolCall(response string) (toolName, input string, ok bool) {
-	matches := toolCallPattern.FindStringSubmatch(response)
+	matches := fencedToolCallPattern.FindStringSubmatch(response)
+	if len(matches) < 3 {
+		matches = toolCallPattern.FindStringSubmatch(response)
+	}
 	if len(matches) >= 3 {
 		return strings.TrimSpace(matches[1]), strings.TrimSpace(matches[2]), true
 	}
@@ -3,11 +3,13 @@ package verify_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/bkyoung/code-reviewer/internal/adapter/llm"
 	"github.com/bkyoung/code-reviewer/internal/adapter/verify"
 	"github.com/bkyoung/code-reviewer/internal/domain"
 	usecaseverify "github.com/bkyoung/code-reviewer/internal/usecase/verify"
@@ -605,6 +607,188 @@ func TestDefaultAgentConfig(t *testing.T) {
 	}
 }
 
+// mockToolCallingClient implements verify.ToolCallingClient for testing.
+// Each call records a copy of the conversation it was given.
+type mockToolCallingClient struct {
+	mockLLMClient
+	responses []llm.ChatResponse
+	histories [][]llm.ChatMessage
+}
+
+func (m *mockToolCallingClient) CallWithTools(ctx context.Context, systemPrompt string, messages []llm.ChatMessage, tools []llm.ToolDefinition) (llm.ChatResponse, error) {
+	m.histories = append(m.histories, append([]llm.ChatMessage(nil), messages...))
+	if len(m.histories) > len(m.responses) {
+		return llm.ChatResponse{}, errors.New("unexpected call")
+	}
+	return m.responses[len(m.histories)-1], nil
+}
+
+func TestAgentVerifier_NativeToolCalling(t *testing.T) {
+	client := &mockToolCallingClient{
+		responses: []llm.ChatResponse{
+			{
+				Text:      "Reading the file.",
+				ToolCalls: []llm.ToolCall{{ID: "call_1", Name: "read_file", Input: "main.go"}},
+				TokensIn:  100, TokensOut: 10, Cost: 0.001,
+			},
+			{
+				ToolCalls: []llm.ToolCall{{ID: "call_2", Name: "grep", Input: "panic"}},
+				TokensIn:  200, TokensOut: 10, Cost: 0.002,
+			},
+			{
+				Text:     `{"verified": true, "classification": "blocking_bug", "confidence": 90, "evidence": "panic(nil) at line 3"}`,
+				TokensIn: 300, TokensOut: 30, Cost: 0.003,
+			},
+		},
+	}
+	repo := &mockRepository{
+		readFileFunc: func(path string) ([]byte, error) {
+			return []byte("package main\n\nfunc main() { panic(nil) }"), nil
+		},
+	}
+	tracker := &mockCostTracker{ceiling: 1.0}
+
+	verifier := verify.NewAgentVerifier(client, repo, tracker, verify.DefaultAgentConfig())
+	result, err := verifier.Verify(context.Background(), domain.CandidateFinding{
+		Finding: domain.Finding{File: "main.go", Description: "Panic call"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(client.calls) != 0 {
+		t.Errorf("expected text protocol not to be used, got %d calls", len(client.calls))
+	}
+	if len(client.histories) != 3 {
+		t.Fatalf("expected 3 turns, got %d", len(client.histories))
+	}
+
+	// The last turn still contains the candidate and every earlier call and result
+	last := client.histories[2]
+	if len(last) != 5 {
+		t.Fatalf("expected 5 messages in the final turn, got %d", len(last))
+	}
+	if last[0].Role != llm.ChatRoleUser || !strings.Contains(last[0].Content, "Panic call") {
+		t.Errorf("expected candidate as first message, got %+v", last[0])
+	}
+	if last[1].Role != llm.ChatRoleAssistant || last[1].ToolCalls[0].ID != "call_1" {
+		t.Errorf("expected first tool call, got %+v", last[1])
+	}
+	if last[2].Role != llm.ChatRoleTool || last[2].ToolCallID != "call_1" || !strings.Contains(last[2].Content, "panic(nil)") {
+		t.Errorf("expected read_file result, got %+v", last[2])
+	}
+	if last[4].Role != llm.ChatRoleTool || last[4].ToolName != "grep" {
+		t.Errorf("expected grep result, got %+v", last[4])
+	}
+
+	if !result.Verified || result.Confidence != 90 {
+		t.Errorf("expected verified verdict, got %+v", result)
+	}
+	if len(result.Actions) != 2 {
+		t.Errorf("expected 2 actions, got %d", len(result.Actions))
+	}
+	if result.TokensIn != 600 || result.TokensOut != 50 {
+		t.Errorf("expected accumulated tokens 600/50, got %d/%d", result.TokensIn, result.TokensOut)
+	}
+	if result.Cost < 0.0059 || result.Cost > 0.0061 {
+		t.Errorf("expected accumulated cost 0.006, got %f", result.Cost)
+	}
+	if tracker.TotalCost() < 0.0059 {
+		t.Errorf("expected cost tracker to receive 0.006, got %f", tracker.TotalCost())
+	}
+}
+
+func TestAgentVerifier_TextProtocolKeepsHistory(t *testing.T) {
+	callCount := 0
+	client := &mockLLMClient{
+		callFunc: func(ctx context.Context, systemPrompt, userPrompt string) (string, int, int, float64, error) {
+			callCount++
+			switch callCount {
+			case 1:
+				return "```tool\nTOOL: read_file\nINPUT: main.go\n```", 100, 10, 0.001, nil
+			case 2:
+				return "```tool\nTOOL: grep\nINPUT: panic\n```", 200, 10, 0.001, nil
+			default:
+				return `{"verified": false, "confidence": 90, "evidence": "guarded"}`, 300, 10, 0.001, nil
+			}
+		},
+	}
+	repo := &mockRepository{
+		readFileFunc: func(path string) ([]byte, error) {
+			return []byte("package main"), nil
+		},
+	}
+
+	verifier := verify.NewAgentVerifier(client, repo, nil, verify.DefaultAgentConfig())
+	result, err := verifier.Verify(context.Background(), domain.CandidateFinding{
+		Finding: domain.Finding{File: "main.go", Description: "Panic call"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(client.calls) != 3 {
+		t.Fatalf("expected 3 calls, got %d", len(client.calls))
+	}
+	third := client.calls[2].UserPrompt
+	for _, want := range []string{"Panic call", "### Step 1", "TOOL: read_file", "### Step 2", "TOOL: grep"} {
+		if !strings.Contains(third, want) {
+			t.Errorf("expected third prompt to contain %q", want)
+		}
+	}
+	if result.TokensIn != 600 || result.TokensOut != 30 {
+		t.Errorf("expected accumulated tokens 600/30, got %d/%d", result.TokensIn, result.TokensOut)
+	}
+}
+
 // Compile-time interface checks
 var _ verify.LLMClient = (*mockLLMClient)(nil)
+var _ verify.ToolCallingClient = (*mockToolCallingClient)(nil)
 var _ usecaseverify.CostTracker = (*mockCostTracker)(nil)
+
+// recordingTool records the inputs it receives.
+type recordingTool struct {
+	inputs []string
+}
+
+func (r *recordingTool) Name() string        { return "test" }
+func (r *recordingTool) Description() string { return "Run a test" }
+func (r *recordingTool) Execute(ctx context.Context, input string) (string, error) {
+	r.inputs = append(r.inputs, input)
+	return "Exit code: 1\n--- FAIL: TestRepro", nil
+}
+
+func TestAgentVerifier_ExtraToolsAndMultiLineInput(t *testing.T) {
+	callCount := 0
+	client := &mockLLMClient{
+		callFunc: func(ctx context.Context, systemPrompt, userPrompt string) (string, int, int, float64, error) {
+			callCount++
+			if callCount == 1 {
+				return "```tool\nTOOL: test\nINPUT: go test -run TestRepro ./pkg\n--- pkg/repro_test.go\npackage pkg\n```", 10, 10, 0, nil
+			}
+			return `{"verified": true, "classification": "blocking_bug", "confidence": 95, "evidence": "TestRepro fails"}`, 10, 10, 0, nil
+		},
+	}
+	tool := &recordingTool{}
+	config := verify.DefaultAgentConfig()
+	config.ExtraTools = []verify.Tool{tool}
+
+	verifier := verify.NewAgentVerifier(client, &mockRepository{}, nil, config)
+	result, err := verifier.Verify(context.Background(), domain.CandidateFinding{
+		Finding: domain.Finding{File: "pkg/a.go", Description: "Off by one"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(client.calls[0].SystemPrompt, "reproducer test") {
+		t.Error("expected system prompt to explain reproducer tests when the test tool is available")
+	}
+	want := "go test -run TestRepro ./pkg\n--- pkg/repro_test.go\npackage pkg"
+	if len(tool.inputs) != 1 || tool.inputs[0] != want {
+		t.Errorf("expected multi-line tool input %q, got %q", want, tool.inputs)
+	}
+	if !result.Verified {
+		t.Error("expected finding to be verified")
+	}
+}
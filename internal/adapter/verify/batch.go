@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/bkyoung/code-reviewer/internal/adapter/observability"
 	"github.com/bkyoung/code-reviewer/internal/config"
 	"github.com/bkyoung/code-reviewer/internal/domain"
 	usecaseverify "github.com/bkyoung/code-reviewer/internal/usecase/verify"
@@ -85,10 +86,17 @@ func (v *BatchVerifier) VerifyBatch(ctx context.Context, candidates []domain.Can
 		return results, nil
 	}
 
+	ctx, span := observability.StartSpan(ctx, "verify.batch", map[string]interface{}{
+		"candidates": len(candidates),
+	})
+	defer span.End()
+
 	// Gather unique files and their contents
 	fileContents, err := v.gatherFileContents(candidates)
 	if err != nil {
-		return nil, fmt.Errorf("gathering file contents: %w", err)
+		err = fmt.Errorf("gathering file contents: %w", err)
+		span.RecordError(err)
+		return nil, err
 	}
 
 	// Build prompts
@@ -96,10 +104,17 @@ func (v *BatchVerifier) VerifyBatch(ctx context.Context, candidates []domain.Can
 	userPrompt := batchVerificationUserPrompt(candidates, fileContents)
 
 	// Single LLM call
-	response, _, _, cost, err := v.llm.Call(ctx, systemPrompt, userPrompt)
+	response, tokensIn, tokensOut, cost, err := v.llm.Call(ctx, systemPrompt, userPrompt)
 	if err != nil {
-		return nil, fmt.Errorf("llm call: %w", err)
+		err = fmt.Errorf("llm call: %w", err)
+		span.RecordError(err)
+		return nil, err
 	}
+	span.SetAttributes(map[string]interface{}{
+		"tokensIn":  tokensIn,
+		"tokensOut": tokensOut,
+		"cost":      cost,
+	})
 
 	// Track cost
 	if v.costTracker != nil {
@@ -120,9 +135,38 @@ func (v *BatchVerifier) VerifyBatch(ctx context.Context, candidates []domain.Can
 		}
 	}
 
+	shareUsage(results, tokensIn, tokensOut, cost)
+
+	verified := 0
+	for i := range results {
+		results[i].Path = domain.VerificationModeBatch
+		if results[i].Verified {
+			verified++
+		}
+	}
+	span.SetAttributes(map[string]interface{}{"verified": verified})
+
 	return results, nil
 }
 
+// shareUsage spreads the usage of the single batch call evenly across its
+// results, so the per-finding usage sums to the call's usage. The first
+// results take any token remainder.
+func shareUsage(results []domain.VerificationResult, tokensIn, tokensOut int, cost float64) {
+	n := len(results)
+	for i := range results {
+		results[i].TokensIn = tokensIn / n
+		if i < tokensIn%n {
+			results[i].TokensIn++
+		}
+		results[i].TokensOut = tokensOut / n
+		if i < tokensOut%n {
+			results[i].TokensOut++
+		}
+		results[i].Cost = cost / float64(n)
+	}
+}
+
 // gatherFileContents reads the content of all files referenced by candidates.
 func (v *BatchVerifier) gatherFileContents(candidates []domain.CandidateFinding) (map[string]string, error) {
 	// Collect unique file paths
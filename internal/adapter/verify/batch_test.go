@@ -86,6 +86,13 @@ func main() {
 	if results[1].Classification != domain.ClassBlockingBug {
 		t.Errorf("expected classification blocking_bug, got %s", results[1].Classification)
 	}
+
+	// The call's usage is shared across the findings it verified
+	for i, r := range results {
+		if r.TokensIn != 50 || r.TokensOut != 25 || r.Cost != 0.0005 {
+			t.Errorf("result %d: expected half of the call's usage, got %d/%d/%v", i, r.TokensIn, r.TokensOut, r.Cost)
+		}
+	}
 }
 
 func TestBatchVerifier_EmptyCandidates(t *testing.T) {
@@ -0,0 +1,146 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bkyoung/code-reviewer/internal/config"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	usecaseverify "github.com/bkyoung/code-reviewer/internal/usecase/verify"
+)
+
+// DepthConfig configures the depth-selecting verifier.
+type DepthConfig struct {
+	// Depth is the default verification depth: "minimal", "medium", or "thorough".
+	// It is used when no depth is given per review.
+	Depth string
+
+	// Confidence thresholds per severity level. In hybrid mode, a batch verdict
+	// below the threshold for its severity is considered low-confidence.
+	Confidence config.ConfidenceThresholds
+}
+
+// DepthVerifier selects a verification strategy from the verification depth:
+//   - minimal: the batch verifier only
+//   - medium: the batch verifier, escalating low-confidence high/critical
+//     verdicts to the agent
+//   - thorough: the agent for every finding
+type DepthVerifier struct {
+	batch      usecaseverify.Verifier
+	escalation usecaseverify.Verifier
+	thorough   usecaseverify.Verifier
+	config     DepthConfig
+}
+
+// NewDepthVerifier creates a depth-selecting verifier. escalation is the agent
+// used by medium depth and thorough the agent used by thorough depth; they may
+// be the same verifier.
+func NewDepthVerifier(
+	batch usecaseverify.Verifier,
+	escalation usecaseverify.Verifier,
+	thorough usecaseverify.Verifier,
+	config DepthConfig,
+) *DepthVerifier {
+	return &DepthVerifier{
+		batch:      batch,
+		escalation: escalation,
+		thorough:   thorough,
+		config:     config,
+	}
+}
+
+// Verify checks a single candidate at the default depth.
+func (v *DepthVerifier) Verify(ctx context.Context, candidate domain.CandidateFinding) (domain.VerificationResult, error) {
+	results, err := v.VerifyBatch(ctx, []domain.CandidateFinding{candidate})
+	if err != nil {
+		return domain.VerificationResult{}, err
+	}
+	if len(results) == 0 {
+		return domain.VerificationResult{}, fmt.Errorf("no results returned")
+	}
+	return results[0], nil
+}
+
+// VerifyBatch verifies candidates at the default depth.
+func (v *DepthVerifier) VerifyBatch(ctx context.Context, candidates []domain.CandidateFinding) ([]domain.VerificationResult, error) {
+	return v.VerifyBatchAtDepth(ctx, v.config.Depth, candidates)
+}
+
+// VerifyBatchAtDepth verifies candidates using the strategy for depth.
+// An empty depth uses the default depth.
+func (v *DepthVerifier) VerifyBatchAtDepth(ctx context.Context, depth string, candidates []domain.CandidateFinding) ([]domain.VerificationResult, error) {
+	if depth == "" {
+		depth = v.config.Depth
+	}
+
+	switch domain.VerificationModeForDepth(depth) {
+	case domain.VerificationModeBatch:
+		return v.batch.VerifyBatch(ctx, candidates)
+	case domain.VerificationModeAgent:
+		return v.thorough.VerifyBatch(ctx, candidates)
+	default:
+		return v.verifyHybrid(ctx, candidates)
+	}
+}
+
+// verifyHybrid runs batch verification and re-verifies high/critical findings
+// whose batch verdict fell below the confidence threshold with the agent.
+// If the agent fails for a finding, its batch verdict is kept.
+func (v *DepthVerifier) verifyHybrid(ctx context.Context, candidates []domain.CandidateFinding) ([]domain.VerificationResult, error) {
+	results, err := v.batch.VerifyBatch(ctx, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	var escalate []int
+	for i, c := range candidates {
+		if i < len(results) && v.needsEscalation(c, results[i]) {
+			escalate = append(escalate, i)
+		}
+	}
+	if len(escalate) == 0 {
+		return results, nil
+	}
+
+	escalated := make([]domain.CandidateFinding, len(escalate))
+	for i, idx := range escalate {
+		escalated[i] = candidates[idx]
+	}
+
+	agentResults, err := v.escalation.VerifyBatch(ctx, escalated)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return results, nil
+	}
+
+	for i, idx := range escalate {
+		if i >= len(agentResults) {
+			break
+		}
+		result := agentResults[i]
+		result.Path = domain.VerificationPathEscalated
+		// Keep the finding's share of the batch call
+		result.TokensIn += results[idx].TokensIn
+		result.TokensOut += results[idx].TokensOut
+		result.Cost += results[idx].Cost
+		results[idx] = result
+	}
+
+	return results, nil
+}
+
+// needsEscalation reports whether a batch verdict should be re-verified by the
+// agent: only high/critical findings below their confidence threshold qualify.
+func (v *DepthVerifier) needsEscalation(candidate domain.CandidateFinding, result domain.VerificationResult) bool {
+	severity := strings.ToLower(candidate.Finding.Severity)
+	if severity != "critical" && severity != "high" {
+		return false
+	}
+	return result.Confidence < ConfidenceThreshold(severity, v.config.Confidence)
+}
+
+// Compile-time interface check
+var _ usecaseverify.Verifier = (*DepthVerifier)(nil)
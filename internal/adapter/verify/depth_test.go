@@ -0,0 +1,197 @@
+package verify_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/verify"
+	"github.com/bkyoung/code-reviewer/internal/config"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+)
+
+// mockVerifier implements usecaseverify.Verifier for testing.
+type mockVerifier struct {
+	verifyBatchFunc func(ctx context.Context, candidates []domain.CandidateFinding) ([]domain.VerificationResult, error)
+	batches         [][]domain.CandidateFinding
+}
+
+func (m *mockVerifier) Verify(ctx context.Context, candidate domain.CandidateFinding) (domain.VerificationResult, error) {
+	results, err := m.VerifyBatch(ctx, []domain.CandidateFinding{candidate})
+	if err != nil {
+		return domain.VerificationResult{}, err
+	}
+	return results[0], nil
+}
+
+func (m *mockVerifier) VerifyBatch(ctx context.Context, candidates []domain.CandidateFinding) ([]domain.VerificationResult, error) {
+	m.batches = append(m.batches, candidates)
+	if m.verifyBatchFunc != nil {
+		return m.verifyBatchFunc(ctx, candidates)
+	}
+	results := make([]domain.VerificationResult, len(candidates))
+	return results, nil
+}
+
+func fixedResults(path string, confidences ...int) func(context.Context, []domain.CandidateFinding) ([]domain.VerificationResult, error) {
+	return func(_ context.Context, candidates []domain.CandidateFinding) ([]domain.VerificationResult, error) {
+		results := make([]domain.VerificationResult, len(candidates))
+		for i := range results {
+			results[i] = domain.VerificationResult{Verified: true, Confidence: confidences[i], Path: path}
+		}
+		return results, nil
+	}
+}
+
+func depthCandidates() []domain.CandidateFinding {
+	return []domain.CandidateFinding{
+		{Finding: domain.Finding{File: "a.go", Severity: "critical", Description: "low-confidence critical"}},
+		{Finding: domain.Finding{File: "b.go", Severity: "high", Description: "confident high"}},
+		{Finding: domain.Finding{File: "c.go", Severity: "low", Description: "low-confidence low"}},
+	}
+}
+
+func TestDepthVerifier_MinimalUsesBatchOnly(t *testing.T) {
+	batch := &mockVerifier{verifyBatchFunc: fixedResults("batch", 10, 90, 10)}
+	agent := &mockVerifier{}
+
+	verifier := verify.NewDepthVerifier(batch, agent, agent, verify.DepthConfig{Depth: "minimal"})
+	results, err := verifier.VerifyBatch(context.Background(), depthCandidates())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if len(agent.batches) != 0 {
+		t.Errorf("expected agent not to be called, got %d calls", len(agent.batches))
+	}
+	for i, r := range results {
+		if r.Path != "batch" {
+			t.Errorf("result %d: expected path batch, got %q", i, r.Path)
+		}
+	}
+}
+
+func TestDepthVerifier_ThoroughUsesThoroughAgent(t *testing.T) {
+	batch := &mockVerifier{}
+	escalation := &mockVerifier{}
+	thorough := &mockVerifier{verifyBatchFunc: fixedResults("agent", 80, 80, 80)}
+
+	verifier := verify.NewDepthVerifier(batch, escalation, thorough, verify.DepthConfig{Depth: "medium"})
+	results, err := verifier.VerifyBatchAtDepth(context.Background(), "thorough", depthCandidates())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(batch.batches) != 0 || len(escalation.batches) != 0 {
+		t.Error("expected only the thorough agent to be called")
+	}
+	if len(thorough.batches) != 1 || len(thorough.batches[0]) != 3 {
+		t.Fatalf("expected thorough agent to verify all 3 candidates, got %v", thorough.batches)
+	}
+	if results[0].Path != "agent" {
+		t.Errorf("expected path agent, got %q", results[0].Path)
+	}
+}
+
+func TestDepthVerifier_MediumEscalatesLowConfidenceHighSeverity(t *testing.T) {
+	batch := &mockVerifier{verifyBatchFunc: fixedResults("batch", 10, 90, 10)}
+	escalation := &mockVerifier{verifyBatchFunc: fixedResults("agent", 85)}
+	thorough := &mockVerifier{}
+
+	verifier := verify.NewDepthVerifier(batch, escalation, thorough, verify.DepthConfig{
+		Depth:      "medium",
+		Confidence: config.ConfidenceThresholds{Critical: 50, High: 60, Low: 80},
+	})
+	results, err := verifier.VerifyBatch(context.Background(), depthCandidates())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Only the low-confidence critical finding is escalated; the low severity
+	// finding stays with its batch verdict despite low confidence.
+	if len(escalation.batches) != 1 || len(escalation.batches[0]) != 1 {
+		t.Fatalf("expected one escalated candidate, got %v", escalation.batches)
+	}
+	if escalation.batches[0][0].Finding.File != "a.go" {
+		t.Errorf("expected a.go to be escalated, got %s", escalation.batches[0][0].Finding.File)
+	}
+	if len(thorough.batches) != 0 {
+		t.Error("expected thorough agent not to be called")
+	}
+
+	if results[0].Path != domain.VerificationPathEscalated || results[0].Confidence != 85 {
+		t.Errorf("expected escalated agent verdict for a.go, got %+v", results[0])
+	}
+	if results[1].Path != "batch" || results[2].Path != "batch" {
+		t.Errorf("expected batch verdicts for b.go and c.go, got %q and %q", results[1].Path, results[2].Path)
+	}
+}
+
+func TestDepthVerifier_MediumEscalationKeepsBatchUsage(t *testing.T) {
+	withUsage := func(results []domain.VerificationResult, tokensIn, tokensOut int, cost float64) []domain.VerificationResult {
+		for i := range results {
+			results[i].TokensIn, results[i].TokensOut, results[i].Cost = tokensIn, tokensOut, cost
+		}
+		return results
+	}
+	batch := &mockVerifier{verifyBatchFunc: func(ctx context.Context, candidates []domain.CandidateFinding) ([]domain.VerificationResult, error) {
+		results, _ := fixedResults("batch", 10, 90, 10)(ctx, candidates)
+		return withUsage(results, 100, 10, 0.25), nil
+	}}
+	escalation := &mockVerifier{verifyBatchFunc: func(ctx context.Context, candidates []domain.CandidateFinding) ([]domain.VerificationResult, error) {
+		results, _ := fixedResults("agent", 85)(ctx, candidates)
+		return withUsage(results, 1000, 200, 1.5), nil
+	}}
+
+	verifier := verify.NewDepthVerifier(batch, escalation, &mockVerifier{}, verify.DepthConfig{
+		Depth:      "medium",
+		Confidence: config.ConfidenceThresholds{Critical: 50, High: 60, Low: 80},
+	})
+	results, err := verifier.VerifyBatch(context.Background(), depthCandidates())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if r := results[0]; r.TokensIn != 1100 || r.TokensOut != 210 || r.Cost != 1.75 {
+		t.Errorf("expected escalated usage to include the batch share, got %d/%d/%v", r.TokensIn, r.TokensOut, r.Cost)
+	}
+	if r := results[1]; r.TokensIn != 100 || r.Cost != 0.25 {
+		t.Errorf("expected batch usage for b.go, got %d/%v", r.TokensIn, r.Cost)
+	}
+}
+
+func TestDepthVerifier_MediumKeepsBatchVerdictWhenAgentFails(t *testing.T) {
+	batch := &mockVerifier{verifyBatchFunc: fixedResults("batch", 10, 90, 10)}
+	escalation := &mockVerifier{
+		verifyBatchFunc: func(context.Context, []domain.CandidateFinding) ([]domain.VerificationResult, error) {
+			return nil, errors.New("agent failed")
+		},
+	}
+
+	verifier := verify.NewDepthVerifier(batch, escalation, escalation, verify.DepthConfig{Depth: "medium"})
+	results, err := verifier.VerifyBatch(context.Background(), depthCandidates())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if results[0].Path != "batch" || results[0].Confidence != 10 {
+		t.Errorf("expected batch verdict to be kept, got %+v", results[0])
+	}
+}
+
+func TestDepthVerifier_EmptyDepthUsesDefault(t *testing.T) {
+	batch := &mockVerifier{verifyBatchFunc: fixedResults("batch", 10, 10, 10)}
+	agent := &mockVerifier{}
+
+	verifier := verify.NewDepthVerifier(batch, agent, agent, verify.DepthConfig{Depth: "minimal"})
+	if _, err := verifier.VerifyBatchAtDepth(context.Background(), "", depthCandidates()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(agent.batches) != 0 {
+		t.Error("expected default minimal depth not to call the agent")
+	}
+}
@@ -8,8 +8,19 @@ import (
 	"github.com/bkyoung/code-reviewer/internal/domain"
 )
 
-// VerificationPrompt generates the system prompt for verification.
+// VerificationPrompt generates the system prompt for verification with the
+// text tool protocol (TOOL:/INPUT: blocks).
 func VerificationPrompt(tools []Tool) string {
+	return verificationPrompt(tools, false)
+}
+
+// NativeToolVerificationPrompt generates the system prompt for verification
+// when tools are declared through the provider's native tool calling.
+func NativeToolVerificationPrompt(tools []Tool) string {
+	return verificationPrompt(tools, true)
+}
+
+func verificationPrompt(tools []Tool, native bool) string {
 	var sb strings.Builder
 
 	sb.WriteString(`You are a code verification agent. Your task is to verify whether a reported code issue actually exists in the codebase.
@@ -77,6 +88,19 @@ Your confidence score (0-100) should reflect:
 	for _, tool := range tools {
 		sb.WriteString(fmt.Sprintf("- **%s**: %s\n", tool.Name(), tool.Description()))
 	}
+	if hasTool(tools, "find_references") {
+		sb.WriteString(`
+For claims about how code is used (e.g., "callers never handle this error"), look up the symbol with
+**find_definition** and check its call sites with **find_references** instead of inferring from grep.
+`)
+	}
+	if hasTool(tools, "test") {
+		sb.WriteString(`
+To confirm a claimed bug, you can write a short reproducer test with the **test** tool and run it.
+A failing reproducer that demonstrates the issue is strong evidence; quote the test name and its
+pass/fail output in your evidence. Keep reproducers small and targeted with -run.
+`)
+	}
 
 	sb.WriteString(`
 ## Response Format
@@ -91,7 +115,16 @@ After investigating, respond with a JSON object:
   "blocks_operation": true
 }
 ` + "```" + `
+`)
 
+	if native {
+		sb.WriteString(`
+## Tool Usage
+Call the tools provided to investigate. Each tool takes a single "input" argument.
+When you have enough evidence, reply with the verdict JSON and no tool calls.
+`)
+	} else {
+		sb.WriteString(`
 ## Tool Usage
 To use a tool, respond with:
 
@@ -101,7 +134,10 @@ INPUT: your input here
 ` + "```" + `
 
 After receiving the tool result, continue your investigation or provide your final verdict.
+`)
+	}
 
+	sb.WriteString(`
 ## Important Notes
 - Always read the relevant file(s) before making a determination
 - Do NOT assume the report is correct - verify it yourself
@@ -250,3 +286,13 @@ func ShouldBlockOperation(result domain.VerificationResult) bool {
 
 	return false
 }
+
+// hasTool reports whether a tool with the given name is available.
+func hasTool(tools []Tool, name string) bool {
+	for _, tool := range tools {
+		if tool.Name() == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,294 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/bkyoung/code-reviewer/internal/usecase/verify"
+)
+
+// IsolationBubblewrap runs commands under bwrap with only the system
+// directories and the Go toolchain mounted read-only, a private /tmp, an empty
+// home directory and all namespaces (including network) unshared.
+// It is the only isolation mechanism: unshare alone leaves the host
+// filesystem writable, so the sandbox refuses to run without bwrap.
+const IsolationBubblewrap = "bwrap"
+
+// ErrSandboxUnavailable is returned when no isolation mechanism is available.
+// The test tool refuses to run code rather than running it unsandboxed.
+var ErrSandboxUnavailable = errors.New("no sandbox available (requires Linux with bwrap)")
+
+// SandboxConfig configures the resource limits for sandboxed commands.
+type SandboxConfig struct {
+	// Timeout is the wall-clock limit for one command, including the build.
+	Timeout time.Duration
+
+	// MemoryMB limits the virtual memory of each process.
+	MemoryMB int
+
+	// CPUSeconds limits the CPU time of each process.
+	CPUSeconds int
+
+	// CacheDir is the Go build cache shared by sandboxed runs. It is kept
+	// separate from the user's cache because sandboxed code can write to it.
+	// Default: <user cache dir>/cr/sandbox-go-build
+	CacheDir string
+}
+
+// DefaultSandboxConfig returns sensible defaults.
+func DefaultSandboxConfig() SandboxConfig {
+	return SandboxConfig{
+		Timeout:    2 * time.Minute,
+		MemoryMB:   4096,
+		CPUSeconds: 300,
+	}
+}
+
+// Sandbox runs commands against a throwaway copy of a repository with the
+// network disabled and CPU, memory and time limits applied.
+type Sandbox struct {
+	config   SandboxConfig
+	lookPath func(file string) (string, error)
+}
+
+// NewSandbox creates a sandbox with the given limits. Zero values fall back
+// to DefaultSandboxConfig.
+func NewSandbox(config SandboxConfig) *Sandbox {
+	defaults := DefaultSandboxConfig()
+	if config.Timeout <= 0 {
+		config.Timeout = defaults.Timeout
+	}
+	if config.MemoryMB <= 0 {
+		config.MemoryMB = defaults.MemoryMB
+	}
+	if config.CPUSeconds <= 0 {
+		config.CPUSeconds = defaults.CPUSeconds
+	}
+	if config.CacheDir == "" {
+		if dir, err := os.UserCacheDir(); err == nil {
+			config.CacheDir = filepath.Join(dir, "cr", "sandbox-go-build")
+		}
+	}
+	return &Sandbox{config: config, lookPath: exec.LookPath}
+}
+
+// Isolation returns the isolation mechanism the sandbox will use, or
+// ErrSandboxUnavailable if none is installed.
+func (s *Sandbox) Isolation() (string, error) {
+	if runtime.GOOS != "linux" {
+		return "", ErrSandboxUnavailable
+	}
+	if _, err := s.lookPath("bwrap"); err != nil {
+		return "", ErrSandboxUnavailable
+	}
+	return IsolationBubblewrap, nil
+}
+
+// Timeout returns the wall-clock limit for one command.
+func (s *Sandbox) Timeout() time.Duration {
+	return s.config.Timeout
+}
+
+// Run executes args in dir inside the sandbox. dir must be a disposable copy;
+// it is the only path the command can write besides the build cache.
+// A non-zero exit status is reported in the result, not as an error.
+func (s *Sandbox) Run(ctx context.Context, dir string, args ...string) (verify.CommandResult, error) {
+	argv, err := s.command(dir, args)
+	if err != nil {
+		return verify.CommandResult{}, err
+	}
+	if err := os.MkdirAll(filepath.Join(dir, ".tmp"), 0o755); err != nil {
+		return verify.CommandResult{}, fmt.Errorf("creating sandbox temp dir: %w", err)
+	}
+	if s.config.CacheDir != "" {
+		if err := os.MkdirAll(s.config.CacheDir, 0o755); err != nil {
+			return verify.CommandResult{}, fmt.Errorf("creating sandbox build cache: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	command := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	command.Dir = dir
+	command.Env = s.environment(dir)
+	// Don't wait forever for pipes held open by stray grandchildren
+	command.WaitDelay = 5 * time.Second
+
+	var stdout, stderr strings.Builder
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+
+	err = command.Run()
+
+	result := verify.CommandResult{
+		Stdout: stdout.String(),
+		Stderr: stderr.String(),
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return result, fmt.Errorf("command timed out after %s", s.config.Timeout)
+	}
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			result.ExitCode = exitErr.ExitCode()
+			return result, nil
+		}
+		return result, fmt.Errorf("running sandboxed command: %w", err)
+	}
+	return result, nil
+}
+
+// systemDirs are the host directories mounted read-only into the sandbox so
+// the shell and the Go toolchain run. Missing ones are skipped. Nothing else
+// of the host filesystem is visible: not $HOME, the cr config with provider
+// API keys, SSH keys or forge tokens.
+var systemDirs = []string{
+	"/usr", "/bin", "/sbin", "/lib", "/lib32", "/lib64", "/libx32",
+	"/etc/ssl", "/etc/ld.so.cache", "/etc/ld.so.conf", "/etc/ld.so.conf.d",
+}
+
+// command wraps args with the isolation mechanism and a shell that applies
+// the resource limits before exec'ing the command.
+func (s *Sandbox) command(dir string, args []string) ([]string, error) {
+	if _, err := s.Isolation(); err != nil {
+		return nil, err
+	}
+
+	limits := fmt.Sprintf("ulimit -t %d && ulimit -v %d && exec \"$@\"", s.config.CPUSeconds, s.config.MemoryMB*1024)
+	inner := append([]string{"sh", "-c", limits, "sh"}, args...)
+
+	argv := []string{
+		"bwrap",
+		"--unshare-all",
+		"--die-with-parent",
+		"--new-session",
+	}
+	for _, path := range systemDirs {
+		argv = append(argv, "--ro-bind-try", path, path)
+	}
+	argv = append(argv,
+		"--dev", "/dev",
+		"--proc", "/proc",
+		"--tmpfs", "/tmp",
+	)
+	// Hide the real home directory; the toolchain, caches and worktree are
+	// mounted on top of it if they live there
+	if home, err := os.UserHomeDir(); err == nil && home != "/" {
+		argv = append(argv, "--tmpfs", home)
+	}
+	if goroot := s.goRoot(); goroot != "" {
+		argv = append(argv, "--ro-bind", goroot, goroot)
+	}
+	if modCache := goModCache(); modCache != "" {
+		argv = append(argv, "--ro-bind-try", modCache, modCache)
+	}
+	if s.config.CacheDir != "" {
+		argv = append(argv, "--bind", s.config.CacheDir, s.config.CacheDir)
+	}
+	argv = append(argv, "--bind", dir, dir, "--chdir", dir, "--")
+	return append(argv, inner...), nil
+}
+
+// goRoot returns the Go installation to mount: $GOROOT, or the root of the
+// go binary found in PATH.
+func (s *Sandbox) goRoot() string {
+	if goroot := os.Getenv("GOROOT"); goroot != "" {
+		return goroot
+	}
+	path, err := s.lookPath("go")
+	if err != nil {
+		return ""
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	return filepath.Dir(filepath.Dir(path))
+}
+
+// goModCache returns the module cache sandboxed builds read dependencies
+// from: $GOMODCACHE, or pkg/mod in the first GOPATH entry (default ~/go).
+func goModCache() string {
+	if modCache := os.Getenv("GOMODCACHE"); modCache != "" {
+		return modCache
+	}
+	if gopath := os.Getenv("GOPATH"); gopath != "" {
+		return filepath.Join(filepath.SplitList(gopath)[0], "pkg", "mod")
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, "go", "pkg", "mod")
+	}
+	return ""
+}
+
+// environment returns a minimal environment for sandboxed commands. API keys
+// and other secrets from the parent environment are not passed through, and
+// the Go toolchain is kept offline.
+func (s *Sandbox) environment(dir string) []string {
+	env := []string{
+		"HOME=" + dir,
+		"TMPDIR=" + filepath.Join(dir, ".tmp"),
+		"GOPROXY=off",
+		"GOFLAGS=-mod=readonly",
+		"GOTOOLCHAIN=local",
+		"GOTELEMETRY=off",
+	}
+	if value, ok := os.LookupEnv("PATH"); ok {
+		env = append(env, "PATH="+value)
+	}
+	if goroot := s.goRoot(); goroot != "" {
+		env = append(env, "GOROOT="+goroot)
+	}
+	if modCache := goModCache(); modCache != "" {
+		env = append(env, "GOMODCACHE="+modCache)
+	}
+	if s.config.CacheDir != "" {
+		env = append(env, "GOCACHE="+s.config.CacheDir)
+	}
+	return env
+}
+
+// copyWorktree copies the regular files of the repository at src into dst,
+// skipping the .git directory and symlinks so the copy cannot reach outside
+// itself.
+func copyWorktree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case d.IsDir():
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(target, 0o755)
+		case d.Type().IsRegular():
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(target, content, info.Mode().Perm())
+		default:
+			// Symlinks, sockets and devices are not copied
+			return nil
+		}
+	})
+}
@@ -0,0 +1,269 @@
+package verify_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/verify"
+)
+
+func TestTestTool_InputValidation(t *testing.T) {
+	tool := verify.NewTestTool(t.TempDir(), verify.NewSandbox(verify.SandboxConfig{}))
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "empty input", input: "", wantErr: "test command required"},
+		{name: "not go test", input: "go build ./...", wantErr: "only 'go test'"},
+		{name: "other command", input: "make test", wantErr: "only 'go test'"},
+		{name: "missing package", input: "go test -run TestX", wantErr: "package required"},
+		{name: "exec flag", input: "go test -exec /bin/sh ./pkg", wantErr: `flag "-exec" not allowed`},
+		{name: "toolexec flag", input: "go test -toolexec=x ./pkg", wantErr: "not allowed"},
+		{name: "absolute package", input: "go test /etc", wantErr: "relative path"},
+		{name: "package traversal", input: "go test ./../other", wantErr: "path traversal"},
+		{name: "invalid count", input: "go test -count=x ./pkg", wantErr: "invalid -count"},
+		{name: "run without pattern", input: "go test ./pkg -run", wantErr: "-run requires a pattern"},
+		{name: "missing reproducer marker", input: "go test ./pkg\npackage pkg", wantErr: "expected"},
+		{name: "reproducer not a test file", input: "go test ./pkg\n--- pkg/main.go\npackage pkg", wantErr: "_test.go"},
+		{name: "reproducer traversal", input: "go test ./pkg\n--- ../evil_test.go\npackage pkg", wantErr: "path traversal"},
+		{name: "reproducer in hidden dir", input: "go test ./pkg\n--- .git/hooks_test.go\npackage pkg", wantErr: "hidden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tool.Execute(context.Background(), tt.input)
+			if err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTestTool_RunsReproducerInSandbox(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds and runs Go tests")
+	}
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		t.Skip("no user cache dir")
+	}
+	sandbox := verify.NewSandbox(verify.SandboxConfig{
+		Timeout:  2 * time.Minute,
+		CacheDir: filepath.Join(cacheDir, "go-build"),
+	})
+	if _, err := sandbox.Isolation(); err != nil {
+		t.Skipf("sandbox unavailable: %v", err)
+	}
+
+	root := t.TempDir()
+	writeFile(t, root, "go.mod", "module example.com/sbx\n\ngo 1.21\n")
+	writeFile(t, root, "calc/calc.go", "package calc\n\nfunc Last(xs []int) int { return xs[len(xs)] }\n")
+	// The .git directory is never copied into the sandbox
+	writeFile(t, root, ".git/config", "[core]\n")
+
+	tool := verify.NewTestTool(root, sandbox)
+
+	input := `go test -v -run TestRepro ./calc
+--- calc/repro_test.go
+package calc
+
+import (
+	"net"
+	"os"
+	"testing"
+)
+
+func TestNoNetwork(t *testing.T) {
+	if _, err := net.Dial("tcp", "1.1.1.1:80"); err == nil {
+		t.Fatal("network should be unavailable")
+	}
+}
+
+func TestReproNoGitDir(t *testing.T) {
+	if _, err := os.Stat("../.git"); err == nil {
+		t.Fatal(".git should not be copied")
+	}
+	if os.Getenv("OPENAI_API_KEY") != "" {
+		t.Fatal("secrets should not be passed through")
+	}
+}
+
+func TestReproLast(t *testing.T) {
+	Last([]int{1, 2, 3})
+}
+`
+	t.Setenv("OPENAI_API_KEY", "sk-secret")
+
+	output, err := tool.Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(output, "Exit code: 1") {
+		t.Errorf("expected failing exit code, got:\n%s", output)
+	}
+	if !strings.Contains(output, "--- PASS: TestReproNoGitDir") {
+		t.Errorf("expected sandbox checks to pass, got:\n%s", output)
+	}
+	if !strings.Contains(output, "index out of range") {
+		t.Errorf("expected reproducer panic in output, got:\n%s", output)
+	}
+	// The reproducer is written to the copy, not the repository
+	if _, err := os.Stat(filepath.Join(root, "calc", "repro_test.go")); err == nil {
+		t.Error("reproducer should not be written to the repository")
+	}
+
+	// Network checks run separately so a failure is attributable
+	output, err = tool.Execute(context.Background(), strings.Replace(input, "-run TestRepro", "-run TestNoNetwork", 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "Exit code: 0") {
+		t.Errorf("expected network to be unavailable in the sandbox, got:\n%s", output)
+	}
+}
+
+func TestSandbox_RequiresBubblewrap(t *testing.T) {
+	// unshare alone leaves the host filesystem writable, so it is not enough
+	bin := t.TempDir()
+	writeFile(t, bin, "unshare", "#!/bin/sh\nexit 0\n")
+	if err := os.Chmod(filepath.Join(bin, "unshare"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+
+	sandbox := verify.NewSandbox(verify.SandboxConfig{})
+	if _, err := sandbox.Isolation(); !errors.Is(err, verify.ErrSandboxUnavailable) {
+		t.Errorf("expected ErrSandboxUnavailable without bwrap, got %v", err)
+	}
+	if _, err := sandbox.Run(context.Background(), t.TempDir(), "true"); !errors.Is(err, verify.ErrSandboxUnavailable) {
+		t.Errorf("expected Run to refuse without bwrap, got %v", err)
+	}
+}
+
+func TestSandbox_MountsOnlySystemDirsAndToolchain(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the sandbox requires Linux")
+	}
+	// A fake bwrap prints the arguments it was started with
+	bin := t.TempDir()
+	writeFile(t, bin, "bwrap", "#!/bin/sh\nprintf '%s\\n' \"$@\"\n")
+	if err := os.Chmod(filepath.Join(bin, "bwrap"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("GOROOT", "/opt/go")
+	t.Setenv("GOMODCACHE", filepath.Join(home, "go", "pkg", "mod"))
+	cacheDir := filepath.Join(home, ".cache", "cr-sandbox")
+
+	sandbox := verify.NewSandbox(verify.SandboxConfig{CacheDir: cacheDir})
+	dir := t.TempDir()
+	result, err := sandbox.Run(context.Background(), dir, "go", "test", "./...")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	args := result.Stdout
+
+	if strings.Contains(args, "--ro-bind\n/\n/\n") {
+		t.Errorf("the host root must not be mounted, got:\n%s", args)
+	}
+	for _, want := range []string{
+		"--ro-bind-try\n/usr\n/usr\n",
+		"--ro-bind-try\n/etc/ssl\n/etc/ssl\n",
+		"--tmpfs\n" + home + "\n",
+		"--ro-bind\n/opt/go\n/opt/go\n",
+		"--bind\n" + dir + "\n" + dir + "\n",
+	} {
+		if !strings.Contains(args, want) {
+			t.Errorf("expected bwrap arguments to contain %q, got:\n%s", want, args)
+		}
+	}
+	// The home tmpfs comes first so the caches under it stay visible
+	hidden := strings.Index(args, "--tmpfs\n"+home+"\n")
+	for _, path := range []string{filepath.Join(home, "go", "pkg", "mod"), cacheDir} {
+		if mounted := strings.Index(args, "\n"+path+"\n"); mounted < hidden {
+			t.Errorf("expected %s to be mounted over the home tmpfs, got:\n%s", path, args)
+		}
+	}
+}
+
+func TestTestTool_CannotReadHomeDirectory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds and runs Go tests")
+	}
+	sandbox := verify.NewSandbox(verify.SandboxConfig{Timeout: 2 * time.Minute})
+	if _, err := sandbox.Isolation(); err != nil {
+		t.Skipf("sandbox unavailable: %v", err)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+
+	// Plant a secret in the real home directory, as a config file with API
+	// keys or an SSH key would be
+	secret, err := os.CreateTemp(home, "cr-sandbox-secret-*")
+	if err != nil {
+		t.Skipf("home directory not writable: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Remove(secret.Name()) })
+	if _, err := secret.WriteString("sk-planted-secret"); err != nil {
+		t.Fatal(err)
+	}
+	_ = secret.Close()
+
+	root := t.TempDir()
+	writeFile(t, root, "go.mod", "module example.com/sbx\n\ngo 1.21\n")
+	writeFile(t, root, "leak/leak.go", "package leak\n")
+
+	input := fmt.Sprintf(`go test -v -run TestReadHome ./leak
+--- leak/read_test.go
+package leak
+
+import (
+	"os"
+	"testing"
+)
+
+func TestReadHome(t *testing.T) {
+	content, err := os.ReadFile(%q)
+	if err == nil {
+		t.Fatalf("read the home directory: %%s", content)
+	}
+}
+`, secret.Name())
+
+	output, err := verify.NewTestTool(root, sandbox).Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(output, "sk-planted-secret") || !strings.Contains(output, "--- PASS: TestReadHome") {
+		t.Errorf("expected the home directory to be unreadable in the sandbox, got:\n%s", output)
+	}
+}
+
+func writeFile(t *testing.T, root, path, content string) {
+	t.Helper()
+	full := filepath.Join(root, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
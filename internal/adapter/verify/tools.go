@@ -3,9 +3,12 @@ package verify
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/bkyoung/code-reviewer/internal/adapter/codenav"
 	"github.com/bkyoung/code-reviewer/internal/usecase/verify"
 )
 
@@ -27,13 +30,20 @@ type Tool interface {
 	Execute(ctx context.Context, input string) (string, error)
 }
 
+// maxNavigationResults caps the locations listed by the navigation tools.
+const maxNavigationResults = 100
+
 // NewToolRegistry creates all verification tools from a repository.
+// The navigation tools share one code index, built on first use.
 func NewToolRegistry(repo verify.Repository) []Tool {
+	index := codenav.NewIndex(repo)
 	return []Tool{
 		NewReadFileTool(repo),
 		NewGrepTool(repo),
 		NewGlobTool(repo),
 		NewBashTool(repo),
+		NewFindDefinitionTool(index),
+		NewFindReferencesTool(index),
 	}
 }
 
@@ -290,6 +300,111 @@ func (t *GlobTool) Execute(ctx context.Context, input string) (string, error) {
 	return truncateOutput(sb.String()), nil
 }
 
+// FindDefinitionTool locates where a symbol is declared.
+type FindDefinitionTool struct {
+	index *codenav.Index
+}
+
+// NewFindDefinitionTool creates a new find definition tool.
+func NewFindDefinitionTool(index *codenav.Index) *FindDefinitionTool {
+	return &FindDefinitionTool{index: index}
+}
+
+// Name returns the tool name.
+func (t *FindDefinitionTool) Name() string {
+	return "find_definition"
+}
+
+// Description returns the tool description.
+func (t *FindDefinitionTool) Description() string {
+	return "Find where a function, method, type, field, variable or constant is defined (type-checked for Go, pattern-based for other languages). " +
+		"Input: symbol name, optionally qualified by type or package (e.g., 'Verify', 'AgentVerifier.Verify', 'config.Load')"
+}
+
+// Execute looks up the symbol's definitions.
+func (t *FindDefinitionTool) Execute(ctx context.Context, input string) (string, error) {
+	symbol := strings.TrimSpace(input)
+	if symbol == "" {
+		return "", fmt.Errorf("symbol name required")
+	}
+
+	locations, err := t.index.Definitions(symbol)
+	if err != nil {
+		return "", fmt.Errorf("find definition %s: %w", symbol, err)
+	}
+
+	if len(locations) == 0 {
+		return "No definitions found", nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d definitions:\n", len(locations)))
+	for i, loc := range locations {
+		if i == maxNavigationResults {
+			sb.WriteString(fmt.Sprintf("... and %d more\n", len(locations)-i))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("%s:%d: [%s %s] %s\n", loc.File, loc.Line, loc.Kind, loc.Symbol, loc.Content))
+	}
+
+	return truncateOutput(sb.String()), nil
+}
+
+// FindReferencesTool lists the places a symbol is used.
+type FindReferencesTool struct {
+	index *codenav.Index
+}
+
+// NewFindReferencesTool creates a new find references tool.
+func NewFindReferencesTool(index *codenav.Index) *FindReferencesTool {
+	return &FindReferencesTool{index: index}
+}
+
+// Name returns the tool name.
+func (t *FindReferencesTool) Name() string {
+	return "find_references"
+}
+
+// Description returns the tool description.
+func (t *FindReferencesTool) Description() string {
+	return "Find where a symbol is used, with the enclosing function of each use (e.g., to check how callers handle an error). " +
+		"Go references are resolved by the type checker; other languages match the name. " +
+		"Input: symbol name, optionally qualified (e.g., 'Parse', 'Client.Do', 'config.Load')"
+}
+
+// Execute lists the references to the symbol.
+func (t *FindReferencesTool) Execute(ctx context.Context, input string) (string, error) {
+	symbol := strings.TrimSpace(input)
+	if symbol == "" {
+		return "", fmt.Errorf("symbol name required")
+	}
+
+	locations, err := t.index.References(symbol)
+	if err != nil {
+		return "", fmt.Errorf("find references %s: %w", symbol, err)
+	}
+
+	if len(locations) == 0 {
+		return "No references found", nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d references:\n", len(locations)))
+	for i, loc := range locations {
+		if i == maxNavigationResults {
+			sb.WriteString(fmt.Sprintf("... and %d more\n", len(locations)-i))
+			break
+		}
+		if loc.Enclosing != "" {
+			sb.WriteString(fmt.Sprintf("%s:%d: (in %s) %s\n", loc.File, loc.Line, loc.Enclosing, loc.Content))
+		} else {
+			sb.WriteString(fmt.Sprintf("%s:%d: %s\n", loc.File, loc.Line, loc.Content))
+		}
+	}
+
+	return truncateOutput(sb.String()), nil
+}
+
 // BashTool runs safe commands in the repository.
 type BashTool struct {
 	repo verify.Repository
@@ -461,6 +576,169 @@ func (t *BashTool) Execute(ctx context.Context, input string) (string, error) {
 	return truncateOutput(sb.String()), nil
 }
 
+// TestTool runs a targeted Go test inside a Sandbox, optionally after adding a
+// reproducer test file. It is opt-in because, unlike the other tools, it
+// executes code from the repository under review.
+type TestTool struct {
+	root    string
+	sandbox *Sandbox
+}
+
+// NewTestTool creates a test tool for the repository rooted at root.
+func NewTestTool(root string, sandbox *Sandbox) *TestTool {
+	return &TestTool{root: root, sandbox: sandbox}
+}
+
+// Name returns the tool name.
+func (t *TestTool) Name() string {
+	return "test"
+}
+
+// Description returns the tool description.
+func (t *TestTool) Description() string {
+	return "Run a Go test in a sandbox (no network, time and memory limits) against a copy of the repository. " +
+		"Input: a go test command on the first line (e.g., 'go test -run TestParse ./internal/parser'); " +
+		"to add a reproducer, follow it with a line '--- <path>_test.go' and the file contents. " +
+		"Allowed flags: -run, -v, -short, -count=N"
+}
+
+// reproducerMarker introduces a reproducer file in the test tool input.
+const reproducerMarker = "--- "
+
+// Execute copies the repository, writes the reproducer (if any) and runs the
+// test command in the sandbox.
+func (t *TestTool) Execute(ctx context.Context, input string) (string, error) {
+	args, reproducerPath, reproducer, err := parseTestInput(input)
+	if err != nil {
+		return "", err
+	}
+	isolation, err := t.sandbox.Isolation()
+	if err != nil {
+		return "", err
+	}
+
+	workdir, err := os.MkdirTemp("", "cr-test-sandbox-")
+	if err != nil {
+		return "", fmt.Errorf("creating sandbox worktree: %w", err)
+	}
+	defer os.RemoveAll(workdir)
+
+	if err := copyWorktree(t.root, workdir); err != nil {
+		return "", fmt.Errorf("copying repository to sandbox: %w", err)
+	}
+	if reproducerPath != "" {
+		target := filepath.Join(workdir, filepath.FromSlash(reproducerPath))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return "", fmt.Errorf("writing reproducer %s: %w", reproducerPath, err)
+		}
+		if err := os.WriteFile(target, []byte(reproducer), 0o644); err != nil {
+			return "", fmt.Errorf("writing reproducer %s: %w", reproducerPath, err)
+		}
+	}
+
+	// Let go test report a hung test with stack traces before the sandbox kills it
+	args = append(args, "-timeout", t.sandbox.Timeout().String())
+	result, err := t.sandbox.Run(ctx, workdir, append([]string{"go", "test"}, args...)...)
+	if err != nil {
+		return "", fmt.Errorf("running test: %w", err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Sandbox: %s\n", isolation))
+	sb.WriteString(fmt.Sprintf("Exit code: %d\n", result.ExitCode))
+
+	if result.Stdout != "" {
+		sb.WriteString("Stdout:\n")
+		sb.WriteString(result.Stdout)
+		sb.WriteString("\n")
+	}
+
+	if result.Stderr != "" {
+		sb.WriteString("Stderr:\n")
+		sb.WriteString(result.Stderr)
+		sb.WriteString("\n")
+	}
+
+	return truncateOutput(sb.String()), nil
+}
+
+// parseTestInput validates the test tool input. It returns the go test
+// arguments and, if present, the reproducer path and contents.
+func parseTestInput(input string) (args []string, reproducerPath, reproducer string, err error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, "", "", fmt.Errorf("test command required")
+	}
+
+	commandLine, rest, _ := strings.Cut(input, "\n")
+	if rest != "" {
+		header, body, _ := strings.Cut(rest, "\n")
+		if !strings.HasPrefix(header, reproducerMarker) {
+			return nil, "", "", fmt.Errorf("expected %q line after the test command", reproducerMarker+"<path>_test.go")
+		}
+		reproducerPath = strings.TrimSpace(strings.TrimPrefix(header, reproducerMarker))
+		if !strings.HasSuffix(reproducerPath, "_test.go") {
+			return nil, "", "", fmt.Errorf("reproducer must be a _test.go file: %s", reproducerPath)
+		}
+		if err := validatePath(reproducerPath); err != nil {
+			return nil, "", "", err
+		}
+		reproducer = body
+	}
+
+	fields := strings.Fields(commandLine)
+	if len(fields) < 2 || fields[0] != "go" || fields[1] != "test" {
+		return nil, "", "", fmt.Errorf("only 'go test' commands are allowed")
+	}
+
+	hasPackage := false
+	for i := 2; i < len(fields); i++ {
+		field := fields[i]
+		switch {
+		case field == "-v" || field == "-short":
+			args = append(args, field)
+		case field == "-run":
+			if i+1 >= len(fields) {
+				return nil, "", "", fmt.Errorf("-run requires a pattern")
+			}
+			i++
+			args = append(args, "-run", strings.Trim(fields[i], `'"`))
+		case strings.HasPrefix(field, "-run="):
+			args = append(args, "-run", strings.Trim(strings.TrimPrefix(field, "-run="), `'"`))
+		case strings.HasPrefix(field, "-count="):
+			if _, err := strconv.Atoi(strings.TrimPrefix(field, "-count=")); err != nil {
+				return nil, "", "", fmt.Errorf("invalid -count: %s", field)
+			}
+			args = append(args, field)
+		case strings.HasPrefix(field, "-"):
+			return nil, "", "", fmt.Errorf("flag %q not allowed (allowed: -run, -v, -short, -count=N)", field)
+		default:
+			if err := validateTestPackage(field); err != nil {
+				return nil, "", "", err
+			}
+			args = append(args, field)
+			hasPackage = true
+		}
+	}
+	if !hasPackage {
+		return nil, "", "", fmt.Errorf("package required (e.g., ./internal/parser)")
+	}
+
+	return args, reproducerPath, reproducer, nil
+}
+
+// validateTestPackage checks that a package argument is a relative package
+// path inside the repository, such as ./pkg or ./pkg/...
+func validateTestPackage(pkg string) error {
+	if pkg == "." || pkg == "./..." {
+		return nil
+	}
+	if !strings.HasPrefix(pkg, "./") {
+		return fmt.Errorf("package must be a relative path starting with ./: %s", pkg)
+	}
+	return validatePath(strings.TrimSuffix(strings.TrimPrefix(pkg, "./"), "/..."))
+}
+
 // truncateOutput truncates output that exceeds MaxToolOutputLength.
 func truncateOutput(s string) string {
 	if len(s) <= MaxToolOutputLength {
@@ -2,9 +2,11 @@ package verify_test
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/bkyoung/code-reviewer/internal/adapter/codenav"
 	"github.com/bkyoung/code-reviewer/internal/adapter/verify"
 	usecaseverify "github.com/bkyoung/code-reviewer/internal/usecase/verify"
 )
@@ -352,13 +354,80 @@ func TestBashTool(t *testing.T) {
 	})
 }
 
+func TestNavigationTools(t *testing.T) {
+	files := map[string]string{
+		"parser/parse.go": "package parser\n\nfunc Parse(s string) (int, error) { return 0, nil }\n",
+		"cmd/main.go":     "package main\n\nimport \"parser\"\n\nfunc run() {\n\tparser.Parse(\"1\")\n}\n",
+	}
+	repo := &mockRepository{
+		globFunc: func(pattern string) ([]string, error) {
+			return []string{"parser/parse.go", "cmd/main.go"}, nil
+		},
+		readFileFunc: func(path string) ([]byte, error) {
+			if content, ok := files[path]; ok {
+				return []byte(content), nil
+			}
+			return nil, fmt.Errorf("not found: %s", path)
+		},
+	}
+	index := codenav.NewIndex(repo)
+
+	t.Run("find_definition lists definitions", func(t *testing.T) {
+		tool := verify.NewFindDefinitionTool(index)
+		if tool.Name() != "find_definition" {
+			t.Errorf("got name %q, want %q", tool.Name(), "find_definition")
+		}
+
+		result, err := tool.Execute(context.Background(), "Parse")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(result, "parser/parse.go:3: [func parser.Parse]") {
+			t.Errorf("unexpected result: %s", result)
+		}
+	})
+
+	t.Run("find_references lists uses with enclosing function", func(t *testing.T) {
+		tool := verify.NewFindReferencesTool(index)
+		if tool.Name() != "find_references" {
+			t.Errorf("got name %q, want %q", tool.Name(), "find_references")
+		}
+
+		result, err := tool.Execute(context.Background(), "parser.Parse")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(result, "cmd/main.go:6: (in run) parser.Parse(\"1\")") {
+			t.Errorf("unexpected result: %s", result)
+		}
+	})
+
+	t.Run("reports no matches", func(t *testing.T) {
+		tool := verify.NewFindReferencesTool(index)
+		result, err := tool.Execute(context.Background(), "Missing")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result != "No references found" {
+			t.Errorf("unexpected result: %s", result)
+		}
+	})
+
+	t.Run("requires symbol", func(t *testing.T) {
+		tool := verify.NewFindDefinitionTool(index)
+		if _, err := tool.Execute(context.Background(), "  "); err == nil {
+			t.Error("expected error for empty symbol")
+		}
+	})
+}
+
 func TestToolRegistry(t *testing.T) {
 	t.Run("creates all tools from repository", func(t *testing.T) {
 		repo := &mockRepository{}
 		tools := verify.NewToolRegistry(repo)
 
-		if len(tools) != 4 {
-			t.Errorf("got %d tools, want 4", len(tools))
+		if len(tools) != 6 {
+			t.Errorf("got %d tools, want 6", len(tools))
 		}
 
 		names := make(map[string]bool)
@@ -366,7 +435,7 @@ func TestToolRegistry(t *testing.T) {
 			names[tool.Name()] = true
 		}
 
-		expected := []string{"read_file", "grep", "glob", "bash"}
+		expected := []string{"read_file", "grep", "glob", "bash", "find_definition", "find_references"}
 		for _, name := range expected {
 			if !names[name] {
 				t.Errorf("missing tool %q", name)
@@ -12,6 +12,7 @@ type Config struct {
 	Merge         MergeConfig               `yaml:"merge"`
 	Planning      PlanningConfig            `yaml:"planning"`
 	Git           GitConfig                 `yaml:"git"`
+	GitHub        GitHubConfig              `yaml:"github"`
 	Output        OutputConfig              `yaml:"output"`
 	Budget        BudgetConfig              `yaml:"budget"`
 	Redaction     RedactionConfig           `yaml:"redaction"`
@@ -22,6 +23,8 @@ type Config struct {
 	Verification  VerificationConfig        `yaml:"verification"`
 	Deduplication DeduplicationConfig       `yaml:"deduplication"`
 	SizeGuards    SizeGuardsConfig          `yaml:"sizeGuards"`
+	Context       ContextConfig             `yaml:"context"`
+	Serve         ServeConfig               `yaml:"serve"`
 }
 
 // ProviderConfig configures a single LLM provider.
@@ -38,6 +41,11 @@ type ProviderConfig struct {
 	Model   string `yaml:"model"`
 	APIKey  string `yaml:"apiKey"`
 
+	// FallbackModels are tried in order when Model fails with anything but
+	// an authentication error (e.g. the model is unavailable or retired).
+	// The model that produced the review is recorded in its ModelName.
+	FallbackModels []string `yaml:"fallbackModels,omitempty"`
+
 	// MaxOutputTokens overrides the default max output tokens for this provider.
 	// Use this for models with different output limits (e.g., older models with 8K,
 	// or newer models with 128K+). Default: 64000 (works for Claude 4.5, GPT-5.2, Gemini 3).
@@ -48,6 +56,11 @@ type ProviderConfig struct {
 	MaxRetries     *int    `yaml:"maxRetries,omitempty"`
 	InitialBackoff *string `yaml:"initialBackoff,omitempty"`
 	MaxBackoff     *string `yaml:"maxBackoff,omitempty"`
+
+	// Client-side rate limits (optional, use global HTTP config if not set).
+	// Size them to the vendor's limits for your account tier; 0 is unlimited.
+	RequestsPerMinute *int `yaml:"requestsPerMinute,omitempty"`
+	TokensPerMinute   *int `yaml:"tokensPerMinute,omitempty"`
 }
 
 // HTTPConfig holds global HTTP client settings.
@@ -57,6 +70,20 @@ type HTTPConfig struct {
 	InitialBackoff    string  `yaml:"initialBackoff"`
 	MaxBackoff        string  `yaml:"maxBackoff"`
 	BackoffMultiplier float64 `yaml:"backoffMultiplier"`
+
+	// Client-side rate limits applied per provider; 0 is unlimited.
+	RequestsPerMinute int `yaml:"requestsPerMinute"`
+	TokensPerMinute   int `yaml:"tokensPerMinute"`
+
+	CircuitBreaker CircuitBreakerConfig `yaml:"circuitBreaker"`
+}
+
+// CircuitBreakerConfig configures the per-provider circuit breaker, which
+// fails calls fast after consecutive server errors or timeouts instead of
+// retrying a provider that is down.
+type CircuitBreakerConfig struct {
+	FailureThreshold int    `yaml:"failureThreshold"` // Consecutive failures that open the breaker (0 disables)
+	Cooldown         string `yaml:"cooldown"`         // How long the breaker stays open before a trial call (default: "30s")
 }
 
 type MergeConfig struct {
@@ -82,8 +109,25 @@ type GitConfig struct {
 	RepositoryDir string `yaml:"repositoryDir"`
 }
 
+// GitHubConfig configures the GitHub API connection.
+type GitHubConfig struct {
+	// APIURL is the REST API base URL. Set it for GitHub Enterprise Server,
+	// e.g. "https://ghe.example.com/api/v3". When empty, GITHUB_API_URL is
+	// used (set automatically in GitHub Actions), then https://api.github.com.
+	APIURL string `yaml:"apiURL"`
+}
+
 type OutputConfig struct {
 	Directory string `yaml:"directory"`
+
+	// Formats lists the output formats to write: markdown, json, sarif,
+	// html, junit, codeclimate, rdjson and rdjsonl. Empty writes markdown,
+	// json, sarif and html.
+	Formats []string `yaml:"formats"`
+
+	// MergedOnly writes outputs for the merged review only, skipping each
+	// provider's review.
+	MergedOnly bool `yaml:"mergedOnly"`
 }
 
 type BudgetConfig struct {
@@ -109,10 +153,19 @@ type StoreConfig struct {
 	Path    string `yaml:"path"`
 }
 
-// ObservabilityConfig configures logging, metrics, and cost tracking.
+// ServeConfig configures the HTTP API of cr serve.
+type ServeConfig struct {
+	Addr        string `yaml:"addr"`        // Listen address (default 127.0.0.1:8080)
+	Token       string `yaml:"token"`       // Bearer token clients must send; required
+	Concurrency int    `yaml:"concurrency"` // Reviews run at once (default 1)
+	MaxQueued   int    `yaml:"maxQueued"`   // Reviews waiting to run before new ones are rejected (default 100)
+}
+
+// ObservabilityConfig configures logging, metrics, tracing and cost tracking.
 type ObservabilityConfig struct {
 	Logging LoggingConfig `yaml:"logging"`
 	Metrics MetricsConfig `yaml:"metrics"`
+	Tracing TracingConfig `yaml:"tracing"`
 }
 
 // LoggingConfig configures request/response logging.
@@ -128,6 +181,15 @@ type MetricsConfig struct {
 	Enabled bool `yaml:"enabled"`
 }
 
+// TracingConfig configures OpenTelemetry tracing of the review pipeline.
+type TracingConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	Exporter    string `yaml:"exporter"`    // otlp, file
+	Endpoint    string `yaml:"endpoint"`    // OTLP/HTTP endpoint URL; empty uses OTEL_EXPORTER_OTLP_ENDPOINT
+	File        string `yaml:"file"`        // JSON lines file for the file exporter
+	ServiceName string `yaml:"serviceName"` // service.name resource attribute
+}
+
 // ReviewConfig configures the code review behavior.
 type ReviewConfig struct {
 	// Instructions are custom instructions included in all review prompts.
@@ -159,6 +221,57 @@ type ReviewConfig struct {
 	// it blocks even if the severity threshold would not.
 	// Example: ["security", "bug"] - security and bug findings always block
 	AlwaysBlockCategories []string `yaml:"alwaysBlockCategories"`
+
+	// CodeContext adds the enclosing function or type and the file's imports
+	// around each diff hunk, so the reviewer sees more than git's 3 lines.
+	CodeContext CodeContextConfig `yaml:"codeContext"`
+
+	// ImpactAnalysis lists the callers of changed Go functions and methods,
+	// so the reviewer can flag callers broken by the change.
+	ImpactAnalysis ImpactAnalysisConfig `yaml:"impactAnalysis"`
+
+	// GeneratedFiles controls skipping generated files, vendored code and
+	// lockfiles, which otherwise spend the review's size budget on noise.
+	GeneratedFiles GeneratedFilesConfig `yaml:"generatedFiles"`
+}
+
+// GeneratedFilesConfig configures the detection of generated and vendored files.
+type GeneratedFilesConfig struct {
+	// Skip excludes files with a "Code generated ... DO NOT EDIT." header,
+	// files marked linguist-generated or linguist-vendored in .gitattributes,
+	// lockfiles, and files under vendor/ or node_modules/ from review.
+	// Default: true
+	Skip bool `yaml:"skip"`
+}
+
+// ImpactAnalysisConfig configures the caller listing for changed Go functions.
+type ImpactAnalysisConfig struct {
+	// Enabled adds the "Affected Call Sites" section to the review prompt.
+	// Default: true
+	Enabled bool `yaml:"enabled"`
+
+	// MaxCallSites caps the call sites listed across all changed functions.
+	// Default: 40
+	MaxCallSites int `yaml:"maxCallSites"`
+
+	// MaxPerFunction caps the call sites listed for one changed function.
+	// Default: 10
+	MaxPerFunction int `yaml:"maxPerFunction"`
+}
+
+// CodeContextConfig configures the code context added around diff hunks.
+type CodeContextConfig struct {
+	// Enabled adds enclosing declarations and imports to the review prompt.
+	// Default: true
+	Enabled bool `yaml:"enabled"`
+
+	// MaxLinesPerFile caps the context lines added for one file.
+	// Default: 300
+	MaxLinesPerFile int `yaml:"maxLinesPerFile"`
+
+	// MaxTotalLines caps the context lines added across all files.
+	// Default: 3000
+	MaxTotalLines int `yaml:"maxTotalLines"`
 }
 
 // ReviewActions maps finding severities to GitHub review actions.
@@ -203,8 +316,9 @@ type VerificationConfig struct {
 	// Default: 64000 (large enough for many findings)
 	MaxTokens int `yaml:"maxTokens"`
 
-	// Depth controls how thoroughly the agent verifies findings.
-	// Valid values: "quick" (read file only), "medium" (read + grep), "deep" (run build/tests).
+	// Depth selects the verification strategy.
+	// Valid values: "minimal" (batch verifier), "medium" (batch, escalating
+	// low-confidence high/critical findings to the agent), "thorough" (agent).
 	Depth string `yaml:"depth"`
 
 	// CostCeiling is the maximum USD to spend on verification per review.
@@ -213,6 +327,30 @@ type VerificationConfig struct {
 
 	// Confidence contains per-severity confidence thresholds.
 	Confidence ConfidenceThresholds `yaml:"confidence"`
+
+	// TestExecution configures the opt-in sandboxed test tool for the agent.
+	TestExecution TestExecutionConfig `yaml:"testExecution"`
+}
+
+// TestExecutionConfig configures the verification agent's "test" tool, which
+// runs targeted Go tests (including reproducers written by the agent) against
+// a copy of the repository with the network disabled and resource limits.
+// It executes code from the change under review, so it is disabled by default.
+type TestExecutionConfig struct {
+	// Enabled offers the test tool to the verification agent.
+	Enabled bool `yaml:"enabled"`
+
+	// Timeout is the wall-clock limit for one test run, including the build.
+	// Default: "2m"
+	Timeout string `yaml:"timeout"`
+
+	// MemoryMB limits the virtual memory of each sandboxed process.
+	// Default: 4096
+	MemoryMB int `yaml:"memoryMB"`
+
+	// CPUSeconds limits the CPU time of each sandboxed process.
+	// Default: 300
+	CPUSeconds int `yaml:"cpuSeconds"`
 }
 
 // ConfidenceThresholds define minimum confidence levels (0-100) for reporting findings.
@@ -257,6 +395,7 @@ func merge(base, overlay Config) Config {
 	result.HTTP = chooseHTTP(base.HTTP, overlay.HTTP)
 	result.Output = chooseOutput(base.Output, overlay.Output)
 	result.Git = chooseGit(base.Git, overlay.Git)
+	result.GitHub = chooseGitHub(base.GitHub, overlay.GitHub)
 	result.Budget = chooseBudget(base.Budget, overlay.Budget)
 	result.Redaction = chooseRedaction(base.Redaction, overlay.Redaction)
 	result.Determinism = chooseDeterminism(base.Determinism, overlay.Determinism)
@@ -268,6 +407,8 @@ func merge(base, overlay Config) Config {
 	result.Verification = chooseVerification(base.Verification, overlay.Verification)
 	result.Deduplication = chooseDeduplication(base.Deduplication, overlay.Deduplication)
 	result.SizeGuards = chooseSizeGuards(base.SizeGuards, overlay.SizeGuards)
+	result.Context = chooseContext(base.Context, overlay.Context)
+	result.Serve = chooseServe(base.Serve, overlay.Serve)
 	result.Providers = mergeProviders(base.Providers, overlay.Providers)
 
 	return result
@@ -288,7 +429,7 @@ func mergeProviders(base, overlay map[string]ProviderConfig) map[string]Provider
 }
 
 func chooseOutput(base, overlay OutputConfig) OutputConfig {
-	if overlay.Directory != "" {
+	if overlay.Directory != "" || len(overlay.Formats) > 0 || overlay.MergedOnly {
 		return overlay
 	}
 	return base
@@ -301,8 +442,16 @@ func chooseGit(base, overlay GitConfig) GitConfig {
 	return base
 }
 
+func chooseGitHub(base, overlay GitHubConfig) GitHubConfig {
+	if overlay.APIURL != "" {
+		return overlay
+	}
+	return base
+}
+
 func chooseHTTP(base, overlay HTTPConfig) HTTPConfig {
-	if overlay.Timeout != "" || overlay.MaxRetries != 0 || overlay.InitialBackoff != "" || overlay.MaxBackoff != "" || overlay.BackoffMultiplier != 0 {
+	if overlay.Timeout != "" || overlay.MaxRetries != 0 || overlay.InitialBackoff != "" || overlay.MaxBackoff != "" || overlay.BackoffMultiplier != 0 ||
+		overlay.RequestsPerMinute != 0 || overlay.TokensPerMinute != 0 || overlay.CircuitBreaker != (CircuitBreakerConfig{}) {
 		return overlay
 	}
 	return base
@@ -350,6 +499,13 @@ func chooseStore(base, overlay StoreConfig) StoreConfig {
 	return base
 }
 
+func chooseServe(base, overlay ServeConfig) ServeConfig {
+	if overlay.Addr != "" || overlay.Token != "" || overlay.Concurrency != 0 || overlay.MaxQueued != 0 {
+		return overlay
+	}
+	return base
+}
+
 func chooseObservability(base, overlay ObservabilityConfig) ObservabilityConfig {
 	result := base
 
@@ -363,6 +519,12 @@ func chooseObservability(base, overlay ObservabilityConfig) ObservabilityConfig
 		result.Metrics = overlay.Metrics
 	}
 
+	// Merge tracing config
+	if overlay.Tracing.Enabled || overlay.Tracing.Exporter != "" || overlay.Tracing.Endpoint != "" ||
+		overlay.Tracing.File != "" || overlay.Tracing.ServiceName != "" {
+		result.Tracing = overlay.Tracing
+	}
+
 	return result
 }
 
@@ -397,9 +559,32 @@ func chooseReview(base, overlay ReviewConfig) ReviewConfig {
 	// AlwaysBlockCategories: union of base and overlay (additive)
 	result.AlwaysBlockCategories = mergeCategories(base.AlwaysBlockCategories, overlay.AlwaysBlockCategories)
 
+	// CodeContext: overlay wins if any field is set
+	if hasCodeContextConfig(overlay.CodeContext) {
+		result.CodeContext = overlay.CodeContext
+	}
+
+	// ImpactAnalysis: overlay wins if any field is set
+	if hasImpactAnalysisConfig(overlay.ImpactAnalysis) {
+		result.ImpactAnalysis = overlay.ImpactAnalysis
+	}
+
+	// GeneratedFiles: overlay wins if set
+	if overlay.GeneratedFiles.Skip {
+		result.GeneratedFiles = overlay.GeneratedFiles
+	}
+
 	return result
 }
 
+func hasCodeContextConfig(cc CodeContextConfig) bool {
+	return cc.Enabled || cc.MaxLinesPerFile != 0 || cc.MaxTotalLines != 0
+}
+
+func hasImpactAnalysisConfig(ic ImpactAnalysisConfig) bool {
+	return ic.Enabled || ic.MaxCallSites != 0 || ic.MaxPerFunction != 0
+}
+
 // hasAny returns true if any action field is non-empty.
 func (a ReviewActions) hasAny() bool {
 	return a.OnCritical != "" || a.OnHigh != "" || a.OnMedium != "" || a.OnLow != "" || a.OnClean != "" || a.OnNonBlocking != ""
@@ -592,6 +777,11 @@ func chooseVerification(base, overlay VerificationConfig) VerificationConfig {
 		result.Confidence = overlay.Confidence
 	}
 
+	// TestExecution: overlay wins if any field is set
+	if hasTestExecutionConfig(overlay.TestExecution) {
+		result.TestExecution = overlay.TestExecution
+	}
+
 	return result
 }
 
@@ -604,13 +794,115 @@ func hasAnyVerificationConfig(vc VerificationConfig) bool {
 		vc.MaxTokens != 0 ||
 		vc.Depth != "" ||
 		vc.CostCeiling != 0 ||
-		hasConfidenceThresholds(vc.Confidence)
+		hasConfidenceThresholds(vc.Confidence) ||
+		hasTestExecutionConfig(vc.TestExecution)
+}
+
+func hasTestExecutionConfig(tc TestExecutionConfig) bool {
+	return tc.Enabled || tc.Timeout != "" || tc.MemoryMB != 0 || tc.CPUSeconds != 0
 }
 
 func hasConfidenceThresholds(ct ConfidenceThresholds) bool {
 	return ct.Default != 0 || ct.Critical != 0 || ct.High != 0 || ct.Medium != 0 || ct.Low != 0
 }
 
+// ContextConfig configures how project context is gathered for reviews.
+// Rules map changed paths to change-type tags, docs and instructions, for
+// repositories whose conventions the built-in path heuristics don't fit.
+type ContextConfig struct {
+	// DesignDocs are globs of design documents included in every review.
+	// Default: ["docs/*_DESIGN.md"]
+	DesignDocs []string `yaml:"designDocs"`
+
+	// DisableBuiltinRules turns off the built-in path heuristics (e.g. paths
+	// containing "auth" are tagged "auth") and the docs they pull in.
+	DisableBuiltinRules bool `yaml:"disableBuiltinRules"`
+
+	// Rules apply when any changed path matches one of their globs.
+	// Rules from all config files are combined.
+	Rules []ContextRule `yaml:"rules"`
+
+	// Tags adds docs and instructions whenever a change type applies,
+	// whether it came from a rule or the built-in heuristics.
+	Tags map[string]ContextTag `yaml:"tags"`
+
+	// DirectoryContext includes .cr/context.md files from the directories
+	// of changed files and their parents.
+	// Default: true
+	DirectoryContext *bool `yaml:"directoryContext,omitempty"`
+}
+
+// ContextRule maps changed paths to tags, docs and instructions.
+type ContextRule struct {
+	// Paths are globs matched against changed paths. "**" matches any number
+	// of directories; a pattern without "/" matches file names at any depth.
+	// Example: ["services/billing/**", "*.sql"]
+	Paths []string `yaml:"paths"`
+
+	// Tags are change types added to the review (e.g. "billing").
+	Tags []string `yaml:"tags"`
+
+	// Docs are paths or globs of documents included in the prompt.
+	Docs []string `yaml:"docs"`
+
+	// Instructions are review instructions included in the prompt.
+	Instructions string `yaml:"instructions"`
+}
+
+// ContextTag holds the docs and instructions for one change type.
+type ContextTag struct {
+	// Docs are paths or globs of documents included in the prompt.
+	Docs []string `yaml:"docs"`
+
+	// Instructions are review instructions included in the prompt.
+	Instructions string `yaml:"instructions"`
+}
+
+// IsDirectoryContextEnabled returns whether .cr/context.md files are included.
+// Defaults to true if not explicitly set.
+func (c ContextConfig) IsDirectoryContextEnabled() bool {
+	if c.DirectoryContext == nil {
+		return true
+	}
+	return *c.DirectoryContext
+}
+
+// chooseContext merges ContextConfig with overlay taking precedence.
+func chooseContext(base, overlay ContextConfig) ContextConfig {
+	result := base
+
+	// DesignDocs: overlay wins if non-empty
+	if len(overlay.DesignDocs) > 0 {
+		result.DesignDocs = overlay.DesignDocs
+	}
+
+	// DisableBuiltinRules: either source can disable
+	result.DisableBuiltinRules = base.DisableBuiltinRules || overlay.DisableBuiltinRules
+
+	// Rules: base rules followed by overlay rules (additive)
+	if len(overlay.Rules) > 0 {
+		result.Rules = append(append([]ContextRule(nil), base.Rules...), overlay.Rules...)
+	}
+
+	// Tags: merge maps, overlay wins per tag
+	if len(overlay.Tags) > 0 {
+		result.Tags = make(map[string]ContextTag, len(base.Tags)+len(overlay.Tags))
+		for tag, value := range base.Tags {
+			result.Tags[tag] = value
+		}
+		for tag, value := range overlay.Tags {
+			result.Tags[tag] = value
+		}
+	}
+
+	// DirectoryContext: overlay wins if set (not nil)
+	if overlay.DirectoryContext != nil {
+		result.DirectoryContext = overlay.DirectoryContext
+	}
+
+	return result
+}
+
 // DeduplicationConfig configures semantic deduplication of findings.
 // When enabled, findings that overlap spatially but have different fingerprints
 // are compared using an LLM to detect semantic duplicates.
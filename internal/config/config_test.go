@@ -53,6 +53,101 @@ func TestLoadReadsFromFileAndEnv(t *testing.T) {
 	}
 }
 
+func TestLoadReadsOutputSelection(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "cr.yaml")
+	if err := os.WriteFile(file, []byte("output:\n  formats: [json, junit]\n  mergedOnly: true\n"), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := config.Load(config.LoaderOptions{
+		ConfigPaths: []string{dir},
+		FileName:    "cr",
+		EnvPrefix:   "CR",
+	})
+	if err != nil {
+		t.Fatalf("load returned error: %v", err)
+	}
+
+	if strings.Join(cfg.Output.Formats, ",") != "json,junit" || !cfg.Output.MergedOnly {
+		t.Fatalf("expected output selection from file, got %+v", cfg.Output)
+	}
+	if cfg.Output.Directory != "out" {
+		t.Fatalf("expected default directory, got %s", cfg.Output.Directory)
+	}
+}
+
+func TestLoadReadsServeConfig(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "cr.yaml")
+	if err := os.WriteFile(file, []byte("serve:\n  token: ${CR_TEST_SERVE_TOKEN}\n  concurrency: 4\n"), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	t.Setenv("CR_TEST_SERVE_TOKEN", "secret")
+
+	cfg, err := config.Load(config.LoaderOptions{
+		ConfigPaths: []string{dir},
+		FileName:    "cr",
+		EnvPrefix:   "CR",
+	})
+	if err != nil {
+		t.Fatalf("load returned error: %v", err)
+	}
+
+	if cfg.Serve.Token != "secret" || cfg.Serve.Concurrency != 4 {
+		t.Fatalf("expected serve settings from file, got %+v", cfg.Serve)
+	}
+	if cfg.Serve.Addr != "127.0.0.1:8080" || cfg.Serve.MaxQueued != 100 {
+		t.Fatalf("expected serve defaults, got %+v", cfg.Serve)
+	}
+}
+
+func TestLoadReadsServeTokenFromEnv(t *testing.T) {
+	t.Setenv("CR_SERVE_TOKEN", "from-env")
+
+	cfg, err := config.Load(config.LoaderOptions{
+		ConfigPaths: []string{t.TempDir()},
+		FileName:    "cr",
+		EnvPrefix:   "CR",
+	})
+	if err != nil {
+		t.Fatalf("load returned error: %v", err)
+	}
+
+	if cfg.Serve.Token != "from-env" {
+		t.Fatalf("expected token from CR_SERVE_TOKEN, got %q", cfg.Serve.Token)
+	}
+}
+
+func TestLoadReadsGitHubAPIURL(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "cr.yaml")
+	if err := os.WriteFile(file, []byte("github:\n  apiURL: https://ghe.example.com/api/v3\n"), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := config.Load(config.LoaderOptions{
+		ConfigPaths: []string{dir},
+		FileName:    "cr",
+		EnvPrefix:   "CR",
+	})
+	if err != nil {
+		t.Fatalf("load returned error: %v", err)
+	}
+
+	if cfg.GitHub.APIURL != "https://ghe.example.com/api/v3" {
+		t.Fatalf("expected GHES API URL, got %q", cfg.GitHub.APIURL)
+	}
+
+	merged, err := config.Merge(cfg, config.Config{})
+	if err != nil {
+		t.Fatalf("Merge returned error: %v", err)
+	}
+	if merged.GitHub.APIURL != cfg.GitHub.APIURL {
+		t.Fatalf("expected empty overlay to keep API URL, got %q", merged.GitHub.APIURL)
+	}
+}
+
 func TestObservabilityConfigDefaults(t *testing.T) {
 	cfg, err := config.Load(config.LoaderOptions{
 		ConfigPaths: []string{},
@@ -79,6 +174,15 @@ func TestObservabilityConfigDefaults(t *testing.T) {
 	if !cfg.Observability.Metrics.Enabled {
 		t.Error("expected metrics to be enabled by default")
 	}
+	if cfg.Observability.Tracing.Enabled {
+		t.Error("expected tracing to be disabled by default")
+	}
+	if cfg.Observability.Tracing.Exporter != "otlp" {
+		t.Errorf("expected default trace exporter 'otlp', got %s", cfg.Observability.Tracing.Exporter)
+	}
+	if cfg.Observability.Tracing.ServiceName != "code-reviewer" {
+		t.Errorf("expected default service name 'code-reviewer', got %s", cfg.Observability.Tracing.ServiceName)
+	}
 }
 
 func TestObservabilityConfigFromFile(t *testing.T) {
@@ -93,6 +197,10 @@ observability:
     redactAPIKeys: false
   metrics:
     enabled: false
+  tracing:
+    enabled: true
+    exporter: file
+    file: /tmp/cr-traces.jsonl
 `
 	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
 		t.Fatalf("failed to write config file: %v", err)
@@ -123,6 +231,12 @@ observability:
 	if cfg.Observability.Metrics.Enabled {
 		t.Error("expected metrics to be disabled from file config")
 	}
+	if !cfg.Observability.Tracing.Enabled || cfg.Observability.Tracing.Exporter != "file" {
+		t.Errorf("expected file tracing from file config, got %+v", cfg.Observability.Tracing)
+	}
+	if cfg.Observability.Tracing.File != "/tmp/cr-traces.jsonl" {
+		t.Errorf("expected trace file '/tmp/cr-traces.jsonl', got %s", cfg.Observability.Tracing.File)
+	}
 }
 
 func TestReviewActionsDefaults(t *testing.T) {
@@ -151,6 +265,26 @@ func TestReviewActionsDefaults(t *testing.T) {
 	if cfg.Review.Actions.OnClean != "approve" {
 		t.Errorf("expected OnClean 'approve', got %s", cfg.Review.Actions.OnClean)
 	}
+
+	// Code context around diff hunks is on by default
+	if !cfg.Review.CodeContext.Enabled {
+		t.Error("expected Review.CodeContext.Enabled to be true by default")
+	}
+	if cfg.Review.CodeContext.MaxLinesPerFile != 300 {
+		t.Errorf("expected Review.CodeContext.MaxLinesPerFile 300, got %d", cfg.Review.CodeContext.MaxLinesPerFile)
+	}
+	if cfg.Review.CodeContext.MaxTotalLines != 3000 {
+		t.Errorf("expected Review.CodeContext.MaxTotalLines 3000, got %d", cfg.Review.CodeContext.MaxTotalLines)
+	}
+	if !cfg.Review.ImpactAnalysis.Enabled {
+		t.Error("expected Review.ImpactAnalysis.Enabled to be true by default")
+	}
+	if cfg.Review.ImpactAnalysis.MaxCallSites != 40 {
+		t.Errorf("expected Review.ImpactAnalysis.MaxCallSites 40, got %d", cfg.Review.ImpactAnalysis.MaxCallSites)
+	}
+	if !cfg.Review.GeneratedFiles.Skip {
+		t.Error("expected Review.GeneratedFiles.Skip to be true by default")
+	}
 }
 
 func TestReviewActionsFromFile(t *testing.T) {
@@ -381,6 +515,16 @@ func TestVerificationConfigDefaults(t *testing.T) {
 	if cfg.Verification.Confidence.Low != 85 {
 		t.Errorf("expected Verification.Confidence.Low 85, got %d", cfg.Verification.Confidence.Low)
 	}
+	// Test execution runs code under review, so it is opt-in
+	if cfg.Verification.TestExecution.Enabled {
+		t.Error("expected Verification.TestExecution.Enabled to be false by default")
+	}
+	if cfg.Verification.TestExecution.Timeout != "2m" {
+		t.Errorf("expected Verification.TestExecution.Timeout '2m', got %s", cfg.Verification.TestExecution.Timeout)
+	}
+	if cfg.Verification.TestExecution.MemoryMB != 4096 {
+		t.Errorf("expected Verification.TestExecution.MemoryMB 4096, got %d", cfg.Verification.TestExecution.MemoryMB)
+	}
 }
 
 func TestVerificationConfigFromFile(t *testing.T) {
@@ -1172,3 +1316,75 @@ func TestBlockThresholdMerge_OverlayWins(t *testing.T) {
 		t.Errorf("expected OnMedium 'request_changes' from overlay threshold, got %s", merged.Review.Actions.OnMedium)
 	}
 }
+
+func TestContextConfigFromFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "cr.yaml")
+	content := `
+context:
+  designDocs: ["design/*.md"]
+  disableBuiltinRules: true
+  directoryContext: false
+  rules:
+    - paths: ["services/billing/**"]
+      tags: [billing]
+      docs: [docs/billing/LEDGER.md]
+      instructions: Amounts are integer cents.
+  tags:
+    billing:
+      instructions: Check rounding.
+`
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := config.Load(config.LoaderOptions{
+		ConfigPaths: []string{dir},
+		FileName:    "cr",
+		EnvPrefix:   "CR_TEST_CONTEXT_FILE",
+	})
+	if err != nil {
+		t.Fatalf("load returned error: %v", err)
+	}
+
+	if len(cfg.Context.DesignDocs) != 1 || cfg.Context.DesignDocs[0] != "design/*.md" {
+		t.Errorf("expected designDocs [design/*.md], got %v", cfg.Context.DesignDocs)
+	}
+	if !cfg.Context.DisableBuiltinRules {
+		t.Error("expected DisableBuiltinRules to be true")
+	}
+	if cfg.Context.IsDirectoryContextEnabled() {
+		t.Error("expected directory context to be disabled")
+	}
+	if len(cfg.Context.Rules) != 1 {
+		t.Fatalf("expected 1 rule, got %d", len(cfg.Context.Rules))
+	}
+	rule := cfg.Context.Rules[0]
+	if rule.Paths[0] != "services/billing/**" || rule.Tags[0] != "billing" || rule.Docs[0] != "docs/billing/LEDGER.md" || rule.Instructions != "Amounts are integer cents." {
+		t.Errorf("unexpected rule: %+v", rule)
+	}
+	if cfg.Context.Tags["billing"].Instructions != "Check rounding." {
+		t.Errorf("unexpected tags: %+v", cfg.Context.Tags)
+	}
+}
+
+func TestContextConfigDefaults(t *testing.T) {
+	cfg, err := config.Load(config.LoaderOptions{
+		ConfigPaths: []string{},
+		FileName:    "nonexistent",
+		EnvPrefix:   "CR",
+	})
+	if err != nil {
+		t.Fatalf("load returned error: %v", err)
+	}
+
+	if len(cfg.Context.DesignDocs) != 1 || cfg.Context.DesignDocs[0] != "docs/*_DESIGN.md" {
+		t.Errorf("expected default designDocs, got %v", cfg.Context.DesignDocs)
+	}
+	if cfg.Context.DisableBuiltinRules {
+		t.Error("expected built-in rules to be enabled by default")
+	}
+	if !cfg.Context.IsDirectoryContextEnabled() {
+		t.Error("expected directory context to be enabled by default")
+	}
+}
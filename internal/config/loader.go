@@ -93,6 +93,7 @@ func expandEnvVars(cfg Config) Config {
 	for name, provider := range cfg.Providers {
 		provider.APIKey = expandEnvString(provider.APIKey)
 		provider.Model = expandEnvString(provider.Model)
+		provider.FallbackModels = expandEnvStringSlice(provider.FallbackModels)
 
 		// Expand provider-specific HTTP overrides
 		if provider.Timeout != nil {
@@ -115,6 +116,7 @@ func expandEnvVars(cfg Config) Config {
 	cfg.HTTP.Timeout = expandEnvString(cfg.HTTP.Timeout)
 	cfg.HTTP.InitialBackoff = expandEnvString(cfg.HTTP.InitialBackoff)
 	cfg.HTTP.MaxBackoff = expandEnvString(cfg.HTTP.MaxBackoff)
+	cfg.HTTP.CircuitBreaker.Cooldown = expandEnvString(cfg.HTTP.CircuitBreaker.Cooldown)
 
 	// Expand merge config
 	cfg.Merge.Provider = expandEnvString(cfg.Merge.Provider)
@@ -129,8 +131,12 @@ func expandEnvVars(cfg Config) Config {
 	// Expand git config
 	cfg.Git.RepositoryDir = expandEnvString(cfg.Git.RepositoryDir)
 
+	// Expand GitHub config
+	cfg.GitHub.APIURL = expandEnvString(cfg.GitHub.APIURL)
+
 	// Expand output config
 	cfg.Output.Directory = expandEnvString(cfg.Output.Directory)
+	cfg.Output.Formats = expandEnvStringSlice(cfg.Output.Formats)
 
 	// Expand budget config
 	cfg.Budget.DegradationPolicy = expandEnvStringSlice(cfg.Budget.DegradationPolicy)
@@ -142,9 +148,15 @@ func expandEnvVars(cfg Config) Config {
 	// Expand store config
 	cfg.Store.Path = expandEnvString(cfg.Store.Path)
 
+	// Expand serve config
+	cfg.Serve.Addr = expandEnvString(cfg.Serve.Addr)
+	cfg.Serve.Token = expandEnvString(cfg.Serve.Token)
+
 	// Expand observability config
 	cfg.Observability.Logging.Level = expandEnvString(cfg.Observability.Logging.Level)
 	cfg.Observability.Logging.Format = expandEnvString(cfg.Observability.Logging.Format)
+	cfg.Observability.Tracing.Endpoint = expandEnvString(cfg.Observability.Tracing.Endpoint)
+	cfg.Observability.Tracing.File = expandEnvString(cfg.Observability.Tracing.File)
 
 	return cfg
 }
@@ -231,6 +243,10 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("http.initialBackoff", "2s")
 	v.SetDefault("http.maxBackoff", "32s")
 	v.SetDefault("http.backoffMultiplier", 2.0)
+	v.SetDefault("http.requestsPerMinute", 0)
+	v.SetDefault("http.tokensPerMinute", 0)
+	v.SetDefault("http.circuitBreaker.failureThreshold", 5)
+	v.SetDefault("http.circuitBreaker.cooldown", "30s")
 
 	// Determinism defaults (Phase 2)
 	v.SetDefault("determinism.enabled", true)
@@ -248,12 +264,22 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("store.enabled", true)
 	v.SetDefault("store.path", defaultStorePath())
 
+	// Serve defaults; the token default lets CR_SERVE_TOKEN set it
+	v.SetDefault("serve.addr", "127.0.0.1:8080")
+	v.SetDefault("serve.token", "")
+	v.SetDefault("serve.concurrency", 1)
+	v.SetDefault("serve.maxQueued", 100)
+
 	// Observability defaults (Phase 3)
 	v.SetDefault("observability.logging.enabled", true)
 	v.SetDefault("observability.logging.level", "info")
 	v.SetDefault("observability.logging.format", "human")
 	v.SetDefault("observability.logging.redactAPIKeys", true)
 	v.SetDefault("observability.metrics.enabled", true)
+	v.SetDefault("observability.tracing.enabled", false)
+	v.SetDefault("observability.tracing.exporter", "otlp")
+	v.SetDefault("observability.tracing.file", "traces.jsonl")
+	v.SetDefault("observability.tracing.serviceName", "code-reviewer")
 
 	// Provider defaults (Phase 1 + Phase 2)
 	// Note: providers.*.enabled is intentionally not defaulted.
@@ -271,6 +297,14 @@ func setDefaults(v *viper.Viper) {
 
 	// Bot username for auto-dismissing stale reviews (Phase 2)
 	v.SetDefault("review.botUsername", "github-actions[bot]")
+	v.SetDefault("review.codeContext.enabled", true)
+	v.SetDefault("review.codeContext.maxLinesPerFile", 300)
+	v.SetDefault("review.codeContext.maxTotalLines", 3000)
+	v.SetDefault("review.impactAnalysis.enabled", true)
+	v.SetDefault("review.impactAnalysis.maxCallSites", 40)
+	v.SetDefault("review.impactAnalysis.maxPerFunction", 10)
+	v.SetDefault("review.generatedFiles.skip", true)
+	v.SetDefault("context.designDocs", []string{"docs/*_DESIGN.md"})
 
 	// Verification defaults (Epic #92 - agent verification)
 	// Disabled by default to avoid unexpected LLM costs; users must opt-in
@@ -285,6 +319,10 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("verification.confidence.high", 70)
 	v.SetDefault("verification.confidence.medium", 75)
 	v.SetDefault("verification.confidence.low", 85)
+	v.SetDefault("verification.testExecution.enabled", false)
+	v.SetDefault("verification.testExecution.timeout", "2m")
+	v.SetDefault("verification.testExecution.memoryMB", 4096)
+	v.SetDefault("verification.testExecution.cpuSeconds", 300)
 
 	// Merge defaults (synthesis provider)
 	// Uses alias without date suffix to always get latest model version
@@ -73,15 +73,18 @@ func TestExpandEnvVars(t *testing.T) {
 	// Set test environment variables
 	os.Setenv("OPENAI_API_KEY", "sk-test-123")
 	os.Setenv("OUTPUT_DIR", "/custom/output")
+	os.Setenv("FALLBACK_MODEL", "gpt-4o-mini")
 	defer os.Unsetenv("OPENAI_API_KEY")
 	defer os.Unsetenv("OUTPUT_DIR")
+	defer os.Unsetenv("FALLBACK_MODEL")
 
 	cfg := Config{
 		Providers: map[string]ProviderConfig{
 			"openai": {
-				Enabled: boolPtr(true),
-				Model:   "gpt-4o-mini",
-				APIKey:  "${OPENAI_API_KEY}",
+				Enabled:        boolPtr(true),
+				Model:          "gpt-4o",
+				APIKey:         "${OPENAI_API_KEY}",
+				FallbackModels: []string{"${FALLBACK_MODEL}", "gpt-3.5-turbo"},
 			},
 		},
 		Output: OutputConfig{
@@ -92,6 +95,7 @@ func TestExpandEnvVars(t *testing.T) {
 	expanded := expandEnvVars(cfg)
 
 	assert.Equal(t, "sk-test-123", expanded.Providers["openai"].APIKey)
+	assert.Equal(t, []string{"gpt-4o-mini", "gpt-3.5-turbo"}, expanded.Providers["openai"].FallbackModels)
 	assert.Equal(t, "/custom/output", expanded.Output.Directory)
 }
 
@@ -286,6 +290,10 @@ func TestHTTPConfigDefaults(t *testing.T) {
 	assert.Equal(t, "2s", cfg.HTTP.InitialBackoff)
 	assert.Equal(t, "32s", cfg.HTTP.MaxBackoff)
 	assert.Equal(t, 2.0, cfg.HTTP.BackoffMultiplier)
+	assert.Equal(t, 0, cfg.HTTP.RequestsPerMinute)
+	assert.Equal(t, 0, cfg.HTTP.TokensPerMinute)
+	assert.Equal(t, 5, cfg.HTTP.CircuitBreaker.FailureThreshold)
+	assert.Equal(t, "30s", cfg.HTTP.CircuitBreaker.Cooldown)
 }
 
 func TestExpandEnvVars_HTTPConfig(t *testing.T) {
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// RulesFile is the path of the per-path review policy file, relative to the
+// repository root.
+const RulesFile = ".cr/rules.yaml"
+
+// PathRules is the content of .cr/rules.yaml.
+type PathRules struct {
+	// Rules are applied in order; for each setting the last matching rule
+	// wins, as in CODEOWNERS.
+	Rules []PathRule `yaml:"rules"`
+}
+
+// PathRule is a review policy for the files matching Paths.
+type PathRule struct {
+	// Paths are globs matched against file paths. "**" matches any number of
+	// directories; a pattern without "/" matches file names at any depth.
+	Paths []string `yaml:"paths"`
+
+	// Ignore excludes matching files from review (e.g. vendored code).
+	Ignore *bool `yaml:"ignore,omitempty"`
+
+	// Instructions are added to the review prompt when a matching file changes.
+	Instructions string `yaml:"instructions"`
+
+	// MinSeverity drops findings below this severity in matching files.
+	MinSeverity string `yaml:"minSeverity"`
+
+	// MaxSeverity lowers findings above this severity in matching files.
+	MaxSeverity string `yaml:"maxSeverity"`
+
+	// BlockThreshold sets the severity actions for matching files, as
+	// review.blockThreshold does globally. Actions override it per severity.
+	BlockThreshold string `yaml:"blockThreshold"`
+
+	// Actions sets the review action per severity for matching files.
+	// OnClean and OnNonBlocking apply to the whole review and are ignored here.
+	Actions ReviewActions `yaml:"actions"`
+
+	// AlwaysBlockCategories replaces review.alwaysBlockCategories for
+	// matching files when set. Use [] to turn category blocking off.
+	AlwaysBlockCategories []string `yaml:"alwaysBlockCategories"`
+}
+
+// ParsePathRules parses the content of .cr/rules.yaml. Empty content yields
+// no rules. Block thresholds are expanded into per-severity actions.
+//
+// The caller reads the file from the review's base rather than from the
+// working tree, so a change cannot relax the rules applied to itself.
+func ParsePathRules(content []byte) (PathRules, error) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(bytes.NewReader(content)); err != nil {
+		return PathRules{}, fmt.Errorf("read %s: %w", RulesFile, err)
+	}
+
+	var rules PathRules
+	if err := v.Unmarshal(&rules); err != nil {
+		return PathRules{}, fmt.Errorf("unmarshal %s: %w", RulesFile, err)
+	}
+
+	for i, rule := range rules.Rules {
+		if len(rule.Paths) == 0 {
+			return PathRules{}, fmt.Errorf("%s: rule %d has no paths", RulesFile, i+1)
+		}
+		for _, severity := range []string{rule.MinSeverity, rule.MaxSeverity} {
+			if severity != "" && !isSeverity(severity) {
+				return PathRules{}, fmt.Errorf("%s: rule %d: invalid severity %q: must be one of: critical, high, medium, low", RulesFile, i+1, severity)
+			}
+		}
+
+		expanded, err := expandBlockThreshold(rule.BlockThreshold)
+		if err != nil {
+			return PathRules{}, fmt.Errorf("%s: rule %d: %w", RulesFile, i+1, err)
+		}
+		rules.Rules[i].Actions = mergeReviewActions(expanded, rule.Actions)
+	}
+
+	return rules, nil
+}
+
+func isSeverity(severity string) bool {
+	switch strings.ToLower(severity) {
+	case "critical", "high", "medium", "low":
+		return true
+	}
+	return false
+}
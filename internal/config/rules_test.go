@@ -0,0 +1,91 @@
+package config_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/config"
+)
+
+func TestParsePathRules(t *testing.T) {
+	rules, err := config.ParsePathRules([]byte(`
+rules:
+  - paths: ["vendor/**", "*.pb.go"]
+    ignore: true
+  - paths: ["payments/**"]
+    blockThreshold: medium
+    actions:
+      onLow: request_changes
+    alwaysBlockCategories: [security, bug]
+  - paths: ["auth/**"]
+    instructions: Focus on session handling.
+    minSeverity: medium
+  - paths: ["**/*_test.go"]
+    maxSeverity: medium
+    alwaysBlockCategories: []
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rules.Rules) != 4 {
+		t.Fatalf("expected 4 rules, got %d", len(rules.Rules))
+	}
+
+	vendor := rules.Rules[0]
+	if vendor.Ignore == nil || !*vendor.Ignore || len(vendor.Paths) != 2 {
+		t.Errorf("unexpected vendor rule: %+v", vendor)
+	}
+
+	// blockThreshold is expanded; explicit actions win
+	payments := rules.Rules[1]
+	if payments.Actions.OnCritical != "request_changes" || payments.Actions.OnMedium != "request_changes" || payments.Actions.OnLow != "request_changes" {
+		t.Errorf("unexpected payments actions: %+v", payments.Actions)
+	}
+	if len(payments.AlwaysBlockCategories) != 2 {
+		t.Errorf("unexpected payments categories: %v", payments.AlwaysBlockCategories)
+	}
+
+	auth := rules.Rules[2]
+	if auth.Instructions != "Focus on session handling." || auth.MinSeverity != "medium" || auth.Ignore != nil {
+		t.Errorf("unexpected auth rule: %+v", auth)
+	}
+	if auth.AlwaysBlockCategories != nil || auth.Actions.OnHigh != "" {
+		t.Errorf("expected auth rule to inherit blocking, got %+v", auth)
+	}
+
+	// An explicit empty list turns category blocking off
+	tests := rules.Rules[3]
+	if tests.AlwaysBlockCategories == nil || len(tests.AlwaysBlockCategories) != 0 {
+		t.Errorf("expected empty non-nil categories, got %#v", tests.AlwaysBlockCategories)
+	}
+}
+
+func TestParsePathRules_Empty(t *testing.T) {
+	rules, err := config.ParsePathRules(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rules.Rules) != 0 {
+		t.Errorf("expected no rules, got %+v", rules)
+	}
+}
+
+func TestParsePathRules_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		errText string
+	}{
+		{"no paths", "rules:\n  - ignore: true\n", "no paths"},
+		{"bad severity", "rules:\n  - paths: [a]\n    minSeverity: urgent\n", "invalid severity"},
+		{"bad threshold", "rules:\n  - paths: [a]\n    blockThreshold: sometimes\n", "invalid blockThreshold"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := config.ParsePathRules([]byte(tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("expected error containing %q, got %v", tt.errText, err)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ type Line struct {
 	Type     LineType // The type of change
 	Content  string   // The line content (without the prefix)
 	NewLine  *int     // Line number in new file (nil for deletions)
+	OldLine  *int     // Line number in old file (nil for additions)
 	Position int      // Position in diff (1-indexed from first @@)
 }
 
@@ -52,6 +53,7 @@ func Parse(patch string) (ParsedDiff, error) {
 	var currentHunk *Hunk
 	position := 0
 	currentNewLine := 0
+	currentOldLine := 0
 
 	for _, line := range lines {
 		// Skip empty lines at end
@@ -87,6 +89,7 @@ func Parse(patch string) (ParsedDiff, error) {
 
 			currentHunk = &hunk
 			currentNewLine = hunk.NewStart
+			currentOldLine = hunk.OldStart
 			continue
 		}
 
@@ -113,17 +116,23 @@ func Parse(patch string) (ParsedDiff, error) {
 				diffLine.Content = line[1:]
 				// Deletions don't have new-side line numbers
 				diffLine.NewLine = nil
+				diffLine.OldLine = IntPtr(currentOldLine)
+				currentOldLine++
 			case ' ':
 				diffLine.Type = LineContext
 				diffLine.Content = line[1:]
 				diffLine.NewLine = IntPtr(currentNewLine)
+				diffLine.OldLine = IntPtr(currentOldLine)
 				currentNewLine++
+				currentOldLine++
 			default:
 				// Treat unknown as context (handles edge cases)
 				diffLine.Type = LineContext
 				diffLine.Content = line
 				diffLine.NewLine = IntPtr(currentNewLine)
+				diffLine.OldLine = IntPtr(currentOldLine)
 				currentNewLine++
+				currentOldLine++
 			}
 		}
 
@@ -158,6 +167,48 @@ func (pd ParsedDiff) FindPosition(newLineNumber int) *int {
 	return nil
 }
 
+// FindLine returns the diff line for a given new-side line number.
+// Returns nil if the line is not in the diff. Callers that need both
+// old- and new-side line numbers (e.g. GitLab positions) use this instead
+// of FindPosition.
+func (pd ParsedDiff) FindLine(newLineNumber int) *Line {
+	if newLineNumber <= 0 {
+		return nil
+	}
+
+	for _, hunk := range pd.Hunks {
+		for i := range hunk.Lines {
+			line := &hunk.Lines[i]
+			if line.NewLine != nil && *line.NewLine == newLineNumber {
+				return line
+			}
+		}
+	}
+
+	return nil
+}
+
+// FindDeletedLine returns the deleted diff line for a given old-side line
+// number. Returns nil if no line with that number was deleted. Findings on
+// removed code cite the old file's line numbers, which only platforms that
+// anchor comments to either side of the diff (e.g. GitLab old_line) can use.
+func (pd ParsedDiff) FindDeletedLine(oldLineNumber int) *Line {
+	if oldLineNumber <= 0 {
+		return nil
+	}
+
+	for _, hunk := range pd.Hunks {
+		for i := range hunk.Lines {
+			line := &hunk.Lines[i]
+			if line.Type == LineDeletion && line.OldLine != nil && *line.OldLine == oldLineNumber {
+				return line
+			}
+		}
+	}
+
+	return nil
+}
+
 // parseHunkHeader parses a hunk header line like "@@ -10,7 +10,8 @@ optional context".
 func parseHunkHeader(line string) (Hunk, error) {
 	hunk := Hunk{}
@@ -354,3 +354,90 @@ index 1234567..abcdefg 100644
 		t.Errorf("FindPosition(10) = %v, want 1", pos)
 	}
 }
+
+func TestParse_TracksOldLineNumbers(t *testing.T) {
+	patch := `@@ -10,3 +20,3 @@ func example() {
+ context
+-removed
++added
+ more context
+`
+
+	parsed, err := diff.Parse(patch)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	lines := parsed.Hunks[0].Lines
+	wantOld := []*int{diff.IntPtr(10), diff.IntPtr(11), nil, diff.IntPtr(12)}
+	wantNew := []*int{diff.IntPtr(20), nil, diff.IntPtr(21), diff.IntPtr(22)}
+	for i, line := range lines {
+		if !equalIntPtr(line.OldLine, wantOld[i]) {
+			t.Errorf("line %d: OldLine = %v, want %v", i, line.OldLine, wantOld[i])
+		}
+		if !equalIntPtr(line.NewLine, wantNew[i]) {
+			t.Errorf("line %d: NewLine = %v, want %v", i, line.NewLine, wantNew[i])
+		}
+	}
+}
+
+func TestParsedDiff_FindLine(t *testing.T) {
+	patch := `@@ -10,2 +10,3 @@ func example() {
+ context
++added
+ more context
+`
+
+	parsed, err := diff.Parse(patch)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	added := parsed.FindLine(11)
+	if added == nil {
+		t.Fatal("FindLine(11) = nil, want added line")
+	}
+	if added.Type != diff.LineAddition || added.OldLine != nil {
+		t.Errorf("FindLine(11) = %+v, want addition without old line", added)
+	}
+
+	context := parsed.FindLine(12)
+	if context == nil {
+		t.Fatal("FindLine(12) = nil, want context line")
+	}
+	if !equalIntPtr(context.OldLine, diff.IntPtr(11)) {
+		t.Errorf("FindLine(12).OldLine = %v, want 11", context.OldLine)
+	}
+
+	if parsed.FindLine(50) != nil {
+		t.Error("FindLine(50) should be nil for lines outside the diff")
+	}
+}
+
+func TestParsedDiff_FindDeletedLine(t *testing.T) {
+	patch := `@@ -10,3 +10,2 @@ func example() {
+ context
+-removed
+ more context
+`
+
+	parsed, err := diff.Parse(patch)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	deleted := parsed.FindDeletedLine(11)
+	if deleted == nil {
+		t.Fatal("FindDeletedLine(11) = nil, want deleted line")
+	}
+	if deleted.Type != diff.LineDeletion || deleted.NewLine != nil {
+		t.Errorf("FindDeletedLine(11) = %+v, want deletion without new line", deleted)
+	}
+
+	if parsed.FindDeletedLine(10) != nil {
+		t.Error("FindDeletedLine(10) should be nil for context lines")
+	}
+	if parsed.FindDeletedLine(50) != nil {
+		t.Error("FindDeletedLine(50) should be nil for lines outside the diff")
+	}
+}
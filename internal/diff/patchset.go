@@ -0,0 +1,210 @@
+package diff
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/bkyoung/code-reviewer/internal/domain"
+)
+
+// ErrEmptyPatch is returned by ParsePatchSet when the input has no file changes.
+var ErrEmptyPatch = errors.New("no file changes found in patch")
+
+// gitExtendedHeaders are the lines git writes between "diff --git" and the
+// first hunk of a file.
+var gitExtendedHeaders = []string{
+	"index ",
+	"old mode ",
+	"new mode ",
+	"new file mode ",
+	"deleted file mode ",
+	"similarity index ",
+	"dissimilarity index ",
+	"rename from ",
+	"rename to ",
+	"copy from ",
+	"copy to ",
+	"Binary files ",
+	"GIT binary patch",
+}
+
+// patchFile accumulates one file of a patch set.
+type patchFile struct {
+	diff    domain.FileDiff
+	lines   []string
+	inHunks bool // A hunk or binary marker has been seen; headers are over
+}
+
+// ParsePatchSet parses a multi-file unified diff into a domain.Diff. It
+// accepts "git diff" output, one or more "git format-patch" emails, and plain
+// "diff -u" output. Commit messages and email headers are ignored.
+//
+// When a patch series changes the same file more than once, the later hunks
+// are appended to the file's patch; their line numbers refer to the file as
+// left by the earlier patches.
+func ParsePatchSet(text string) (domain.Diff, error) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	var files []*patchFile
+	byPath := make(map[string]*patchFile)
+	var current *patchFile
+	oldLeft, newLeft := 0, 0
+
+	finish := func() {
+		if current == nil || current.diff.Path == "" {
+			current = nil
+			return
+		}
+		if current.diff.Status != domain.FileStatusRenamed {
+			current.diff.OldPath = ""
+		}
+		patch := strings.Join(current.lines, "\n") + "\n"
+		if existing, ok := byPath[current.diff.Path]; ok {
+			existing.diff.Patch += patch
+			existing.diff.IsBinary = existing.diff.IsBinary || current.diff.IsBinary
+			if current.diff.Status == domain.FileStatusDeleted {
+				existing.diff.Status = domain.FileStatusDeleted
+			}
+		} else {
+			current.diff.Patch = patch
+			byPath[current.diff.Path] = current
+			files = append(files, current)
+		}
+		current = nil
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		// Inside a hunk, every line belongs to it until the counts run out
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				newLeft--
+			case strings.HasPrefix(line, "-"):
+				oldLeft--
+			case strings.HasPrefix(line, "\\"):
+			default:
+				// Context, including blank lines whose leading space was stripped
+				oldLeft--
+				newLeft--
+			}
+			current.lines = append(current.lines, line)
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			finish()
+			current = &patchFile{diff: domain.FileDiff{Status: domain.FileStatusModified}}
+			current.diff.OldPath, current.diff.Path = gitHeaderPaths(strings.TrimPrefix(line, "diff --git "))
+			current.lines = append(current.lines, line)
+
+		case strings.HasPrefix(line, "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ "):
+			// Plain "diff -u" output has no "diff --git" line
+			if current == nil || current.inHunks {
+				finish()
+				current = &patchFile{diff: domain.FileDiff{Status: domain.FileStatusModified}}
+			}
+			oldPath := patchPath(strings.TrimPrefix(line, "--- "), "a/")
+			newPath := patchPath(strings.TrimPrefix(lines[i+1], "+++ "), "b/")
+			switch {
+			case oldPath == "":
+				current.diff.Status = domain.FileStatusAdded
+				current.diff.Path = newPath
+			case newPath == "":
+				current.diff.Status = domain.FileStatusDeleted
+				current.diff.Path = oldPath
+			default:
+				// Renames are only taken from git's "rename from/to" headers;
+				// plain diffs often compare a backup copy (file.orig) instead
+				current.diff.Path = newPath
+			}
+			current.lines = append(current.lines, line, lines[i+1])
+			i++
+
+		case strings.HasPrefix(line, "@@") && current != nil:
+			hunk, _ := parseHunkHeader(line)
+			oldLeft, newLeft = hunk.OldLines, hunk.NewLines
+			current.inHunks = true
+			current.lines = append(current.lines, line)
+
+		case current != nil && !current.inHunks && hasGitExtendedHeader(line):
+			applyGitExtendedHeader(&current.diff, line)
+			if current.diff.IsBinary {
+				current.inHunks = true
+			}
+			current.lines = append(current.lines, line)
+
+		default:
+			// Commit messages, email headers and format-patch signatures
+		}
+	}
+	finish()
+
+	if len(files) == 0 {
+		return domain.Diff{}, ErrEmptyPatch
+	}
+	result := domain.Diff{Files: make([]domain.FileDiff, 0, len(files))}
+	for _, f := range files {
+		result.Files = append(result.Files, f.diff)
+	}
+	return result, nil
+}
+
+// gitHeaderPaths returns the old and new paths of a "diff --git a/x b/y"
+// line, or empty paths when they can't be told apart.
+func gitHeaderPaths(rest string) (oldPath, newPath string) {
+	if !strings.HasPrefix(rest, "a/") {
+		return "", ""
+	}
+	idx := strings.LastIndex(rest, " b/")
+	if idx < 0 {
+		return "", ""
+	}
+	oldPath, newPath = rest[2:idx], rest[idx+3:]
+	if oldPath == newPath {
+		return "", newPath
+	}
+	return oldPath, newPath
+}
+
+// patchPath returns the path of a "---" or "+++" line without its prefix
+// and timestamp, or "" for /dev/null.
+func patchPath(s, prefix string) string {
+	if idx := strings.Index(s, "\t"); idx >= 0 {
+		s = s[:idx]
+	}
+	s = strings.TrimSpace(s)
+	if s == "/dev/null" {
+		return ""
+	}
+	return strings.TrimPrefix(s, prefix)
+}
+
+func hasGitExtendedHeader(line string) bool {
+	for _, prefix := range gitExtendedHeaders {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyGitExtendedHeader updates the file's status from a git header line.
+func applyGitExtendedHeader(f *domain.FileDiff, line string) {
+	switch {
+	case strings.HasPrefix(line, "new file mode "):
+		f.Status = domain.FileStatusAdded
+	case strings.HasPrefix(line, "deleted file mode "):
+		f.Status = domain.FileStatusDeleted
+	case strings.HasPrefix(line, "rename from "):
+		f.Status = domain.FileStatusRenamed
+		f.OldPath = strings.TrimPrefix(line, "rename from ")
+	case strings.HasPrefix(line, "rename to "):
+		f.Status = domain.FileStatusRenamed
+		f.Path = strings.TrimPrefix(line, "rename to ")
+	case strings.HasPrefix(line, "Binary files "), strings.HasPrefix(line, "GIT binary patch"):
+		f.IsBinary = true
+	}
+}
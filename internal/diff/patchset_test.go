@@ -0,0 +1,174 @@
+package diff_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/diff"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+)
+
+const gitDiffPatch = `diff --git a/store/store.go b/store/store.go
+index 83db48f..bf269f4 100644
+--- a/store/store.go
++++ b/store/store.go
+@@ -1,4 +1,4 @@
+ package store
+
+--- old comment
++-- new comment
+ func Get() {}
+diff --git a/docs/new.md b/docs/new.md
+new file mode 100644
+index 0000000..e69de29
+--- /dev/null
++++ b/docs/new.md
+@@ -0,0 +1,2 @@
++# New
++text
+diff --git a/old.txt b/old.txt
+deleted file mode 100644
+index e69de29..0000000
+--- a/old.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-gone
+diff --git a/a.go b/b.go
+similarity index 100%
+rename from a.go
+rename to b.go
+diff --git a/logo.png b/logo.png
+index 1111111..2222222 100644
+Binary files a/logo.png and b/logo.png differ
+`
+
+func TestParsePatchSet_GitDiff(t *testing.T) {
+	got, err := diff.ParsePatchSet(gitDiffPatch)
+	if err != nil {
+		t.Fatalf("ParsePatchSet failed: %v", err)
+	}
+
+	want := []domain.FileDiff{
+		{Path: "store/store.go", Status: domain.FileStatusModified},
+		{Path: "docs/new.md", Status: domain.FileStatusAdded},
+		{Path: "old.txt", Status: domain.FileStatusDeleted},
+		{Path: "b.go", OldPath: "a.go", Status: domain.FileStatusRenamed},
+		{Path: "logo.png", Status: domain.FileStatusModified, IsBinary: true},
+	}
+	if len(got.Files) != len(want) {
+		t.Fatalf("expected %d files, got %d: %+v", len(want), len(got.Files), got.Files)
+	}
+	for i, w := range want {
+		f := got.Files[i]
+		if f.Path != w.Path || f.OldPath != w.OldPath || f.Status != w.Status || f.IsBinary != w.IsBinary {
+			t.Errorf("file %d: got %+v, want %+v", i, f, w)
+		}
+	}
+
+	// A deleted line that looks like a "---" header stays in its hunk
+	store := got.Files[0].Patch
+	if !strings.HasPrefix(store, "diff --git a/store/store.go") || !strings.Contains(store, "\n--- old comment\n+-- new comment\n") {
+		t.Errorf("unexpected patch for store/store.go:\n%s", store)
+	}
+	if strings.Contains(store, "docs/new.md") {
+		t.Errorf("expected the next file's lines not to leak into store/store.go:\n%s", store)
+	}
+}
+
+func TestParsePatchSet_FormatPatchSeries(t *testing.T) {
+	series := `From 1a2b3c Mon Sep 17 00:00:00 2001
+From: Dev <dev@example.com>
+Date: Mon, 1 Jan 2024 10:00:00 +0000
+Subject: [PATCH 1/2] Add greeting
+
+Adds a greeting.
+---
+ hello.go | 1 +
+ 1 file changed, 1 insertion(+)
+
+diff --git a/hello.go b/hello.go
+index 1111111..2222222 100644
+--- a/hello.go
++++ b/hello.go
+@@ -1,2 +1,3 @@
+ package hello
++const Greeting = "hi"
+
+--
+2.43.0
+
+
+From 4d5e6f Mon Sep 17 00:00:00 2001
+From: Dev <dev@example.com>
+Subject: [PATCH 2/2] Change greeting
+
+---
+ hello.go | 2 +-
+ 1 file changed, 1 insertion(+), 1 deletion(-)
+
+diff --git a/hello.go b/hello.go
+index 2222222..3333333 100644
+--- a/hello.go
++++ b/hello.go
+@@ -1,3 +1,3 @@
+ package hello
+-const Greeting = "hi"
++const Greeting = "hello"
+
+--
+2.43.0
+`
+	got, err := diff.ParsePatchSet(series)
+	if err != nil {
+		t.Fatalf("ParsePatchSet failed: %v", err)
+	}
+	if len(got.Files) != 1 || got.Files[0].Path != "hello.go" {
+		t.Fatalf("expected the two patches to be combined into hello.go, got %+v", got.Files)
+	}
+
+	patch := got.Files[0].Patch
+	for _, unwanted := range []string{"Subject:", "Adds a greeting", "2.43.0", "1 file changed"} {
+		if strings.Contains(patch, unwanted) {
+			t.Errorf("expected %q to be dropped, got:\n%s", unwanted, patch)
+		}
+	}
+	if strings.Count(patch, "@@") != 4 || !strings.Contains(patch, `+const Greeting = "hello"`) {
+		t.Errorf("expected both hunks in the patch, got:\n%s", patch)
+	}
+}
+
+func TestParsePatchSet_PlainUnifiedDiff(t *testing.T) {
+	plain := "--- config.yaml.orig\t2024-01-01 10:00:00\n" +
+		"+++ config.yaml\t2024-01-01 10:05:00\n" +
+		"@@ -1,2 +1,2 @@\n" +
+		" name: app\n" +
+		"-port: 80\n" +
+		"+port: 8080\n" +
+		"--- /dev/null\n" +
+		"+++ notes.txt\n" +
+		"@@ -0,0 +1 @@\n" +
+		"+note\n"
+
+	got, err := diff.ParsePatchSet(plain)
+	if err != nil {
+		t.Fatalf("ParsePatchSet failed: %v", err)
+	}
+	if len(got.Files) != 2 {
+		t.Fatalf("expected 2 files, got %+v", got.Files)
+	}
+	if f := got.Files[0]; f.Path != "config.yaml" || f.Status != domain.FileStatusModified || f.OldPath != "" {
+		t.Errorf("unexpected first file: %+v", f)
+	}
+	if f := got.Files[1]; f.Path != "notes.txt" || f.Status != domain.FileStatusAdded {
+		t.Errorf("unexpected second file: %+v", f)
+	}
+}
+
+func TestParsePatchSet_Empty(t *testing.T) {
+	for _, input := range []string{"", "Just a commit message\n\nwith no diff\n"} {
+		if _, err := diff.ParsePatchSet(input); !errors.Is(err, diff.ErrEmptyPatch) {
+			t.Errorf("ParsePatchSet(%q): expected ErrEmptyPatch, got %v", input, err)
+		}
+	}
+}
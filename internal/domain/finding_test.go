@@ -288,3 +288,26 @@ func TestFinding_Fingerprint_ExcludesNonIdentityFields(t *testing.T) {
 		t.Error("fingerprint should not change when only Evidence differs")
 	}
 }
+
+func TestFinding_Replacement(t *testing.T) {
+	tests := []struct {
+		name       string
+		suggestion string
+		wantCode   string
+		wantProse  string
+		wantOK     bool
+	}{
+		{"prose only", "Use a constant", "", "", false},
+		{"block", "Use a constant.\n```suggestion\nconst limit = 10\n```\n", "const limit = 10\n", "Use a constant.", true},
+		{"empty block deletes lines", "```suggestion\n```", "", "", true},
+		{"unterminated block", "```suggestion\nconst limit = 10\n", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, prose, ok := domain.Finding{Suggestion: tt.suggestion}.Replacement()
+			if code != tt.wantCode || prose != tt.wantProse || ok != tt.wantOK {
+				t.Errorf("Replacement() = %q, %q, %v; want %q, %q, %v", code, prose, ok, tt.wantCode, tt.wantProse, tt.wantOK)
+			}
+		})
+	}
+}
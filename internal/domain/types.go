@@ -4,6 +4,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"strings"
 )
 
 const (
@@ -27,8 +28,19 @@ type FileDiff struct {
 	Status   string
 	Patch    string
 	IsBinary bool // True for binary files (patch contains "Binary files differ")
+
+	// Generated is set when the file is generated, vendored or a lockfile
+	// (one of the Generated* constants) and is excluded from review.
+	Generated string
 }
 
+// Reasons a file is excluded from review as noise.
+const (
+	GeneratedCode     = "generated"
+	GeneratedVendored = "vendored"
+	GeneratedLockfile = "lockfile"
+)
+
 // Review is the output from an LLM provider.
 type Review struct {
 	ProviderName string    `json:"providerName"`
@@ -50,6 +62,8 @@ type Review struct {
 	DiscoveryFindings  []CandidateFinding `json:"discoveryFindings,omitempty"`
 	VerifiedFindings   []VerifiedFinding  `json:"verifiedFindings,omitempty"`
 	ReportableFindings []VerifiedFinding  `json:"reportableFindings,omitempty"`
+	VerificationMode   string             `json:"verificationMode,omitempty"` // batch, agent, or hybrid
+	VerificationCost   float64            `json:"verificationCost,omitempty"` // Verification cost in USD (batch and agent calls)
 
 	// Size guard fields (Epic #7 - PR size guards)
 	// When the PR exceeds token limits, files may be truncated to fit.
@@ -58,6 +72,10 @@ type Review struct {
 	WasTruncated      bool     `json:"wasTruncated,omitempty"`      // True if files were removed to fit
 	TruncatedFiles    []string `json:"truncatedFiles,omitempty"`    // List of files removed for size
 	TruncationWarning string   `json:"truncationWarning,omitempty"` // User-friendly warning message
+
+	// FallbackFrom lists the configured models that failed, in order, before
+	// ModelName produced this review. Empty when the first model succeeded.
+	FallbackFrom []string `json:"fallbackFrom,omitempty"`
 }
 
 // Finding represents a single issue detected by an LLM.
@@ -150,6 +168,32 @@ func (f Finding) Fingerprint() FindingFingerprint {
 	return FingerprintFromFinding(f)
 }
 
+// suggestionFence opens a GitHub-style suggestion block in a suggestion.
+const suggestionFence = "```suggestion"
+
+// Replacement returns the code of a suggestion block in the finding's
+// suggestion, which replaces lines LineStart through LineEnd, and the
+// prose before the block. ok is false when the suggestion has no block,
+// i.e. no concrete replacement.
+func (f Finding) Replacement() (code, prose string, ok bool) {
+	start := strings.Index(f.Suggestion, suggestionFence)
+	if start == -1 {
+		return "", "", false
+	}
+	rest := f.Suggestion[start+len(suggestionFence):]
+	newline := strings.IndexByte(rest, '\n')
+	if newline == -1 {
+		return "", "", false
+	}
+	rest = rest[newline+1:]
+
+	end := strings.Index(rest, "```")
+	if end == -1 {
+		return "", "", false
+	}
+	return rest[:end], strings.TrimSpace(f.Suggestion[:start]), true
+}
+
 // MarkdownArtifact encapsulates the Markdown generation inputs.
 type MarkdownArtifact struct {
 	OutputDir    string
@@ -167,6 +211,8 @@ type JSONArtifact struct {
 	Repository   string
 	BaseRef      string
 	TargetRef    string
+	Diff         Diff // For the base and target commit SHAs
+	ConfigHash   string
 	Review       Review
 	ProviderName string
 }
@@ -26,6 +26,34 @@ func (c Classification) IsValid() bool {
 	}
 }
 
+// Verification modes selected by verification.depth.
+const (
+	// VerificationModeBatch verifies all findings in a single LLM call (depth: minimal).
+	VerificationModeBatch = "batch"
+	// VerificationModeAgent verifies each finding with the tool-using agent (depth: thorough).
+	VerificationModeAgent = "agent"
+	// VerificationModeHybrid runs batch verification and escalates low-confidence
+	// high/critical findings to the agent (depth: medium).
+	VerificationModeHybrid = "hybrid"
+)
+
+// VerificationPathEscalated marks a finding that was verified by the batch
+// verifier and then re-verified by the agent in hybrid mode.
+const VerificationPathEscalated = "batch+agent"
+
+// VerificationModeForDepth maps a verification depth ("minimal", "medium",
+// "thorough") to its verification mode. Unknown or empty depths use hybrid.
+func VerificationModeForDepth(depth string) string {
+	switch depth {
+	case "minimal":
+		return VerificationModeBatch
+	case "thorough":
+		return VerificationModeAgent
+	default:
+		return VerificationModeHybrid
+	}
+}
+
 // CandidateFinding represents an unverified finding from the discovery phase.
 // Multiple LLMs may report the same issue, captured by Sources and AgreementScore.
 type CandidateFinding struct {
@@ -38,13 +66,17 @@ type CandidateFinding struct {
 // The verification agent has read the full codebase and confirmed or rejected
 // the candidate finding, providing classification and confidence scoring.
 type VerifiedFinding struct {
-	Finding         Finding              `json:"finding"`         // The original finding
-	Verified        bool                 `json:"verified"`        // Whether verification confirmed the issue exists
-	Classification  Classification       `json:"classification"`  // Category: blocking_bug, security, performance, style
-	Confidence      int                  `json:"confidence"`      // 0-100, agent's confidence in the verification
-	Evidence        string               `json:"evidence"`        // Agent's explanation and supporting evidence
-	BlocksOperation bool                 `json:"blocksOperation"` // Whether this finding should block merge/operation
-	VerificationLog []VerificationAction `json:"verificationLog"` // Record of agent actions during verification
+	Finding          Finding              `json:"finding"`                    // The original finding
+	Verified         bool                 `json:"verified"`                   // Whether verification confirmed the issue exists
+	Classification   Classification       `json:"classification"`             // Category: blocking_bug, security, performance, style
+	Confidence       int                  `json:"confidence"`                 // 0-100, agent's confidence in the verification
+	Evidence         string               `json:"evidence"`                   // Agent's explanation and supporting evidence
+	BlocksOperation  bool                 `json:"blocksOperation"`            // Whether this finding should block merge/operation
+	VerificationLog  []VerificationAction `json:"verificationLog"`            // Record of agent actions during verification
+	VerificationPath string               `json:"verificationPath,omitempty"` // How the finding was verified: batch, agent, or batch+agent
+	TokensIn         int                  `json:"tokensIn,omitempty"`         // Input tokens consumed verifying this finding, including its share of a batch call
+	TokensOut        int                  `json:"tokensOut,omitempty"`        // Output tokens generated verifying this finding, including its share of a batch call
+	Cost             float64              `json:"cost,omitempty"`             // Cost in USD of verifying this finding, including its share of a batch call
 }
 
 // VerificationAction records a single tool invocation by the verification agent.
@@ -58,10 +90,14 @@ type VerificationAction struct {
 // VerificationResult encapsulates the outcome of verifying a candidate finding.
 // This is the return type from the Verifier interface.
 type VerificationResult struct {
-	Verified        bool                 `json:"verified"`        // Whether the finding was confirmed
-	Classification  Classification       `json:"classification"`  // The determined classification
-	Confidence      int                  `json:"confidence"`      // 0-100 confidence score
-	Evidence        string               `json:"evidence"`        // Explanation of the verification decision
-	BlocksOperation bool                 `json:"blocksOperation"` // Whether this blocks the operation
-	Actions         []VerificationAction `json:"actions"`         // Agent actions taken during verification
+	Verified        bool                 `json:"verified"`            // Whether the finding was confirmed
+	Classification  Classification       `json:"classification"`      // The determined classification
+	Confidence      int                  `json:"confidence"`          // 0-100 confidence score
+	Evidence        string               `json:"evidence"`            // Explanation of the verification decision
+	BlocksOperation bool                 `json:"blocksOperation"`     // Whether this blocks the operation
+	Actions         []VerificationAction `json:"actions"`             // Agent actions taken during verification
+	Path            string               `json:"path,omitempty"`      // How the finding was verified: batch, agent, or batch+agent
+	TokensIn        int                  `json:"tokensIn,omitempty"`  // Input tokens consumed by this verification (a batch call is shared across its findings)
+	TokensOut       int                  `json:"tokensOut,omitempty"` // Output tokens generated by this verification (a batch call is shared across its findings)
+	Cost            float64              `json:"cost,omitempty"`      // Cost in USD of this verification (a batch call is shared across its findings)
 }
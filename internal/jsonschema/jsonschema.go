@@ -0,0 +1,153 @@
+// Package jsonschema generates JSON Schemas (draft 2020-12) from Go types.
+// It covers the subset of the specification the tool's output formats need:
+// objects, arrays, maps, scalars, enums, numeric bounds and $ref to shared
+// definitions.
+package jsonschema
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Draft is the JSON Schema dialect of generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema.
+type Schema struct {
+	Schema      string             `json:"$schema,omitempty"`
+	ID          string             `json:"$id,omitempty"`
+	Ref         string             `json:"$ref,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Type        string             `json:"type,omitempty"`
+	Enum        []string           `json:"enum,omitempty"`
+	Minimum     *float64           `json:"minimum,omitempty"`
+	Maximum     *float64           `json:"maximum,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+
+	// AdditionalProperties is false for structs, which allow only their
+	// fields, and the value schema for maps.
+	AdditionalProperties any `json:"additionalProperties,omitempty"`
+
+	Defs map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// Generate returns the schema of v's type. Struct fields are named by
+// their json tags; fields tagged omitempty are optional. Named struct types
+// other than the root are shared under $defs. Fields can be documented
+// with tags:
+//
+//	description:"..."  the field's description
+//	enum:"a,b,c"       the allowed string values
+//	minimum:"0"        the numeric lower bound
+//	maximum:"100"      the numeric upper bound
+func Generate(v any) *Schema {
+	g := &generator{defs: make(map[string]*Schema)}
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	root := g.structSchema(t)
+	root.Schema = Draft
+	if len(g.defs) > 0 {
+		root.Defs = g.defs
+	}
+	return root
+}
+
+type generator struct {
+	defs map[string]*Schema
+}
+
+func (g *generator) schema(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.schema(t.Elem())
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if _, ok := g.defs[t.Name()]; !ok {
+			g.defs[t.Name()] = nil // Reserve the name for recursive types
+			g.defs[t.Name()] = g.structSchema(t)
+		}
+		return &Schema{Ref: "#/$defs/" + t.Name()}
+	default:
+		// Interfaces and other kinds accept any value
+		return &Schema{}
+	}
+}
+
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema), AdditionalProperties: false}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, omitempty, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+
+		// Each call returns a new schema, so per-field keywords can be set
+		// next to a $ref (allowed since 2020-12)
+		property := g.schema(field.Type)
+		property.Description = field.Tag.Get("description")
+		if enum := field.Tag.Get("enum"); enum != "" {
+			property.Enum = strings.Split(enum, ",")
+		}
+		property.Minimum = bound(field.Tag.Get("minimum"))
+		property.Maximum = bound(field.Tag.Get("maximum"))
+
+		s.Properties[name] = property
+		if !omitempty {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
+
+// jsonName returns the field's JSON name and whether it is omitted when
+// empty. ok is false for fields encoding/json skips.
+func jsonName(field reflect.StructField) (name string, omitempty, ok bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	name, options, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	for _, option := range strings.Split(options, ",") {
+		if option == "omitempty" || option == "omitzero" {
+			omitempty = true
+		}
+	}
+	return name, omitempty, true
+}
+
+func bound(tag string) *float64 {
+	if tag == "" {
+		return nil
+	}
+	v, err := strconv.ParseFloat(tag, 64)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
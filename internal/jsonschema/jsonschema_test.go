@@ -0,0 +1,127 @@
+package jsonschema_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/jsonschema"
+	validator "github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+type item struct {
+	Name  string `json:"name" description:"The item name"`
+	Score int    `json:"score" minimum:"0" maximum:"100"`
+}
+
+type document struct {
+	Version string            `json:"version" enum:"1,2"`
+	Items   []item            `json:"items"`
+	Main    *item             `json:"main,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Ratio   float64           `json:"ratio"`
+	Enabled bool              `json:"enabled"`
+	Skipped string            `json:"-"`
+	hidden  string
+}
+
+func TestGenerate(t *testing.T) {
+	schema := jsonschema.Generate(document{})
+
+	if schema.Schema != jsonschema.Draft || schema.Type != "object" || schema.AdditionalProperties != false {
+		t.Errorf("unexpected root schema: %+v", schema)
+	}
+	if got := strings.Join(schema.Required, ","); got != "version,items,ratio,enabled" {
+		t.Errorf("expected fields without omitempty to be required, got %q", got)
+	}
+	if _, ok := schema.Properties["Skipped"]; ok {
+		t.Error("expected json:\"-\" fields to be skipped")
+	}
+	if got := schema.Properties["items"].Items.Ref; got != "#/$defs/item" {
+		t.Errorf("expected struct items to reference $defs, got %q", got)
+	}
+	if got := schema.Properties["main"]; got.Ref != "#/$defs/item" {
+		t.Errorf("expected pointer fields to reference the struct, got %+v", got)
+	}
+
+	def := schema.Defs["item"]
+	if def == nil || def.Properties["name"].Description != "The item name" {
+		t.Fatalf("expected documented item definition, got %+v", def)
+	}
+	if score := def.Properties["score"]; *score.Minimum != 0 || *score.Maximum != 100 || score.Type != "integer" {
+		t.Errorf("unexpected score schema: %+v", score)
+	}
+
+	encoded, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("failed to encode schema: %v", err)
+	}
+	for _, want := range []string{`"$schema":"https://json-schema.org/draft/2020-12/schema"`, `"additionalProperties":false`, `"enum":["1","2"]`, `"labels":{"type":"object","additionalProperties":{"type":"string"}}`} {
+		if !strings.Contains(string(encoded), want) {
+			t.Errorf("expected encoded schema to contain %s, got %s", want, encoded)
+		}
+	}
+}
+
+// compile compiles a generated schema with an independent draft 2020-12
+// validator, so the tests check the schema against the specification rather
+// than against our own reading of it.
+func compile(t *testing.T, schema *jsonschema.Schema) *validator.Schema {
+	t.Helper()
+	encoded, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("failed to encode schema: %v", err)
+	}
+	doc, err := validator.UnmarshalJSON(bytes.NewReader(encoded))
+	if err != nil {
+		t.Fatalf("failed to decode schema: %v", err)
+	}
+	compiler := validator.NewCompiler()
+	if err := compiler.AddResource("schema.json", doc); err != nil {
+		t.Fatalf("failed to add schema: %v", err)
+	}
+	compiled, err := compiler.Compile("schema.json")
+	if err != nil {
+		t.Fatalf("failed to compile schema: %v", err)
+	}
+	return compiled
+}
+
+func TestGeneratedSchemaValidates(t *testing.T) {
+	schema := compile(t, jsonschema.Generate(document{}))
+
+	tests := []struct {
+		name     string
+		document string
+		wantErr  string
+	}{
+		{name: "valid", document: `{"version":"1","items":[{"name":"a","score":5}],"main":{"name":"b","score":0},"labels":{"k":"v"},"ratio":0.5,"enabled":true}`},
+		{name: "missing required", document: `{"version":"1","items":[],"ratio":1}`, wantErr: "enabled"},
+		{name: "unexpected property", document: `{"version":"1","items":[],"ratio":1,"enabled":false,"extra":1}`, wantErr: "extra"},
+		{name: "enum", document: `{"version":"3","items":[],"ratio":1,"enabled":false}`, wantErr: "/version"},
+		{name: "null array", document: `{"version":"1","items":null,"ratio":1,"enabled":false}`, wantErr: "/items"},
+		{name: "nested type", document: `{"version":"1","items":[{"name":1,"score":1}],"ratio":1,"enabled":false}`, wantErr: "/items/0/name"},
+		{name: "integer", document: `{"version":"1","items":[{"name":"a","score":1.5}],"ratio":1,"enabled":false}`, wantErr: "/items/0/score"},
+		{name: "maximum", document: `{"version":"1","items":[{"name":"a","score":101}],"ratio":1,"enabled":false}`, wantErr: "/items/0/score"},
+		{name: "map values", document: `{"version":"1","items":[],"labels":{"k":true},"ratio":1,"enabled":false}`, wantErr: "/labels/k"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance, err := validator.UnmarshalJSON(strings.NewReader(tt.document))
+			if err != nil {
+				t.Fatalf("failed to decode document: %v", err)
+			}
+			err = schema.Validate(instance)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected valid document, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Package pathglob matches repository paths against gitignore-style globs,
+// as used by context rules and per-path review policies.
+package pathglob
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Match reports whether a slash-separated path matches a glob. "**" matches
+// zero or more directories; other segments use path.Match. A pattern without
+// a slash matches the file name at any depth, as in .gitignore.
+func Match(pattern, p string) bool {
+	pattern = strings.TrimPrefix(filepath.ToSlash(pattern), "./")
+	p = strings.TrimPrefix(filepath.ToSlash(p), "./")
+	if !strings.Contains(pattern, "/") {
+		matched, _ := path.Match(pattern, path.Base(p))
+		return matched
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(p, "/"))
+}
+
+// MatchAny reports whether the path matches any of the globs.
+func MatchAny(patterns []string, p string) bool {
+	for _, pattern := range patterns {
+		if Match(pattern, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments matches path segments against pattern segments.
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			// Try every possible number of directories for "**"
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(pattern[0], segments[0]); !matched {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
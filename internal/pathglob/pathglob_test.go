@@ -0,0 +1,45 @@
+package pathglob_test
+
+import (
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/pathglob"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"services/billing/**", "services/billing/ledger.go", true},
+		{"services/billing/**", "services/billing/internal/tax/tax.go", true},
+		{"services/billing/**", "services/payments/ledger.go", false},
+		{"services/*/migrations/*.sql", "services/billing/migrations/001.sql", true},
+		{"services/*/migrations/*.sql", "services/billing/schema/001.sql", false},
+		{"**/handler.go", "handler.go", true},
+		{"**/handler.go", "api/v1/handler.go", true},
+		{"*.sql", "db/migrations/001.sql", true},
+		{"*.sql", "db/migrations/001.go", false},
+		{"./docs/*.md", "docs/README.md", true},
+		{"docs/*.md", "docs/api/README.md", false},
+	}
+	for _, tt := range tests {
+		if got := pathglob.Match(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestMatchAny(t *testing.T) {
+	patterns := []string{"vendor/**", "*.pb.go"}
+	if !pathglob.MatchAny(patterns, "api/v1/service.pb.go") {
+		t.Error("expected *.pb.go to match at any depth")
+	}
+	if pathglob.MatchAny(patterns, "api/v1/service.go") {
+		t.Error("expected no match")
+	}
+	if pathglob.MatchAny(nil, "main.go") {
+		t.Error("expected no match for empty patterns")
+	}
+}
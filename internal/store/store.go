@@ -2,9 +2,13 @@ package store
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// ErrJobNotFound is returned by GetJob for unknown job IDs.
+var ErrJobNotFound = errors.New("job not found")
+
 // Store defines the persistence layer interface for review history and feedback.
 type Store interface {
 	// Run management
@@ -31,6 +35,11 @@ type Store interface {
 	GetPrecisionPriors(ctx context.Context) (map[string]map[string]PrecisionPrior, error)
 	UpdatePrecisionPrior(ctx context.Context, provider, category string, accepted, rejected int) error
 
+	// Review jobs submitted to the HTTP API
+	SaveJob(ctx context.Context, job JobRecord) error
+	GetJob(ctx context.Context, jobID string) (JobRecord, error)
+	ListJobs(ctx context.Context, statuses ...string) ([]JobRecord, error)
+
 	// Utility
 	Close() error
 }
@@ -80,6 +89,19 @@ type Feedback struct {
 	Timestamp  time.Time
 }
 
+// JobRecord stores a review job submitted to the HTTP API. The request and
+// result are stored as JSON documents.
+type JobRecord struct {
+	JobID      string
+	Status     string // "queued", "running", "succeeded" or "failed"
+	Request    string
+	Result     string // Empty until the job succeeds
+	Error      string
+	CreatedAt  time.Time
+	StartedAt  time.Time // Zero until the job starts
+	FinishedAt time.Time // Zero until the job finishes
+}
+
 // PrecisionPrior represents the Beta distribution parameters for a provider's accuracy.
 type PrecisionPrior struct {
 	Provider string
@@ -0,0 +1,399 @@
+// Package bitbucket provides use cases for interacting with Bitbucket Cloud.
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/bitbucket"
+	"github.com/bkyoung/code-reviewer/internal/adapter/github"
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/posting"
+)
+
+const (
+	// summaryMarker tags the bot's summary comment so that summaries from
+	// earlier runs can be found and removed once a new one is posted.
+	summaryMarker = "<!-- CR_SUMMARY -->"
+
+	// BuildStatusKey identifies the review's commit build status. Reusing the
+	// key makes each run update the same status instead of adding another.
+	BuildStatusKey = "code-reviewer"
+)
+
+// PullRequestClient defines the interface for interacting with Bitbucket pull requests.
+// This interface allows for mocking in tests.
+type PullRequestClient interface {
+	CurrentUser(ctx context.Context) (*bitbucket.User, error)
+	GetPullRequest(ctx context.Context, workspace, repoSlug string, id int) (*bitbucket.PullRequest, error)
+	ListComments(ctx context.Context, workspace, repoSlug string, id int) ([]bitbucket.Comment, error)
+	CreateComment(ctx context.Context, workspace, repoSlug string, id int, input bitbucket.CreateCommentRequest) (*bitbucket.Comment, error)
+	DeleteComment(ctx context.Context, workspace, repoSlug string, id int, commentID int64) error
+	ResolveComment(ctx context.Context, workspace, repoSlug string, id int, commentID int64) error
+	Approve(ctx context.Context, workspace, repoSlug string, id int) error
+	Unapprove(ctx context.Context, workspace, repoSlug string, id int) error
+	RequestChanges(ctx context.Context, workspace, repoSlug string, id int) error
+	RemoveRequestChanges(ctx context.Context, workspace, repoSlug string, id int) error
+	SetBuildStatus(ctx context.Context, workspace, repoSlug, commitSHA string, status bitbucket.BuildStatus) error
+}
+
+// ReviewPoster orchestrates publishing code review findings to Bitbucket Cloud.
+// A review becomes a summary comment plus inline comments; the severity-based
+// event sets the bot's approve/request-changes state and a commit build status.
+type ReviewPoster struct {
+	client PullRequestClient
+}
+
+// NewReviewPoster creates a new ReviewPoster with the given client.
+func NewReviewPoster(client PullRequestClient) *ReviewPoster {
+	return &ReviewPoster{client: client}
+}
+
+// PostReviewRequest contains all data needed to post a review.
+type PostReviewRequest struct {
+	// Workspace is the Bitbucket workspace ID.
+	Workspace string
+
+	// RepoSlug is the repository slug.
+	RepoSlug string
+
+	// PullRequestID is the pull request ID.
+	PullRequestID int
+
+	// CommitSHA is the head commit SHA that was reviewed; the build status is set on it.
+	CommitSHA string
+
+	// Review contains the summary and other metadata.
+	Review domain.Review
+
+	// Findings are the positioned findings to post as inline comments.
+	Findings []bitbucket.PositionedFinding
+
+	// OverrideEvent optionally overrides the automatically determined event.
+	OverrideEvent github.ReviewEvent
+
+	// ReviewActions configures the review action for each finding severity.
+	ReviewActions github.ReviewActions
+
+	// BotUsername enables deduplication, reply-status analysis, removal of
+	// stale bot summaries and resolution of stale finding comments. The bot is identified as the credentials' account;
+	// BotUsername is matched against nickname or display name only when that
+	// lookup fails. If empty, existing comments are ignored.
+	BotUsername string
+}
+
+// PostReviewResult contains the result of posting a review.
+type PostReviewResult struct {
+	// SummaryCommentID is the ID of the summary comment.
+	SummaryCommentID int64
+
+	// CommentsPosted is the number of inline comments posted.
+	CommentsPosted int
+
+	// CommentsSkipped is the number of findings skipped (not in diff or rejected by Bitbucket).
+	CommentsSkipped int
+
+	// DuplicatesSkipped is the number of findings skipped because they were
+	// already posted in previous runs (exact fingerprint match).
+	DuplicatesSkipped int
+
+	// Event is the review event that was determined.
+	Event github.ReviewEvent
+
+	// HTMLURL is the URL to view the pull request.
+	HTMLURL string
+
+	// DeletedSummaries is the number of previous bot summary comments removed.
+	DeletedSummaries int
+
+	// ResolvedCount is the number of previous bot finding comments resolved
+	// because their finding is no longer reported.
+	ResolvedCount int
+
+	// AcknowledgedCount is the number of existing findings with acknowledgment replies.
+	AcknowledgedCount int
+
+	// DisputedCount is the number of existing findings with dispute replies.
+	DisputedCount int
+
+	// OpenCount is the number of existing findings with no status-changing replies.
+	OpenCount int
+}
+
+// PostReview publishes a code review to a Bitbucket Cloud pull request.
+//
+// If BotUsername is set:
+//   - Existing bot comments are fetched to deduplicate findings by fingerprint
+//   - Reply statuses are analyzed; acknowledged/disputed findings don't block
+//   - Previous bot summary comments are deleted AFTER the new one posts
+//   - Bot finding comments whose fingerprint is no longer among the findings
+//     are resolved AFTER the new summary posts
+//
+// The summary comment must post successfully; failures on individual inline
+// comments are logged and counted in CommentsSkipped. Approval and build
+// status failures are logged but do not affect the result.
+func (p *ReviewPoster) PostReview(ctx context.Context, req PostReviewRequest) (*PostReviewResult, error) {
+	if req.OverrideEvent != "" {
+		normalized, valid := github.NormalizeAction(string(req.OverrideEvent))
+		if !valid {
+			return nil, fmt.Errorf("invalid OverrideEvent: %q (must be APPROVE, REQUEST_CHANGES, or COMMENT)", req.OverrideEvent)
+		}
+		req.OverrideEvent = normalized
+	}
+
+	pr, err := p.client.GetPullRequest(ctx, req.Workspace, req.RepoSlug, req.PullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pull request: %w", err)
+	}
+
+	findings := req.Findings
+	var duplicatesSkipped int
+	var existingStatuses map[domain.FindingFingerprint]domain.FindingStatus
+	var statusCounts posting.StatusCounts
+	var botComments []bitbucket.Comment
+
+	if req.BotUsername != "" {
+		isBot := p.botMatcher(ctx, req.BotUsername)
+		comments, err := p.client.ListComments(ctx, req.Workspace, req.RepoSlug, req.PullRequestID)
+		if err != nil {
+			log.Printf("warning: failed to fetch comments: %v", err)
+		} else {
+			botComments = filterBotComments(comments, isBot)
+			findings, duplicatesSkipped = posting.FilterDuplicates(req.Findings, findingOf, commentBodies(botComments))
+			existingStatuses, statusCounts = posting.AnalyzeStatuses(commentThreads(comments, botComments, isBot))
+		}
+	}
+
+	var event github.ReviewEvent
+	if req.OverrideEvent != "" {
+		event = req.OverrideEvent
+	} else {
+		event = posting.EffectiveEvent(req.Findings, findingOf, existingStatuses, req.ReviewActions)
+	}
+
+	summary := req.Review.Summary + posting.FormatStatusSection(statusCounts) + "\n\n" + summaryMarker
+	summaryComment, err := p.client.CreateComment(ctx, req.Workspace, req.RepoSlug, req.PullRequestID, bitbucket.CreateCommentRequest{
+		Content: bitbucket.Content{Raw: summary},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if summaryComment == nil {
+		return nil, fmt.Errorf("CreateComment returned nil response")
+	}
+
+	var posted, skipped int
+	for _, pf := range findings {
+		if !pf.InDiff() {
+			skipped++
+			continue
+		}
+
+		fingerprint := domain.FingerprintFromFinding(pf.Finding)
+		_, err := p.client.CreateComment(ctx, req.Workspace, req.RepoSlug, req.PullRequestID, bitbucket.CreateCommentRequest{
+			Content: bitbucket.Content{Raw: github.FormatFindingCommentWithFingerprint(pf.Finding, fingerprint)},
+			Inline:  &bitbucket.Inline{Path: pf.Path, To: pf.Line},
+		})
+		if err != nil {
+			log.Printf("warning: failed to post comment on %s:%d: %v", pf.Path, *pf.Line, err)
+			skipped++
+			continue
+		}
+		posted++
+	}
+
+	p.applyReviewState(ctx, req.Workspace, req.RepoSlug, req.PullRequestID, event)
+	p.setBuildStatus(ctx, req, pr.Links.HTML.Href, event)
+
+	var deletedSummaries, resolvedCount int
+	if req.BotUsername != "" {
+		deletedSummaries = p.deleteStaleSummaries(ctx, req, botComments, summaryComment.ID)
+		resolvedCount = p.resolveStaleComments(ctx, req, botComments)
+	}
+
+	return &PostReviewResult{
+		SummaryCommentID:  summaryComment.ID,
+		CommentsPosted:    posted,
+		CommentsSkipped:   skipped,
+		DuplicatesSkipped: duplicatesSkipped,
+		Event:             event,
+		HTMLURL:           pr.Links.HTML.Href,
+		DeletedSummaries:  deletedSummaries,
+		ResolvedCount:     resolvedCount,
+		AcknowledgedCount: statusCounts.Acknowledged,
+		DisputedCount:     statusCounts.Disputed,
+		OpenCount:         statusCounts.Open,
+	}, nil
+}
+
+// botMatcher returns a predicate that recognises the bot's own comments.
+// The credentials' account UUID is preferred; if it can't be looked up the
+// configured name is compared with nickname and display name.
+func (p *ReviewPoster) botMatcher(ctx context.Context, configured string) func(bitbucket.User) bool {
+	user, err := p.client.CurrentUser(ctx)
+	if err == nil && user != nil && user.UUID != "" {
+		return func(u bitbucket.User) bool { return u.UUID == user.UUID }
+	}
+	if err != nil {
+		log.Printf("warning: failed to look up token user, using %q: %v", configured, err)
+	}
+	return func(u bitbucket.User) bool {
+		return strings.EqualFold(u.Nickname, configured) || strings.EqualFold(u.DisplayName, configured)
+	}
+}
+
+// applyReviewState maps the review event onto the bot's participant state.
+// Bitbucket keeps approval and change requests separately, so the opposite
+// state is withdrawn first; withdrawing a state the bot doesn't hold is a no-op.
+func (p *ReviewPoster) applyReviewState(ctx context.Context, workspace, repoSlug string, id int, event github.ReviewEvent) {
+	withdraw := func(name string, fn func(context.Context, string, string, int) error) {
+		if err := fn(ctx, workspace, repoSlug, id); err != nil && !isNotHeld(err) {
+			log.Printf("warning: failed to withdraw %s: %v", name, err)
+		}
+	}
+
+	switch event {
+	case github.EventApprove:
+		withdraw("change request", p.client.RemoveRequestChanges)
+		if err := p.client.Approve(ctx, workspace, repoSlug, id); err != nil {
+			log.Printf("warning: failed to approve pull request: %v", err)
+		}
+	case github.EventRequestChanges:
+		withdraw("approval", p.client.Unapprove)
+		if err := p.client.RequestChanges(ctx, workspace, repoSlug, id); err != nil {
+			log.Printf("warning: failed to request changes: %v", err)
+		}
+	default:
+		withdraw("approval", p.client.Unapprove)
+		withdraw("change request", p.client.RemoveRequestChanges)
+	}
+}
+
+// isNotHeld reports whether an error means the participant state being
+// withdrawn was not set (Bitbucket answers 404 or 409).
+func isNotHeld(err error) bool {
+	var httpErr *llmhttp.Error
+	if !errors.As(err, &httpErr) {
+		return false
+	}
+	return httpErr.StatusCode == http.StatusNotFound || httpErr.StatusCode == http.StatusConflict
+}
+
+// setBuildStatus records the review outcome as a commit build status so branch
+// restrictions can require a passing review. Only REQUEST_CHANGES fails.
+func (p *ReviewPoster) setBuildStatus(ctx context.Context, req PostReviewRequest, prURL string, event github.ReviewEvent) {
+	if req.CommitSHA == "" {
+		return
+	}
+
+	status := bitbucket.BuildStatus{
+		Key:         BuildStatusKey,
+		State:       bitbucket.BuildSuccessful,
+		Name:        "Code review",
+		URL:         prURL,
+		Description: "No blocking findings",
+	}
+	if event == github.EventRequestChanges {
+		status.State = bitbucket.BuildFailed
+		status.Description = "Changes requested"
+	}
+
+	if err := p.client.SetBuildStatus(ctx, req.Workspace, req.RepoSlug, req.CommitSHA, status); err != nil {
+		log.Printf("warning: failed to set build status: %v", err)
+	}
+}
+
+// deleteStaleSummaries removes the bot's previous summary comments; the new
+// summary supersedes them. Inline finding comments are kept so their reply
+// threads survive (see resolveStaleComments). Returns the number deleted;
+// errors are logged only.
+func (p *ReviewPoster) deleteStaleSummaries(ctx context.Context, req PostReviewRequest, botComments []bitbucket.Comment, excludeID int64) int {
+	var deleted int
+	for _, c := range botComments {
+		if c.ID == excludeID || c.Inline != nil || !strings.Contains(c.Content.Raw, summaryMarker) {
+			continue
+		}
+		if err := p.client.DeleteComment(ctx, req.Workspace, req.RepoSlug, req.PullRequestID, c.ID); err != nil {
+			log.Printf("warning: failed to delete summary comment %d: %v", c.ID, err)
+			continue
+		}
+		deleted++
+	}
+	return deleted
+}
+
+// resolveStaleComments resolves the bot's finding comments whose fingerprint is
+// no longer among the current findings. Resolving rather than deleting keeps
+// the reply thread readable. Returns the number of comments resolved; errors
+// are logged but do not block the review posting workflow.
+func (p *ReviewPoster) resolveStaleComments(ctx context.Context, req PostReviewRequest, botComments []bitbucket.Comment) int {
+	current := make(map[domain.FindingFingerprint]bool, len(req.Findings))
+	for _, pf := range req.Findings {
+		current[domain.FingerprintFromFinding(pf.Finding)] = true
+	}
+
+	var resolved int
+	for _, c := range botComments {
+		if c.Resolution != nil {
+			continue
+		}
+		fp, ok := github.ExtractFingerprintFromComment(c.Content.Raw)
+		if !ok || current[fp] {
+			continue
+		}
+		if err := p.client.ResolveComment(ctx, req.Workspace, req.RepoSlug, req.PullRequestID, c.ID); err != nil {
+			log.Printf("warning: failed to resolve comment %d: %v", c.ID, err)
+			continue
+		}
+		resolved++
+	}
+	return resolved
+}
+
+// filterBotComments returns the bot's live top-level comments.
+func filterBotComments(comments []bitbucket.Comment, isBot func(bitbucket.User) bool) []bitbucket.Comment {
+	var result []bitbucket.Comment
+	for _, c := range comments {
+		if c.Deleted || c.Parent != nil || !isBot(c.User) {
+			continue
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
+// findingOf returns the domain finding of a positioned finding.
+func findingOf(pf bitbucket.PositionedFinding) domain.Finding {
+	return pf.Finding
+}
+
+// commentBodies returns the raw bodies of the given comments.
+func commentBodies(comments []bitbucket.Comment) []string {
+	bodies := make([]string, 0, len(comments))
+	for _, c := range comments {
+		bodies = append(bodies, c.Content.Raw)
+	}
+	return bodies
+}
+
+// commentThreads pairs each bot comment with the human replies to it.
+func commentThreads(comments, botComments []bitbucket.Comment, isBot func(bitbucket.User) bool) []posting.Thread {
+	replies := make(map[int64][]string)
+	for _, c := range comments {
+		if c.Deleted || c.Parent == nil || isBot(c.User) {
+			continue
+		}
+		replies[c.Parent.ID] = append(replies[c.Parent.ID], c.Content.Raw)
+	}
+
+	threads := make([]posting.Thread, 0, len(botComments))
+	for _, c := range botComments {
+		threads = append(threads, posting.Thread{Body: c.Content.Raw, Replies: replies[c.ID]})
+	}
+	return threads
+}
@@ -0,0 +1,275 @@
+package bitbucket_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/bitbucket"
+	"github.com/bkyoung/code-reviewer/internal/adapter/github"
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	usecasebitbucket "github.com/bkyoung/code-reviewer/internal/usecase/bitbucket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var botUser = bitbucket.User{UUID: "{bot}", Nickname: "cr-bot"}
+
+// MockPullRequestClient is a mock implementation of the PullRequestClient interface.
+type MockPullRequestClient struct {
+	CurrentUserFunc   func(ctx context.Context) (*bitbucket.User, error)
+	ListCommentsFunc  func(ctx context.Context) ([]bitbucket.Comment, error)
+	CreateCommentFunc func(ctx context.Context, input bitbucket.CreateCommentRequest) (*bitbucket.Comment, error)
+	UnapproveErr      error
+	Created           []bitbucket.CreateCommentRequest
+	DeletedIDs        []int64
+	ResolvedIDs       []int64
+	Actions           []string
+	BuildStatuses     []bitbucket.BuildStatus
+}
+
+func (m *MockPullRequestClient) CurrentUser(ctx context.Context) (*bitbucket.User, error) {
+	if m.CurrentUserFunc != nil {
+		return m.CurrentUserFunc(ctx)
+	}
+	return &botUser, nil
+}
+
+func (m *MockPullRequestClient) GetPullRequest(ctx context.Context, workspace, repoSlug string, id int) (*bitbucket.PullRequest, error) {
+	pr := &bitbucket.PullRequest{ID: id}
+	pr.Links.HTML.Href = "https://bitbucket.org/ws/repo/pull-requests/4"
+	return pr, nil
+}
+
+func (m *MockPullRequestClient) ListComments(ctx context.Context, workspace, repoSlug string, id int) ([]bitbucket.Comment, error) {
+	if m.ListCommentsFunc != nil {
+		return m.ListCommentsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockPullRequestClient) CreateComment(ctx context.Context, workspace, repoSlug string, id int, input bitbucket.CreateCommentRequest) (*bitbucket.Comment, error) {
+	m.Created = append(m.Created, input)
+	if m.CreateCommentFunc != nil {
+		return m.CreateCommentFunc(ctx, input)
+	}
+	return &bitbucket.Comment{ID: int64(1000 + len(m.Created))}, nil
+}
+
+func (m *MockPullRequestClient) DeleteComment(ctx context.Context, workspace, repoSlug string, id int, commentID int64) error {
+	m.DeletedIDs = append(m.DeletedIDs, commentID)
+	return nil
+}
+
+func (m *MockPullRequestClient) ResolveComment(ctx context.Context, workspace, repoSlug string, id int, commentID int64) error {
+	m.ResolvedIDs = append(m.ResolvedIDs, commentID)
+	return nil
+}
+
+func (m *MockPullRequestClient) Approve(ctx context.Context, workspace, repoSlug string, id int) error {
+	m.Actions = append(m.Actions, "approve")
+	return nil
+}
+
+func (m *MockPullRequestClient) Unapprove(ctx context.Context, workspace, repoSlug string, id int) error {
+	m.Actions = append(m.Actions, "unapprove")
+	return m.UnapproveErr
+}
+
+func (m *MockPullRequestClient) RequestChanges(ctx context.Context, workspace, repoSlug string, id int) error {
+	m.Actions = append(m.Actions, "request-changes")
+	return nil
+}
+
+func (m *MockPullRequestClient) RemoveRequestChanges(ctx context.Context, workspace, repoSlug string, id int) error {
+	m.Actions = append(m.Actions, "remove-request-changes")
+	return nil
+}
+
+func (m *MockPullRequestClient) SetBuildStatus(ctx context.Context, workspace, repoSlug, commitSHA string, status bitbucket.BuildStatus) error {
+	m.BuildStatuses = append(m.BuildStatuses, status)
+	return nil
+}
+
+func makeFinding(file string, line int, severity, description string) domain.Finding {
+	return domain.NewFinding(domain.FindingInput{
+		File:        file,
+		LineStart:   line,
+		LineEnd:     line,
+		Severity:    severity,
+		Category:    "bug",
+		Description: description,
+		Evidence:    true,
+	})
+}
+
+func inDiff(f domain.Finding) bitbucket.PositionedFinding {
+	line := f.LineStart
+	return bitbucket.PositionedFinding{Finding: f, Path: f.File, Line: &line}
+}
+
+func TestReviewPoster_PostReview_RequestChanges(t *testing.T) {
+	client := &MockPullRequestClient{
+		UnapproveErr: &llmhttp.Error{Type: llmhttp.ErrTypeInvalidRequest, StatusCode: 404},
+	}
+	poster := usecasebitbucket.NewReviewPoster(client)
+
+	result, err := poster.PostReview(context.Background(), usecasebitbucket.PostReviewRequest{
+		Workspace:     "ws",
+		RepoSlug:      "repo",
+		PullRequestID: 4,
+		CommitSHA:     "abc123",
+		Review:        domain.Review{Summary: "Summary"},
+		Findings: []bitbucket.PositionedFinding{
+			inDiff(makeFinding("a.go", 3, "high", "nil deref")),
+			{Finding: makeFinding("b.go", 9, "low", "elsewhere"), Path: "b.go"},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, client.Created, 2)
+	assert.Nil(t, client.Created[0].Inline)
+	assert.Contains(t, client.Created[0].Content.Raw, "<!-- CR_SUMMARY -->")
+	require.NotNil(t, client.Created[1].Inline)
+	assert.Equal(t, "a.go", client.Created[1].Inline.Path)
+	assert.Equal(t, 3, *client.Created[1].Inline.To)
+
+	assert.Equal(t, []string{"unapprove", "request-changes"}, client.Actions)
+	require.Len(t, client.BuildStatuses, 1)
+	assert.Equal(t, bitbucket.BuildFailed, client.BuildStatuses[0].State)
+	assert.Equal(t, usecasebitbucket.BuildStatusKey, client.BuildStatuses[0].Key)
+	assert.Equal(t, "https://bitbucket.org/ws/repo/pull-requests/4", client.BuildStatuses[0].URL)
+
+	assert.Equal(t, int64(1001), result.SummaryCommentID)
+	assert.Equal(t, 1, result.CommentsPosted)
+	assert.Equal(t, 1, result.CommentsSkipped)
+	assert.Equal(t, github.EventRequestChanges, result.Event)
+	assert.Equal(t, "https://bitbucket.org/ws/repo/pull-requests/4", result.HTMLURL)
+}
+
+func TestReviewPoster_PostReview_ApproveClean(t *testing.T) {
+	client := &MockPullRequestClient{}
+	poster := usecasebitbucket.NewReviewPoster(client)
+
+	result, err := poster.PostReview(context.Background(), usecasebitbucket.PostReviewRequest{
+		Workspace: "ws", RepoSlug: "repo", PullRequestID: 4, CommitSHA: "abc123",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, github.EventApprove, result.Event)
+	assert.Equal(t, []string{"remove-request-changes", "approve"}, client.Actions)
+	assert.Equal(t, bitbucket.BuildSuccessful, client.BuildStatuses[0].State)
+}
+
+func TestReviewPoster_PostReview_SummaryFailureReturnsError(t *testing.T) {
+	client := &MockPullRequestClient{
+		CreateCommentFunc: func(ctx context.Context, input bitbucket.CreateCommentRequest) (*bitbucket.Comment, error) {
+			return nil, errors.New("boom")
+		},
+	}
+	poster := usecasebitbucket.NewReviewPoster(client)
+
+	_, err := poster.PostReview(context.Background(), usecasebitbucket.PostReviewRequest{
+		Workspace: "ws", RepoSlug: "repo", PullRequestID: 4, CommitSHA: "abc123",
+	})
+	require.Error(t, err)
+	assert.Empty(t, client.Actions)
+	assert.Empty(t, client.BuildStatuses)
+}
+
+func TestReviewPoster_PostReview_DedupStatusesAndStaleSummaries(t *testing.T) {
+	dup := makeFinding("a.go", 3, "high", "already reported")
+	disputed := makeFinding("a.go", 20, "critical", "false alarm")
+	dupBody := github.FormatFindingCommentWithFingerprint(dup, domain.FingerprintFromFinding(dup))
+	disputedBody := github.FormatFindingCommentWithFingerprint(disputed, domain.FingerprintFromFinding(disputed))
+
+	client := &MockPullRequestClient{
+		ListCommentsFunc: func(ctx context.Context) ([]bitbucket.Comment, error) {
+			return []bitbucket.Comment{
+				{ID: 1, User: botUser, Content: bitbucket.Content{Raw: "old\n\n<!-- CR_SUMMARY -->"}},
+				{ID: 2, User: botUser, Content: bitbucket.Content{Raw: dupBody}, Inline: &bitbucket.Inline{Path: "a.go"}},
+				{ID: 3, User: botUser, Content: bitbucket.Content{Raw: disputedBody}, Inline: &bitbucket.Inline{Path: "a.go"}},
+				{ID: 4, User: bitbucket.User{UUID: "{dev}"}, Content: bitbucket.Content{Raw: "this is a false positive"}, Parent: &bitbucket.CommentRef{ID: 3}},
+				{ID: 5, User: bitbucket.User{UUID: "{dev}"}, Content: bitbucket.Content{Raw: "<!-- CR_SUMMARY -->"}},
+			}, nil
+		},
+	}
+	poster := usecasebitbucket.NewReviewPoster(client)
+
+	result, err := poster.PostReview(context.Background(), usecasebitbucket.PostReviewRequest{
+		Workspace:     "ws",
+		RepoSlug:      "repo",
+		PullRequestID: 4,
+		Findings:      []bitbucket.PositionedFinding{inDiff(dup), inDiff(disputed)},
+		BotUsername:   "cr-bot",
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, client.Created, 1, "only the summary is posted")
+	assert.Equal(t, 2, result.DuplicatesSkipped)
+	assert.Equal(t, 1, result.DisputedCount)
+	assert.Equal(t, 1, result.OpenCount)
+	assert.Equal(t, github.EventRequestChanges, result.Event)
+	assert.Equal(t, []int64{1}, client.DeletedIDs, "only the bot's old summary is deleted")
+	assert.Equal(t, 1, result.DeletedSummaries)
+}
+
+func TestReviewPoster_PostReview_ResolvesStaleFindingComments(t *testing.T) {
+	fixed := makeFinding("a.go", 3, "high", "fixed since")
+	alreadyResolved := makeFinding("a.go", 7, "high", "resolved by a human")
+	stillPresent := makeFinding("a.go", 20, "medium", "still here")
+	body := func(f domain.Finding) string {
+		return github.FormatFindingCommentWithFingerprint(f, domain.FingerprintFromFinding(f))
+	}
+
+	client := &MockPullRequestClient{
+		ListCommentsFunc: func(ctx context.Context) ([]bitbucket.Comment, error) {
+			return []bitbucket.Comment{
+				{ID: 1, User: botUser, Content: bitbucket.Content{Raw: "old\n\n<!-- CR_SUMMARY -->"}},
+				{ID: 2, User: botUser, Content: bitbucket.Content{Raw: body(fixed)}, Inline: &bitbucket.Inline{Path: "a.go"}},
+				{ID: 3, User: botUser, Content: bitbucket.Content{Raw: body(stillPresent)}, Inline: &bitbucket.Inline{Path: "a.go"}},
+				{ID: 4, User: botUser, Content: bitbucket.Content{Raw: body(alreadyResolved)}, Inline: &bitbucket.Inline{Path: "a.go"},
+					Resolution: &bitbucket.CommentResolution{Type: "comment_resolution"}},
+				{ID: 5, User: bitbucket.User{UUID: "{dev}"}, Content: bitbucket.Content{Raw: body(fixed)}, Inline: &bitbucket.Inline{Path: "a.go"}},
+			}, nil
+		},
+	}
+	poster := usecasebitbucket.NewReviewPoster(client)
+
+	result, err := poster.PostReview(context.Background(), usecasebitbucket.PostReviewRequest{
+		Workspace:     "ws",
+		RepoSlug:      "repo",
+		PullRequestID: 4,
+		Findings:      []bitbucket.PositionedFinding{inDiff(stillPresent)},
+		BotUsername:   "cr-bot",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []int64{2}, client.ResolvedIDs, "only the bot's open comment for the fixed finding is resolved")
+	assert.Equal(t, 1, result.ResolvedCount)
+	assert.Equal(t, []int64{1}, client.DeletedIDs, "finding comments are resolved, not deleted")
+}
+
+func TestReviewPoster_PostReview_FallsBackToNickname(t *testing.T) {
+	f := makeFinding("a.go", 3, "low", "nit")
+	body := github.FormatFindingCommentWithFingerprint(f, domain.FingerprintFromFinding(f))
+
+	client := &MockPullRequestClient{
+		CurrentUserFunc: func(ctx context.Context) (*bitbucket.User, error) {
+			return nil, errors.New("forbidden")
+		},
+		ListCommentsFunc: func(ctx context.Context) ([]bitbucket.Comment, error) {
+			return []bitbucket.Comment{{ID: 2, User: bitbucket.User{Nickname: "CR-Bot"}, Content: bitbucket.Content{Raw: body}}}, nil
+		},
+	}
+	poster := usecasebitbucket.NewReviewPoster(client)
+
+	result, err := poster.PostReview(context.Background(), usecasebitbucket.PostReviewRequest{
+		Workspace: "ws", RepoSlug: "repo", PullRequestID: 4,
+		Findings:    []bitbucket.PositionedFinding{inDiff(f)},
+		BotUsername: "cr-bot",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.DuplicatesSkipped)
+}
@@ -0,0 +1,295 @@
+// Package gitea provides use cases for interacting with Gitea.
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/gitea"
+	"github.com/bkyoung/code-reviewer/internal/adapter/github"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/posting"
+)
+
+// ReviewClient defines the interface for interacting with Gitea reviews.
+// This interface allows for mocking in tests.
+type ReviewClient interface {
+	CurrentUser(ctx context.Context) (*gitea.User, error)
+	CreateReview(ctx context.Context, owner, repo string, index int, input gitea.CreateReviewRequest) (*gitea.PullReview, error)
+	ListReviews(ctx context.Context, owner, repo string, index int) ([]gitea.PullReview, error)
+	ListReviewComments(ctx context.Context, owner, repo string, index int) ([]gitea.PullReviewComment, error)
+	DismissReview(ctx context.Context, owner, repo string, index int, reviewID int64, message string) error
+}
+
+// ReviewPoster orchestrates posting code review findings to Gitea as PR reviews.
+// It follows the GitHub poster: one review per run carrying the summary and all
+// in-diff findings, fingerprint deduplication, reply-status analysis and
+// dismissal of the bot's previous reviews.
+type ReviewPoster struct {
+	client ReviewClient
+}
+
+// NewReviewPoster creates a new ReviewPoster with the given client.
+func NewReviewPoster(client ReviewClient) *ReviewPoster {
+	return &ReviewPoster{client: client}
+}
+
+// PostReviewRequest contains all data needed to post a review.
+type PostReviewRequest struct {
+	// Owner is the repository owner (user or organization).
+	Owner string
+
+	// Repo is the repository name.
+	Repo string
+
+	// PullNumber is the PR index.
+	PullNumber int
+
+	// CommitSHA is the head commit SHA of the PR.
+	CommitSHA string
+
+	// Review contains the summary and other metadata.
+	Review domain.Review
+
+	// Findings are the positioned findings to post as inline comments.
+	Findings []gitea.PositionedFinding
+
+	// OverrideEvent optionally overrides the automatically determined event.
+	OverrideEvent github.ReviewEvent
+
+	// ReviewActions configures the review action for each finding severity.
+	ReviewActions github.ReviewActions
+
+	// BotUsername enables deduplication, reply-status analysis and dismissal of
+	// stale bot reviews. The bot is identified as the token's user; BotUsername
+	// is only used when that lookup fails. If empty, no reviews are dismissed.
+	BotUsername string
+}
+
+// PostReviewResult contains the result of posting a review.
+type PostReviewResult struct {
+	// ReviewID is the Gitea review ID.
+	ReviewID int64
+
+	// CommentsPosted is the number of inline comments posted.
+	CommentsPosted int
+
+	// CommentsSkipped is the number of findings skipped (not in diff).
+	CommentsSkipped int
+
+	// DuplicatesSkipped is the number of findings skipped because they were
+	// already posted in previous reviews (exact fingerprint match).
+	DuplicatesSkipped int
+
+	// Event is the review event that was used.
+	Event github.ReviewEvent
+
+	// HTMLURL is the URL to view the review on Gitea.
+	HTMLURL string
+
+	// DismissedCount is the number of previous bot reviews that were dismissed.
+	DismissedCount int
+
+	// AcknowledgedCount is the number of existing findings with acknowledgment replies.
+	AcknowledgedCount int
+
+	// DisputedCount is the number of existing findings with dispute replies.
+	DisputedCount int
+
+	// OpenCount is the number of existing findings with no status-changing replies.
+	OpenCount int
+}
+
+// PostReview posts a code review to a Gitea pull request.
+//
+// If BotUsername is set:
+//   - Existing bot comments are fetched to deduplicate findings by fingerprint
+//   - Reply statuses are analyzed; acknowledged/disputed findings don't block
+//   - Previous reviews from the bot are dismissed AFTER posting succeeds
+//
+// Dismiss failures are logged but do not affect the result.
+func (p *ReviewPoster) PostReview(ctx context.Context, req PostReviewRequest) (*PostReviewResult, error) {
+	if req.OverrideEvent != "" {
+		normalized, valid := github.NormalizeAction(string(req.OverrideEvent))
+		if !valid {
+			return nil, fmt.Errorf("invalid OverrideEvent: %q (must be APPROVE, REQUEST_CHANGES, or COMMENT)", req.OverrideEvent)
+		}
+		req.OverrideEvent = normalized
+	}
+
+	findings := req.Findings
+	var duplicatesSkipped int
+	var existingStatuses map[domain.FindingFingerprint]domain.FindingStatus
+	var statusCounts posting.StatusCounts
+	var botUsername string
+
+	if req.BotUsername != "" {
+		botUsername = p.resolveBotUsername(ctx, req.BotUsername)
+		comments, err := p.client.ListReviewComments(ctx, req.Owner, req.Repo, req.PullNumber)
+		if err != nil {
+			log.Printf("warning: failed to fetch review comments: %v", err)
+		} else {
+			findings, duplicatesSkipped = posting.FilterDuplicates(req.Findings, findingOf, botCommentBodies(comments, botUsername))
+			existingStatuses, statusCounts = posting.AnalyzeStatuses(commentThreads(comments, botUsername))
+		}
+	}
+
+	// Blocking uses the unfiltered findings: a duplicate that is still open
+	// must keep blocking even though it is not re-posted.
+	var event github.ReviewEvent
+	if req.OverrideEvent != "" {
+		event = req.OverrideEvent
+	} else {
+		event = posting.EffectiveEvent(req.Findings, findingOf, existingStatuses, req.ReviewActions)
+	}
+
+	var comments []gitea.ReviewComment
+	for _, pf := range findings {
+		if !pf.InDiff() {
+			continue
+		}
+		comments = append(comments, gitea.ReviewComment{
+			Path:        pf.Path,
+			Body:        github.FormatFindingCommentWithFingerprint(pf.Finding, domain.FingerprintFromFinding(pf.Finding)),
+			NewPosition: *pf.Line,
+		})
+	}
+
+	resp, err := p.client.CreateReview(ctx, req.Owner, req.Repo, req.PullNumber, gitea.CreateReviewRequest{
+		CommitID: req.CommitSHA,
+		Event:    reviewState(event),
+		Body:     req.Review.Summary + posting.FormatStatusSection(statusCounts),
+		Comments: comments,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, fmt.Errorf("CreateReview returned nil response")
+	}
+
+	var dismissedCount int
+	if botUsername != "" {
+		dismissedCount = p.dismissStaleReviews(ctx, req.Owner, req.Repo, req.PullNumber, botUsername, resp.ID)
+	}
+
+	return &PostReviewResult{
+		ReviewID:          resp.ID,
+		CommentsPosted:    len(comments),
+		CommentsSkipped:   len(findings) - len(comments),
+		DuplicatesSkipped: duplicatesSkipped,
+		Event:             event,
+		HTMLURL:           resp.HTMLURL,
+		DismissedCount:    dismissedCount,
+		AcknowledgedCount: statusCounts.Acknowledged,
+		DisputedCount:     statusCounts.Disputed,
+		OpenCount:         statusCounts.Open,
+	}, nil
+}
+
+// reviewState converts a GitHub-style review event to Gitea's review state.
+func reviewState(event github.ReviewEvent) gitea.ReviewState {
+	switch event {
+	case github.EventApprove:
+		return gitea.StateApproved
+	case github.EventRequestChanges:
+		return gitea.StateRequestChanges
+	default:
+		return gitea.StateComment
+	}
+}
+
+// resolveBotUsername returns the login of the token's user, falling back
+// to the configured name if the lookup fails.
+func (p *ReviewPoster) resolveBotUsername(ctx context.Context, configured string) string {
+	user, err := p.client.CurrentUser(ctx)
+	if err != nil || user == nil || user.Login == "" {
+		if err != nil {
+			log.Printf("warning: failed to look up token user, using %q: %v", configured, err)
+		}
+		return configured
+	}
+	return user.Login
+}
+
+// dismissStaleReviews dismisses all previous submitted reviews from the bot.
+// The excludeReviewID parameter specifies the newly created review. Returns the
+// number of reviews dismissed; errors are logged but do not block posting.
+func (p *ReviewPoster) dismissStaleReviews(ctx context.Context, owner, repo string, index int, botUsername string, excludeReviewID int64) int {
+	reviews, err := p.client.ListReviews(ctx, owner, repo, index)
+	if err != nil {
+		log.Printf("warning: failed to list reviews for dismissal: %v", err)
+		return 0
+	}
+
+	var dismissedCount int
+	for _, review := range reviews {
+		if review.ID == excludeReviewID || !shouldDismissReview(review, botUsername) {
+			continue
+		}
+		if err := p.client.DismissReview(ctx, owner, repo, index, review.ID, "Superseded by new review"); err != nil {
+			log.Printf("warning: failed to dismiss review %d: %v", review.ID, err)
+			continue
+		}
+		dismissedCount++
+	}
+
+	return dismissedCount
+}
+
+// shouldDismissReview returns true for submitted, undismissed reviews by the bot.
+func shouldDismissReview(review gitea.PullReview, botUsername string) bool {
+	if !strings.EqualFold(review.User.Login, botUsername) || review.Dismissed {
+		return false
+	}
+	return review.State != gitea.StatePending && review.State != gitea.StateRequestReview
+}
+
+// findingOf returns the domain finding of a positioned finding.
+func findingOf(pf gitea.PositionedFinding) domain.Finding {
+	return pf.Finding
+}
+
+// botCommentBodies returns the bodies of review comments authored by the bot.
+func botCommentBodies(comments []gitea.PullReviewComment, botUsername string) []string {
+	var bodies []string
+	for _, comment := range comments {
+		if strings.EqualFold(comment.User.Login, botUsername) {
+			bodies = append(bodies, comment.Body)
+		}
+	}
+	return bodies
+}
+
+// commentThreads pairs each bot comment with the human replies to it. Gitea
+// has no reply-to field, so a conversation is the set of comments on the same
+// path and line; replies are the non-bot comments there.
+func commentThreads(comments []gitea.PullReviewComment, botUsername string) []posting.Thread {
+	type location struct {
+		path string
+		line int
+	}
+
+	replies := make(map[location][]string)
+	for _, comment := range comments {
+		if strings.EqualFold(comment.User.Login, botUsername) {
+			continue
+		}
+		loc := location{comment.Path, comment.Position}
+		replies[loc] = append(replies[loc], comment.Body)
+	}
+
+	var threads []posting.Thread
+	for _, comment := range comments {
+		if !strings.EqualFold(comment.User.Login, botUsername) {
+			continue
+		}
+		threads = append(threads, posting.Thread{
+			Body:    comment.Body,
+			Replies: replies[location{comment.Path, comment.Position}],
+		})
+	}
+	return threads
+}
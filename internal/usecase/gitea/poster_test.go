@@ -0,0 +1,180 @@
+package gitea_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/gitea"
+	"github.com/bkyoung/code-reviewer/internal/adapter/github"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	usecasegitea "github.com/bkyoung/code-reviewer/internal/usecase/gitea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockReviewClient is a mock implementation of the ReviewClient interface.
+type MockReviewClient struct {
+	CurrentUserFunc        func(ctx context.Context) (*gitea.User, error)
+	CreateReviewFunc       func(ctx context.Context, input gitea.CreateReviewRequest) (*gitea.PullReview, error)
+	ListReviewsFunc        func(ctx context.Context) ([]gitea.PullReview, error)
+	ListReviewCommentsFunc func(ctx context.Context) ([]gitea.PullReviewComment, error)
+	LastInput              *gitea.CreateReviewRequest
+	DismissedIDs           []int64
+}
+
+func (m *MockReviewClient) CurrentUser(ctx context.Context) (*gitea.User, error) {
+	if m.CurrentUserFunc != nil {
+		return m.CurrentUserFunc(ctx)
+	}
+	return &gitea.User{Login: "cr-bot"}, nil
+}
+
+func (m *MockReviewClient) CreateReview(ctx context.Context, owner, repo string, index int, input gitea.CreateReviewRequest) (*gitea.PullReview, error) {
+	m.LastInput = &input
+	if m.CreateReviewFunc != nil {
+		return m.CreateReviewFunc(ctx, input)
+	}
+	return &gitea.PullReview{ID: 100, HTMLURL: "https://gitea.example.com/o/r/pulls/1"}, nil
+}
+
+func (m *MockReviewClient) ListReviews(ctx context.Context, owner, repo string, index int) ([]gitea.PullReview, error) {
+	if m.ListReviewsFunc != nil {
+		return m.ListReviewsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockReviewClient) ListReviewComments(ctx context.Context, owner, repo string, index int) ([]gitea.PullReviewComment, error) {
+	if m.ListReviewCommentsFunc != nil {
+		return m.ListReviewCommentsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockReviewClient) DismissReview(ctx context.Context, owner, repo string, index int, reviewID int64, message string) error {
+	m.DismissedIDs = append(m.DismissedIDs, reviewID)
+	return nil
+}
+
+func makeFinding(file string, line int, severity, description string) domain.Finding {
+	return domain.NewFinding(domain.FindingInput{
+		File:        file,
+		LineStart:   line,
+		LineEnd:     line,
+		Severity:    severity,
+		Category:    "bug",
+		Description: description,
+		Evidence:    true,
+	})
+}
+
+func inDiff(f domain.Finding) gitea.PositionedFinding {
+	line := f.LineStart
+	return gitea.PositionedFinding{Finding: f, Path: f.File, Line: &line}
+}
+
+func TestReviewPoster_PostReview_Success(t *testing.T) {
+	client := &MockReviewClient{}
+	poster := usecasegitea.NewReviewPoster(client)
+
+	result, err := poster.PostReview(context.Background(), usecasegitea.PostReviewRequest{
+		Owner:      "o",
+		Repo:       "r",
+		PullNumber: 1,
+		CommitSHA:  "abc123",
+		Review:     domain.Review{Summary: "Summary"},
+		Findings: []gitea.PositionedFinding{
+			inDiff(makeFinding("a.go", 3, "critical", "sql injection")),
+			{Finding: makeFinding("b.go", 9, "low", "elsewhere"), Path: "b.go"},
+		},
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, client.LastInput)
+	assert.Equal(t, gitea.StateRequestChanges, client.LastInput.Event)
+	assert.Equal(t, "abc123", client.LastInput.CommitID)
+	require.Len(t, client.LastInput.Comments, 1)
+	assert.Equal(t, 3, client.LastInput.Comments[0].NewPosition)
+	assert.Contains(t, client.LastInput.Comments[0].Body, "CR_FINGERPRINT:")
+
+	assert.Equal(t, int64(100), result.ReviewID)
+	assert.Equal(t, 1, result.CommentsPosted)
+	assert.Equal(t, 1, result.CommentsSkipped)
+	assert.Equal(t, github.EventRequestChanges, result.Event)
+	assert.Empty(t, client.DismissedIDs, "no bot username, nothing dismissed")
+}
+
+func TestReviewPoster_PostReview_ApproveMapsToApproved(t *testing.T) {
+	client := &MockReviewClient{}
+	poster := usecasegitea.NewReviewPoster(client)
+
+	result, err := poster.PostReview(context.Background(), usecasegitea.PostReviewRequest{
+		Owner: "o", Repo: "r", PullNumber: 1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, github.EventApprove, result.Event)
+	assert.Equal(t, gitea.StateApproved, client.LastInput.Event)
+}
+
+func TestReviewPoster_PostReview_CreateErrorReturned(t *testing.T) {
+	client := &MockReviewClient{
+		CreateReviewFunc: func(ctx context.Context, input gitea.CreateReviewRequest) (*gitea.PullReview, error) {
+			return nil, errors.New("boom")
+		},
+		ListReviewsFunc: func(ctx context.Context) ([]gitea.PullReview, error) {
+			return []gitea.PullReview{{ID: 1, User: gitea.User{Login: "cr-bot"}, State: gitea.StateComment}}, nil
+		},
+	}
+	poster := usecasegitea.NewReviewPoster(client)
+
+	_, err := poster.PostReview(context.Background(), usecasegitea.PostReviewRequest{
+		Owner: "o", Repo: "r", PullNumber: 1, BotUsername: "cr-bot",
+	})
+	require.Error(t, err)
+	assert.Empty(t, client.DismissedIDs, "previous reviews must survive a failed post")
+}
+
+func TestReviewPoster_PostReview_DedupStatusesAndDismissal(t *testing.T) {
+	dup := makeFinding("a.go", 3, "high", "already reported")
+	ack := makeFinding("a.go", 20, "high", "accepted")
+
+	client := &MockReviewClient{
+		ListReviewCommentsFunc: func(ctx context.Context) ([]gitea.PullReviewComment, error) {
+			return []gitea.PullReviewComment{
+				{Path: "a.go", Position: 3, User: gitea.User{Login: "cr-bot"},
+					Body: github.FormatFindingCommentWithFingerprint(dup, domain.FingerprintFromFinding(dup))},
+				{Path: "a.go", Position: 20, User: gitea.User{Login: "cr-bot"},
+					Body: github.FormatFindingCommentWithFingerprint(ack, domain.FingerprintFromFinding(ack))},
+				{Path: "a.go", Position: 20, User: gitea.User{Login: "dev"}, Body: "acknowledged, won't fix"},
+			}, nil
+		},
+		ListReviewsFunc: func(ctx context.Context) ([]gitea.PullReview, error) {
+			return []gitea.PullReview{
+				{ID: 1, User: gitea.User{Login: "cr-bot"}, State: gitea.StateRequestChanges},
+				{ID: 2, User: gitea.User{Login: "cr-bot"}, State: gitea.StateComment, Dismissed: true},
+				{ID: 3, User: gitea.User{Login: "dev"}, State: gitea.StateApproved},
+				{ID: 4, User: gitea.User{Login: "cr-bot"}, State: gitea.StatePending},
+				{ID: 100, User: gitea.User{Login: "cr-bot"}, State: gitea.StateRequestChanges},
+			}, nil
+		},
+	}
+	poster := usecasegitea.NewReviewPoster(client)
+
+	result, err := poster.PostReview(context.Background(), usecasegitea.PostReviewRequest{
+		Owner:       "o",
+		Repo:        "r",
+		PullNumber:  1,
+		Findings:    []gitea.PositionedFinding{inDiff(dup), inDiff(ack)},
+		BotUsername: "fallback",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.DuplicatesSkipped)
+	assert.Empty(t, client.LastInput.Comments)
+	assert.Equal(t, 1, result.AcknowledgedCount)
+	assert.Equal(t, 1, result.OpenCount)
+	assert.Equal(t, github.EventRequestChanges, result.Event, "open duplicate still blocks")
+	assert.Equal(t, []int64{1}, client.DismissedIDs)
+	assert.Equal(t, 1, result.DismissedCount)
+}
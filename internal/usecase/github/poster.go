@@ -10,6 +10,7 @@ import (
 	"github.com/bkyoung/code-reviewer/internal/adapter/github"
 	"github.com/bkyoung/code-reviewer/internal/domain"
 	"github.com/bkyoung/code-reviewer/internal/usecase/dedup"
+	"github.com/bkyoung/code-reviewer/internal/usecase/posting"
 )
 
 // ReviewClient defines the interface for interacting with GitHub reviews.
@@ -19,8 +20,16 @@ type ReviewClient interface {
 	ListReviews(ctx context.Context, owner, repo string, pullNumber int) ([]github.ReviewSummary, error)
 	DismissReview(ctx context.Context, owner, repo string, pullNumber int, reviewID int64, message string) (*github.DismissReviewResponse, error)
 	ListPullRequestComments(ctx context.Context, owner, repo string, pullNumber int) ([]github.PullRequestComment, error)
+	ListReviewThreads(ctx context.Context, owner, repo string, pullNumber int) ([]github.ReviewThread, error)
+	ResolveReviewThread(ctx context.Context, threadID string) error
+	CreateReplyComment(ctx context.Context, owner, repo string, pullNumber int, commentID int64, body string) (*github.PullRequestComment, error)
 }
 
+// noLongerDetectedPrefix starts the reply posted on a bot thread whose finding
+// is no longer reported and whose thread could not be resolved. It also marks
+// threads that were already answered so later runs don't reply again.
+const noLongerDetectedPrefix = "✅ No longer detected in"
+
 // ReviewPoster orchestrates posting code review findings to GitHub as PR reviews.
 // It determines the appropriate review event based on finding severities,
 // filters out findings that are not in the diff, and delegates the actual
@@ -133,6 +142,14 @@ type PostReviewResult struct {
 	// DismissedCount is the number of previous bot reviews that were dismissed.
 	DismissedCount int
 
+	// ResolvedThreadCount is the number of bot comment threads resolved because
+	// their finding is no longer reported.
+	ResolvedThreadCount int
+
+	// NoLongerDetectedCount is the number of stale bot threads that could not
+	// be resolved and received a "no longer detected" reply instead.
+	NoLongerDetectedCount int
+
 	// Status counts from reply analysis (Issue #108)
 	// AcknowledgedCount is the number of existing findings with acknowledgment replies.
 	AcknowledgedCount int
@@ -153,6 +170,8 @@ type PostReviewResult struct {
 //   - Reply statuses are analyzed to determine acknowledged/disputed findings (Issue #108)
 //   - Acknowledged/disputed findings don't count toward blocking
 //   - Previous reviews from that bot are dismissed AFTER posting succeeds
+//   - Bot threads whose finding is no longer reported are resolved (or get a
+//     "no longer detected" reply if resolving fails) AFTER posting succeeds
 //
 // This ensures the PR always has at least one review signal - if posting fails,
 // previous reviews are preserved. Dismiss failures are logged but do not affect
@@ -175,28 +194,29 @@ func (p *ReviewPoster) PostReview(ctx context.Context, req PostReviewRequest) (*
 	var duplicatesSkipped int
 	var semanticDuplicatesSkipped int
 	var existingStatuses map[domain.FindingFingerprint]domain.FindingStatus
-	var statusCounts StatusCounts
+	var statusCounts posting.StatusCounts
+	var existingComments []github.PullRequestComment
+	var semanticMatches map[domain.FindingFingerprint]bool
 
 	// Analyze existing comments if BotUsername is set
 	if req.BotUsername != "" {
-		var comments []github.PullRequestComment
-		var err error
-
-		// Fetch comments once for both deduplication and status analysis
-		comments, err = p.client.ListPullRequestComments(ctx, req.Owner, req.Repo, req.PullNumber)
+		// Fetch comments once for deduplication, status analysis and stale thread resolution
+		comments, err := p.client.ListPullRequestComments(ctx, req.Owner, req.Repo, req.PullNumber)
 		if err != nil {
 			log.Printf("warning: failed to fetch comments: %v", err)
 		} else {
+			existingComments = comments
+
 			// Stage 1: Fingerprint deduplication (exact match)
-			findings, duplicatesSkipped = filterDuplicateFindings(req.Findings, comments, req.BotUsername)
+			findings, duplicatesSkipped = posting.FilterDuplicates(req.Findings, findingOf, botCommentBodies(comments, req.BotUsername))
 
 			// Stage 2: Semantic deduplication (LLM-based) - Issue #111
 			if p.semanticComparer != nil && len(findings) > 0 {
-				findings, semanticDuplicatesSkipped = p.filterSemanticDuplicates(ctx, findings, comments, req.BotUsername)
+				findings, semanticDuplicatesSkipped, semanticMatches = p.filterSemanticDuplicates(ctx, findings, comments, req.BotUsername)
 			}
 
 			// Analyze reply statuses (Issue #108)
-			existingStatuses, statusCounts = analyzeFindingStatuses(comments, req.BotUsername)
+			existingStatuses, statusCounts = posting.AnalyzeStatuses(findingThreads(comments, req.BotUsername))
 		}
 	}
 
@@ -214,11 +234,11 @@ func (p *ReviewPoster) PostReview(ctx context.Context, req PostReviewRequest) (*
 	if req.OverrideEvent != "" {
 		event = req.OverrideEvent
 	} else {
-		event = determineEffectiveEvent(req.Findings, existingStatuses, req.ReviewActions)
+		event = posting.EffectiveEvent(req.Findings, findingOf, existingStatuses, req.ReviewActions)
 	}
 
 	// Build the summary with status section appended (if applicable)
-	summary := req.Review.Summary + formatStatusSection(statusCounts)
+	summary := req.Review.Summary + posting.FormatStatusSection(statusCounts)
 
 	// Call the client to create the new review first
 	input := github.CreateReviewInput{
@@ -247,6 +267,12 @@ func (p *ReviewPoster) PostReview(ctx context.Context, req PostReviewRequest) (*
 		dismissedCount = p.dismissStaleReviews(ctx, req.Owner, req.Repo, req.PullNumber, req.BotUsername, resp.ID)
 	}
 
+	// Close out threads for findings that disappeared, also only after a successful post
+	var resolvedThreads, noLongerDetected int
+	if len(existingComments) > 0 {
+		resolvedThreads, noLongerDetected = p.resolveStaleThreads(ctx, req, existingComments, semanticMatches)
+	}
+
 	return &PostReviewResult{
 		ReviewID:                  resp.ID,
 		CommentsPosted:            inDiffCount,
@@ -256,6 +282,8 @@ func (p *ReviewPoster) PostReview(ctx context.Context, req PostReviewRequest) (*
 		Event:                     event,
 		HTMLURL:                   resp.HTMLURL,
 		DismissedCount:            dismissedCount,
+		ResolvedThreadCount:       resolvedThreads,
+		NoLongerDetectedCount:     noLongerDetected,
 		AcknowledgedCount:         statusCounts.Acknowledged,
 		DisputedCount:             statusCounts.Disputed,
 		OpenCount:                 statusCounts.Open,
@@ -292,6 +320,86 @@ func (p *ReviewPoster) dismissStaleReviews(ctx context.Context, owner, repo stri
 	return dismissedCount
 }
 
+// resolveStaleThreads closes the bot's comment threads whose fingerprint is not
+// among the current findings. Fingerprints in stillReported belong to existing
+// comments that a current finding semantically duplicates; those threads stay
+// open even though the new finding has a different fingerprint. Each thread is resolved via GraphQL; if threads
+// cannot be listed or resolving fails, a "no longer detected" reply is posted
+// instead (once per thread). Returns the resolved and replied counts. Errors are
+// logged but do not block the review posting workflow.
+func (p *ReviewPoster) resolveStaleThreads(ctx context.Context, req PostReviewRequest, comments []github.PullRequestComment, stillReported map[domain.FindingFingerprint]bool) (resolved, replied int) {
+	current := make(map[domain.FindingFingerprint]bool, len(req.Findings)+len(stillReported))
+	for _, pf := range req.Findings {
+		current[domain.FingerprintFromFinding(pf.Finding)] = true
+	}
+	for fp := range stillReported {
+		current[fp] = true
+	}
+
+	answered := make(map[int64]bool)
+	var stale []github.PullRequestComment
+	for _, comment := range comments {
+		if !strings.EqualFold(comment.User.Login, req.BotUsername) {
+			continue
+		}
+		if comment.InReplyToID != 0 {
+			if strings.HasPrefix(comment.Body, noLongerDetectedPrefix) {
+				answered[comment.InReplyToID] = true
+			}
+			continue
+		}
+		if fp, ok := github.ExtractFingerprintFromComment(comment.Body); ok && !current[fp] {
+			stale = append(stale, comment)
+		}
+	}
+	if len(stale) == 0 {
+		return 0, 0
+	}
+
+	threadsByComment := make(map[int64]github.ReviewThread)
+	threads, err := p.client.ListReviewThreads(ctx, req.Owner, req.Repo, req.PullNumber)
+	if err != nil {
+		log.Printf("warning: failed to list review threads, falling back to replies: %v", err)
+	}
+	for _, thread := range threads {
+		threadsByComment[thread.RootCommentID] = thread
+	}
+
+	for _, comment := range stale {
+		if thread, ok := threadsByComment[comment.ID]; ok {
+			if thread.IsResolved {
+				continue
+			}
+			err := p.client.ResolveReviewThread(ctx, thread.ID)
+			if err == nil {
+				resolved++
+				continue
+			}
+			log.Printf("warning: failed to resolve thread for comment %d: %v", comment.ID, err)
+		}
+
+		if answered[comment.ID] {
+			continue
+		}
+		body := fmt.Sprintf("%s `%s`.", noLongerDetectedPrefix, shortSHA(req.CommitSHA))
+		if _, err := p.client.CreateReplyComment(ctx, req.Owner, req.Repo, req.PullNumber, comment.ID, body); err != nil {
+			log.Printf("warning: failed to reply to comment %d: %v", comment.ID, err)
+			continue
+		}
+		replied++
+	}
+
+	return resolved, replied
+}
+
+// shortSHA abbreviates a commit SHA to the 7 characters GitHub displays.
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
 // shouldDismissReview returns true if the review should be dismissed.
 // A review should be dismissed if it's from the bot and not already dismissed.
 func shouldDismissReview(review github.ReviewSummary, botUsername string) bool {
@@ -314,175 +422,52 @@ func shouldDismissReview(review github.ReviewSummary, botUsername string) bool {
 	return true
 }
 
-// StatusCounts tracks the count of findings by status.
-type StatusCounts struct {
-	Open         int
-	Acknowledged int
-	Disputed     int
+// findingOf returns the domain finding of a positioned finding.
+func findingOf(pf github.PositionedFinding) domain.Finding {
+	return pf.Finding
 }
 
-// filterDuplicateFindings filters out findings that have already been posted.
-// Returns the filtered findings and the count of duplicates skipped.
-func filterDuplicateFindings(
-	findings []github.PositionedFinding,
-	comments []github.PullRequestComment,
-	botUsername string,
-) ([]github.PositionedFinding, int) {
-	existingFingerprints := extractBotFingerprints(comments, botUsername)
-	if len(existingFingerprints) == 0 {
-		return findings, 0
-	}
-
-	var filtered []github.PositionedFinding
-	var duplicatesSkipped int
-	for _, pf := range findings {
-		fp := domain.FingerprintFromFinding(pf.Finding)
-		if existingFingerprints[fp] {
-			duplicatesSkipped++
-			continue
-		}
-		filtered = append(filtered, pf)
-	}
-
-	return filtered, duplicatesSkipped
-}
-
-// extractBotFingerprints extracts fingerprints from comments authored by the bot.
-// Returns a map of fingerprints for O(1) lookup.
-func extractBotFingerprints(comments []github.PullRequestComment, botUsername string) map[domain.FindingFingerprint]bool {
-	fingerprints := make(map[domain.FindingFingerprint]bool)
-
+// botCommentBodies returns the bodies of comments authored by the bot
+// (case-insensitive), for fingerprint deduplication.
+func botCommentBodies(comments []github.PullRequestComment, botUsername string) []string {
+	var bodies []string
 	for _, comment := range comments {
-		// Skip comments not from the bot (case-insensitive)
-		if !strings.EqualFold(comment.User.Login, botUsername) {
-			continue
-		}
-
-		// Try to extract fingerprint from comment body
-		if fp, ok := github.ExtractFingerprintFromComment(comment.Body); ok {
-			fingerprints[fp] = true
+		if strings.EqualFold(comment.User.Login, botUsername) {
+			bodies = append(bodies, comment.Body)
 		}
 	}
-
-	return fingerprints
+	return bodies
 }
 
-// analyzeFindingStatuses analyzes bot comments and their replies to determine
-// the status of each existing finding (Issue #108).
-// Returns a map of fingerprint → status and counts for each status.
-func analyzeFindingStatuses(
-	comments []github.PullRequestComment,
-	botUsername string,
-) (map[domain.FindingFingerprint]domain.FindingStatus, StatusCounts) {
-	statuses := make(map[domain.FindingFingerprint]domain.FindingStatus)
-	var counts StatusCounts
-
-	// Group comments by parent to get reply chains
+// findingThreads pairs each of the bot's top-level comments with the human
+// replies to it, for reply-status analysis (Issue #108).
+func findingThreads(comments []github.PullRequestComment, botUsername string) []posting.Thread {
 	grouped := github.GroupCommentsByParent(comments, botUsername)
-
+	threads := make([]posting.Thread, 0, len(grouped))
 	for _, group := range grouped {
-		// Extract fingerprint from parent (bot) comment
-		fp, ok := github.ExtractFingerprintFromComment(group.Parent.Body)
-		if !ok {
-			continue // Skip comments without fingerprints (legacy)
-		}
-
-		// Collect reply texts
-		var replyTexts []string
+		thread := posting.Thread{Body: group.Parent.Body}
 		for _, reply := range group.Replies {
-			replyTexts = append(replyTexts, reply.Body)
-		}
-
-		// Detect status from replies
-		status := domain.DetectStatusFromReplies(replyTexts)
-		statuses[fp] = status
-
-		// Update counts
-		switch status {
-		case domain.StatusAcknowledged:
-			counts.Acknowledged++
-		case domain.StatusDisputed:
-			counts.Disputed++
-		case domain.StatusOpen:
-			counts.Open++
+			thread.Replies = append(thread.Replies, reply.Body)
 		}
+		threads = append(threads, thread)
 	}
-
-	return statuses, counts
-}
-
-// formatStatusSection creates a markdown section showing finding status breakdown.
-// Returns an empty string if all counts are zero.
-func formatStatusSection(counts StatusCounts) string {
-	// Only include section if there are any existing findings tracked
-	total := counts.Open + counts.Acknowledged + counts.Disputed
-	if total == 0 {
-		return ""
-	}
-
-	var sb strings.Builder
-	sb.WriteString("\n\n---\n\n")
-	sb.WriteString("### Existing Finding Status\n\n")
-
-	// Always show all statuses for clarity
-	sb.WriteString("| Status | Count | Effect |\n")
-	sb.WriteString("|--------|-------|--------|\n")
-	sb.WriteString("| 🔓 Open | ")
-	sb.WriteString(fmt.Sprintf("%d", counts.Open))
-	sb.WriteString(" | Counts toward blocking |\n")
-	sb.WriteString("| ✅ Acknowledged | ")
-	sb.WriteString(fmt.Sprintf("%d", counts.Acknowledged))
-	sb.WriteString(" | Won't block (author accepted) |\n")
-	sb.WriteString("| ❌ Disputed | ")
-	sb.WriteString(fmt.Sprintf("%d", counts.Disputed))
-	sb.WriteString(" | Won't block (author disputes) |\n")
-
-	return sb.String()
-}
-
-// determineEffectiveEvent determines the review event considering reply statuses.
-// Findings that have been acknowledged or disputed don't count toward blocking.
-func determineEffectiveEvent(
-	findings []github.PositionedFinding,
-	existingStatuses map[domain.FindingFingerprint]domain.FindingStatus,
-	actions github.ReviewActions,
-) github.ReviewEvent {
-	// If no status tracking, fall back to standard behavior
-	if existingStatuses == nil {
-		return github.DetermineReviewEventWithActions(findings, actions)
-	}
-
-	// Filter findings to only include those that are "effective" (not acknowledged/disputed)
-	var effectiveFindings []github.PositionedFinding
-	for _, pf := range findings {
-		fp := domain.FingerprintFromFinding(pf.Finding)
-		status, exists := existingStatuses[fp]
-
-		// Include finding if:
-		// - It's new (not in existingStatuses)
-		// - It's in existingStatuses but status is Open
-		if !exists || status == domain.StatusOpen {
-			effectiveFindings = append(effectiveFindings, pf)
-		}
-		// Acknowledged and Disputed findings are excluded from blocking calculation
-	}
-
-	return github.DetermineReviewEventWithActions(effectiveFindings, actions)
+	return threads
 }
 
 // filterSemanticDuplicates uses LLM-based comparison to identify findings that are
 // semantic duplicates of existing comments, even if they have different fingerprints.
-// Returns the filtered findings and count of semantic duplicates found.
+// Returns the filtered findings, the count of semantic duplicates found and the
+// fingerprints of the existing comments those duplicates matched.
 func (p *ReviewPoster) filterSemanticDuplicates(
 	ctx context.Context,
 	findings []github.PositionedFinding,
 	comments []github.PullRequestComment,
 	botUsername string,
-) ([]github.PositionedFinding, int) {
+) ([]github.PositionedFinding, int, map[domain.FindingFingerprint]bool) {
 	// Convert bot comments to ExistingFinding for candidate detection
 	existingFindings := extractExistingFindings(comments, botUsername)
 	if len(existingFindings) == 0 {
-		return findings, 0
+		return findings, 0, nil
 	}
 
 	// Convert positioned findings to domain.Finding for candidate detection
@@ -501,7 +486,7 @@ func (p *ReviewPoster) filterSemanticDuplicates(
 
 	// If no candidates, nothing to compare
 	if len(candidates) == 0 {
-		return findings, 0
+		return findings, 0, nil
 	}
 
 	// Build set of original indices that were included in candidates (not overflow).
@@ -527,12 +512,13 @@ func (p *ReviewPoster) filterSemanticDuplicates(
 	if err != nil {
 		// Fail open: on error, treat all findings as unique
 		log.Printf("warning: semantic dedup failed: %v (treating all as unique)", err)
-		return findings, 0
+		return findings, 0, nil
 	}
 
 	// Mark original finding indices that are semantic duplicates.
 	// Only consider indices that were actually sent as candidates (not overflow).
 	duplicateOriginalIndices := make(map[int]bool)
+	matchedExisting := make(map[domain.FindingFingerprint]bool)
 	for _, dup := range result.Duplicates {
 		fp := domain.FingerprintFromFinding(dup.NewFinding)
 		log.Printf("semantic dedup: %s is duplicate of existing (reason: %s)", fp, dup.Reason)
@@ -549,6 +535,7 @@ func (p *ReviewPoster) filterSemanticDuplicates(
 				dup.NewFinding.Severity == pf.Finding.Severity &&
 				dup.NewFinding.Description == pf.Finding.Description {
 				duplicateOriginalIndices[origIdx] = true
+				matchedExisting[dup.ExistingFingerprint] = true
 			}
 		}
 	}
@@ -569,7 +556,7 @@ func (p *ReviewPoster) filterSemanticDuplicates(
 		filtered = append(filtered, pf)
 	}
 
-	return filtered, duplicatesFound
+	return filtered, duplicatesFound, matchedExisting
 }
 
 // extractExistingFindings converts bot comments to ExistingFinding for semantic comparison.
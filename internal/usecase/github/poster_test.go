@@ -9,6 +9,7 @@ import (
 	"github.com/bkyoung/code-reviewer/internal/adapter/github"
 	"github.com/bkyoung/code-reviewer/internal/diff"
 	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/dedup"
 	usecasegithub "github.com/bkyoung/code-reviewer/internal/usecase/github"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -22,8 +23,12 @@ type MockReviewClient struct {
 	ListReviewsFunc             func(ctx context.Context, owner, repo string, pullNumber int) ([]github.ReviewSummary, error)
 	DismissReviewFunc           func(ctx context.Context, owner, repo string, pullNumber int, reviewID int64, message string) (*github.DismissReviewResponse, error)
 	ListPullRequestCommentsFunc func(ctx context.Context, owner, repo string, pullNumber int) ([]github.PullRequestComment, error)
+	ListReviewThreadsFunc       func(ctx context.Context, owner, repo string, pullNumber int) ([]github.ReviewThread, error)
+	ResolveReviewThreadFunc     func(ctx context.Context, threadID string) error
 	LastInput                   *github.CreateReviewInput
 	DismissedIDs                []int64
+	ResolvedThreadIDs           []string
+	Replies                     map[int64]string
 }
 
 func (m *MockReviewClient) CreateReview(ctx context.Context, input github.CreateReviewInput) (*github.CreateReviewResponse, error) {
@@ -60,6 +65,35 @@ func (m *MockReviewClient) ListPullRequestComments(ctx context.Context, owner, r
 	return []github.PullRequestComment{}, nil
 }
 
+func (m *MockReviewClient) ListReviewThreads(ctx context.Context, owner, repo string, pullNumber int) ([]github.ReviewThread, error) {
+	if m.ListReviewThreadsFunc != nil {
+		return m.ListReviewThreadsFunc(ctx, owner, repo, pullNumber)
+	}
+	return []github.ReviewThread{}, nil
+}
+
+func (m *MockReviewClient) ResolveReviewThread(ctx context.Context, threadID string) error {
+	if m.ResolveReviewThreadFunc != nil {
+		if err := m.ResolveReviewThreadFunc(ctx, threadID); err != nil {
+			return err
+		}
+	}
+	m.mu.Lock()
+	m.ResolvedThreadIDs = append(m.ResolvedThreadIDs, threadID)
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *MockReviewClient) CreateReplyComment(ctx context.Context, owner, repo string, pullNumber int, commentID int64, body string) (*github.PullRequestComment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Replies == nil {
+		m.Replies = make(map[int64]string)
+	}
+	m.Replies[commentID] = body
+	return &github.PullRequestComment{ID: commentID + 1000, InReplyToID: commentID, Body: body}, nil
+}
+
 // GetDismissedIDs returns a copy of dismissed IDs in a thread-safe manner.
 func (m *MockReviewClient) GetDismissedIDs() []int64 {
 	m.mu.Lock()
@@ -1468,3 +1502,167 @@ func TestReviewPoster_PostReview_SummaryOmitsStatusSectionWhenEmpty(t *testing.T
 
 	require.NoError(t, err)
 }
+
+// Stale thread resolution tests
+
+func botFingerprintComment(id int64, f domain.Finding) github.PullRequestComment {
+	fp := domain.FingerprintFromFinding(f)
+	return github.PullRequestComment{
+		ID:   id,
+		Body: "**Severity:** high\n\n<!-- CR_FINGERPRINT:" + string(fp) + " -->",
+		User: github.User{Login: "github-actions[bot]"},
+	}
+}
+
+func TestReviewPoster_PostReview_ResolvesThreadsForFixedFindings(t *testing.T) {
+	fixed := makeFinding("file1.go", 10, "high", "Fixed issue")
+	alreadyResolved := makeFinding("file1.go", 30, "high", "Resolved by a human")
+	stillPresent := makeFinding("file2.go", 20, "medium", "Still here")
+
+	client := &MockReviewClient{
+		ListPullRequestCommentsFunc: func(ctx context.Context, owner, repo string, pullNumber int) ([]github.PullRequestComment, error) {
+			return []github.PullRequestComment{
+				botFingerprintComment(1, fixed),
+				botFingerprintComment(2, stillPresent),
+				botFingerprintComment(3, alreadyResolved),
+			}, nil
+		},
+		ListReviewThreadsFunc: func(ctx context.Context, owner, repo string, pullNumber int) ([]github.ReviewThread, error) {
+			return []github.ReviewThread{
+				{ID: "T1", RootCommentID: 1},
+				{ID: "T2", RootCommentID: 2},
+				{ID: "T3", RootCommentID: 3, IsResolved: true},
+			}, nil
+		},
+	}
+	poster := usecasegithub.NewReviewPoster(client)
+
+	result, err := poster.PostReview(context.Background(), usecasegithub.PostReviewRequest{
+		Owner:       "owner",
+		Repo:        "repo",
+		PullNumber:  1,
+		CommitSHA:   "0123456789abcdef",
+		Findings:    []github.PositionedFinding{{Finding: stillPresent, DiffPosition: diff.IntPtr(15)}},
+		BotUsername: "github-actions[bot]",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"T1"}, client.ResolvedThreadIDs, "only the open thread of the fixed finding is resolved")
+	assert.Empty(t, client.Replies)
+	assert.Equal(t, 1, result.ResolvedThreadCount)
+	assert.Equal(t, 0, result.NoLongerDetectedCount)
+}
+
+func TestReviewPoster_PostReview_RepliesWhenThreadCannotBeResolved(t *testing.T) {
+	fixed := makeFinding("file1.go", 10, "high", "Fixed issue")
+	answeredBefore := makeFinding("file1.go", 40, "high", "Already answered")
+
+	client := &MockReviewClient{
+		ListPullRequestCommentsFunc: func(ctx context.Context, owner, repo string, pullNumber int) ([]github.PullRequestComment, error) {
+			return []github.PullRequestComment{
+				botFingerprintComment(1, fixed),
+				botFingerprintComment(2, answeredBefore),
+				{ID: 3, InReplyToID: 2, Body: "✅ No longer detected in `aaaaaaa`.", User: github.User{Login: "github-actions[bot]"}},
+			}, nil
+		},
+		ListReviewThreadsFunc: func(ctx context.Context, owner, repo string, pullNumber int) ([]github.ReviewThread, error) {
+			return []github.ReviewThread{{ID: "T1", RootCommentID: 1}}, nil
+		},
+		ResolveReviewThreadFunc: func(ctx context.Context, threadID string) error {
+			return errors.New("Resource not accessible by integration")
+		},
+	}
+	poster := usecasegithub.NewReviewPoster(client)
+
+	result, err := poster.PostReview(context.Background(), usecasegithub.PostReviewRequest{
+		Owner:       "owner",
+		Repo:        "repo",
+		PullNumber:  1,
+		CommitSHA:   "0123456789abcdef",
+		BotUsername: "github-actions[bot]",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]string{1: "✅ No longer detected in `0123456`."}, client.Replies,
+		"threads already answered are not replied to again")
+	assert.Equal(t, 0, result.ResolvedThreadCount)
+	assert.Equal(t, 1, result.NoLongerDetectedCount)
+}
+
+func TestReviewPoster_PostReview_NoStaleResolutionOnCreateFailure(t *testing.T) {
+	client := &MockReviewClient{
+		ListPullRequestCommentsFunc: func(ctx context.Context, owner, repo string, pullNumber int) ([]github.PullRequestComment, error) {
+			return []github.PullRequestComment{botFingerprintComment(1, makeFinding("a.go", 1, "high", "gone"))}, nil
+		},
+		ListReviewThreadsFunc: func(ctx context.Context, owner, repo string, pullNumber int) ([]github.ReviewThread, error) {
+			return []github.ReviewThread{{ID: "T1", RootCommentID: 1}}, nil
+		},
+		CreateReviewFunc: func(ctx context.Context, input github.CreateReviewInput) (*github.CreateReviewResponse, error) {
+			return nil, errors.New("boom")
+		},
+	}
+	poster := usecasegithub.NewReviewPoster(client)
+
+	_, err := poster.PostReview(context.Background(), usecasegithub.PostReviewRequest{
+		Owner:       "owner",
+		Repo:        "repo",
+		PullNumber:  1,
+		CommitSHA:   "sha",
+		BotUsername: "github-actions[bot]",
+	})
+
+	require.Error(t, err)
+	assert.Empty(t, client.ResolvedThreadIDs)
+	assert.Empty(t, client.Replies)
+}
+
+// duplicateComparer reports every candidate pair as a semantic duplicate.
+type duplicateComparer struct{}
+
+func (duplicateComparer) Compare(ctx context.Context, candidates []dedup.CandidatePair) (*dedup.ComparisonResult, error) {
+	result := &dedup.ComparisonResult{}
+	for _, cp := range candidates {
+		result.Duplicates = append(result.Duplicates, dedup.DuplicateMatch{
+			NewFinding:          cp.New,
+			ExistingFingerprint: cp.Existing.Fingerprint,
+			Reason:              "same issue",
+		})
+	}
+	return result, nil
+}
+
+func TestReviewPoster_PostReview_KeepsThreadsOfSemanticDuplicatesOpen(t *testing.T) {
+	original := makeFinding("file1.go", 10, "high", "Possible nil dereference")
+	reworded := makeFinding("file1.go", 11, "high", "Pointer may be nil here")
+
+	existing := botFingerprintComment(1, original)
+	existing.Path = "file1.go"
+	existing.Line = diff.IntPtr(10)
+
+	client := &MockReviewClient{
+		ListPullRequestCommentsFunc: func(ctx context.Context, owner, repo string, pullNumber int) ([]github.PullRequestComment, error) {
+			return []github.PullRequestComment{existing}, nil
+		},
+		ListReviewThreadsFunc: func(ctx context.Context, owner, repo string, pullNumber int) ([]github.ReviewThread, error) {
+			return []github.ReviewThread{{ID: "T1", RootCommentID: 1}}, nil
+		},
+	}
+	poster := usecasegithub.NewReviewPoster(client,
+		usecasegithub.WithSemanticComparer(duplicateComparer{}, usecasegithub.DefaultSemanticDedupConfig()))
+
+	result, err := poster.PostReview(context.Background(), usecasegithub.PostReviewRequest{
+		Owner:       "owner",
+		Repo:        "repo",
+		PullNumber:  1,
+		CommitSHA:   "0123456789abcdef",
+		Findings:    []github.PositionedFinding{{Finding: reworded, DiffPosition: diff.IntPtr(5)}},
+		BotUsername: "github-actions[bot]",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.SemanticDuplicatesSkipped)
+	assert.Empty(t, client.ResolvedThreadIDs, "the duplicated finding is still reported, so its thread stays open")
+	assert.Empty(t, client.Replies)
+	assert.Equal(t, 0, result.ResolvedThreadCount)
+	assert.Equal(t, 0, result.NoLongerDetectedCount)
+}
@@ -0,0 +1,334 @@
+// Package gitlab provides use cases for interacting with GitLab.
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/github"
+	"github.com/bkyoung/code-reviewer/internal/adapter/gitlab"
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/posting"
+)
+
+// summaryMarker tags the bot's summary discussion so that summaries from
+// earlier runs can be found and resolved once a new one is posted.
+const summaryMarker = "<!-- CR_SUMMARY -->"
+
+// MergeRequestClient defines the interface for interacting with GitLab merge requests.
+// This interface allows for mocking in tests.
+type MergeRequestClient interface {
+	CurrentUser(ctx context.Context) (*gitlab.User, error)
+	GetMergeRequest(ctx context.Context, project string, mrIID int) (*gitlab.MergeRequest, error)
+	ListDiscussions(ctx context.Context, project string, mrIID int) ([]gitlab.Discussion, error)
+	CreateDiscussion(ctx context.Context, project string, mrIID int, input gitlab.CreateDiscussionRequest) (*gitlab.Discussion, error)
+	ResolveDiscussion(ctx context.Context, project string, mrIID int, discussionID string, resolved bool) error
+	Approve(ctx context.Context, project string, mrIID int, sha string) error
+	Unapprove(ctx context.Context, project string, mrIID int) error
+}
+
+// ReviewPoster orchestrates posting code review findings to GitLab merge requests.
+// GitLab has no single "review" object like GitHub, so a review is published as
+// a summary discussion plus one positioned discussion per in-diff finding, and
+// the severity-based event is expressed by approving or unapproving the MR.
+type ReviewPoster struct {
+	client MergeRequestClient
+}
+
+// NewReviewPoster creates a new ReviewPoster with the given client.
+func NewReviewPoster(client MergeRequestClient) *ReviewPoster {
+	return &ReviewPoster{client: client}
+}
+
+// PostReviewRequest contains all data needed to post a review.
+type PostReviewRequest struct {
+	// Project is the GitLab project path ("group/subgroup/project") or numeric ID.
+	Project string
+
+	// MergeRequestIID is the project-scoped merge request number.
+	MergeRequestIID int
+
+	// CommitSHA is the head commit SHA that was reviewed.
+	CommitSHA string
+
+	// Review contains the summary and other metadata.
+	Review domain.Review
+
+	// Findings are the positioned findings to post as inline discussions.
+	Findings []gitlab.PositionedFinding
+
+	// OverrideEvent optionally overrides the automatically determined event.
+	OverrideEvent github.ReviewEvent
+
+	// ReviewActions configures the review action for each finding severity.
+	// The same severity→action logic as GitHub is used; APPROVE approves the
+	// MR and any other event revokes the bot's approval.
+	ReviewActions github.ReviewActions
+
+	// BotUsername enables deduplication, reply-status analysis and resolution
+	// of stale bot discussions. The bot is identified as the token's user;
+	// BotUsername is only used when that lookup fails. If empty, existing
+	// discussions are ignored.
+	BotUsername string
+}
+
+// PostReviewResult contains the result of posting a review.
+type PostReviewResult struct {
+	// SummaryDiscussionID is the ID of the summary discussion.
+	SummaryDiscussionID string
+
+	// CommentsPosted is the number of inline discussions posted.
+	CommentsPosted int
+
+	// CommentsSkipped is the number of findings skipped (not in diff or rejected by GitLab).
+	CommentsSkipped int
+
+	// DuplicatesSkipped is the number of findings skipped because they were
+	// already posted in previous runs (exact fingerprint match).
+	DuplicatesSkipped int
+
+	// Event is the review event that was determined.
+	Event github.ReviewEvent
+
+	// HTMLURL is the URL to view the merge request.
+	HTMLURL string
+
+	// ResolvedCount is the number of stale bot discussions resolved.
+	ResolvedCount int
+
+	// AcknowledgedCount is the number of existing findings with acknowledgment replies.
+	AcknowledgedCount int
+
+	// DisputedCount is the number of existing findings with dispute replies.
+	DisputedCount int
+
+	// OpenCount is the number of existing findings with no status-changing replies.
+	OpenCount int
+}
+
+// PostReview posts a code review to a GitLab merge request.
+//
+// If BotUsername is set:
+//   - Existing bot discussions are fetched to deduplicate findings by fingerprint
+//   - Reply statuses are analyzed; acknowledged/disputed findings don't block
+//   - Previous summaries and discussions whose finding is no longer reported
+//     are resolved AFTER posting succeeds
+//
+// The summary discussion must post successfully; failures on individual inline
+// discussions (e.g. a line GitLab considers outside the MR version) are logged
+// and counted in CommentsSkipped. Approval failures are logged but do not
+// affect the result.
+func (p *ReviewPoster) PostReview(ctx context.Context, req PostReviewRequest) (*PostReviewResult, error) {
+	if req.OverrideEvent != "" {
+		normalized, valid := github.NormalizeAction(string(req.OverrideEvent))
+		if !valid {
+			return nil, fmt.Errorf("invalid OverrideEvent: %q (must be APPROVE, REQUEST_CHANGES, or COMMENT)", req.OverrideEvent)
+		}
+		req.OverrideEvent = normalized
+	}
+
+	mr, err := p.client.GetMergeRequest(ctx, req.Project, req.MergeRequestIID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch merge request: %w", err)
+	}
+	if req.CommitSHA != "" && mr.DiffRefs.HeadSHA != "" && mr.DiffRefs.HeadSHA != req.CommitSHA {
+		log.Printf("warning: merge request head %s differs from reviewed commit %s; positions use the latest MR version",
+			mr.DiffRefs.HeadSHA, req.CommitSHA)
+	}
+
+	findings := req.Findings
+	var duplicatesSkipped int
+	var existingStatuses map[domain.FindingFingerprint]domain.FindingStatus
+	var statusCounts posting.StatusCounts
+	var botDiscussions []gitlab.Discussion
+
+	if req.BotUsername != "" {
+		botUsername := p.resolveBotUsername(ctx, req.BotUsername)
+		discussions, err := p.client.ListDiscussions(ctx, req.Project, req.MergeRequestIID)
+		if err != nil {
+			log.Printf("warning: failed to fetch discussions: %v", err)
+		} else {
+			botDiscussions = filterBotDiscussions(discussions, botUsername)
+			findings, duplicatesSkipped = posting.FilterDuplicates(req.Findings, findingOf, discussionBodies(botDiscussions))
+			existingStatuses, statusCounts = posting.AnalyzeStatuses(discussionThreads(botDiscussions, botUsername))
+		}
+	}
+
+	var event github.ReviewEvent
+	if req.OverrideEvent != "" {
+		event = req.OverrideEvent
+	} else {
+		event = posting.EffectiveEvent(req.Findings, findingOf, existingStatuses, req.ReviewActions)
+	}
+
+	summary := req.Review.Summary + posting.FormatStatusSection(statusCounts) + "\n\n" + summaryMarker
+	summaryDiscussion, err := p.client.CreateDiscussion(ctx, req.Project, req.MergeRequestIID, gitlab.CreateDiscussionRequest{
+		Body: summary,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if summaryDiscussion == nil {
+		return nil, fmt.Errorf("CreateDiscussion returned nil response")
+	}
+
+	var posted, skipped int
+	for _, pf := range findings {
+		position := gitlab.NewPosition(pf, mr.DiffRefs)
+		if position == nil {
+			skipped++
+			continue
+		}
+
+		fingerprint := domain.FingerprintFromFinding(pf.Finding)
+		_, err := p.client.CreateDiscussion(ctx, req.Project, req.MergeRequestIID, gitlab.CreateDiscussionRequest{
+			Body:     github.FormatFindingCommentWithFingerprint(pf.Finding, fingerprint),
+			Position: position,
+		})
+		if err != nil {
+			log.Printf("warning: failed to post discussion on %s:%d: %v", pf.NewPath, pf.Finding.LineStart, err)
+			skipped++
+			continue
+		}
+		posted++
+	}
+
+	p.applyApproval(ctx, req.Project, req.MergeRequestIID, mr.DiffRefs.HeadSHA, event)
+
+	var resolvedCount int
+	if req.BotUsername != "" {
+		resolvedCount = p.resolveStaleDiscussions(ctx, req, botDiscussions, summaryDiscussion.ID)
+	}
+
+	return &PostReviewResult{
+		SummaryDiscussionID: summaryDiscussion.ID,
+		CommentsPosted:      posted,
+		CommentsSkipped:     skipped,
+		DuplicatesSkipped:   duplicatesSkipped,
+		Event:               event,
+		HTMLURL:             mr.WebURL,
+		ResolvedCount:       resolvedCount,
+		AcknowledgedCount:   statusCounts.Acknowledged,
+		DisputedCount:       statusCounts.Disputed,
+		OpenCount:           statusCounts.Open,
+	}, nil
+}
+
+// resolveBotUsername returns the username of the token's user, falling back
+// to the configured name if the lookup fails.
+func (p *ReviewPoster) resolveBotUsername(ctx context.Context, configured string) string {
+	user, err := p.client.CurrentUser(ctx)
+	if err != nil || user == nil || user.Username == "" {
+		if err != nil {
+			log.Printf("warning: failed to look up token user, using %q: %v", configured, err)
+		}
+		return configured
+	}
+	return user.Username
+}
+
+// applyApproval maps the review event onto GitLab's approval state.
+// APPROVE approves the MR; REQUEST_CHANGES and COMMENT revoke any earlier
+// approval from the bot so a stale approval never outlives new findings.
+func (p *ReviewPoster) applyApproval(ctx context.Context, project string, mrIID int, headSHA string, event github.ReviewEvent) {
+	if event == github.EventApprove {
+		if err := p.client.Approve(ctx, project, mrIID, headSHA); err != nil {
+			log.Printf("warning: failed to approve merge request: %v", err)
+		}
+		return
+	}
+
+	if err := p.client.Unapprove(ctx, project, mrIID); err != nil {
+		// 404 means the bot had not approved; nothing to revoke
+		var httpErr *llmhttp.Error
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+			return
+		}
+		log.Printf("warning: failed to unapprove merge request: %v", err)
+	}
+}
+
+// resolveStaleDiscussions resolves previous bot summaries and finding discussions
+// whose fingerprint is no longer among the current findings. The new summary
+// discussion is excluded. Returns the number of discussions resolved; errors are
+// logged but do not block the review posting workflow.
+func (p *ReviewPoster) resolveStaleDiscussions(ctx context.Context, req PostReviewRequest, botDiscussions []gitlab.Discussion, excludeID string) int {
+	current := make(map[domain.FindingFingerprint]bool, len(req.Findings))
+	for _, pf := range req.Findings {
+		current[domain.FingerprintFromFinding(pf.Finding)] = true
+	}
+
+	var resolved int
+	for _, d := range botDiscussions {
+		if d.ID == excludeID || d.Resolved() || len(d.Notes) == 0 {
+			continue
+		}
+
+		body := d.Notes[0].Body
+		stale := strings.Contains(body, summaryMarker)
+		if fp, ok := github.ExtractFingerprintFromComment(body); ok && !current[fp] {
+			stale = true
+		}
+		if !stale {
+			continue
+		}
+
+		if err := p.client.ResolveDiscussion(ctx, req.Project, req.MergeRequestIID, d.ID, true); err != nil {
+			log.Printf("warning: failed to resolve discussion %s: %v", d.ID, err)
+			continue
+		}
+		resolved++
+	}
+
+	return resolved
+}
+
+// filterBotDiscussions returns discussions started by the bot (case-insensitive).
+func filterBotDiscussions(discussions []gitlab.Discussion, botUsername string) []gitlab.Discussion {
+	var result []gitlab.Discussion
+	for _, d := range discussions {
+		if len(d.Notes) == 0 || d.Notes[0].System {
+			continue
+		}
+		if strings.EqualFold(d.Notes[0].Author.Username, botUsername) {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// findingOf returns the domain finding of a positioned finding.
+func findingOf(pf gitlab.PositionedFinding) domain.Finding {
+	return pf.Finding
+}
+
+// discussionBodies returns the first note of each bot discussion.
+func discussionBodies(botDiscussions []gitlab.Discussion) []string {
+	bodies := make([]string, 0, len(botDiscussions))
+	for _, d := range botDiscussions {
+		bodies = append(bodies, d.Notes[0].Body)
+	}
+	return bodies
+}
+
+// discussionThreads pairs each bot discussion with its human (non-system,
+// non-bot) notes for reply-status analysis.
+func discussionThreads(botDiscussions []gitlab.Discussion, botUsername string) []posting.Thread {
+	threads := make([]posting.Thread, 0, len(botDiscussions))
+	for _, d := range botDiscussions {
+		thread := posting.Thread{Body: d.Notes[0].Body}
+		for _, note := range d.Notes[1:] {
+			if note.System || strings.EqualFold(note.Author.Username, botUsername) {
+				continue
+			}
+			thread.Replies = append(thread.Replies, note.Body)
+		}
+		threads = append(threads, thread)
+	}
+	return threads
+}
@@ -0,0 +1,348 @@
+package gitlab_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/github"
+	"github.com/bkyoung/code-reviewer/internal/adapter/gitlab"
+	llmhttp "github.com/bkyoung/code-reviewer/internal/adapter/llm/http"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	usecasegitlab "github.com/bkyoung/code-reviewer/internal/usecase/gitlab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockMergeRequestClient is a mock implementation of the MergeRequestClient interface.
+type MockMergeRequestClient struct {
+	mu                   sync.Mutex
+	CurrentUserFunc      func(ctx context.Context) (*gitlab.User, error)
+	ListDiscussionsFunc  func(ctx context.Context, project string, mrIID int) ([]gitlab.Discussion, error)
+	CreateDiscussionFunc func(ctx context.Context, input gitlab.CreateDiscussionRequest) (*gitlab.Discussion, error)
+	ApproveFunc          func(ctx context.Context, sha string) error
+	UnapproveFunc        func(ctx context.Context) error
+	Created              []gitlab.CreateDiscussionRequest
+	ResolvedIDs          []string
+	ApprovedSHAs         []string
+	UnapproveCalls       int
+	MergeRequestHeadSHA  string
+	MergeRequestWebURL   string
+}
+
+func (m *MockMergeRequestClient) CurrentUser(ctx context.Context) (*gitlab.User, error) {
+	if m.CurrentUserFunc != nil {
+		return m.CurrentUserFunc(ctx)
+	}
+	return &gitlab.User{Username: "cr-bot"}, nil
+}
+
+func (m *MockMergeRequestClient) GetMergeRequest(ctx context.Context, project string, mrIID int) (*gitlab.MergeRequest, error) {
+	head := m.MergeRequestHeadSHA
+	if head == "" {
+		head = "head123"
+	}
+	return &gitlab.MergeRequest{
+		IID:    mrIID,
+		SHA:    head,
+		WebURL: m.MergeRequestWebURL,
+		DiffRefs: gitlab.DiffRefs{
+			BaseSHA:  "base123",
+			StartSHA: "start123",
+			HeadSHA:  head,
+		},
+	}, nil
+}
+
+func (m *MockMergeRequestClient) ListDiscussions(ctx context.Context, project string, mrIID int) ([]gitlab.Discussion, error) {
+	if m.ListDiscussionsFunc != nil {
+		return m.ListDiscussionsFunc(ctx, project, mrIID)
+	}
+	return nil, nil
+}
+
+func (m *MockMergeRequestClient) CreateDiscussion(ctx context.Context, project string, mrIID int, input gitlab.CreateDiscussionRequest) (*gitlab.Discussion, error) {
+	m.mu.Lock()
+	m.Created = append(m.Created, input)
+	id := len(m.Created)
+	m.mu.Unlock()
+	if m.CreateDiscussionFunc != nil {
+		return m.CreateDiscussionFunc(ctx, input)
+	}
+	return &gitlab.Discussion{ID: fmt.Sprintf("new-%d", id)}, nil
+}
+
+func (m *MockMergeRequestClient) ResolveDiscussion(ctx context.Context, project string, mrIID int, discussionID string, resolved bool) error {
+	m.mu.Lock()
+	m.ResolvedIDs = append(m.ResolvedIDs, discussionID)
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *MockMergeRequestClient) Approve(ctx context.Context, project string, mrIID int, sha string) error {
+	m.mu.Lock()
+	m.ApprovedSHAs = append(m.ApprovedSHAs, sha)
+	m.mu.Unlock()
+	if m.ApproveFunc != nil {
+		return m.ApproveFunc(ctx, sha)
+	}
+	return nil
+}
+
+func (m *MockMergeRequestClient) Unapprove(ctx context.Context, project string, mrIID int) error {
+	m.mu.Lock()
+	m.UnapproveCalls++
+	m.mu.Unlock()
+	if m.UnapproveFunc != nil {
+		return m.UnapproveFunc(ctx)
+	}
+	return nil
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func makeFinding(file string, line int, severity, description string) domain.Finding {
+	return domain.NewFinding(domain.FindingInput{
+		File:        file,
+		LineStart:   line,
+		LineEnd:     line,
+		Severity:    severity,
+		Category:    "bug",
+		Description: description,
+		Suggestion:  "fix it",
+		Evidence:    true,
+	})
+}
+
+func inDiff(f domain.Finding) gitlab.PositionedFinding {
+	return gitlab.PositionedFinding{
+		Finding: f,
+		NewPath: f.File,
+		OldPath: f.File,
+		NewLine: intPtr(f.LineStart),
+	}
+}
+
+func botDiscussion(id, body string, resolved bool, replies ...gitlab.Note) gitlab.Discussion {
+	notes := []gitlab.Note{{
+		Body:       body,
+		Author:     gitlab.User{Username: "cr-bot"},
+		Resolvable: true,
+		Resolved:   resolved,
+	}}
+	notes = append(notes, replies...)
+	return gitlab.Discussion{ID: id, Notes: notes}
+}
+
+func TestReviewPoster_PostReview_PostsSummaryAndInlineDiscussions(t *testing.T) {
+	client := &MockMergeRequestClient{MergeRequestWebURL: "https://gitlab.com/g/p/-/merge_requests/7"}
+	poster := usecasegitlab.NewReviewPoster(client)
+
+	result, err := poster.PostReview(context.Background(), usecasegitlab.PostReviewRequest{
+		Project:         "g/p",
+		MergeRequestIID: 7,
+		CommitSHA:       "head123",
+		Review:          domain.Review{Summary: "Looks mostly fine"},
+		Findings: []gitlab.PositionedFinding{
+			inDiff(makeFinding("a.go", 10, "high", "nil deref")),
+			{Finding: makeFinding("b.go", 3, "low", "outside diff"), NewPath: "b.go", OldPath: "b.go"},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, client.Created, 2)
+	assert.Nil(t, client.Created[0].Position, "summary should be a general discussion")
+	assert.Contains(t, client.Created[0].Body, "Looks mostly fine")
+	assert.Contains(t, client.Created[0].Body, "<!-- CR_SUMMARY -->")
+
+	inline := client.Created[1]
+	require.NotNil(t, inline.Position)
+	assert.Equal(t, gitlab.PositionTypeText, inline.Position.PositionType)
+	assert.Equal(t, "base123", inline.Position.BaseSHA)
+	assert.Equal(t, "start123", inline.Position.StartSHA)
+	assert.Equal(t, "head123", inline.Position.HeadSHA)
+	assert.Equal(t, "a.go", inline.Position.NewPath)
+	assert.Equal(t, 10, *inline.Position.NewLine)
+	assert.Contains(t, inline.Body, "CR_FINGERPRINT:")
+
+	assert.Equal(t, 1, result.CommentsPosted)
+	assert.Equal(t, 1, result.CommentsSkipped)
+	assert.Equal(t, github.EventRequestChanges, result.Event)
+	assert.Equal(t, "https://gitlab.com/g/p/-/merge_requests/7", result.HTMLURL)
+	assert.Equal(t, 1, client.UnapproveCalls)
+	assert.Empty(t, client.ApprovedSHAs)
+}
+
+func TestReviewPoster_PostReview_ApprovesCleanReview(t *testing.T) {
+	client := &MockMergeRequestClient{}
+	poster := usecasegitlab.NewReviewPoster(client)
+
+	result, err := poster.PostReview(context.Background(), usecasegitlab.PostReviewRequest{
+		Project:         "g/p",
+		MergeRequestIID: 7,
+		Review:          domain.Review{Summary: "Clean"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, github.EventApprove, result.Event)
+	assert.Equal(t, []string{"head123"}, client.ApprovedSHAs)
+	assert.Zero(t, client.UnapproveCalls)
+}
+
+func TestReviewPoster_PostReview_IgnoresUnapproveNotFound(t *testing.T) {
+	client := &MockMergeRequestClient{
+		UnapproveFunc: func(ctx context.Context) error {
+			return &llmhttp.Error{Type: llmhttp.ErrTypeInvalidRequest, StatusCode: 404}
+		},
+	}
+	poster := usecasegitlab.NewReviewPoster(client)
+
+	result, err := poster.PostReview(context.Background(), usecasegitlab.PostReviewRequest{
+		Project:         "g/p",
+		MergeRequestIID: 7,
+		Findings:        []gitlab.PositionedFinding{inDiff(makeFinding("a.go", 1, "high", "bug"))},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, github.EventRequestChanges, result.Event)
+}
+
+func TestReviewPoster_PostReview_SummaryFailureReturnsError(t *testing.T) {
+	client := &MockMergeRequestClient{
+		CreateDiscussionFunc: func(ctx context.Context, input gitlab.CreateDiscussionRequest) (*gitlab.Discussion, error) {
+			return nil, errors.New("boom")
+		},
+	}
+	poster := usecasegitlab.NewReviewPoster(client)
+
+	_, err := poster.PostReview(context.Background(), usecasegitlab.PostReviewRequest{
+		Project:         "g/p",
+		MergeRequestIID: 7,
+	})
+	require.Error(t, err)
+	assert.Empty(t, client.ApprovedSHAs)
+}
+
+func TestReviewPoster_PostReview_InlineFailureIsSkipped(t *testing.T) {
+	client := &MockMergeRequestClient{
+		CreateDiscussionFunc: func(ctx context.Context, input gitlab.CreateDiscussionRequest) (*gitlab.Discussion, error) {
+			if input.Position != nil {
+				return nil, errors.New("line_code can't be blank")
+			}
+			return &gitlab.Discussion{ID: "summary"}, nil
+		},
+	}
+	poster := usecasegitlab.NewReviewPoster(client)
+
+	result, err := poster.PostReview(context.Background(), usecasegitlab.PostReviewRequest{
+		Project:         "g/p",
+		MergeRequestIID: 7,
+		Findings:        []gitlab.PositionedFinding{inDiff(makeFinding("a.go", 1, "low", "nit"))},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.CommentsPosted)
+	assert.Equal(t, 1, result.CommentsSkipped)
+}
+
+func TestReviewPoster_PostReview_InvalidOverrideEvent(t *testing.T) {
+	poster := usecasegitlab.NewReviewPoster(&MockMergeRequestClient{})
+
+	_, err := poster.PostReview(context.Background(), usecasegitlab.PostReviewRequest{
+		Project:         "g/p",
+		MergeRequestIID: 7,
+		OverrideEvent:   "MERGE",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid OverrideEvent")
+}
+
+func TestReviewPoster_PostReview_DeduplicatesAndResolvesStale(t *testing.T) {
+	kept := makeFinding("a.go", 10, "high", "still here")
+	gone := makeFinding("c.go", 5, "medium", "fixed already")
+
+	keptBody := github.FormatFindingCommentWithFingerprint(kept, domain.FingerprintFromFinding(kept))
+	goneBody := github.FormatFindingCommentWithFingerprint(gone, domain.FingerprintFromFinding(gone))
+
+	client := &MockMergeRequestClient{
+		ListDiscussionsFunc: func(ctx context.Context, project string, mrIID int) ([]gitlab.Discussion, error) {
+			return []gitlab.Discussion{
+				botDiscussion("old-summary", "Old summary\n\n<!-- CR_SUMMARY -->", false),
+				botDiscussion("kept", keptBody, false),
+				botDiscussion("gone", goneBody, false),
+				botDiscussion("gone-resolved", goneBody, true),
+				{ID: "human", Notes: []gitlab.Note{{Body: "question", Author: gitlab.User{Username: "dev"}}}},
+			}, nil
+		},
+	}
+	poster := usecasegitlab.NewReviewPoster(client)
+
+	result, err := poster.PostReview(context.Background(), usecasegitlab.PostReviewRequest{
+		Project:         "g/p",
+		MergeRequestIID: 7,
+		Findings:        []gitlab.PositionedFinding{inDiff(kept)},
+		BotUsername:     "fallback-bot",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, client.Created, 1, "only the summary should be posted")
+	assert.Equal(t, 1, result.DuplicatesSkipped)
+	assert.Equal(t, 0, result.CommentsPosted)
+	assert.ElementsMatch(t, []string{"old-summary", "gone"}, client.ResolvedIDs)
+	assert.Equal(t, 2, result.ResolvedCount)
+}
+
+func TestReviewPoster_PostReview_AcknowledgedFindingsDoNotBlock(t *testing.T) {
+	f := makeFinding("a.go", 10, "high", "accepted risk")
+	body := github.FormatFindingCommentWithFingerprint(f, domain.FingerprintFromFinding(f))
+
+	client := &MockMergeRequestClient{
+		ListDiscussionsFunc: func(ctx context.Context, project string, mrIID int) ([]gitlab.Discussion, error) {
+			return []gitlab.Discussion{
+				botDiscussion("d1", body, false, gitlab.Note{
+					Body:   "acknowledged, will fix later",
+					Author: gitlab.User{Username: "dev"},
+				}),
+			}, nil
+		},
+	}
+	poster := usecasegitlab.NewReviewPoster(client)
+
+	result, err := poster.PostReview(context.Background(), usecasegitlab.PostReviewRequest{
+		Project:         "g/p",
+		MergeRequestIID: 7,
+		Findings:        []gitlab.PositionedFinding{inDiff(f)},
+		BotUsername:     "cr-bot",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.AcknowledgedCount)
+	assert.Equal(t, github.EventApprove, result.Event)
+	assert.Contains(t, client.Created[0].Body, "Acknowledged")
+}
+
+func TestReviewPoster_PostReview_FallsBackToConfiguredBotUsername(t *testing.T) {
+	f := makeFinding("a.go", 10, "high", "dup")
+	body := github.FormatFindingCommentWithFingerprint(f, domain.FingerprintFromFinding(f))
+
+	client := &MockMergeRequestClient{
+		CurrentUserFunc: func(ctx context.Context) (*gitlab.User, error) {
+			return nil, errors.New("forbidden")
+		},
+		ListDiscussionsFunc: func(ctx context.Context, project string, mrIID int) ([]gitlab.Discussion, error) {
+			return []gitlab.Discussion{botDiscussion("d1", body, false)}, nil
+		},
+	}
+	poster := usecasegitlab.NewReviewPoster(client)
+
+	result, err := poster.PostReview(context.Background(), usecasegitlab.PostReviewRequest{
+		Project:         "g/p",
+		MergeRequestIID: 7,
+		Findings:        []gitlab.PositionedFinding{inDiff(f)},
+		BotUsername:     "CR-BOT",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.DuplicatesSkipped)
+}
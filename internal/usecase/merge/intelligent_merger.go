@@ -101,7 +101,7 @@ func (m *IntelligentMerger) Merge(ctx context.Context, reviews []domain.Review)
 	}
 
 	// Synthesize summary
-	summary := m.synthesizeSummary(reviews)
+	summary := m.synthesizeSummary(ctx, reviews)
 
 	// Aggregate usage metadata from all providers
 	var totalTokensIn, totalTokensOut int
@@ -371,7 +371,7 @@ func (m *IntelligentMerger) selectRepresentative(group findingGroup) domain.Find
 // synthesizeSummary creates a summary from multiple review summaries.
 // If useLLM is true and synthProvider is available, uses LLM to generate cohesive narrative.
 // Falls back to concatenation if LLM fails or is disabled.
-func (m *IntelligentMerger) synthesizeSummary(reviews []domain.Review) string {
+func (m *IntelligentMerger) synthesizeSummary(ctx context.Context, reviews []domain.Review) string {
 	if len(reviews) == 0 {
 		return "No reviews to merge."
 	}
@@ -383,7 +383,6 @@ func (m *IntelligentMerger) synthesizeSummary(reviews []domain.Review) string {
 	// Try LLM-based synthesis if enabled
 	if m.useLLM && m.synthProvider != nil {
 		prompt := buildSynthesisPrompt(reviews)
-		ctx := context.Background()
 
 		// Use synthesis provider (typically a cheap, fast model like gpt-4o-mini)
 		synthesizedSummary, err := m.synthProvider.Review(ctx, prompt, 0)
@@ -227,7 +227,7 @@ func TestSynthesizeSummary(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			merger := NewIntelligentMerger(nil)
-			summary := merger.synthesizeSummary(tt.reviews)
+			summary := merger.synthesizeSummary(context.Background(), tt.reviews)
 
 			for _, expected := range tt.expected {
 				if !strings.Contains(strings.ToLower(summary), strings.ToLower(expected)) {
@@ -466,7 +466,7 @@ func TestSynthesizeSummary_WithLLM(t *testing.T) {
 		{ProviderName: "anthropic", Summary: "SQL injection and validation issues."},
 	}
 
-	summary := merger.synthesizeSummary(reviews)
+	summary := merger.synthesizeSummary(context.Background(), reviews)
 
 	// Should use LLM response
 	if !strings.Contains(summary, "Comprehensive analysis") {
@@ -499,7 +499,7 @@ func TestSynthesizeSummary_LLMFallback(t *testing.T) {
 		{ProviderName: "anthropic", Summary: "Found problems."},
 	}
 
-	summary := merger.synthesizeSummary(reviews)
+	summary := merger.synthesizeSummary(context.Background(), reviews)
 
 	// Should fall back to concatenation
 	if !strings.Contains(summary, "openai:") || !strings.Contains(summary, "|") {
@@ -525,7 +525,7 @@ func TestSynthesizeSummary_LLMDisabled(t *testing.T) {
 		{ProviderName: "anthropic", Summary: "Found problems."},
 	}
 
-	summary := merger.synthesizeSummary(reviews)
+	summary := merger.synthesizeSummary(context.Background(), reviews)
 
 	// Should use concatenation
 	if !strings.Contains(summary, "openai:") {
@@ -549,7 +549,7 @@ func TestSynthesizeSummary_NoProvider(t *testing.T) {
 		{ProviderName: "anthropic", Summary: "Found problems."},
 	}
 
-	summary := merger.synthesizeSummary(reviews)
+	summary := merger.synthesizeSummary(context.Background(), reviews)
 
 	// Should fall back to concatenation when provider is nil
 	if !strings.Contains(summary, "openai:") || !strings.Contains(summary, "|") {
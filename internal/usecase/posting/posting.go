@@ -0,0 +1,150 @@
+// Package posting holds the platform-neutral parts of publishing a review:
+// fingerprint deduplication, reply-status analysis and the severity-based
+// review event. The platform posters only map comments and positions onto it.
+package posting
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/github"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+)
+
+// Positioned is a platform's positioned finding.
+type Positioned interface {
+	// InDiff reports whether the finding can receive an inline comment.
+	InDiff() bool
+}
+
+// Thread is one of the bot's finding comments with the human replies to it.
+type Thread struct {
+	// Body is the bot comment body carrying the fingerprint marker.
+	Body string
+
+	// Replies are the bodies of the human replies in the thread.
+	Replies []string
+}
+
+// StatusCounts tracks the count of findings by status.
+type StatusCounts struct {
+	Open         int
+	Acknowledged int
+	Disputed     int
+}
+
+// FilterDuplicates removes findings whose fingerprint already appears in one
+// of the bot's comment bodies. Returns the remaining findings and the count
+// of duplicates skipped.
+func FilterDuplicates[T any](findings []T, finding func(T) domain.Finding, botBodies []string) ([]T, int) {
+	existing := make(map[domain.FindingFingerprint]bool)
+	for _, body := range botBodies {
+		if fp, ok := github.ExtractFingerprintFromComment(body); ok {
+			existing[fp] = true
+		}
+	}
+	if len(existing) == 0 {
+		return findings, 0
+	}
+
+	var filtered []T
+	var duplicatesSkipped int
+	for _, f := range findings {
+		if existing[domain.FingerprintFromFinding(finding(f))] {
+			duplicatesSkipped++
+			continue
+		}
+		filtered = append(filtered, f)
+	}
+	return filtered, duplicatesSkipped
+}
+
+// AnalyzeStatuses derives each existing finding's status from the human
+// replies in its thread. Threads without a fingerprint (legacy comments) are
+// ignored; if a fingerprint appears in several threads the first one wins.
+func AnalyzeStatuses(threads []Thread) (map[domain.FindingFingerprint]domain.FindingStatus, StatusCounts) {
+	statuses := make(map[domain.FindingFingerprint]domain.FindingStatus)
+	var counts StatusCounts
+
+	for _, thread := range threads {
+		fp, ok := github.ExtractFingerprintFromComment(thread.Body)
+		if !ok {
+			continue
+		}
+		if _, seen := statuses[fp]; seen {
+			continue
+		}
+
+		status := domain.DetectStatusFromReplies(thread.Replies)
+		statuses[fp] = status
+
+		switch status {
+		case domain.StatusAcknowledged:
+			counts.Acknowledged++
+		case domain.StatusDisputed:
+			counts.Disputed++
+		case domain.StatusOpen:
+			counts.Open++
+		}
+	}
+
+	return statuses, counts
+}
+
+// EffectiveEvent determines the review event from the findings' severities.
+// Only in-diff findings count, and acknowledged or disputed findings don't
+// count toward blocking.
+func EffectiveEvent[T Positioned](
+	findings []T,
+	finding func(T) domain.Finding,
+	existingStatuses map[domain.FindingFingerprint]domain.FindingStatus,
+	actions github.ReviewActions,
+) github.ReviewEvent {
+	var effective []github.PositionedFinding
+	for _, f := range findings {
+		df := finding(f)
+		if status, exists := existingStatuses[domain.FingerprintFromFinding(df)]; exists && status != domain.StatusOpen {
+			continue
+		}
+		// The event logic only checks whether a position is set
+		pf := github.PositionedFinding{Finding: df}
+		if f.InDiff() {
+			pf.DiffPosition = &inDiffPosition
+		}
+		effective = append(effective, pf)
+	}
+
+	return github.DetermineReviewEventWithActions(effective, actions)
+}
+
+// inDiffPosition marks a projected finding as being in the diff.
+var inDiffPosition = 1
+
+// FormatStatusSection creates a markdown section showing finding status breakdown.
+// Returns an empty string if all counts are zero.
+func FormatStatusSection(counts StatusCounts) string {
+	// Only include section if there are any existing findings tracked
+	total := counts.Open + counts.Acknowledged + counts.Disputed
+	if total == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n\n---\n\n")
+	sb.WriteString("### Existing Finding Status\n\n")
+
+	// Always show all statuses for clarity
+	sb.WriteString("| Status | Count | Effect |\n")
+	sb.WriteString("|--------|-------|--------|\n")
+	sb.WriteString("| 🔓 Open | ")
+	sb.WriteString(fmt.Sprintf("%d", counts.Open))
+	sb.WriteString(" | Counts toward blocking |\n")
+	sb.WriteString("| ✅ Acknowledged | ")
+	sb.WriteString(fmt.Sprintf("%d", counts.Acknowledged))
+	sb.WriteString(" | Won't block (author accepted) |\n")
+	sb.WriteString("| ❌ Disputed | ")
+	sb.WriteString(fmt.Sprintf("%d", counts.Disputed))
+	sb.WriteString(" | Won't block (author disputes) |\n")
+
+	return sb.String()
+}
@@ -0,0 +1,121 @@
+package posting_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/github"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/posting"
+)
+
+// positioned is a minimal platform positioned finding.
+type positioned struct {
+	finding domain.Finding
+	inDiff  bool
+}
+
+func (p positioned) InDiff() bool { return p.inDiff }
+
+func findingOf(p positioned) domain.Finding { return p.finding }
+
+func makeFinding(line int, severity, description string) domain.Finding {
+	return domain.Finding{File: "a.go", LineStart: line, LineEnd: line, Severity: severity, Category: "bug", Description: description}
+}
+
+func commentBody(f domain.Finding) string {
+	return github.FormatFindingCommentWithFingerprint(f, domain.FingerprintFromFinding(f))
+}
+
+func TestFilterDuplicates(t *testing.T) {
+	posted := makeFinding(1, "high", "posted before")
+	fresh := makeFinding(2, "high", "new")
+	findings := []positioned{{finding: posted, inDiff: true}, {finding: fresh, inDiff: true}}
+
+	filtered, skipped := posting.FilterDuplicates(findings, findingOf, []string{commentBody(posted), "summary without fingerprint"})
+
+	if skipped != 1 {
+		t.Errorf("expected 1 duplicate, got %d", skipped)
+	}
+	if len(filtered) != 1 || filtered[0].finding.Description != "new" {
+		t.Errorf("expected only the new finding to remain, got %+v", filtered)
+	}
+}
+
+func TestAnalyzeStatuses(t *testing.T) {
+	acked := makeFinding(1, "high", "acked")
+	disputed := makeFinding(2, "high", "disputed")
+	open := makeFinding(3, "high", "open")
+
+	statuses, counts := posting.AnalyzeStatuses([]posting.Thread{
+		{Body: commentBody(acked), Replies: []string{"Good catch, acknowledged"}},
+		{Body: commentBody(disputed), Replies: []string{"This is a false positive"}},
+		{Body: commentBody(open)},
+		{Body: commentBody(open), Replies: []string{"acknowledged"}}, // later thread for the same fingerprint is ignored
+		{Body: "no fingerprint", Replies: []string{"acknowledged"}},
+	})
+
+	want := posting.StatusCounts{Open: 1, Acknowledged: 1, Disputed: 1}
+	if counts != want {
+		t.Errorf("expected counts %+v, got %+v", want, counts)
+	}
+	if got := statuses[domain.FingerprintFromFinding(open)]; got != domain.StatusOpen {
+		t.Errorf("expected first thread to decide the status, got %s", got)
+	}
+	if len(statuses) != 3 {
+		t.Errorf("expected 3 statuses, got %d", len(statuses))
+	}
+}
+
+func TestEffectiveEvent(t *testing.T) {
+	critical := makeFinding(1, "critical", "blocking")
+	tests := []struct {
+		name     string
+		findings []positioned
+		statuses map[domain.FindingFingerprint]domain.FindingStatus
+		want     github.ReviewEvent
+	}{
+		{
+			name:     "open in-diff critical blocks",
+			findings: []positioned{{finding: critical, inDiff: true}},
+			want:     github.EventRequestChanges,
+		},
+		{
+			name:     "out-of-diff critical does not block",
+			findings: []positioned{{finding: critical}},
+			want:     github.EventApprove,
+		},
+		{
+			name:     "disputed critical does not block",
+			findings: []positioned{{finding: critical, inDiff: true}},
+			statuses: map[domain.FindingFingerprint]domain.FindingStatus{domain.FingerprintFromFinding(critical): domain.StatusDisputed},
+			want:     github.EventApprove,
+		},
+		{
+			name:     "open existing critical still blocks",
+			findings: []positioned{{finding: critical, inDiff: true}},
+			statuses: map[domain.FindingFingerprint]domain.FindingStatus{domain.FingerprintFromFinding(critical): domain.StatusOpen},
+			want:     github.EventRequestChanges,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := posting.EffectiveEvent(tt.findings, findingOf, tt.statuses, github.ReviewActions{}); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatStatusSection(t *testing.T) {
+	if got := posting.FormatStatusSection(posting.StatusCounts{}); got != "" {
+		t.Errorf("expected empty section for zero counts, got %q", got)
+	}
+	got := posting.FormatStatusSection(posting.StatusCounts{Open: 2, Disputed: 1})
+	for _, want := range []string{"### Existing Finding Status", "| 🔓 Open | 2 |", "| ❌ Disputed | 1 |"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected section to contain %q, got %q", want, got)
+		}
+	}
+}
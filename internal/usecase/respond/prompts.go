@@ -0,0 +1,97 @@
+package respond
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/github"
+)
+
+// systemPrompt instructs the LLM to act as the reviewer answering a follow-up.
+const systemPrompt = `You are the automated code reviewer that wrote the original review comment in this pull request thread.
+A developer has replied with a question or remark. Answer it directly and concisely in GitHub-flavored markdown.
+
+Guidelines:
+- Base your answer on the current file contents shown, not only on the original comment.
+- If the developer asks whether the issue is fixed, check the current code and say clearly whether it is.
+- If the developer's argument shows the finding was wrong, acknowledge it plainly.
+- Explain why an issue matters with concrete consequences; suggest a fix when helpful.
+- Do not repeat the original comment, and keep the answer under 200 words.
+- Do not include HTML comments or hidden markers.`
+
+// conversationSystemPrompt instructs the LLM to answer a question addressed to
+// the reviewer in the pull request conversation.
+const conversationSystemPrompt = `You are the automated code reviewer of this pull request.
+A developer has mentioned you in the pull request conversation. Answer their latest message directly and concisely in GitHub-flavored markdown.
+
+Guidelines:
+- Your review findings and the recent conversation are shown; file contents are included for files the developer mentioned.
+- Refer to findings by file and line so the developer can find them.
+- If the developer asks whether an issue is fixed and the current code is shown, check it and say clearly whether it is; otherwise say you can only check files they name.
+- If the developer's argument shows a finding was wrong, acknowledge it plainly.
+- Keep the answer under 200 words.
+- Do not include HTML comments or hidden markers.`
+
+// findingExcerpt pairs a finding comment with the current code around it.
+type findingExcerpt struct {
+	finding github.PullRequestComment
+	content string
+}
+
+// buildUserPrompt assembles the finding, the current code and the conversation.
+func buildUserPrompt(root github.PullRequestComment, thread []github.PullRequestComment, excerpt string) string {
+	var sb strings.Builder
+
+	sb.WriteString("## Original Review Comment\n\n")
+	if root.Path != "" {
+		sb.WriteString(fmt.Sprintf("File: %s\n\n", location(root)))
+	}
+	sb.WriteString(stripMarkers(root.Body))
+	sb.WriteString("\n\n## Current File Contents\n\n```\n")
+	sb.WriteString(excerpt)
+	sb.WriteString("```\n\n## Conversation\n\n")
+
+	for _, c := range thread {
+		sb.WriteString(fmt.Sprintf("**%s:**\n%s\n\n", c.User.Login, stripMarkers(c.Body)))
+	}
+
+	sb.WriteString("Respond to the latest message in the conversation.\n")
+	return sb.String()
+}
+
+// buildConversationPrompt assembles the bot's findings, the current code of
+// the mentioned files and the recent pull request conversation.
+func buildConversationPrompt(findings []github.PullRequestComment, excerpts []findingExcerpt, conversation []github.IssueComment) string {
+	var sb strings.Builder
+
+	sb.WriteString("## Review Findings\n\n")
+	for _, f := range findings {
+		sb.WriteString(fmt.Sprintf("### %s\n\n%s\n\n", location(f), stripMarkers(f.Body)))
+	}
+
+	if len(excerpts) > 0 {
+		sb.WriteString("## Current File Contents\n\n")
+		for _, e := range excerpts {
+			sb.WriteString(fmt.Sprintf("%s\n\n```\n%s```\n\n", location(e.finding), e.content))
+		}
+	}
+
+	sb.WriteString("## Conversation\n\n")
+	for _, c := range conversation {
+		sb.WriteString(fmt.Sprintf("**%s:**\n%s\n\n", c.User.Login, stripMarkers(c.Body)))
+	}
+
+	sb.WriteString("Respond to the latest message in the conversation.\n")
+	return sb.String()
+}
+
+// location formats the file and line a review comment is attached to.
+func location(c github.PullRequestComment) string {
+	if c.Path == "" {
+		return "(no file)"
+	}
+	if c.Line != nil {
+		return fmt.Sprintf("%s:%d", c.Path, *c.Line)
+	}
+	return c.Path
+}
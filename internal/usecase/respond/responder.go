@@ -0,0 +1,470 @@
+// Package respond answers follow-up questions that developers post as replies
+// to the bot's inline review comments or address to the bot in the pull
+// request conversation.
+package respond
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/github"
+	"github.com/bkyoung/code-reviewer/internal/usecase/verify"
+)
+
+// ResponseMarker is embedded in every conversational reply so later runs can
+// recognize them for rate limiting.
+const ResponseMarker = "<!-- CR_RESPONSE -->"
+
+// Defaults for the self-imposed rate limits.
+const (
+	DefaultMaxThreadReplies = 3
+	DefaultMaxHourlyReplies = 10
+	defaultContextLines     = 20
+
+	// maxConversationMessages bounds how much of the pull request
+	// conversation is sent to the LLM.
+	maxConversationMessages = 10
+
+	// maxQuotedLines bounds the quote of the question in conversation replies.
+	maxQuotedLines = 3
+)
+
+// htmlCommentPattern strips hidden markers (fingerprints, response markers)
+// from comment bodies before they are shown to the LLM.
+var htmlCommentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+// CommentClient defines the GitHub operations needed to read and answer a
+// review thread or the pull request conversation.
+// This interface allows for mocking in tests.
+type CommentClient interface {
+	ListPullRequestComments(ctx context.Context, owner, repo string, pullNumber int) ([]github.PullRequestComment, error)
+	CreateReplyComment(ctx context.Context, owner, repo string, pullNumber int, commentID int64, body string) (*github.PullRequestComment, error)
+	ListIssueComments(ctx context.Context, owner, repo string, number int) ([]github.IssueComment, error)
+	CreateIssueComment(ctx context.Context, owner, repo string, number int, body string) (*github.IssueComment, error)
+}
+
+// LLMClient defines the interface for LLM interactions.
+type LLMClient interface {
+	Call(ctx context.Context, systemPrompt, userPrompt string) (response string, tokensIn, tokensOut int, cost float64, err error)
+}
+
+// Responder loads a review comment thread (or the pull request conversation),
+// asks an LLM to answer the latest human message and posts the answer to the
+// same thread (or conversation).
+type Responder struct {
+	client CommentClient
+	llm    LLMClient
+	repo   verify.Repository
+	now    func() time.Time
+}
+
+// NewResponder creates a Responder. File contents are read through repo.
+func NewResponder(client CommentClient, llm LLMClient, repo verify.Repository) *Responder {
+	return &Responder{
+		client: client,
+		llm:    llm,
+		repo:   repo,
+		now:    time.Now,
+	}
+}
+
+// SetClock overrides the time source used for the hourly rate limit (for testing).
+func (r *Responder) SetClock(now func() time.Time) {
+	r.now = now
+}
+
+// Request identifies the comment to respond to.
+type Request struct {
+	// Owner is the GitHub repository owner (user or organization).
+	Owner string
+
+	// Repo is the GitHub repository name.
+	Repo string
+
+	// PullNumber is the PR number.
+	PullNumber int
+
+	// CommentID is the comment that triggered the run: a review comment, or
+	// an issue comment if IssueComment is set.
+	CommentID int64
+
+	// IssueComment marks CommentID as a comment in the pull request
+	// conversation (an issue_comment event) rather than a review comment.
+	IssueComment bool
+
+	// BotUsername identifies the bot's own comments. Threads must start with
+	// a bot finding and the bot never responds to itself. Conversation
+	// comments must mention the bot.
+	BotUsername string
+
+	// MaxThreadReplies caps the conversational replies per thread. The pull
+	// request conversation counts as one thread.
+	// Zero uses DefaultMaxThreadReplies.
+	MaxThreadReplies int
+
+	// MaxHourlyReplies caps the conversational replies per PR in the last hour.
+	// Zero uses DefaultMaxHourlyReplies.
+	MaxHourlyReplies int
+}
+
+// Result describes what the responder did.
+type Result struct {
+	// Replied is true if a reply was posted.
+	Replied bool
+
+	// ReplyID is the ID of the posted reply.
+	ReplyID int64
+
+	// HTMLURL is the URL of the posted reply.
+	HTMLURL string
+
+	// SkipReason explains why no reply was posted.
+	SkipReason string
+
+	// Cost is the LLM cost of the response in USD.
+	Cost float64
+}
+
+// Respond answers the triggering comment if it is a human reply in a bot
+// finding thread and the rate limits allow it. Skips are reported in the
+// result, not as errors.
+func (r *Responder) Respond(ctx context.Context, req Request) (Result, error) {
+	if req.BotUsername == "" {
+		return Result{}, fmt.Errorf("bot username is required to identify the bot's threads")
+	}
+
+	comments, err := r.client.ListPullRequestComments(ctx, req.Owner, req.Repo, req.PullNumber)
+	if err != nil {
+		return Result{}, fmt.Errorf("list comments: %w", err)
+	}
+	if req.IssueComment {
+		return r.respondInConversation(ctx, req, comments)
+	}
+
+	byID := make(map[int64]github.PullRequestComment, len(comments))
+	for _, c := range comments {
+		byID[c.ID] = c
+	}
+
+	trigger, ok := byID[req.CommentID]
+	if !ok {
+		return skipped("comment is not a review comment on this pull request"), nil
+	}
+	if isBot(trigger.User, req.BotUsername) {
+		return skipped("comment was written by a bot"), nil
+	}
+	if trigger.InReplyToID == 0 {
+		return skipped("comment is not a reply"), nil
+	}
+
+	root, ok := byID[trigger.InReplyToID]
+	if !ok || !strings.EqualFold(root.User.Login, req.BotUsername) {
+		return skipped("thread was not started by the bot"), nil
+	}
+	if _, ok := github.ExtractFingerprintFromComment(root.Body); !ok {
+		return skipped("thread does not contain a review finding"), nil
+	}
+
+	thread := threadComments(comments, root.ID)
+	limits := rateLimits{
+		trigger: trigger.CreatedAt,
+		thread:  reviewMessages(thread),
+		all:     reviewMessages(comments),
+		scope:   "thread",
+	}
+	if reason := r.rateLimited(req, limits); reason != "" {
+		return skipped(reason), nil
+	}
+
+	prompt := buildUserPrompt(root, thread, r.fileExcerpt(root))
+	answer, _, _, cost, err := r.llm.Call(ctx, systemPrompt, prompt)
+	if err != nil {
+		return Result{}, fmt.Errorf("generate response: %w", err)
+	}
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return Result{}, fmt.Errorf("generate response: LLM returned an empty answer")
+	}
+
+	reply, err := r.client.CreateReplyComment(ctx, req.Owner, req.Repo, req.PullNumber, root.ID, answer+"\n\n"+ResponseMarker)
+	if err != nil {
+		return Result{}, fmt.Errorf("post reply: %w", err)
+	}
+
+	return Result{
+		Replied: true,
+		ReplyID: reply.ID,
+		HTMLURL: reply.HTMLURL,
+		Cost:    cost,
+	}, nil
+}
+
+// respondInConversation answers an issue comment that mentions the bot. The
+// bot's inline findings on the pull request, the recent conversation and the
+// files the comment mentions give the LLM its context; the answer is posted
+// as a new comment in the conversation that quotes the question.
+func (r *Responder) respondInConversation(ctx context.Context, req Request, reviewComments []github.PullRequestComment) (Result, error) {
+	conversation, err := r.client.ListIssueComments(ctx, req.Owner, req.Repo, req.PullNumber)
+	if err != nil {
+		return Result{}, fmt.Errorf("list conversation: %w", err)
+	}
+
+	index := -1
+	for i, c := range conversation {
+		if c.ID == req.CommentID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return skipped("comment is not part of this pull request's conversation"), nil
+	}
+	trigger := conversation[index]
+	if isBot(trigger.User, req.BotUsername) {
+		return skipped("comment was written by a bot"), nil
+	}
+	if !mentionsBot(trigger.Body, req.BotUsername) {
+		return skipped("comment does not mention the bot"), nil
+	}
+
+	findings := botFindings(reviewComments, req.BotUsername)
+	if len(findings) == 0 {
+		return skipped("bot has no review findings on this pull request"), nil
+	}
+
+	limits := rateLimits{
+		trigger: trigger.CreatedAt,
+		thread:  issueMessages(conversation),
+		all:     append(reviewMessages(reviewComments), issueMessages(conversation)...),
+		scope:   "conversation",
+	}
+	if reason := r.rateLimited(req, limits); reason != "" {
+		return skipped(reason), nil
+	}
+
+	var excerpts []findingExcerpt
+	for _, f := range findings {
+		if f.Path != "" && strings.Contains(trigger.Body, f.Path) {
+			excerpts = append(excerpts, findingExcerpt{finding: f, content: r.fileExcerpt(f)})
+		}
+	}
+
+	history := conversation[:index+1]
+	if len(history) > maxConversationMessages {
+		history = history[len(history)-maxConversationMessages:]
+	}
+
+	prompt := buildConversationPrompt(findings, excerpts, history)
+	answer, _, _, cost, err := r.llm.Call(ctx, conversationSystemPrompt, prompt)
+	if err != nil {
+		return Result{}, fmt.Errorf("generate response: %w", err)
+	}
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return Result{}, fmt.Errorf("generate response: LLM returned an empty answer")
+	}
+
+	body := quote(trigger) + "\n\n" + answer + "\n\n" + ResponseMarker
+	comment, err := r.client.CreateIssueComment(ctx, req.Owner, req.Repo, req.PullNumber, body)
+	if err != nil {
+		return Result{}, fmt.Errorf("post reply: %w", err)
+	}
+
+	return Result{
+		Replied: true,
+		ReplyID: comment.ID,
+		HTMLURL: comment.HTMLURL,
+		Cost:    cost,
+	}, nil
+}
+
+// message is the part of a review or issue comment the rate limits look at.
+type message struct {
+	user      github.User
+	body      string
+	createdAt string
+}
+
+// rateLimits holds the messages the limits are counted over.
+type rateLimits struct {
+	// trigger is the creation time of the comment being answered.
+	trigger string
+
+	// thread holds the review thread or the pull request conversation.
+	thread []message
+
+	// all holds every comment on the pull request, for the hourly limit.
+	all []message
+
+	// scope names the thread in skip reasons.
+	scope string
+}
+
+// rateLimited returns a skip reason if answering would exceed a limit or the
+// trigger has already been answered; otherwise an empty string.
+func (r *Responder) rateLimited(req Request, limits rateLimits) string {
+	maxThread := req.MaxThreadReplies
+	if maxThread <= 0 {
+		maxThread = DefaultMaxThreadReplies
+	}
+	maxHourly := req.MaxHourlyReplies
+	if maxHourly <= 0 {
+		maxHourly = DefaultMaxHourlyReplies
+	}
+
+	var threadReplies int
+	for _, m := range limits.thread {
+		if !isResponse(m, req.BotUsername) {
+			continue
+		}
+		threadReplies++
+		// RFC3339 timestamps in UTC sort lexicographically
+		if m.createdAt >= limits.trigger {
+			return "comment has already been answered"
+		}
+	}
+	if threadReplies >= maxThread {
+		return fmt.Sprintf("%s reached the limit of %d replies", limits.scope, maxThread)
+	}
+
+	cutoff := r.now().Add(-time.Hour)
+	var hourlyReplies int
+	for _, m := range limits.all {
+		if !isResponse(m, req.BotUsername) {
+			continue
+		}
+		if created, err := time.Parse(time.RFC3339, m.createdAt); err == nil && created.After(cutoff) {
+			hourlyReplies++
+		}
+	}
+	if hourlyReplies >= maxHourly {
+		return fmt.Sprintf("pull request reached the limit of %d replies per hour", maxHourly)
+	}
+
+	return ""
+}
+
+// fileExcerpt returns numbered lines around the commented line of the current
+// file, or a note if the file cannot be read.
+func (r *Responder) fileExcerpt(root github.PullRequestComment) string {
+	if root.Path == "" {
+		return "(no file associated with this comment)"
+	}
+	content, err := r.repo.ReadFile(root.Path)
+	if err != nil {
+		return fmt.Sprintf("(%s could not be read; it may have been deleted or renamed)", root.Path)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	center := 1
+	if root.Line != nil {
+		center = *root.Line
+	}
+	start := center - defaultContextLines
+	if start < 1 {
+		start = 1
+	}
+	end := center + defaultContextLines
+	if end > len(lines) {
+		end = len(lines)
+	}
+
+	var sb strings.Builder
+	for i := start; i <= end; i++ {
+		sb.WriteString(fmt.Sprintf("%5d | %s\n", i, lines[i-1]))
+	}
+	return sb.String()
+}
+
+// threadComments returns the replies to a root comment in chronological order.
+func threadComments(comments []github.PullRequestComment, rootID int64) []github.PullRequestComment {
+	var thread []github.PullRequestComment
+	for _, c := range comments {
+		if c.InReplyToID == rootID {
+			thread = append(thread, c)
+		}
+	}
+	sort.SliceStable(thread, func(i, j int) bool {
+		return thread[i].CreatedAt < thread[j].CreatedAt
+	})
+	return thread
+}
+
+// isBot reports whether a comment author is the bot itself or any other bot
+// account, which prevents reply loops between automations.
+func isBot(user github.User, botUsername string) bool {
+	return strings.EqualFold(user.Login, botUsername) ||
+		strings.EqualFold(user.Type, "Bot") ||
+		strings.HasSuffix(strings.ToLower(user.Login), "[bot]")
+}
+
+// isResponse reports whether a message is one of the bot's conversational replies.
+func isResponse(m message, botUsername string) bool {
+	return strings.EqualFold(m.user.Login, botUsername) && strings.Contains(m.body, ResponseMarker)
+}
+
+// mentionsBot reports whether a comment @-mentions the bot. App accounts are
+// mentioned without their "[bot]" suffix.
+func mentionsBot(body, botUsername string) bool {
+	name := strings.TrimSuffix(strings.ToLower(botUsername), "[bot]")
+	pattern := regexp.MustCompile(`(?i)(^|[^\w@])@` + regexp.QuoteMeta(name) + `(\[bot\])?\b`)
+	return pattern.MatchString(body)
+}
+
+// botFindings returns the bot's top-level review comments that carry a finding.
+func botFindings(comments []github.PullRequestComment, botUsername string) []github.PullRequestComment {
+	var findings []github.PullRequestComment
+	for _, c := range comments {
+		if c.InReplyToID != 0 || !strings.EqualFold(c.User.Login, botUsername) {
+			continue
+		}
+		if _, ok := github.ExtractFingerprintFromComment(c.Body); ok {
+			findings = append(findings, c)
+		}
+	}
+	return findings
+}
+
+// quote renders the start of the question as a markdown quote so the answer
+// can be matched to it in the conversation.
+func quote(c github.IssueComment) string {
+	lines := strings.Split(stripMarkers(c.Body), "\n")
+	if len(lines) > maxQuotedLines {
+		lines = append(lines[:maxQuotedLines], "…")
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("> @%s wrote:\n", c.User.Login))
+	for _, line := range lines {
+		sb.WriteString("> " + line + "\n")
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func reviewMessages(comments []github.PullRequestComment) []message {
+	messages := make([]message, len(comments))
+	for i, c := range comments {
+		messages[i] = message{user: c.User, body: c.Body, createdAt: c.CreatedAt}
+	}
+	return messages
+}
+
+func issueMessages(comments []github.IssueComment) []message {
+	messages := make([]message, len(comments))
+	for i, c := range comments {
+		messages[i] = message{user: c.User, body: c.Body, createdAt: c.CreatedAt}
+	}
+	return messages
+}
+
+// stripMarkers removes hidden HTML comment markers from a comment body.
+func stripMarkers(body string) string {
+	return strings.TrimSpace(htmlCommentPattern.ReplaceAllString(body, ""))
+}
+
+func skipped(reason string) Result {
+	return Result{SkipReason: reason}
+}
@@ -0,0 +1,387 @@
+package respond_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bkyoung/code-reviewer/internal/adapter/github"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/respond"
+	"github.com/bkyoung/code-reviewer/internal/usecase/verify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const bot = "github-actions[bot]"
+
+type mockCommentClient struct {
+	comments     []github.PullRequestComment
+	conversation []github.IssueComment
+	replyTo      int64
+	reply        string
+}
+
+func (m *mockCommentClient) ListPullRequestComments(ctx context.Context, owner, repo string, pullNumber int) ([]github.PullRequestComment, error) {
+	return m.comments, nil
+}
+
+func (m *mockCommentClient) CreateReplyComment(ctx context.Context, owner, repo string, pullNumber int, commentID int64, body string) (*github.PullRequestComment, error) {
+	m.replyTo = commentID
+	m.reply = body
+	return &github.PullRequestComment{ID: 999, HTMLURL: "https://github.com/o/r/pull/1#discussion_r999"}, nil
+}
+
+func (m *mockCommentClient) ListIssueComments(ctx context.Context, owner, repo string, number int) ([]github.IssueComment, error) {
+	return m.conversation, nil
+}
+
+func (m *mockCommentClient) CreateIssueComment(ctx context.Context, owner, repo string, number int, body string) (*github.IssueComment, error) {
+	m.reply = body
+	return &github.IssueComment{ID: 998, HTMLURL: "https://github.com/o/r/pull/1#issuecomment-998"}, nil
+}
+
+type mockLLM struct {
+	prompt string
+	calls  int
+}
+
+func (m *mockLLM) Call(ctx context.Context, systemPrompt, userPrompt string) (string, int, int, float64, error) {
+	m.calls++
+	m.prompt = userPrompt
+	return "  It is still unchecked on line 3.  ", 100, 20, 0.002, nil
+}
+
+type mockRepo struct {
+	files map[string]string
+}
+
+func (m *mockRepo) ReadFile(path string) ([]byte, error) {
+	if content, ok := m.files[path]; ok {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *mockRepo) FileExists(path string) bool { _, ok := m.files[path]; return ok }
+
+func (m *mockRepo) Glob(pattern string) ([]string, error) { return nil, nil }
+
+func (m *mockRepo) Grep(pattern string, paths ...string) ([]verify.GrepMatch, error) {
+	return nil, nil
+}
+
+func (m *mockRepo) RunCommand(ctx context.Context, cmd string, args ...string) (verify.CommandResult, error) {
+	return verify.CommandResult{}, errors.New("not supported")
+}
+
+func rootComment() github.PullRequestComment {
+	finding := domain.Finding{File: "main.go", LineStart: 3, Severity: "high", Category: "bug", Description: "err is ignored"}
+	line := 3
+	return github.PullRequestComment{
+		ID:        10,
+		Path:      "main.go",
+		Line:      &line,
+		Body:      github.FormatFindingCommentWithFingerprint(finding, domain.FingerprintFromFinding(finding)),
+		User:      github.User{Login: bot, Type: "Bot"},
+		CreatedAt: "2026-01-01T10:00:00Z",
+	}
+}
+
+func humanReply(id int64, createdAt, body string) github.PullRequestComment {
+	return github.PullRequestComment{
+		ID:          id,
+		InReplyToID: 10,
+		Body:        body,
+		User:        github.User{Login: "dev", Type: "User"},
+		CreatedAt:   createdAt,
+	}
+}
+
+func botResponse(id int64, createdAt string) github.PullRequestComment {
+	return github.PullRequestComment{
+		ID:          id,
+		InReplyToID: 10,
+		Body:        "Earlier answer\n\n" + respond.ResponseMarker,
+		User:        github.User{Login: bot, Type: "Bot"},
+		CreatedAt:   createdAt,
+	}
+}
+
+func newResponder(client *mockCommentClient, llm *mockLLM) *respond.Responder {
+	repo := &mockRepo{files: map[string]string{"main.go": "package main\n\nfunc main() { f() }\n"}}
+	r := respond.NewResponder(client, llm, repo)
+	r.SetClock(func() time.Time { return time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC) })
+	return r
+}
+
+func request(commentID int64) respond.Request {
+	return respond.Request{Owner: "o", Repo: "r", PullNumber: 1, CommentID: commentID, BotUsername: bot}
+}
+
+func TestRespond_PostsThreadedReply(t *testing.T) {
+	client := &mockCommentClient{comments: []github.PullRequestComment{
+		rootComment(),
+		humanReply(11, "2026-01-01T11:00:00Z", "Is this fixed now?"),
+	}}
+	llm := &mockLLM{}
+
+	result, err := newResponder(client, llm).Respond(context.Background(), request(11))
+
+	require.NoError(t, err)
+	assert.True(t, result.Replied)
+	assert.Equal(t, int64(999), result.ReplyID)
+	assert.InDelta(t, 0.002, result.Cost, 1e-9)
+	assert.Equal(t, int64(10), client.replyTo, "reply goes to the thread root")
+	assert.Equal(t, "It is still unchecked on line 3.\n\n"+respond.ResponseMarker, client.reply)
+
+	assert.Contains(t, llm.prompt, "err is ignored")
+	assert.Contains(t, llm.prompt, "    3 | func main() { f() }")
+	assert.Contains(t, llm.prompt, "Is this fixed now?")
+	assert.NotContains(t, llm.prompt, "CR_FINGERPRINT", "hidden markers are stripped")
+}
+
+func TestRespond_Skips(t *testing.T) {
+	otherRoot := github.PullRequestComment{ID: 20, Body: "human review", User: github.User{Login: "dev"}}
+	replyToHuman := github.PullRequestComment{ID: 21, InReplyToID: 20, Body: "?", User: github.User{Login: "dev2"}}
+
+	tests := []struct {
+		name      string
+		comments  []github.PullRequestComment
+		commentID int64
+		reason    string
+	}{
+		{
+			name:      "own comment",
+			comments:  []github.PullRequestComment{rootComment(), botResponse(11, "2026-01-01T11:00:00Z")},
+			commentID: 11,
+			reason:    "bot",
+		},
+		{
+			name:      "other bot",
+			comments:  []github.PullRequestComment{rootComment(), {ID: 11, InReplyToID: 10, User: github.User{Login: "dependabot[bot]"}}},
+			commentID: 11,
+			reason:    "bot",
+		},
+		{
+			name:      "top-level comment",
+			comments:  []github.PullRequestComment{otherRoot},
+			commentID: 20,
+			reason:    "not a reply",
+		},
+		{
+			name:      "human thread",
+			comments:  []github.PullRequestComment{otherRoot, replyToHuman},
+			commentID: 21,
+			reason:    "not started by the bot",
+		},
+		{
+			name:      "unknown comment",
+			comments:  []github.PullRequestComment{rootComment()},
+			commentID: 404,
+			reason:    "not a review comment",
+		},
+		{
+			name: "already answered",
+			comments: []github.PullRequestComment{
+				rootComment(),
+				humanReply(11, "2026-01-01T11:00:00Z", "why?"),
+				botResponse(12, "2026-01-01T11:01:00Z"),
+			},
+			commentID: 11,
+			reason:    "already been answered",
+		},
+		{
+			name: "thread limit",
+			comments: []github.PullRequestComment{
+				rootComment(),
+				botResponse(11, "2026-01-01T08:00:00Z"),
+				botResponse(12, "2026-01-01T08:10:00Z"),
+				botResponse(13, "2026-01-01T08:20:00Z"),
+				humanReply(14, "2026-01-01T11:50:00Z", "and now?"),
+			},
+			commentID: 14,
+			reason:    "limit of 3 replies",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mockCommentClient{comments: tt.comments}
+			llm := &mockLLM{}
+
+			result, err := newResponder(client, llm).Respond(context.Background(), request(tt.commentID))
+
+			require.NoError(t, err)
+			assert.False(t, result.Replied)
+			assert.Contains(t, result.SkipReason, tt.reason)
+			assert.Zero(t, llm.calls)
+			assert.Empty(t, client.reply)
+		})
+	}
+}
+
+func TestRespond_HourlyLimitAcrossThreads(t *testing.T) {
+	comments := []github.PullRequestComment{rootComment()}
+	for i := int64(0); i < 2; i++ {
+		other := botResponse(100+i, "2026-01-01T11:30:00Z")
+		other.InReplyToID = 50 // a different thread
+		comments = append(comments, other)
+	}
+	comments = append(comments, humanReply(11, "2026-01-01T11:45:00Z", "why?"))
+
+	client := &mockCommentClient{comments: comments}
+	llm := &mockLLM{}
+	req := request(11)
+	req.MaxHourlyReplies = 2
+
+	result, err := newResponder(client, llm).Respond(context.Background(), req)
+
+	require.NoError(t, err)
+	assert.Contains(t, result.SkipReason, "2 replies per hour")
+	assert.Zero(t, llm.calls)
+}
+
+func TestRespond_RequiresBotUsername(t *testing.T) {
+	req := request(11)
+	req.BotUsername = ""
+
+	_, err := newResponder(&mockCommentClient{}, &mockLLM{}).Respond(context.Background(), req)
+
+	require.Error(t, err)
+}
+
+func issueComment(id int64, login, createdAt, body string) github.IssueComment {
+	return github.IssueComment{ID: id, Body: body, User: github.User{Login: login, Type: "User"}, CreatedAt: createdAt}
+}
+
+func conversationRequest(commentID int64) respond.Request {
+	req := request(commentID)
+	req.IssueComment = true
+	return req
+}
+
+func TestRespond_AnswersInConversation(t *testing.T) {
+	client := &mockCommentClient{
+		comments: []github.PullRequestComment{rootComment()},
+		conversation: []github.IssueComment{
+			issueComment(30, "lead", "2026-01-01T10:30:00Z", "Please address the review."),
+			issueComment(31, "dev", "2026-01-01T11:00:00Z", "@github-actions is the finding in main.go still valid?"),
+		},
+	}
+	llm := &mockLLM{}
+
+	result, err := newResponder(client, llm).Respond(context.Background(), conversationRequest(31))
+
+	require.NoError(t, err)
+	assert.True(t, result.Replied)
+	assert.Equal(t, int64(998), result.ReplyID)
+	assert.Contains(t, result.HTMLURL, "issuecomment-998")
+	assert.Zero(t, client.replyTo, "conversation answers are not threaded replies")
+	assert.Equal(t, "> @dev wrote:\n> @github-actions is the finding in main.go still valid?\n\n"+
+		"It is still unchecked on line 3.\n\n"+respond.ResponseMarker, client.reply)
+
+	assert.Contains(t, llm.prompt, "### main.go:3")
+	assert.Contains(t, llm.prompt, "err is ignored")
+	assert.Contains(t, llm.prompt, "    3 | func main() { f() }", "mentioned files are included")
+	assert.Contains(t, llm.prompt, "Please address the review.")
+	assert.NotContains(t, llm.prompt, "CR_FINGERPRINT", "hidden markers are stripped")
+}
+
+func TestRespond_ConversationSkips(t *testing.T) {
+	answered := respond.ResponseMarker
+	tests := []struct {
+		name         string
+		comments     []github.PullRequestComment
+		conversation []github.IssueComment
+		commentID    int64
+		reason       string
+	}{
+		{
+			name:         "unknown comment",
+			comments:     []github.PullRequestComment{rootComment()},
+			conversation: []github.IssueComment{issueComment(31, "dev", "2026-01-01T11:00:00Z", "@github-actions why?")},
+			commentID:    404,
+			reason:       "not part of this pull request's conversation",
+		},
+		{
+			name:         "own comment",
+			comments:     []github.PullRequestComment{rootComment()},
+			conversation: []github.IssueComment{issueComment(31, bot, "2026-01-01T11:00:00Z", "@github-actions hi\n\n"+answered)},
+			commentID:    31,
+			reason:       "bot",
+		},
+		{
+			name:         "no mention",
+			comments:     []github.PullRequestComment{rootComment()},
+			conversation: []github.IssueComment{issueComment(31, "dev", "2026-01-01T11:00:00Z", "LGTM, merging after CI")},
+			commentID:    31,
+			reason:       "does not mention the bot",
+		},
+		{
+			name:         "mention inside an email address",
+			comments:     []github.PullRequestComment{rootComment()},
+			conversation: []github.IssueComment{issueComment(31, "dev", "2026-01-01T11:00:00Z", "ask ci@github-actions.example")},
+			commentID:    31,
+			reason:       "does not mention the bot",
+		},
+		{
+			name:         "no findings",
+			conversation: []github.IssueComment{issueComment(31, "dev", "2026-01-01T11:00:00Z", "@github-actions why?")},
+			commentID:    31,
+			reason:       "no review findings",
+		},
+		{
+			name:     "already answered",
+			comments: []github.PullRequestComment{rootComment()},
+			conversation: []github.IssueComment{
+				issueComment(31, "dev", "2026-01-01T11:00:00Z", "@github-actions why?"),
+				issueComment(32, bot, "2026-01-01T11:01:00Z", "Because.\n\n"+answered),
+			},
+			commentID: 31,
+			reason:    "already been answered",
+		},
+		{
+			name:     "conversation limit",
+			comments: []github.PullRequestComment{rootComment()},
+			conversation: []github.IssueComment{
+				issueComment(31, bot, "2026-01-01T08:00:00Z", answered),
+				issueComment(32, bot, "2026-01-01T08:10:00Z", answered),
+				issueComment(33, bot, "2026-01-01T08:20:00Z", answered),
+				issueComment(34, "dev", "2026-01-01T11:50:00Z", "@github-actions and now?"),
+			},
+			commentID: 34,
+			reason:    "conversation reached the limit of 3 replies",
+		},
+		{
+			name: "hourly limit counts thread replies",
+			comments: []github.PullRequestComment{
+				rootComment(),
+				botResponse(11, "2026-01-01T11:30:00Z"),
+				botResponse(12, "2026-01-01T11:35:00Z"),
+			},
+			conversation: []github.IssueComment{issueComment(31, "dev", "2026-01-01T11:50:00Z", "@github-actions[bot] why?")},
+			commentID:    31,
+			reason:       "2 replies per hour",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mockCommentClient{comments: tt.comments, conversation: tt.conversation}
+			llm := &mockLLM{}
+			req := conversationRequest(tt.commentID)
+			req.MaxHourlyReplies = 2
+
+			result, err := newResponder(client, llm).Respond(context.Background(), req)
+
+			require.NoError(t, err)
+			assert.False(t, result.Replied)
+			assert.Contains(t, result.SkipReason, tt.reason)
+			assert.Zero(t, llm.calls)
+			assert.Empty(t, client.reply)
+		})
+	}
+}
@@ -0,0 +1,399 @@
+package review
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bkyoung/code-reviewer/internal/diff"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+)
+
+// CodeContextConfig limits how much surrounding code is added to the prompt.
+type CodeContextConfig struct {
+	// MaxLinesPerFile caps the context lines added for one file. Declarations
+	// larger than this are left out; the diff hunk is still reviewed.
+	MaxLinesPerFile int
+
+	// MaxTotalLines caps the context lines added across all files.
+	MaxTotalLines int
+}
+
+// DefaultCodeContextConfig returns sensible defaults.
+func DefaultCodeContextConfig() CodeContextConfig {
+	return CodeContextConfig{
+		MaxLinesPerFile: 300,
+		MaxTotalLines:   3000,
+	}
+}
+
+// CodeContextExpander adds the code around each diff hunk that git's three
+// lines of context leave out: the full enclosing function or type declaration
+// and the file's imports. This lets discovery see, for example, that an
+// import exists before claiming it is missing.
+type CodeContextExpander struct {
+	repoDir string
+	config  CodeContextConfig
+}
+
+// NewCodeContextExpander creates an expander that reads changed files from
+// the working tree at repoDir.
+func NewCodeContextExpander(repoDir string, config CodeContextConfig) *CodeContextExpander {
+	defaults := DefaultCodeContextConfig()
+	if config.MaxLinesPerFile <= 0 {
+		config.MaxLinesPerFile = defaults.MaxLinesPerFile
+	}
+	if config.MaxTotalLines <= 0 {
+		config.MaxTotalLines = defaults.MaxTotalLines
+	}
+	return &CodeContextExpander{repoDir: repoDir, config: config}
+}
+
+// lineRange is an inclusive 1-indexed range of file lines.
+type lineRange struct {
+	start, end int
+}
+
+func (r lineRange) size() int {
+	return r.end - r.start + 1
+}
+
+// Expand returns the rendered context for each changed file, keyed by path.
+// Source files are expanded first so they get the line budget. Files that are
+// deleted, binary, unreadable or whose working tree copy doesn't match the
+// diff are skipped.
+func (e *CodeContextExpander) Expand(d domain.Diff) map[string]string {
+	files := make([]domain.FileDiff, 0, len(d.Files))
+	for _, f := range d.Files {
+		if f.Status == domain.FileStatusDeleted || f.IsBinary || f.Patch == "" {
+			continue
+		}
+		files = append(files, f)
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return fileTypePriority(files[i].Path) < fileTypePriority(files[j].Path)
+	})
+
+	gatherer := NewContextGatherer(e.repoDir)
+	contexts := make(map[string]string)
+	remaining := e.config.MaxTotalLines
+
+	for _, f := range files {
+		if remaining <= 0 {
+			break
+		}
+		content, err := gatherer.loadFile(f.Path)
+		if err != nil {
+			continue
+		}
+		parsed, err := diff.Parse(f.Patch)
+		if err != nil || len(parsed.Hunks) == 0 {
+			continue
+		}
+		lines := strings.Split(content, "\n")
+		if !matchesWorkingTree(parsed, lines) {
+			continue
+		}
+
+		budget := e.config.MaxLinesPerFile
+		if remaining < budget {
+			budget = remaining
+		}
+		ranges := contextRanges(f.Path, content, lines, changedRanges(parsed), budget)
+		if len(ranges) == 0 {
+			continue
+		}
+
+		rendered, count := renderContext(lines, ranges)
+		contexts[f.Path] = rendered
+		remaining -= count
+	}
+
+	return contexts
+}
+
+// matchesWorkingTree reports whether the added lines of the diff are present
+// at their new-side line numbers, i.e. the file on disk is the diff's target.
+func matchesWorkingTree(parsed diff.ParsedDiff, lines []string) bool {
+	for _, hunk := range parsed.Hunks {
+		for _, line := range hunk.Lines {
+			if line.Type != diff.LineAddition || line.NewLine == nil {
+				continue
+			}
+			n := *line.NewLine
+			if n < 1 || n > len(lines) || strings.TrimRight(lines[n-1], "\r") != strings.TrimRight(line.Content, "\r") {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// changedRanges returns the new-side line range touched by each hunk. A
+// deletion maps to the line now following it.
+func changedRanges(parsed diff.ParsedDiff) []lineRange {
+	var ranges []lineRange
+	for _, hunk := range parsed.Hunks {
+		r := lineRange{}
+		record := func(n int) {
+			if n < 1 {
+				n = 1
+			}
+			if r.start == 0 || n < r.start {
+				r.start = n
+			}
+			if n > r.end {
+				r.end = n
+			}
+		}
+
+		next := hunk.NewStart
+		for _, line := range hunk.Lines {
+			switch {
+			case line.Type == diff.LineDeletion:
+				record(next)
+			case line.NewLine != nil:
+				if line.Type == diff.LineAddition {
+					record(*line.NewLine)
+				}
+				next = *line.NewLine + 1
+			}
+		}
+		if r.start > 0 {
+			ranges = append(ranges, r)
+		}
+	}
+	return ranges
+}
+
+// contextRanges selects the import block and the declarations enclosing the
+// changed ranges, within budget lines.
+func contextRanges(path, content string, lines []string, changed []lineRange, budget int) []lineRange {
+	var imports, enclosing []lineRange
+	if strings.HasSuffix(path, ".go") {
+		imports, enclosing = goContextRanges(content, changed)
+	} else {
+		imports = importRanges(lines)
+		for _, c := range changed {
+			if r, ok := enclosingBlock(lines, c); ok {
+				enclosing = append(enclosing, r)
+			}
+		}
+	}
+
+	var selected []lineRange
+	used := 0
+	for _, r := range append(imports, enclosing...) {
+		if r.size() > budget-used {
+			continue
+		}
+		selected = append(selected, r)
+		used += r.size()
+	}
+	return mergeRanges(selected)
+}
+
+// goContextRanges uses go/ast to find the package clause and imports, and the
+// top-level declarations (with their doc comments) overlapping the changes.
+func goContextRanges(content string, changed []lineRange) (imports, enclosing []lineRange) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", content, parser.ParseComments|parser.SkipObjectResolution)
+	if file == nil {
+		return nil, nil
+	}
+	_ = err // Partial ASTs still locate most declarations
+
+	line := func(pos token.Pos) int { return fset.Position(pos).Line }
+
+	pkgLine := line(file.Package)
+	imports = append(imports, lineRange{pkgLine, pkgLine})
+
+	for _, decl := range file.Decls {
+		start, end := line(decl.Pos()), line(decl.End())
+		if gen, ok := decl.(*ast.GenDecl); ok {
+			if gen.Tok == token.IMPORT {
+				imports = append(imports, lineRange{start, end})
+				continue
+			}
+			if gen.Doc != nil {
+				start = line(gen.Doc.Pos())
+			}
+		}
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Doc != nil {
+			start = line(fn.Doc.Pos())
+		}
+		for _, c := range changed {
+			if c.start <= end && c.end >= start {
+				enclosing = append(enclosing, lineRange{start, end})
+				break
+			}
+		}
+	}
+	return imports, enclosing
+}
+
+// importPattern matches import lines in common languages.
+var importPattern = regexp.MustCompile(`^\s*(?:import\s|from\s+\S+\s+import\s|require\b|const\s+\w+\s*=\s*require\(|use\s|using\s|#include\s|package\s)`)
+
+// importRanges returns the contiguous import lines near the top of a file.
+func importRanges(lines []string) []lineRange {
+	var ranges []lineRange
+	limit := len(lines)
+	if limit > 200 {
+		limit = 200
+	}
+	inMultiline := false
+	for i := 0; i < limit; i++ {
+		line := lines[i]
+		n := i + 1
+		switch {
+		case inMultiline:
+			ranges = extendRange(ranges, n)
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, ")") || strings.HasPrefix(trimmed, "}") {
+				inMultiline = false
+			}
+		case importPattern.MatchString(line):
+			ranges = extendRange(ranges, n)
+			// "import {" or "import (" spanning several lines
+			trimmed := strings.TrimSpace(line)
+			if strings.HasSuffix(trimmed, "{") || strings.HasSuffix(trimmed, "(") {
+				inMultiline = true
+			}
+		}
+	}
+	return ranges
+}
+
+// extendRange adds line n to the last range if adjacent (allowing one blank
+// line between imports), or starts a new range.
+func extendRange(ranges []lineRange, n int) []lineRange {
+	if k := len(ranges); k > 0 && n-ranges[k-1].end <= 2 {
+		ranges[k-1].end = n
+		return ranges
+	}
+	return append(ranges, lineRange{n, n})
+}
+
+// controlFlowPattern matches block openers that are not declarations.
+var controlFlowPattern = regexp.MustCompile(`^\s*(?:\}\s*)?(?:if|else|elif|for|foreach|while|do|switch|case|default|try|catch|except|finally|with|match|loop|unless|until|begin|rescue|ensure)\b`)
+
+// enclosingBlock finds the declaration around a changed range in a non-Go
+// file by indentation: it walks up to the nearest less-indented line that
+// opens a declaration (skipping control flow), then down to where the
+// indentation returns to that level, including a closing "}" or "end".
+func enclosingBlock(lines []string, changed lineRange) (lineRange, bool) {
+	if changed.start < 1 || changed.start > len(lines) {
+		return lineRange{}, false
+	}
+
+	target := indentOf(lines[changed.start-1])
+	start := 0
+	for i := changed.start - 1; i >= 1; i-- {
+		line := lines[i-1]
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indent := indentOf(line)
+		if indent >= target {
+			continue
+		}
+		target = indent
+		if !controlFlowPattern.MatchString(line) && !strings.HasPrefix(strings.TrimSpace(line), "}") {
+			start = i
+			break
+		}
+		if indent == 0 {
+			break
+		}
+	}
+	if start == 0 {
+		return lineRange{}, false
+	}
+	// Include decorators and annotations directly above the declaration
+	for start > 1 && strings.HasPrefix(strings.TrimSpace(lines[start-2]), "@") {
+		start--
+	}
+
+	base := indentOf(lines[start-1])
+	end := len(lines)
+	for i := changed.end + 1; i <= len(lines); i++ {
+		line := lines[i-1]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || indentOf(line) > base {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "}") || strings.HasPrefix(trimmed, ")") || trimmed == "end" || strings.HasPrefix(trimmed, "end ") {
+			end = i
+		} else {
+			end = i - 1
+		}
+		break
+	}
+	for end > changed.end && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	if end < changed.end {
+		end = changed.end
+	}
+	return lineRange{start, end}, true
+}
+
+// indentOf returns the indentation width of a line, counting tabs as 4.
+func indentOf(line string) int {
+	width := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// mergeRanges sorts ranges and merges overlapping or nearby ones.
+func mergeRanges(ranges []lineRange) []lineRange {
+	if len(ranges) == 0 {
+		return nil
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].start < ranges[j].start })
+	merged := []lineRange{ranges[0]}
+	for _, r := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		// Ranges separated by a single line (usually blank) are joined
+		if r.start <= last.end+2 {
+			if r.end > last.end {
+				last.end = r.end
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// renderContext prints the ranges with line numbers, separated by "...".
+// Returns the text and the number of lines included.
+func renderContext(lines []string, ranges []lineRange) (string, int) {
+	width := len(fmt.Sprint(ranges[len(ranges)-1].end))
+	var sb strings.Builder
+	count := 0
+	for i, r := range ranges {
+		if i > 0 {
+			sb.WriteString("...\n")
+		}
+		for n := r.start; n <= r.end && n <= len(lines); n++ {
+			sb.WriteString(fmt.Sprintf("%*d | %s\n", width, n, strings.TrimRight(lines[n-1], "\r")))
+			count++
+		}
+	}
+	return sb.String(), count
+}
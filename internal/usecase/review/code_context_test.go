@@ -0,0 +1,205 @@
+package review
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/domain"
+)
+
+const contextGoFile = `package store
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrNotFound = errors.New("not found")
+
+// Get returns the value for key.
+func Get(items map[string]string, key string) (string, error) {
+	v, ok := items[key]
+	if !ok {
+		return "", fmt.Errorf("get %s: %w", key, ErrNotFound)
+	}
+	return v, nil
+}
+
+func Unrelated() int {
+	return 42
+}
+`
+
+// contextGoPatch adds the Errorf line (line 14) to Get.
+const contextGoPatch = `@@ -12,6 +12,6 @@ func Get(items map[string]string, key string) (string, error) {
+ 	v, ok := items[key]
+ 	if !ok {
+-		return "", ErrNotFound
++		return "", fmt.Errorf("get %s: %w", key, ErrNotFound)
+ 	}
+ 	return v, nil
+ }
+`
+
+func writeContextRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for path, content := range files {
+		full := filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestCodeContextExpander_GoEnclosingFunctionAndImports(t *testing.T) {
+	root := writeContextRepo(t, map[string]string{"store/store.go": contextGoFile})
+	expander := NewCodeContextExpander(root, DefaultCodeContextConfig())
+
+	contexts := expander.Expand(domain.Diff{Files: []domain.FileDiff{
+		{Path: "store/store.go", Status: domain.FileStatusModified, Patch: contextGoPatch},
+	}})
+
+	got, ok := contexts["store/store.go"]
+	if !ok {
+		t.Fatalf("expected context for store/store.go, got %v", contexts)
+	}
+
+	for _, want := range []string{
+		" 1 | package store",
+		" 3 | import (",
+		` 5 | 	"fmt"`,
+		"10 | // Get returns the value for key.",
+		"11 | func Get(items map[string]string, key string) (string, error) {",
+		"17 | }",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected context to contain %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Unrelated") || strings.Contains(got, "ErrNotFound = ") {
+		t.Errorf("expected only imports and the enclosing function, got:\n%s", got)
+	}
+	if !strings.Contains(got, "...\n") {
+		t.Errorf("expected a separator between the imports and the function, got:\n%s", got)
+	}
+}
+
+func TestCodeContextExpander_IndentationBlocks(t *testing.T) {
+	content := `import os
+from app.db import Database
+
+
+class UserService:
+    def __init__(self, db):
+        self.db = db
+
+    def load_user(self, user_id):
+        if user_id is None:
+            return None
+        return self.db.get(user_id)
+
+    def delete_user(self, user_id):
+        self.db.delete(user_id)
+`
+	patch := `@@ -10,3 +10,3 @@ class UserService:
+         if user_id is None:
+-            return False
++            return None
+         return self.db.get(user_id)
+`
+	root := writeContextRepo(t, map[string]string{"app/service.py": content})
+	expander := NewCodeContextExpander(root, DefaultCodeContextConfig())
+
+	got := expander.Expand(domain.Diff{Files: []domain.FileDiff{
+		{Path: "app/service.py", Status: domain.FileStatusModified, Patch: patch},
+	}})["app/service.py"]
+
+	for _, want := range []string{
+		" 1 | import os",
+		" 2 | from app.db import Database",
+		" 9 |     def load_user(self, user_id):",
+		"12 |         return self.db.get(user_id)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected context to contain %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "delete_user") || strings.Contains(got, "__init__") {
+		t.Errorf("expected only the enclosing method, got:\n%s", got)
+	}
+}
+
+func TestCodeContextExpander_SkipsMismatchedWorkingTree(t *testing.T) {
+	// The working tree no longer has the added line (e.g. reviewing an older ref)
+	changed := strings.Replace(contextGoFile, `fmt.Errorf("get %s: %w", key, ErrNotFound)`, "ErrNotFound", 1)
+	root := writeContextRepo(t, map[string]string{"store/store.go": changed})
+	expander := NewCodeContextExpander(root, DefaultCodeContextConfig())
+
+	contexts := expander.Expand(domain.Diff{Files: []domain.FileDiff{
+		{Path: "store/store.go", Status: domain.FileStatusModified, Patch: contextGoPatch},
+		{Path: "gone.go", Status: domain.FileStatusDeleted, Patch: "@@ -1 +0,0 @@\n-package gone\n"},
+		{Path: "missing.go", Status: domain.FileStatusAdded, Patch: "@@ -0,0 +1 @@\n+package missing\n"},
+	}})
+
+	if len(contexts) != 0 {
+		t.Errorf("expected no context, got %v", contexts)
+	}
+}
+
+func TestCodeContextExpander_RespectsBudget(t *testing.T) {
+	root := writeContextRepo(t, map[string]string{"store/store.go": contextGoFile})
+
+	// The function (8 lines) doesn't fit; the package clause and imports do
+	expander := NewCodeContextExpander(root, CodeContextConfig{MaxLinesPerFile: 8, MaxTotalLines: 100})
+	got := expander.Expand(domain.Diff{Files: []domain.FileDiff{
+		{Path: "store/store.go", Status: domain.FileStatusModified, Patch: contextGoPatch},
+	}})["store/store.go"]
+	if !strings.Contains(got, "import (") || strings.Contains(got, "func Get") {
+		t.Errorf("expected imports only, got:\n%s", got)
+	}
+	if lines := strings.Count(got, " | "); lines > 8 {
+		t.Errorf("expected at most 8 lines, got %d", lines)
+	}
+
+	// Once the total budget is spent, later files get nothing
+	root = writeContextRepo(t, map[string]string{
+		"a/store.go": contextGoFile,
+		"b/store.go": contextGoFile,
+	})
+	expander = NewCodeContextExpander(root, CodeContextConfig{MaxLinesPerFile: 100, MaxTotalLines: 15})
+	contexts := expander.Expand(domain.Diff{Files: []domain.FileDiff{
+		{Path: "a/store.go", Status: domain.FileStatusModified, Patch: contextGoPatch},
+		{Path: "b/store.go", Status: domain.FileStatusModified, Patch: contextGoPatch},
+	}})
+	total := 0
+	for _, c := range contexts {
+		total += strings.Count(c, " | ")
+	}
+	if total > 15 {
+		t.Errorf("expected at most 15 lines in total, got %d", total)
+	}
+}
+
+func TestFormatDiff_WithCodeContext(t *testing.T) {
+	diff := domain.Diff{Files: []domain.FileDiff{
+		{Path: "store/store.go", Status: domain.FileStatusModified, Patch: contextGoPatch},
+		{Path: "README.md", Status: domain.FileStatusModified, Patch: "@@ -1 +1 @@\n-old\n+new\n"},
+	}}
+
+	builder := NewEnhancedPromptBuilder()
+	got := builder.formatDiff(diff, map[string]string{"store/store.go": " 1 | package store\n"})
+
+	if !strings.Contains(got, "Context for store/store.go") || !strings.Contains(got, " 1 | package store") {
+		t.Errorf("expected context section for store/store.go, got:\n%s", got)
+	}
+	if strings.Contains(got, "Context for README.md") {
+		t.Errorf("expected no context section for README.md, got:\n%s", got)
+	}
+}
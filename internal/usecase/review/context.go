@@ -3,10 +3,13 @@ package review
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/pathglob"
 )
 
 // ProjectContext holds all contextual information for a code review.
@@ -22,7 +25,13 @@ type ProjectContext struct {
 	PlanningAnswers    string   // Answers from interactive planning phase
 
 	// Automatically gathered context
-	RelevantDocs []string // Docs related to changed files
+	RelevantDocs     []string          // Docs related to changed files
+	PathInstructions []string          // Instructions from context rules matching the changed files
+	DirectoryContext []string          // .cr/context.md files covering the changed files
+	CodeContext      map[string]string // Imports and enclosing declarations per changed file path
+
+	// AffectedCallSites lists callers of the changed Go functions, nearest first
+	AffectedCallSites string
 
 	// Metadata
 	ChangedPaths []string // Paths of changed files
@@ -32,31 +41,95 @@ type ProjectContext struct {
 // ContextConfig controls what context to gather.
 type ContextConfig struct {
 	// Paths to search for docs
-	DesignDocsGlob string // Glob pattern for design docs (e.g., "docs/*_DESIGN.md")
+	DesignDocsGlobs []string // Glob patterns for design docs (e.g., "docs/*_DESIGN.md")
+
+	// DisableBuiltinRules turns off the built-in path heuristics (e.g. paths
+	// containing "auth" are tagged "auth") and the docs they pull in.
+	DisableBuiltinRules bool
+
+	// Rules map changed paths to change types, docs and instructions.
+	Rules []ContextRule
+
+	// Tags adds docs and instructions whenever a change type applies,
+	// whether it came from a rule or the built-in heuristics.
+	Tags map[string]ContextTag
+
+	// DirectoryContext includes .cr/context.md files from the directories
+	// of changed files and their parents.
+	DirectoryContext bool
 }
 
+// ContextRule applies when any changed path matches one of its globs.
+type ContextRule struct {
+	Paths        []string // Path globs; "**" matches any number of directories
+	Tags         []string // Change types added to the review
+	Docs         []string // Docs (paths or globs) included in the prompt
+	Instructions string   // Review instructions included in the prompt
+}
+
+// ContextTag holds the docs and instructions for one change type.
+type ContextTag struct {
+	Docs         []string // Docs (paths or globs) included in the prompt
+	Instructions string   // Review instructions included in the prompt
+}
+
+// DefaultContextConfig returns the built-in context gathering behavior.
+func DefaultContextConfig() ContextConfig {
+	return ContextConfig{
+		DesignDocsGlobs:  []string{"docs/*_DESIGN.md"},
+		DirectoryContext: true,
+	}
+}
+
+// directoryContextFile is the per-directory context file, relative to the
+// directory it describes.
+const directoryContextFile = ".cr/context.md"
+
 // ContextGatherer gathers project context for code reviews.
 type ContextGatherer struct {
 	repoDir string
 	config  ContextConfig
 }
 
-// NewContextGatherer creates a new context gatherer.
+// NewContextGatherer creates a new context gatherer with the default config.
 func NewContextGatherer(repoDir string) *ContextGatherer {
+	return NewContextGathererWithConfig(repoDir, DefaultContextConfig())
+}
+
+// NewContextGathererWithConfig creates a context gatherer with custom rules.
+// Tag names are matched case-insensitively.
+func NewContextGathererWithConfig(repoDir string, config ContextConfig) *ContextGatherer {
+	if len(config.Tags) > 0 {
+		tags := make(map[string]ContextTag, len(config.Tags))
+		for tag, value := range config.Tags {
+			tags[strings.ToLower(tag)] = value
+		}
+		config.Tags = tags
+	}
 	return &ContextGatherer{
 		repoDir: repoDir,
-		config: ContextConfig{
-			DesignDocsGlob: "docs/*_DESIGN.md",
-		},
+		config:  config,
 	}
 }
 
 // detectChangeTypes identifies the type of changes based on file paths.
-// Returns a slice of change type strings (e.g., "auth", "database", "api").
+// Returns a sorted slice of change type strings (e.g., "auth", "database", "api").
 func (g *ContextGatherer) detectChangeTypes(diff domain.Diff) []string {
 	typeSet := make(map[string]bool)
 
 	for _, file := range diff.Files {
+		for _, rule := range g.config.Rules {
+			if rule.matchesAny([]string{file.Path}) {
+				for _, tag := range rule.Tags {
+					typeSet[tag] = true
+				}
+			}
+		}
+
+		if g.config.DisableBuiltinRules {
+			continue
+		}
+
 		path := strings.ToLower(file.Path)
 
 		// Auth-related
@@ -113,6 +186,7 @@ func (g *ContextGatherer) detectChangeTypes(diff domain.Diff) []string {
 	for t := range typeSet {
 		types = append(types, t)
 	}
+	sort.Strings(types)
 
 	return types
 }
@@ -144,58 +218,165 @@ func (g *ContextGatherer) loadFile(path string) (string, error) {
 	return string(content), nil
 }
 
-// loadDesignDocs loads all design documents matching the glob pattern.
+// loadDesignDocs loads all design documents matching the glob patterns.
 func (g *ContextGatherer) loadDesignDocs() ([]string, error) {
-	pattern := filepath.Join(g.repoDir, g.config.DesignDocsGlob)
-	matches, err := filepath.Glob(pattern)
-	if err != nil {
-		return nil, fmt.Errorf("failed to glob design docs: %w", err)
-	}
-
 	var docs []string
-	for _, match := range matches {
-		content, err := os.ReadFile(match)
+	loaded := make(map[string]bool)
+	for _, glob := range g.config.DesignDocsGlobs {
+		matches, err := filepath.Glob(filepath.Join(g.repoDir, glob))
 		if err != nil {
-			continue // Skip files we can't read
+			return nil, fmt.Errorf("failed to glob design docs: %w", err)
 		}
 
-		relPath, _ := filepath.Rel(g.repoDir, match)
-		docs = append(docs, fmt.Sprintf("=== %s ===\n%s", relPath, string(content)))
+		for _, match := range matches {
+			relPath, _ := filepath.Rel(g.repoDir, match)
+			if loaded[relPath] {
+				continue
+			}
+			content, err := os.ReadFile(match)
+			if err != nil {
+				continue // Skip files we can't read
+			}
+
+			docs = append(docs, fmt.Sprintf("=== %s ===\n%s", relPath, string(content)))
+			loaded[relPath] = true
+		}
 	}
 
 	return docs, nil
 }
 
-// findRelevantDocs finds documentation related to the changed files.
-// changedPaths is currently unused but reserved for future path-based matching.
-func (g *ContextGatherer) findRelevantDocs(changedPaths []string, changeTypes []string) ([]string, error) {
-	var relevantDocs []string
+// builtinTagDocs maps the built-in change types to relevant doc files.
+var builtinTagDocs = map[string][]string{
+	"auth":     {"docs/SECURITY.md", "docs/AUTH_DESIGN.md"},
+	"database": {"docs/DATABASE_DESIGN.md"},
+	"security": {"docs/SECURITY.md"},
+}
 
-	// Map change types to relevant doc files
-	docMap := map[string][]string{
-		"auth":     {"docs/SECURITY.md", "docs/AUTH_DESIGN.md"},
-		"database": {"docs/DATABASE_DESIGN.md"},
-		"security": {"docs/SECURITY.md"},
+// findRelevantDocs finds documentation related to the changed files: docs of
+// the rules matching changedPaths, then docs of each change type.
+func (g *ContextGatherer) findRelevantDocs(changedPaths []string, changeTypes []string) ([]string, error) {
+	var docPaths []string
+	for _, rule := range g.config.Rules {
+		if rule.matchesAny(changedPaths) {
+			docPaths = append(docPaths, rule.Docs...)
+		}
+	}
+	for _, changeType := range changeTypes {
+		if !g.config.DisableBuiltinRules {
+			docPaths = append(docPaths, builtinTagDocs[changeType]...)
+		}
+		docPaths = append(docPaths, g.config.Tags[strings.ToLower(changeType)].Docs...)
 	}
 
+	var relevantDocs []string
+
 	// Track loaded paths to avoid duplicates
 	loadedPaths := make(map[string]bool)
 
-	// Load docs based on change types
+	for _, docPath := range g.expandDocPaths(docPaths) {
+		if loadedPaths[docPath] {
+			continue // Skip already loaded
+		}
+		content, err := g.loadFile(docPath)
+		if err == nil {
+			relevantDocs = append(relevantDocs, fmt.Sprintf("=== %s ===\n%s", docPath, content))
+			loadedPaths[docPath] = true
+		}
+	}
+
+	return relevantDocs, nil
+}
+
+// expandDocPaths resolves glob patterns among doc paths to the matching
+// repository files. Plain paths are returned as-is.
+func (g *ContextGatherer) expandDocPaths(docPaths []string) []string {
+	var expanded []string
+	for _, docPath := range docPaths {
+		if !strings.ContainsAny(docPath, "*?[") {
+			expanded = append(expanded, docPath)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(g.repoDir, docPath))
+		if err != nil {
+			continue
+		}
+		for _, match := range matches {
+			if relPath, err := filepath.Rel(g.repoDir, match); err == nil {
+				expanded = append(expanded, filepath.ToSlash(relPath))
+			}
+		}
+	}
+	return expanded
+}
+
+// findInstructions returns the instructions of the rules matching
+// changedPaths and of each change type, without duplicates.
+func (g *ContextGatherer) findInstructions(changedPaths []string, changeTypes []string) []string {
+	var instructions []string
+	seen := make(map[string]bool)
+	add := func(text string) {
+		text = strings.TrimSpace(text)
+		if text != "" && !seen[text] {
+			instructions = append(instructions, text)
+			seen[text] = true
+		}
+	}
+
+	for _, rule := range g.config.Rules {
+		if rule.matchesAny(changedPaths) {
+			add(rule.Instructions)
+		}
+	}
 	for _, changeType := range changeTypes {
-		if docPaths, ok := docMap[changeType]; ok {
-			for _, docPath := range docPaths {
-				if loadedPaths[docPath] {
-					continue // Skip already loaded
-				}
-				content, err := g.loadFile(docPath)
-				if err == nil {
-					relevantDocs = append(relevantDocs, fmt.Sprintf("=== %s ===\n%s", docPath, content))
-					loadedPaths[docPath] = true
-				}
+		add(g.config.Tags[strings.ToLower(changeType)].Instructions)
+	}
+	return instructions
+}
+
+// loadDirectoryContext loads the .cr/context.md files of the directories
+// containing changed files and of their parents, outermost first.
+func (g *ContextGatherer) loadDirectoryContext(changedPaths []string) []string {
+	if !g.config.DirectoryContext {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var dirs []string
+	for _, changed := range changedPaths {
+		var chain []string
+		for dir := path.Dir(filepath.ToSlash(changed)); ; dir = path.Dir(dir) {
+			chain = append(chain, dir)
+			if dir == "." || dir == "/" {
+				break
 			}
 		}
+		// Outermost directories first, so general notes precede specific ones
+		for i := len(chain) - 1; i >= 0; i-- {
+			if !seen[chain[i]] {
+				seen[chain[i]] = true
+				dirs = append(dirs, chain[i])
+			}
+		}
+	}
+	var contexts []string
+	for _, dir := range dirs {
+		contextPath := path.Join(dir, directoryContextFile)
+		content, err := g.loadFile(contextPath)
+		if err != nil {
+			continue
+		}
+		contexts = append(contexts, fmt.Sprintf("=== %s ===\n%s", contextPath, content))
 	}
+	return contexts
+}
 
-	return relevantDocs, nil
+// matchesAny reports whether any of the paths matches one of the rule's globs.
+func (r ContextRule) matchesAny(paths []string) bool {
+	for _, p := range paths {
+		if pathglob.MatchAny(r.Paths, p) {
+			return true
+		}
+	}
+	return false
 }
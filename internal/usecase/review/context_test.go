@@ -1,6 +1,7 @@
 package review
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/bkyoung/code-reviewer/internal/domain"
@@ -261,7 +262,7 @@ func TestLoadFile(t *testing.T) {
 
 func TestLoadDesignDocs(t *testing.T) {
 	gatherer := NewContextGatherer("testdata")
-	gatherer.config.DesignDocsGlob = "docs/*_DESIGN.md"
+	gatherer.config.DesignDocsGlobs = []string{"docs/*_DESIGN.md"}
 
 	docs, err := gatherer.loadDesignDocs()
 	if err != nil {
@@ -406,3 +407,115 @@ func hasSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestContextRules(t *testing.T) {
+	root := writeContextRepo(t, map[string]string{
+		"docs/billing/LEDGER.md": "Ledger entries are append-only.",
+		"docs/billing/TAX.md":    "Tax is computed per line item.",
+		"docs/SQL_STYLE.md":      "Use snake_case columns.",
+		"docs/SECURITY.md":       "Security guidelines.",
+		"docs/LIB_DESIGN.md":     "Library design.",
+	})
+	gatherer := NewContextGathererWithConfig(root, ContextConfig{
+		DesignDocsGlobs:     []string{"docs/LIB_DESIGN.md"},
+		DisableBuiltinRules: true,
+		Rules: []ContextRule{
+			{
+				Paths:        []string{"services/billing/**"},
+				Tags:         []string{"billing"},
+				Docs:         []string{"docs/billing/*.md"},
+				Instructions: "Amounts are integer cents; flag float arithmetic.",
+			},
+			{Paths: []string{"*.sql"}, Tags: []string{"database"}},
+		},
+		Tags: map[string]ContextTag{
+			"Database": {Docs: []string{"docs/SQL_STYLE.md"}, Instructions: "Migrations must be reversible."},
+		},
+	})
+
+	diff := domain.Diff{Files: []domain.FileDiff{
+		{Path: "services/billing/ledger.go"},
+		{Path: "services/billing/migrations/001.sql"},
+		{Path: "services/auth/login.go"},
+	}}
+
+	// Built-in heuristics are disabled, so auth/login.go is not tagged
+	changeTypes := gatherer.detectChangeTypes(diff)
+	if len(changeTypes) != 2 || changeTypes[0] != "billing" || changeTypes[1] != "database" {
+		t.Errorf("expected [billing database], got %v", changeTypes)
+	}
+
+	paths := []string{"services/billing/ledger.go", "services/billing/migrations/001.sql", "services/auth/login.go"}
+	docs, err := gatherer.findRelevantDocs(paths, changeTypes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(docs) != 3 {
+		t.Fatalf("expected 3 docs, got %d: %v", len(docs), docs)
+	}
+	for i, want := range []string{"docs/billing/LEDGER.md", "docs/billing/TAX.md", "docs/SQL_STYLE.md"} {
+		if !strings.HasPrefix(docs[i], "=== "+want+" ===") {
+			t.Errorf("doc %d: expected %s, got %q", i, want, docs[i])
+		}
+	}
+
+	instructions := gatherer.findInstructions(paths, changeTypes)
+	if len(instructions) != 2 || !strings.Contains(instructions[0], "integer cents") || !strings.Contains(instructions[1], "reversible") {
+		t.Errorf("unexpected instructions: %v", instructions)
+	}
+
+	designDocs, _ := gatherer.loadDesignDocs()
+	if len(designDocs) != 1 || !strings.Contains(designDocs[0], "Library design.") {
+		t.Errorf("expected the configured design doc, got %v", designDocs)
+	}
+}
+
+func TestContextRules_KeepBuiltinRules(t *testing.T) {
+	gatherer := NewContextGathererWithConfig("testdata", ContextConfig{
+		Rules: []ContextRule{{Paths: []string{"services/billing/**"}, Tags: []string{"billing"}}},
+	})
+
+	changeTypes := gatherer.detectChangeTypes(domain.Diff{Files: []domain.FileDiff{
+		{Path: "services/billing/auth.go"},
+	}})
+	if len(changeTypes) != 2 || changeTypes[0] != "auth" || changeTypes[1] != "billing" {
+		t.Errorf("expected [auth billing], got %v", changeTypes)
+	}
+
+	// Built-in tag docs are still included
+	docs, _ := gatherer.findRelevantDocs([]string{"services/billing/auth.go"}, changeTypes)
+	if len(docs) != 2 {
+		t.Errorf("expected SECURITY.md and AUTH_DESIGN.md, got %d docs", len(docs))
+	}
+}
+
+func TestLoadDirectoryContext(t *testing.T) {
+	root := writeContextRepo(t, map[string]string{
+		".cr/context.md":                  "Repository-wide notes.",
+		"services/.cr/context.md":         "All services use the shared logger.",
+		"services/billing/.cr/context.md": "Billing owns the ledger.",
+		"services/auth/.cr/context.md":    "Auth notes.",
+	})
+	gatherer := NewContextGatherer(root)
+
+	contexts := gatherer.loadDirectoryContext([]string{
+		"services/billing/internal/ledger.go",
+		"services/billing/api.go",
+		"README.md",
+	})
+
+	want := []string{".cr/context.md", "services/.cr/context.md", "services/billing/.cr/context.md"}
+	if len(contexts) != len(want) {
+		t.Fatalf("expected %d contexts, got %d: %v", len(want), len(contexts), contexts)
+	}
+	for i, path := range want {
+		if !strings.HasPrefix(contexts[i], "=== "+path+" ===") {
+			t.Errorf("context %d: expected %s, got %q", i, path, contexts[i])
+		}
+	}
+
+	disabled := NewContextGathererWithConfig(root, ContextConfig{})
+	if got := disabled.loadDirectoryContext([]string{"services/billing/api.go"}); len(got) != 0 {
+		t.Errorf("expected no directory context when disabled, got %v", got)
+	}
+}
@@ -17,11 +17,14 @@ func NewDiffComputer(git GitEngine) *DiffComputer {
 }
 
 // ComputeDiffForReview computes the cumulative diff for a review request.
-// It returns the diff between BaseRef and TargetRef, optionally including
-// uncommitted changes.
+// It returns the request's own Diff when set, otherwise the diff between
+// BaseRef and TargetRef, optionally including uncommitted changes.
 func (dc *DiffComputer) ComputeDiffForReview(
 	ctx context.Context,
 	req BranchRequest,
 ) (domain.Diff, error) {
+	if req.Diff != nil {
+		return *req.Diff, nil
+	}
 	return dc.git.GetCumulativeDiff(ctx, req.BaseRef, req.TargetRef, req.IncludeUncommitted)
 }
@@ -125,3 +125,25 @@ func TestDiffComputer_ComputeDiffForReview_WithUncommittedChanges(t *testing.T)
 		t.Errorf("Files count = %d, want 2", len(diff.Files))
 	}
 }
+
+func TestDiffComputer_ComputeDiffForReview_UsesRequestDiff(t *testing.T) {
+	ctx := context.Background()
+	git := &mockGitEngine{cumulativeDiffErr: errors.New("not a git repository")}
+	computer := NewDiffComputer(git)
+
+	patch := domain.Diff{Files: []domain.FileDiff{{Path: "main.go", Status: domain.FileStatusModified}}}
+	diff, err := computer.ComputeDiffForReview(ctx, BranchRequest{
+		BaseRef:   "patch",
+		TargetRef: "stdin",
+		Diff:      &patch,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if git.cumulativeDiffCalls != 0 {
+		t.Errorf("expected git not to be called, got %d calls", git.cumulativeDiffCalls)
+	}
+	if len(diff.Files) != 1 || diff.Files[0].Path != "main.go" {
+		t.Errorf("expected the request diff, got %+v", diff.Files)
+	}
+}
@@ -0,0 +1,122 @@
+package review
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bkyoung/code-reviewer/internal/domain"
+)
+
+// Output formats, selected with --format or output.formats.
+const (
+	FormatMarkdown    = "markdown"
+	FormatJSON        = "json"
+	FormatSARIF       = "sarif"
+	FormatHTML        = "html"
+	FormatJUnit       = "junit"
+	FormatCodeClimate = "codeclimate"
+	FormatRDJSON      = "rdjson"
+	FormatRDJSONL     = "rdjsonl"
+)
+
+// Formats lists every supported output format.
+var Formats = []string{
+	FormatMarkdown, FormatJSON, FormatSARIF, FormatHTML,
+	FormatJUnit, FormatCodeClimate, FormatRDJSON, FormatRDJSONL,
+}
+
+// DefaultFormats are written when no formats are selected. The HTML report
+// and the CI formats are opt-in.
+var DefaultFormats = []string{FormatMarkdown, FormatJSON, FormatSARIF}
+
+// ValidateFormats returns an error naming the first unsupported format.
+func ValidateFormats(formats []string) error {
+	for _, format := range formats {
+		if !slices.Contains(Formats, format) {
+			return fmt.Errorf("unsupported output format %q: must be one of %s", format, strings.Join(Formats, ", "))
+		}
+	}
+	return nil
+}
+
+// formatSet returns the selected formats as a set, falling back to
+// DefaultFormats when none are selected.
+func formatSet(formats []string) map[string]bool {
+	if len(formats) == 0 {
+		formats = DefaultFormats
+	}
+	set := make(map[string]bool, len(formats))
+	for _, format := range formats {
+		set[format] = true
+	}
+	return set
+}
+
+// defaultBlockingSeverities block when their review action is not set,
+// matching the code host publishers.
+var defaultBlockingSeverities = map[string]bool{"critical": true, "high": true}
+
+// blockingSeverities returns the severities whose review action is
+// request_changes, so report formats can mark the same findings as
+// blocking that the published review would.
+func blockingSeverities(req BranchRequest) []string {
+	var blocking []string
+	for _, a := range []struct {
+		severity string
+		action   string
+	}{
+		{"critical", req.ActionOnCritical},
+		{"high", req.ActionOnHigh},
+		{"medium", req.ActionOnMedium},
+		{"low", req.ActionOnLow},
+	} {
+		if actionBlocks(a.action, a.severity) {
+			blocking = append(blocking, a.severity)
+		}
+	}
+	return blocking
+}
+
+// actionBlocks reports whether a review action requests changes. Unset or
+// unknown actions fall back to the severity's default, as the publishers do.
+func actionBlocks(action, severity string) bool {
+	switch strings.ReplaceAll(strings.ToLower(strings.TrimSpace(action)), "-", "_") {
+	case "request_changes":
+		return true
+	case "approve", "comment":
+		return false
+	default:
+		return defaultBlockingSeverities[severity]
+	}
+}
+
+// Blocks reports whether a finding would block the change: its severity
+// is blocking or its category is always blocking. Path policies matching
+// the finding's file override the actions and categories, as they do for
+// the published review.
+func (a ReportArtifact) Blocks(f domain.Finding) bool {
+	severity := strings.ToLower(f.Severity)
+	blocking := slices.Contains(a.BlockingSeverities, severity)
+	categories := a.AlwaysBlockCategories
+
+	if len(a.PathPolicies) > 0 {
+		policy := a.PathPolicies.resolve(f.File)
+		if action := policy.action(severity); action != "" {
+			blocking = actionBlocks(action, severity)
+		}
+		if policy.AlwaysBlockCategories != nil {
+			categories = policy.AlwaysBlockCategories
+		}
+	}
+
+	if blocking {
+		return true
+	}
+	for _, category := range categories {
+		if strings.EqualFold(category, f.Category) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,236 @@
+package review
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bkyoung/code-reviewer/internal/diff"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/pathglob"
+)
+
+// generatedHeaderLines is how far into a file the generated-code header is
+// looked for.
+const generatedHeaderLines = 20
+
+// generatedHeader matches the "Code generated ... DO NOT EDIT." marker
+// (https://go.dev/s/generatedcode), in any common line-comment style.
+var generatedHeader = regexp.MustCompile(`^\s*(//|#|--|;|/\*|\*)\s*Code generated .*DO NOT EDIT\.?`)
+
+// lockfiles are dependency lockfiles, matched by file name.
+var lockfiles = map[string]bool{
+	"go.sum":              true,
+	"go.work.sum":         true,
+	"package-lock.json":   true,
+	"npm-shrinkwrap.json": true,
+	"yarn.lock":           true,
+	"pnpm-lock.yaml":      true,
+	"bun.lockb":           true,
+	"Cargo.lock":          true,
+	"poetry.lock":         true,
+	"Pipfile.lock":        true,
+	"uv.lock":             true,
+	"Gemfile.lock":        true,
+	"composer.lock":       true,
+	"mix.lock":            true,
+	"pubspec.lock":        true,
+	"Podfile.lock":        true,
+	"packages.lock.json":  true,
+	"gradle.lockfile":     true,
+	"flake.lock":          true,
+}
+
+// vendoredDirs are directories holding third-party code, at any depth.
+var vendoredDirs = []string{"vendor", "node_modules"}
+
+// gitAttribute is a linguist attribute set or unset for a path pattern in
+// .gitattributes.
+type gitAttribute struct {
+	pattern string
+	name    string
+	value   bool
+}
+
+// GeneratedFileDetector marks generated files, vendored code and lockfiles
+// in a diff, so they can be left out of the review.
+type GeneratedFileDetector struct {
+	repoDir    string
+	attributes []gitAttribute
+}
+
+// NewGeneratedFileDetector creates a detector for the repository at repoDir.
+// It reads linguist-generated and linguist-vendored from the root
+// .gitattributes; a missing or unreadable file is ignored.
+func NewGeneratedFileDetector(repoDir string) *GeneratedFileDetector {
+	return &GeneratedFileDetector{
+		repoDir:    repoDir,
+		attributes: loadLinguistAttributes(filepath.Join(repoDir, ".gitattributes")),
+	}
+}
+
+// Mark sets Generated on each file of the diff that is generated, vendored
+// or a lockfile. The returned diff shares no file slice with d.
+func (g *GeneratedFileDetector) Mark(d domain.Diff) domain.Diff {
+	files := make([]domain.FileDiff, len(d.Files))
+	for i, f := range d.Files {
+		f.Generated = g.Detect(f)
+		files[i] = f
+	}
+	d.Files = files
+	return d
+}
+
+// Detect returns why file is excluded from review (one of the
+// domain.Generated* constants), or "" when it should be reviewed.
+// .gitattributes settings take precedence over the built-in rules.
+func (g *GeneratedFileDetector) Detect(file domain.FileDiff) string {
+	generated, generatedSet := g.attribute(file.Path, "linguist-generated")
+	vendored, vendoredSet := g.attribute(file.Path, "linguist-vendored")
+
+	switch {
+	case generatedSet && generated:
+		return domain.GeneratedCode
+	case vendoredSet && vendored:
+		return domain.GeneratedVendored
+	}
+
+	if !vendoredSet && isVendoredPath(file.Path) {
+		return domain.GeneratedVendored
+	}
+	if !generatedSet {
+		if lockfiles[path.Base(file.Path)] {
+			return domain.GeneratedLockfile
+		}
+		if g.hasGeneratedHeader(file) {
+			return domain.GeneratedCode
+		}
+	}
+	return ""
+}
+
+// attribute resolves a linguist attribute for file; the last matching
+// .gitattributes line wins. ok is false when no line sets or unsets it.
+func (g *GeneratedFileDetector) attribute(file, name string) (value, ok bool) {
+	for _, attr := range g.attributes {
+		if attr.name == name && pathglob.Match(attr.pattern, file) {
+			value, ok = attr.value, true
+		}
+	}
+	return value, ok
+}
+
+// hasGeneratedHeader looks for the generated-code marker in the file's
+// first lines, from the patch when it shows them and otherwise from the
+// working tree.
+func (g *GeneratedFileDetector) hasGeneratedHeader(file domain.FileDiff) bool {
+	if file.IsBinary || file.Status == domain.FileStatusDeleted {
+		return false
+	}
+
+	if parsed, err := diff.Parse(file.Patch); err == nil {
+		for _, hunk := range parsed.Hunks {
+			for _, line := range hunk.Lines {
+				if line.NewLine == nil || *line.NewLine > generatedHeaderLines {
+					continue
+				}
+				if generatedHeader.MatchString(line.Content) {
+					return true
+				}
+			}
+		}
+		// A new file's patch shows all of its lines
+		if file.Status == domain.FileStatusAdded {
+			return false
+		}
+	}
+
+	if g.repoDir == "" {
+		return false
+	}
+	f, err := os.Open(filepath.Join(g.repoDir, filepath.FromSlash(file.Path)))
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for i := 0; i < generatedHeaderLines && scanner.Scan(); i++ {
+		if generatedHeader.MatchString(scanner.Text()) {
+			return true
+		}
+	}
+	return false
+}
+
+// isVendoredPath reports whether p is inside a vendored directory.
+func isVendoredPath(p string) bool {
+	dirs := strings.Split(path.Dir(p), "/")
+	for _, dir := range dirs {
+		for _, vendored := range vendoredDirs {
+			if dir == vendored {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// loadLinguistAttributes parses the linguist-generated and linguist-vendored
+// settings from a .gitattributes file. "attr" and "attr=true" set an
+// attribute; "-attr" and "attr=false" unset it.
+func loadLinguistAttributes(file string) []gitAttribute {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+
+	var attributes []gitAttribute
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		pattern := strings.TrimPrefix(fields[0], "/")
+		for _, field := range fields[1:] {
+			value := true
+			if strings.HasPrefix(field, "-") {
+				value = false
+				field = field[1:]
+			}
+			name, setting, hasSetting := strings.Cut(field, "=")
+			if name != "linguist-generated" && name != "linguist-vendored" {
+				continue
+			}
+			if hasSetting {
+				value = setting == "true" || setting == "set"
+			}
+			attributes = append(attributes, gitAttribute{pattern: pattern, name: name, value: value})
+		}
+	}
+	return attributes
+}
+
+// FilterGeneratedFiles separates a marked diff into the files to review and
+// the generated, vendored and lock files. Like binary files, the excluded
+// files stay in the full diff so the published summary can list them.
+func FilterGeneratedFiles(d domain.Diff) (reviewDiff domain.Diff, generatedFiles []domain.FileDiff) {
+	reviewFiles := make([]domain.FileDiff, 0, len(d.Files))
+	for _, f := range d.Files {
+		if f.Generated != "" {
+			generatedFiles = append(generatedFiles, f)
+		} else {
+			reviewFiles = append(reviewFiles, f)
+		}
+	}
+
+	reviewDiff = domain.Diff{
+		FromCommitHash: d.FromCommitHash,
+		ToCommitHash:   d.ToCommitHash,
+		Files:          reviewFiles,
+	}
+	return reviewDiff, generatedFiles
+}
@@ -0,0 +1,80 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/domain"
+)
+
+func TestGeneratedFileDetector_Detect(t *testing.T) {
+	root := writeContextRepo(t, map[string]string{
+		".gitattributes": "# generated assets\n" +
+			"*.pb.go linguist-generated\n" +
+			"/web/dist/** linguist-generated=true\n" +
+			"third_party/** linguist-vendored\n" +
+			"vendor/internal/** -linguist-vendored\n" +
+			"api/handwritten.pb.go linguist-generated=false\n",
+		"mocks/store.go":        "// Code generated by MockGen. DO NOT EDIT.\npackage mocks\n",
+		"store/store.go":        "package store\n\nfunc Get() {}\n",
+		"api/handwritten.pb.go": "// Code generated by hand. DO NOT EDIT.\npackage api\n",
+	})
+	detector := NewGeneratedFileDetector(root)
+
+	tests := []struct {
+		name string
+		file domain.FileDiff
+		want string
+	}{
+		{"gitattributes generated", domain.FileDiff{Path: "api/v1/api.pb.go", Status: domain.FileStatusModified}, domain.GeneratedCode},
+		{"anchored gitattributes pattern", domain.FileDiff{Path: "web/dist/app.js", Status: domain.FileStatusModified}, domain.GeneratedCode},
+		{"gitattributes vendored", domain.FileDiff{Path: "third_party/lib/lib.c", Status: domain.FileStatusAdded}, domain.GeneratedVendored},
+		{"gitattributes overrides header", domain.FileDiff{Path: "api/handwritten.pb.go", Status: domain.FileStatusModified}, ""},
+		{"gitattributes overrides vendor dir", domain.FileDiff{Path: "vendor/internal/fork.go", Status: domain.FileStatusModified}, ""},
+		{"vendor dir", domain.FileDiff{Path: "vendor/github.com/pkg/errors/errors.go", Status: domain.FileStatusModified}, domain.GeneratedVendored},
+		{"nested node_modules", domain.FileDiff{Path: "web/node_modules/left-pad/index.js", Status: domain.FileStatusAdded}, domain.GeneratedVendored},
+		{"lockfile", domain.FileDiff{Path: "go.sum", Status: domain.FileStatusModified}, domain.GeneratedLockfile},
+		{"nested lockfile", domain.FileDiff{Path: "web/package-lock.json", Status: domain.FileStatusModified}, domain.GeneratedLockfile},
+		{"header in working tree", domain.FileDiff{Path: "mocks/store.go", Status: domain.FileStatusModified, Patch: "@@ -3 +3 @@\n-func A() {}\n+func B() {}\n"}, domain.GeneratedCode},
+		{"header in new file patch", domain.FileDiff{Path: "gen/enum_string.go", Status: domain.FileStatusAdded, Patch: "@@ -0,0 +1,2 @@\n+// Code generated by \"stringer -type=Enum\"; DO NOT EDIT.\n+package gen\n"}, domain.GeneratedCode},
+		{"python header", domain.FileDiff{Path: "gen/schema.py", Status: domain.FileStatusAdded, Patch: "@@ -0,0 +1,2 @@\n+# Code generated by schemagen. DO NOT EDIT.\n+SCHEMA = {}\n"}, domain.GeneratedCode},
+		{"header far into the file", domain.FileDiff{Path: "doc/gen.go", Status: domain.FileStatusAdded, Patch: "@@ -30,0 +30 @@\n+// Code generated by x. DO NOT EDIT.\n"}, ""},
+		{"regular file", domain.FileDiff{Path: "store/store.go", Status: domain.FileStatusModified, Patch: "@@ -3 +3 @@\n-func A() {}\n+func Get() {}\n"}, ""},
+		{"vendor in file name only", domain.FileDiff{Path: "pkg/vendor.go", Status: domain.FileStatusModified}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detector.Detect(tt.file); got != tt.want {
+				t.Errorf("Detect(%s) = %q, want %q", tt.file.Path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGeneratedFileDetector_MarkAndFilter(t *testing.T) {
+	detector := NewGeneratedFileDetector(t.TempDir())
+	original := domain.Diff{
+		FromCommitHash: "abc",
+		ToCommitHash:   "def",
+		Files: []domain.FileDiff{
+			{Path: "main.go", Status: domain.FileStatusModified},
+			{Path: "yarn.lock", Status: domain.FileStatusModified},
+			{Path: "node_modules/x/index.js", Status: domain.FileStatusAdded},
+		},
+	}
+
+	marked := detector.Mark(original)
+	if original.Files[1].Generated != "" {
+		t.Error("expected Mark to leave the original diff unchanged")
+	}
+
+	reviewDiff, generated := FilterGeneratedFiles(marked)
+	if len(reviewDiff.Files) != 1 || reviewDiff.Files[0].Path != "main.go" {
+		t.Errorf("expected only main.go to be reviewed, got %+v", reviewDiff.Files)
+	}
+	if reviewDiff.FromCommitHash != "abc" || reviewDiff.ToCommitHash != "def" {
+		t.Errorf("expected commit hashes to be kept, got %q..%q", reviewDiff.FromCommitHash, reviewDiff.ToCommitHash)
+	}
+	if len(generated) != 2 || generated[0].Generated != domain.GeneratedLockfile || generated[1].Generated != domain.GeneratedVendored {
+		t.Errorf("expected the lockfile and vendored file to be skipped, got %+v", generated)
+	}
+}
@@ -0,0 +1,246 @@
+package review
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/bkyoung/code-reviewer/internal/diff"
+	"github.com/bkyoung/code-reviewer/internal/domain"
+)
+
+// CallSite is a use of a changed function elsewhere in the repository.
+type CallSite struct {
+	File      string // Repository-relative path
+	Line      int    // 1-indexed line number
+	Enclosing string // Function containing the call, e.g. "Handler.Serve"
+	Content   string // Trimmed source line
+}
+
+// CallSiteFinder locates the call sites of the Go function or method whose
+// name is declared at file:line. symbol is the declaration's resolved name,
+// e.g. "Handler.Serve" or "config.Load".
+type CallSiteFinder interface {
+	CallSites(file string, line int) (symbol string, sites []CallSite, err error)
+}
+
+// ImpactConfig limits the call sites added to the prompt.
+type ImpactConfig struct {
+	// MaxCallSites caps the call sites listed across all changed functions.
+	MaxCallSites int
+
+	// MaxPerFunction caps the call sites listed for one changed function.
+	MaxPerFunction int
+}
+
+// DefaultImpactConfig returns sensible defaults.
+func DefaultImpactConfig() ImpactConfig {
+	return ImpactConfig{
+		MaxCallSites:   40,
+		MaxPerFunction: 10,
+	}
+}
+
+// ImpactAnalyzer finds the Go functions and methods changed by a diff and
+// lists their callers, so reviewers see the other side of a signature or
+// behavior change.
+type ImpactAnalyzer struct {
+	repoDir string
+	finder  CallSiteFinder
+	config  ImpactConfig
+}
+
+// NewImpactAnalyzer creates an analyzer that reads changed files from the
+// working tree at repoDir and looks up callers with finder.
+func NewImpactAnalyzer(repoDir string, finder CallSiteFinder, config ImpactConfig) *ImpactAnalyzer {
+	defaults := DefaultImpactConfig()
+	if config.MaxCallSites <= 0 {
+		config.MaxCallSites = defaults.MaxCallSites
+	}
+	if config.MaxPerFunction <= 0 {
+		config.MaxPerFunction = defaults.MaxPerFunction
+	}
+	return &ImpactAnalyzer{repoDir: repoDir, finder: finder, config: config}
+}
+
+// changedFunc is a Go function or method touched by the diff.
+type changedFunc struct {
+	name             string // Declared name, shown if the finder resolves no symbol
+	file             string
+	nameLine         int
+	decl             lineRange
+	signatureChanged bool
+}
+
+// Analyze returns the rendered "affected call sites" section for the diff,
+// or "" when no changed function has callers outside itself.
+func (a *ImpactAnalyzer) Analyze(d domain.Diff) string {
+	funcs := a.changedFuncs(d)
+	// Signature changes are the most likely to break callers
+	sort.SliceStable(funcs, func(i, j int) bool {
+		return funcs[i].signatureChanged && !funcs[j].signatureChanged
+	})
+
+	var sb strings.Builder
+	remaining := a.config.MaxCallSites
+	for _, fn := range funcs {
+		if remaining <= 0 {
+			break
+		}
+		symbol, sites, err := a.finder.CallSites(fn.file, fn.nameLine)
+		if err != nil {
+			continue
+		}
+		if symbol == "" {
+			symbol = fn.name
+		}
+		sites = externalCallSites(fn, sites)
+		if len(sites) == 0 {
+			continue
+		}
+		rankCallSites(fn.file, sites)
+
+		limit := a.config.MaxPerFunction
+		if remaining < limit {
+			limit = remaining
+		}
+		shown := sites
+		if len(shown) > limit {
+			shown = shown[:limit]
+		}
+		remaining -= len(shown)
+
+		change := "changed"
+		if fn.signatureChanged {
+			change = "signature changed"
+		}
+		sb.WriteString(fmt.Sprintf("%s (%s:%d, %s), %d call site(s):\n", symbol, fn.file, fn.nameLine, change, len(sites)))
+		for _, site := range shown {
+			sb.WriteString(fmt.Sprintf("- %s:%d", site.File, site.Line))
+			if site.Enclosing != "" {
+				sb.WriteString(" in " + site.Enclosing)
+			}
+			sb.WriteString(": " + site.Content + "\n")
+		}
+		if hidden := len(sites) - len(shown); hidden > 0 {
+			sb.WriteString(fmt.Sprintf("- ... %d more not shown\n", hidden))
+		}
+		sb.WriteString("\n")
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// changedFuncs returns the functions and methods in non-test Go files whose
+// declarations overlap the diff's changes.
+func (a *ImpactAnalyzer) changedFuncs(d domain.Diff) []changedFunc {
+	gatherer := NewContextGatherer(a.repoDir)
+	var funcs []changedFunc
+	for _, f := range d.Files {
+		if !strings.HasSuffix(f.Path, ".go") || strings.HasSuffix(f.Path, "_test.go") ||
+			f.Status == domain.FileStatusDeleted || f.IsBinary || f.Patch == "" {
+			continue
+		}
+		content, err := gatherer.loadFile(f.Path)
+		if err != nil {
+			continue
+		}
+		parsed, err := diff.Parse(f.Patch)
+		if err != nil || len(parsed.Hunks) == 0 {
+			continue
+		}
+		if !matchesWorkingTree(parsed, strings.Split(content, "\n")) {
+			continue
+		}
+
+		fset := token.NewFileSet()
+		file, _ := parser.ParseFile(fset, f.Path, content, parser.SkipObjectResolution)
+		if file == nil || file.Name == nil {
+			continue
+		}
+		line := func(pos token.Pos) int { return fset.Position(pos).Line }
+		changed := changedRanges(parsed)
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Name == nil {
+				continue
+			}
+			declRange := lineRange{line(fn.Pos()), line(fn.End())}
+			signature := lineRange{line(fn.Pos()), line(fn.Type.End())}
+			if !overlapsAny(declRange, changed) {
+				continue
+			}
+
+			funcs = append(funcs, changedFunc{
+				name:             fn.Name.Name,
+				file:             f.Path,
+				nameLine:         line(fn.Name.Pos()),
+				decl:             declRange,
+				signatureChanged: overlapsAny(signature, changed),
+			})
+		}
+	}
+	return funcs
+}
+
+// overlapsAny reports whether r overlaps any of the ranges.
+func overlapsAny(r lineRange, ranges []lineRange) bool {
+	for _, c := range ranges {
+		if c.start <= r.end && c.end >= r.start {
+			return true
+		}
+	}
+	return false
+}
+
+// externalCallSites drops call sites inside the changed function itself
+// (recursion), which the diff already shows.
+func externalCallSites(fn changedFunc, sites []CallSite) []CallSite {
+	out := sites[:0:0]
+	for _, site := range sites {
+		if site.File == fn.file && site.Line >= fn.decl.start && site.Line <= fn.decl.end {
+			continue
+		}
+		out = append(out, site)
+	}
+	return out
+}
+
+// rankCallSites orders call sites by proximity to the changed file: same
+// file, then same package, then by directory distance. Tests come last.
+func rankCallSites(file string, sites []CallSite) {
+	sort.SliceStable(sites, func(i, j int) bool {
+		di, dj := callSiteDistance(file, sites[i].File), callSiteDistance(file, sites[j].File)
+		if di != dj {
+			return di < dj
+		}
+		if sites[i].File != sites[j].File {
+			return sites[i].File < sites[j].File
+		}
+		return sites[i].Line < sites[j].Line
+	})
+}
+
+// callSiteDistance is 0 for the same file, 1 for the same directory, and
+// grows with the number of directories between the two paths.
+func callSiteDistance(from, to string) int {
+	distance := 0
+	if strings.HasSuffix(to, "_test.go") {
+		distance += 1000
+	}
+	if from == to {
+		return distance
+	}
+
+	fromDirs := strings.Split(path.Dir(from), "/")
+	toDirs := strings.Split(path.Dir(to), "/")
+	common := 0
+	for common < len(fromDirs) && common < len(toDirs) && fromDirs[common] == toDirs[common] {
+		common++
+	}
+	return distance + 1 + (len(fromDirs) - common) + (len(toDirs) - common)
+}
@@ -0,0 +1,156 @@
+package review
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/domain"
+)
+
+// fakeCallSiteFinder returns symbols and call sites keyed by "file:line".
+type fakeCallSiteFinder struct {
+	symbols map[string]string
+	sites   map[string][]CallSite
+	queries []string
+}
+
+func (f *fakeCallSiteFinder) CallSites(file string, line int) (string, []CallSite, error) {
+	key := fmt.Sprintf("%s:%d", file, line)
+	f.queries = append(f.queries, key)
+	return f.symbols[key], f.sites[key], nil
+}
+
+const impactGoFile = `package store
+
+type Store struct {
+	items map[string]string
+}
+
+// Get returns the value for key.
+func (s *Store) Get(key string, fallback string) string {
+	if v, ok := s.items[key]; ok {
+		return v
+	}
+	return s.Get(fallback, "")
+}
+
+func Keys(s *Store) []string {
+	var keys []string
+	for k := range s.items {
+		keys = append(keys, k)
+	}
+	return keys
+}
+`
+
+// impactGoPatch changes the signature of Store.Get (line 8).
+const impactGoPatch = `@@ -5,9 +5,9 @@ type Store struct {
+ }
+ 
+ // Get returns the value for key.
+-func (s *Store) Get(key string) string {
++func (s *Store) Get(key string, fallback string) string {
+ 	if v, ok := s.items[key]; ok {
+ 		return v
+ 	}
+-	return ""
++	return s.Get(fallback, "")
+ }
+`
+
+func TestImpactAnalyzer_ListsCallersOfChangedFunctions(t *testing.T) {
+	root := writeContextRepo(t, map[string]string{"store/store.go": impactGoFile})
+	finder := &fakeCallSiteFinder{symbols: map[string]string{"store/store.go:8": "Store.Get"}, sites: map[string][]CallSite{
+		"store/store.go:8": {
+			{File: "store/store_test.go", Line: 9, Enclosing: "TestGet", Content: `s.Get("a")`},
+			{File: "cmd/app/main.go", Line: 20, Enclosing: "main", Content: `st.Get(os.Args[1])`},
+			{File: "store/store.go", Line: 12, Enclosing: "Store.Get", Content: `return s.Get(fallback, "")`},
+			{File: "store/cache.go", Line: 30, Enclosing: "Cache.Load", Content: `c.store.Get(key)`},
+			{File: "store/store.go", Line: 40, Enclosing: "Store.Must", Content: `v := s.Get(key)`},
+		},
+		// Keys is unchanged, so it must not be queried
+		"store/store.go:15": {{File: "api/api.go", Line: 1, Content: "store.Keys(s)"}},
+	}}
+	analyzer := NewImpactAnalyzer(root, finder, DefaultImpactConfig())
+
+	got := analyzer.Analyze(domain.Diff{Files: []domain.FileDiff{
+		{Path: "store/store.go", Status: domain.FileStatusModified, Patch: impactGoPatch},
+	}})
+
+	if len(finder.queries) != 1 || finder.queries[0] != "store/store.go:8" {
+		t.Errorf("expected only Store.Get to be queried, got %v", finder.queries)
+	}
+
+	want := `Store.Get (store/store.go:8, signature changed), 4 call site(s):
+- store/store.go:40 in Store.Must: v := s.Get(key)
+- store/cache.go:30 in Cache.Load: c.store.Get(key)
+- cmd/app/main.go:20 in main: st.Get(os.Args[1])
+- store/store_test.go:9 in TestGet: s.Get("a")
+`
+	if got != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestImpactAnalyzer_RespectsLimits(t *testing.T) {
+	root := writeContextRepo(t, map[string]string{"store/store.go": impactGoFile})
+	var sites []CallSite
+	for i := 1; i <= 5; i++ {
+		sites = append(sites, CallSite{File: "api/api.go", Line: i, Content: "s.Get(k)"})
+	}
+	finder := &fakeCallSiteFinder{sites: map[string][]CallSite{"store/store.go:8": sites}}
+
+	analyzer := NewImpactAnalyzer(root, finder, ImpactConfig{MaxCallSites: 10, MaxPerFunction: 2})
+	got := analyzer.Analyze(domain.Diff{Files: []domain.FileDiff{
+		{Path: "store/store.go", Status: domain.FileStatusModified, Patch: impactGoPatch},
+	}})
+
+	if strings.Count(got, "- api/api.go:") != 2 {
+		t.Errorf("expected 2 call sites shown, got:\n%s", got)
+	}
+	if !strings.Contains(got, "5 call site(s)") || !strings.Contains(got, "3 more not shown") {
+		t.Errorf("expected the hidden call sites to be counted, got:\n%s", got)
+	}
+}
+
+func TestImpactAnalyzer_SkipsUnusableFiles(t *testing.T) {
+	root := writeContextRepo(t, map[string]string{
+		"store/store.go":      impactGoFile,
+		"store/store_test.go": impactGoFile,
+	})
+	finder := &fakeCallSiteFinder{}
+	analyzer := NewImpactAnalyzer(root, finder, DefaultImpactConfig())
+
+	changedBody := strings.Replace(impactGoPatch, "+	return s.Get(fallback, \"\")", "+	return \"changed\"", 1)
+	got := analyzer.Analyze(domain.Diff{Files: []domain.FileDiff{
+		// Test files, non-Go files and deleted files are ignored
+		{Path: "store/store_test.go", Status: domain.FileStatusModified, Patch: impactGoPatch},
+		{Path: "README.md", Status: domain.FileStatusModified, Patch: "@@ -1 +1 @@\n-a\n+b\n"},
+		{Path: "old/old.go", Status: domain.FileStatusDeleted, Patch: "@@ -1 +0,0 @@\n-package old\n"},
+		// The working tree doesn't match this patch
+		{Path: "store/store.go", Status: domain.FileStatusModified, Patch: changedBody},
+	}})
+
+	if got != "" || len(finder.queries) != 0 {
+		t.Errorf("expected no analysis, got %q (queries %v)", got, finder.queries)
+	}
+}
+
+func TestCallSiteDistance(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     int
+	}{
+		{"a/b/x.go", "a/b/x.go", 0},
+		{"a/b/x.go", "a/b/y.go", 1},
+		{"a/b/x.go", "a/c/y.go", 3},
+		{"a/b/x.go", "z/y.go", 4},
+		{"a/b/x.go", "a/b/x_test.go", 1001},
+	}
+	for _, tt := range tests {
+		if got := callSiteDistance(tt.from, tt.to); got != tt.want {
+			t.Errorf("callSiteDistance(%q, %q) = %d, want %d", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package review
+
+import (
+	"context"
+	"time"
+
+	"github.com/bkyoung/code-reviewer/internal/domain"
+)
+
+// MetricsWriter persists the metrics of a review run.
+type MetricsWriter interface {
+	// Scope returns a context that collects the run's own LLM call
+	// statistics, so runs sharing a process report only their own calls.
+	// Write must be called with the returned context.
+	Scope(ctx context.Context) context.Context
+
+	Write(ctx context.Context, artifact MetricsArtifact) (string, error)
+}
+
+// MetricsArtifact encapsulates the metrics generation inputs.
+type MetricsArtifact struct {
+	OutputDir  string
+	Repository string
+	BaseRef    string
+	TargetRef  string
+	Metrics    RunMetrics
+
+	// PrometheusFile, when set, also receives the metrics in Prometheus
+	// text format (e.g. for the node_exporter textfile collector).
+	PrometheusFile string
+}
+
+// RunMetrics counts what each stage of a review run did.
+type RunMetrics struct {
+	Duration     time.Duration
+	Providers    map[string]ProviderMetrics
+	Merge        MergeMetrics
+	Verification VerificationMetrics
+	Publish      PublishMetrics
+}
+
+// ProviderMetrics describes one provider's review. FallbackFrom lists the
+// models that failed before Model produced it.
+type ProviderMetrics struct {
+	Model        string
+	FallbackFrom []string
+	Duration     time.Duration
+	TokensIn     int
+	TokensOut    int
+	Cost         float64
+	Findings     int
+}
+
+// MergeMetrics describes the merge of the provider reviews.
+type MergeMetrics struct {
+	InputFindings int // Findings across all provider reviews
+	Findings      int // Findings after merging similar ones
+}
+
+// VerificationMetrics describes the verification stage. Enabled is false
+// when verification was skipped or failed.
+type VerificationMetrics struct {
+	Enabled    bool
+	Candidates int
+	Verified   int
+	Reportable int
+	ToolCalls  int
+	TokensIn   int
+	TokensOut  int
+	Cost       float64
+}
+
+// PublishMetrics describes the review posted to the code host. Enabled is
+// false when nothing was posted.
+type PublishMetrics struct {
+	Enabled           bool
+	CommentsPosted    int
+	CommentsSkipped   int
+	DuplicatesSkipped int
+}
+
+// countVerified counts the findings that verification confirmed.
+func countVerified(verified []domain.VerifiedFinding) int {
+	n := 0
+	for _, v := range verified {
+		if v.Verified {
+			n++
+		}
+	}
+	return n
+}
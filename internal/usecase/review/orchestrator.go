@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"maps"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -71,6 +74,49 @@ type SARIFArtifact struct {
 	ProviderName string
 }
 
+// ReportWriter persists a review in one output format, e.g. JUnit XML,
+// Code Climate JSON or reviewdog's rdjson. Register writers in Outputs.
+type ReportWriter interface {
+	Write(ctx context.Context, artifact ReportArtifact) (string, error)
+}
+
+// ReportArtifact encapsulates the inputs of one review's output.
+type ReportArtifact struct {
+	OutputDir    string
+	Repository   string
+	BaseRef      string
+	TargetRef    string
+	Diff         domain.Diff
+	ConfigHash   string // Identifies the review settings, as in the run history
+	Review       domain.Review
+	ProviderName string
+
+	// BlockingSeverities and AlwaysBlockCategories mark the findings that
+	// would block the change, for formats that tell them apart (e.g. JUnit
+	// failures). PathPolicies override them per file.
+	BlockingSeverities    []string
+	AlwaysBlockCategories []string
+	PathPolicies          PathPolicies
+}
+
+// RunWriter renders a whole review run as one file, such as the HTML
+// report. Register writers in Outputs.
+type RunWriter interface {
+	Write(ctx context.Context, artifact RunArtifact) (string, error)
+}
+
+// RunArtifact encapsulates the inputs of a run-wide output: the diff, the
+// merged review (with its verification results) and each provider's review.
+type RunArtifact struct {
+	OutputDir  string
+	Repository string
+	BaseRef    string
+	TargetRef  string
+	Diff       domain.Diff
+	Merged     domain.Review
+	Reviews    []domain.Review
+}
+
 // SeedFunc generates deterministic seeds per review scope.
 type SeedFunc func(baseRef, targetRef string) uint64
 
@@ -92,9 +138,12 @@ type Store interface {
 	Close() error
 }
 
-// GitHubPoster defines the outbound port for posting reviews to GitHub PRs.
-type GitHubPoster interface {
-	PostReview(ctx context.Context, req GitHubPostRequest) (*GitHubPostResult, error)
+// ReviewPublisher defines the outbound port for publishing a review to a code
+// host pull/merge request (GitHub, GitLab, Gitea, Bitbucket Cloud).
+// Implementations own platform-specific positioning, deduplication and
+// stale-review handling.
+type ReviewPublisher interface {
+	PublishReview(ctx context.Context, req PublishRequest) (*PublishResult, error)
 }
 
 // Verifier verifies candidate findings before reporting.
@@ -106,8 +155,19 @@ type Verifier interface {
 	VerifyBatch(ctx context.Context, candidates []domain.CandidateFinding) ([]domain.VerificationResult, error)
 }
 
-// GitHubPostRequest contains all data needed to post a review to GitHub.
-type GitHubPostRequest struct {
+// DepthVerifier is implemented by verifiers that select their strategy from the
+// verification depth. When the configured Verifier implements it, the review's
+// VerificationSettings.Depth is passed through.
+type DepthVerifier interface {
+	VerifyBatchAtDepth(ctx context.Context, depth string, candidates []domain.CandidateFinding) ([]domain.VerificationResult, error)
+}
+
+// PublishRequest contains all data needed to publish a review to a code host.
+type PublishRequest struct {
+	// Platform is the code host to publish to: "github" (default), "gitlab",
+	// "gitea" or "bitbucket". Owner is the user, organization, namespace path
+	// or workspace; PRNumber is the pull request number or merge request IID.
+	Platform  string
 	Owner     string
 	Repo      string
 	PRNumber  int
@@ -115,7 +175,7 @@ type GitHubPostRequest struct {
 	Review    domain.Review
 	Diff      domain.Diff // For calculating diff positions
 
-	// ReviewActions configures the GitHub review action for each severity level.
+	// ReviewActions configures the review action for each severity level.
 	// Empty values use sensible defaults.
 	ActionOnCritical    string
 	ActionOnHigh        string
@@ -127,18 +187,26 @@ type GitHubPostRequest struct {
 	// AlwaysBlockCategories lists finding categories that always trigger REQUEST_CHANGES.
 	AlwaysBlockCategories []string
 
+	// PathPolicies override the review actions and always-block categories
+	// for findings in matching files.
+	PathPolicies PathPolicies
+
 	// BotUsername is the bot username for auto-dismissing stale reviews.
 	// If set, previous reviews from this user are dismissed AFTER the new
 	// review posts successfully. This ensures the PR always has review signal.
 	BotUsername string
 }
 
-// GitHubPostResult contains the result of posting a review.
-type GitHubPostResult struct {
-	ReviewID        int64
+// PublishResult contains the result of publishing a review.
+type PublishResult struct {
+	ReviewID        string // Platform review/discussion/comment ID of the summary
 	CommentsPosted  int
 	CommentsSkipped int
 	HTMLURL         string
+
+	// DuplicatesSkipped counts findings not posted because an earlier
+	// review already reported them.
+	DuplicatesSkipped int
 }
 
 // StorePrecisionPrior represents precision tracking for a provider/category combination.
@@ -191,18 +259,47 @@ type OrchestratorDeps struct {
 	Git           GitEngine
 	Providers     map[string]Provider
 	Merger        Merger
-	Markdown      MarkdownWriter
-	JSON          JSONWriter
-	SARIF         SARIFWriter
+	Markdown      MarkdownWriter // Optional: shorthand for registering the markdown output
+	JSON          JSONWriter     // Optional: shorthand for registering the json output
+	SARIF         SARIFWriter    // Optional: shorthand for registering the sarif output
 	Redactor      Redactor
 	SeedGenerator SeedFunc
 	PromptBuilder PromptBuilder
-	Store         Store          // Optional: persistence layer for review history
-	Logger        Logger         // Optional: structured logging for warnings and info
-	PlanningAgent *PlanningAgent // Optional: interactive planning agent (only works in TTY mode)
-	RepoDir       string         // Repository directory for context gathering (optional)
-	GitHubPoster  GitHubPoster   // Optional: posts review to GitHub PR with inline comments
-	DiffComputer  *DiffComputer  // Optional: computes diffs (auto-created if nil)
+	Store         Store           // Optional: persistence layer for review history
+	Logger        Logger          // Optional: structured logging for warnings and info
+	Tracer        Tracer          // Optional: traces the review stages (no-op if nil)
+	PlanningAgent *PlanningAgent  // Optional: interactive planning agent (only works in TTY mode)
+	RepoDir       string          // Repository directory for context gathering (optional)
+	Publisher     ReviewPublisher // Optional: publishes review to the PR/MR with inline comments
+	DiffComputer  *DiffComputer   // Optional: computes diffs (auto-created if nil)
+
+	// CodeContext adds enclosing declarations and imports to each diff hunk (optional)
+	CodeContext *CodeContextExpander
+
+	// Impact lists callers of changed Go functions (optional)
+	Impact *ImpactAnalyzer
+
+	// ContextConfig holds the context gathering rules (optional, defaults if nil)
+	ContextConfig *ContextConfig
+
+	// PathPolicies loads the per-path review policies from .cr/rules.yaml
+	// at the review's base ref (optional)
+	PathPolicies PathPolicyLoader
+
+	// GeneratedFiles detects generated, vendored and lock files to skip (optional)
+	GeneratedFiles *GeneratedFileDetector
+
+	// Metrics writes the run metrics artifact (optional)
+	Metrics MetricsWriter
+
+	// Outputs holds the writers for each output format (optional). The
+	// Markdown, JSON and SARIF writers are added to it unless it already
+	// has those formats.
+	Outputs *Outputs
+
+	// Stdout receives the merged review when BranchRequest.Stdout is set
+	// (os.Stdout if nil)
+	Stdout io.Writer
 
 	// Verification support (Epic #92)
 	Verifier Verifier // Optional: verifies candidate findings before reporting
@@ -231,14 +328,31 @@ type BranchRequest struct {
 	ContextFiles       []string // Optional: additional context files to include
 	NoArchitecture     bool     // Skip loading ARCHITECTURE.md
 	NoAutoContext      bool     // Disable automatic context gathering (design docs, relevant docs)
+	NoCodeContext      bool     // Disable enclosing-declaration context around diff hunks
+	NoImpactAnalysis   bool     // Disable listing callers of changed Go functions
+	IncludeGenerated   bool     // Review generated, vendored and lock files too
+	MetricsFile        string   // Optional: also write run metrics in Prometheus text format here
+	Formats            []string // Output formats to write; the registered DefaultFormats if empty
+	MergedOnly         bool     // Write outputs for the merged review only, not for each provider
+	Stdout             string   // Optional: also write the merged review in this format to stdout
 	Interactive        bool     // Enable interactive planning mode (requires TTY)
 
-	// GitHub integration fields (for posting inline review comments)
-	PostToGitHub bool   // Enable posting review to GitHub PR
-	GitHubOwner  string // Repository owner (user or org)
-	GitHubRepo   string // Repository name
-	PRNumber     int    // Pull request number
-	CommitSHA    string // Head commit SHA for the review
+	// Diff, when set, is reviewed instead of the diff between BaseRef and
+	// TargetRef (e.g. a patch read from a file). BaseRef and TargetRef then
+	// only label the review.
+	Diff *domain.Diff
+
+	// NoRepository skips everything read from the working tree (project
+	// docs, code context, call sites), for patches reviewed outside a repository.
+	NoRepository bool
+
+	// Code host integration fields (for posting inline review comments)
+	PostReview bool   // Enable publishing the review to the PR/MR on Platform
+	Platform   string // Code host: "github" (default), "gitlab", "gitea", "bitbucket"
+	RepoOwner  string // Repository owner (user, org, namespace path or workspace)
+	RepoName   string // Repository name or slug
+	PRNumber   int    // Pull request number (merge request IID on GitLab)
+	CommitSHA  string // Head commit SHA for the review
 
 	// Review action configuration (configures GitHub review action per severity)
 	// Values: "approve", "comment", "request_changes" (case-insensitive)
@@ -300,8 +414,13 @@ type Result struct {
 	MarkdownPaths map[string]string
 	JSONPaths     map[string]string
 	SARIFPaths    map[string]string
+	Paths         map[string]map[string]string // Format -> review name -> path; run-wide outputs are under "merged"
 	Reviews       []domain.Review
-	GitHubResult  *GitHubPostResult // Set when PostToGitHub is enabled
+	PublishResult *PublishResult // Set when PostReview is enabled
+	MetricsPath   string         // Set when a MetricsWriter is configured
+	BaseSHA       string         // Commit the reviewed diff starts from
+	TargetSHA     string         // Commit the reviewed diff ends at
+	ConfigHash    string         // Identifies the review settings, as in the run history
 }
 
 // Orchestrator implements the core review flow for Phase 1.
@@ -316,6 +435,14 @@ func NewOrchestrator(deps OrchestratorDeps) *Orchestrator {
 	if deps.DiffComputer == nil && deps.Git != nil {
 		deps.DiffComputer = NewDiffComputer(deps.Git)
 	}
+	if deps.Tracer == nil {
+		deps.Tracer = noopTracer{}
+	}
+	deps.Tracer = progressTracer{next: deps.Tracer}
+	deps.Outputs = deps.Outputs.withShorthands(deps.Markdown, deps.JSON, deps.SARIF)
+	if deps.Stdout == nil {
+		deps.Stdout = os.Stdout
+	}
 	return &Orchestrator{deps: deps}
 }
 
@@ -330,15 +457,6 @@ func (o *Orchestrator) validateDependencies() error {
 	if o.deps.Merger == nil {
 		return errors.New("merger is required")
 	}
-	if o.deps.Markdown == nil {
-		return errors.New("markdown writer is required")
-	}
-	if o.deps.JSON == nil {
-		return errors.New("json writer is required")
-	}
-	if o.deps.SARIF == nil {
-		return errors.New("sarif writer is required")
-	}
 	if o.deps.PromptBuilder == nil {
 		return errors.New("prompt builder is required")
 	}
@@ -362,27 +480,103 @@ func (o *Orchestrator) ReviewBranch(ctx context.Context, req BranchRequest) (Res
 	if err := validateRequest(req); err != nil {
 		return Result{}, err
 	}
+	if req.Stdout != "" && !o.deps.Outputs.Has(req.Stdout) {
+		return Result{}, fmt.Errorf("no writer registered for stdout format %q", req.Stdout)
+	}
+
+	ctx, span := o.deps.Tracer.StartSpan(ctx, "review", map[string]interface{}{
+		"baseRef":    req.BaseRef,
+		"targetRef":  req.TargetRef,
+		"repository": req.Repository,
+		"providers":  len(o.deps.Providers),
+	})
+	defer span.End()
+
+	result, err := o.reviewBranch(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		return result, err
+	}
+
+	if n := len(result.Reviews); n > 0 {
+		merged := result.Reviews[n-1]
+		span.SetAttributes(map[string]interface{}{
+			"findings":  len(merged.Findings),
+			"tokensIn":  merged.TokensIn,
+			"tokensOut": merged.TokensOut,
+			"cost":      merged.Cost + merged.VerificationCost,
+		})
+	}
+	return result, nil
+}
+
+// reviewBranch runs the review stages, each in its own span.
+func (o *Orchestrator) reviewBranch(ctx context.Context, req BranchRequest) (Result, error) {
+	started := time.Now()
+	if o.deps.Metrics != nil {
+		ctx = o.deps.Metrics.Scope(ctx)
+	}
+	formats := formatSet(req.Formats)
 
 	// Compute full diff (DiffComputer is auto-wired in NewOrchestrator when Git is provided)
-	diff, err := o.deps.DiffComputer.ComputeDiffForReview(ctx, req)
+	diffCtx, diffSpan := o.deps.Tracer.StartSpan(ctx, "review.diff", nil)
+	diff, err := o.deps.DiffComputer.ComputeDiffForReview(diffCtx, req)
+	if err != nil {
+		diffSpan.RecordError(err)
+		diffSpan.End()
+		return Result{}, err
+	}
+
+	policies, err := o.pathPolicies(diffCtx, req)
 	if err != nil {
+		diffSpan.RecordError(err)
+		diffSpan.End()
 		return Result{}, err
 	}
 
+	// Drop files that path policies exclude from review (e.g. vendored code)
+	diff, ignoredFiles := policies.FilterDiff(diff)
+	if len(ignoredFiles) > 0 {
+		log.Printf("Ignored %d file(s) by path rules\n", len(ignoredFiles))
+	}
+
+	// Leave generated files, vendored code and lockfiles out of the prompts.
+	// The full diff keeps them, marked, so the published summary lists them.
+	reviewDiff := diff
+	if o.deps.GeneratedFiles != nil && !req.IncludeGenerated {
+		diff = o.deps.GeneratedFiles.Mark(diff)
+		var generatedFiles []domain.FileDiff
+		reviewDiff, generatedFiles = FilterGeneratedFiles(diff)
+		if len(generatedFiles) > 0 {
+			log.Printf("Skipped %d generated, vendored or lock file(s)\n", len(generatedFiles))
+		}
+	}
+	diffSpan.SetAttributes(map[string]interface{}{
+		"files":         len(diff.Files),
+		"reviewedFiles": len(reviewDiff.Files),
+		"ignoredFiles":  len(ignoredFiles),
+	})
+	diffSpan.End()
+
 	// Gather project context if RepoDir is configured
+	contextCtx, contextSpan := o.deps.Tracer.StartSpan(ctx, "review.context", nil)
 	projectContext := ProjectContext{}
 	if o.deps.RepoDir != "" {
-		gatherer := NewContextGatherer(o.deps.RepoDir)
+		contextConfig := DefaultContextConfig()
+		if o.deps.ContextConfig != nil {
+			contextConfig = *o.deps.ContextConfig
+		}
+		gatherer := NewContextGathererWithConfig(o.deps.RepoDir, contextConfig)
 
 		// Load architecture documentation (unless disabled)
-		if !req.NoArchitecture {
+		if !req.NoArchitecture && !req.NoRepository {
 			if architecture, err := gatherer.loadFile("ARCHITECTURE.md"); err == nil {
 				projectContext.Architecture = architecture
 			}
 		}
 
 		// Load README and design docs (unless auto-context is disabled)
-		if !req.NoAutoContext {
+		if !req.NoAutoContext && !req.NoRepository {
 			// Load README
 			if readme, err := gatherer.loadFile("README.md"); err == nil {
 				projectContext.README = readme
@@ -394,15 +588,17 @@ func (o *Orchestrator) ReviewBranch(ctx context.Context, req BranchRequest) (Res
 			}
 
 			// Detect change types and find relevant docs
-			projectContext.ChangeTypes = gatherer.detectChangeTypes(diff)
-			projectContext.ChangedPaths = make([]string, 0, len(diff.Files))
-			for _, file := range diff.Files {
+			projectContext.ChangeTypes = gatherer.detectChangeTypes(reviewDiff)
+			projectContext.ChangedPaths = make([]string, 0, len(reviewDiff.Files))
+			for _, file := range reviewDiff.Files {
 				projectContext.ChangedPaths = append(projectContext.ChangedPaths, file.Path)
 			}
 
 			if relevantDocs, err := gatherer.findRelevantDocs(projectContext.ChangedPaths, projectContext.ChangeTypes); err == nil {
 				projectContext.RelevantDocs = relevantDocs
 			}
+			projectContext.PathInstructions = gatherer.findInstructions(projectContext.ChangedPaths, projectContext.ChangeTypes)
+			projectContext.DirectoryContext = gatherer.loadDirectoryContext(projectContext.ChangedPaths)
 		}
 
 		// Load custom context files from request (always, regardless of flags)
@@ -411,7 +607,10 @@ func (o *Orchestrator) ReviewBranch(ctx context.Context, req BranchRequest) (Res
 			for _, file := range req.ContextFiles {
 				content, err := gatherer.loadFile(file)
 				if err != nil {
-					return Result{}, fmt.Errorf("failed to load context file %s: %w", file, err)
+					err = fmt.Errorf("failed to load context file %s: %w", file, err)
+					contextSpan.RecordError(err)
+					contextSpan.End()
+					return Result{}, err
 				}
 				contextFiles = append(contextFiles, fmt.Sprintf("=== %s ===\n%s", file, content))
 			}
@@ -419,12 +618,31 @@ func (o *Orchestrator) ReviewBranch(ctx context.Context, req BranchRequest) (Res
 		}
 	}
 
+	// Expand hunks with their enclosing declarations and imports (optional)
+	if o.deps.CodeContext != nil && !req.NoCodeContext && !req.NoRepository {
+		projectContext.CodeContext = o.deps.CodeContext.Expand(reviewDiff)
+	}
+
+	// List callers of changed Go functions (optional)
+	if o.deps.Impact != nil && !req.NoImpactAnalysis && !req.NoRepository {
+		projectContext.AffectedCallSites = o.deps.Impact.Analyze(reviewDiff)
+	}
+
+	// Add instructions from path policies matching the changed files
+	if len(policies) > 0 {
+		changedPaths := make([]string, 0, len(reviewDiff.Files))
+		for _, file := range reviewDiff.Files {
+			changedPaths = append(changedPaths, file.Path)
+		}
+		projectContext.PathInstructions = append(projectContext.PathInstructions, policies.Instructions(changedPaths)...)
+	}
+
 	// Always set custom instructions from request (even if RepoDir is not configured)
 	projectContext.CustomInstructions = req.CustomInstructions
 
 	// Planning Phase: Interactive clarifying questions (optional, only in TTY mode)
 	if req.Interactive && IsInteractive() && o.deps.PlanningAgent != nil {
-		planningResult, err := o.deps.PlanningAgent.Plan(ctx, projectContext, diff)
+		planningResult, err := o.deps.PlanningAgent.Plan(contextCtx, projectContext, reviewDiff)
 		if err != nil {
 			// Planning failure shouldn't block the review - log warning and continue
 			if o.deps.Logger != nil {
@@ -439,6 +657,14 @@ func (o *Orchestrator) ReviewBranch(ctx context.Context, req BranchRequest) (Res
 			projectContext = planningResult.EnhancedContext
 		}
 	}
+	contextSpan.SetAttributes(map[string]interface{}{
+		"changedPaths":       len(projectContext.ChangedPaths),
+		"relevantDocs":       len(projectContext.RelevantDocs),
+		"customContextFiles": len(projectContext.CustomContextFiles),
+		"codeContextFiles":   len(projectContext.CodeContext),
+		"callSites":          projectContext.AffectedCallSites != "",
+	})
+	contextSpan.End()
 
 	// Generate run ID for potential store usage
 	now := time.Now()
@@ -475,33 +701,38 @@ func (o *Orchestrator) ReviewBranch(ctx context.Context, req BranchRequest) (Res
 		}
 	}
 
+	var metricsMu sync.Mutex
+	metrics := RunMetrics{Providers: make(map[string]ProviderMetrics, len(o.deps.Providers))}
+
 	var wg sync.WaitGroup
 	resultsChan := make(chan struct {
-		review    domain.Review
-		path      string
-		jsonPath  string
-		sarifPath string
-		err       error
+		review domain.Review
+		paths  map[string]string
+		err    error
 	}, len(o.deps.Providers))
 
 	for name, provider := range o.deps.Providers {
 		wg.Add(1)
 		go func(name string, provider Provider, runID string) {
+			ctx, span := o.deps.Tracer.StartSpan(ctx, "review.provider", map[string]interface{}{
+				"provider": name,
+			})
 			defer func() {
 				if r := recover(); r != nil {
+					err := fmt.Errorf("provider %s panicked: %v", name, r)
+					span.RecordError(err)
 					resultsChan <- struct {
-						review    domain.Review
-						path      string
-						jsonPath  string
-						sarifPath string
-						err       error
-					}{err: fmt.Errorf("provider %s panicked: %v", name, r)}
+						review domain.Review
+						paths  map[string]string
+						err    error
+					}{err: err}
 				}
+				span.End()
 				wg.Done()
 			}()
 
 			// Filter binary files before building prompt (saves tokens, prevents impossible findings)
-			textDiff, binaryFiles := FilterBinaryFiles(diff)
+			textDiff, binaryFiles := FilterBinaryFiles(reviewDiff)
 			if len(binaryFiles) > 0 {
 				log.Printf("[%s] Filtered %d binary file(s) from review", name, len(binaryFiles))
 			}
@@ -509,12 +740,11 @@ func (o *Orchestrator) ReviewBranch(ctx context.Context, req BranchRequest) (Res
 			// Build provider-specific prompt using filtered diff
 			providerReq, err := o.deps.PromptBuilder(projectContext, textDiff, req, name)
 			if err != nil {
+				span.RecordError(err)
 				resultsChan <- struct {
-					review    domain.Review
-					path      string
-					jsonPath  string
-					sarifPath string
-					err       error
+					review domain.Review
+					paths  map[string]string
+					err    error
 				}{err: fmt.Errorf("prompt building failed for %s: %w", name, err)}
 				return
 			}
@@ -531,86 +761,67 @@ func (o *Orchestrator) ReviewBranch(ctx context.Context, req BranchRequest) (Res
 			if o.deps.Redactor != nil {
 				redactedPrompt, err := o.deps.Redactor.Redact(providerReq.Prompt)
 				if err != nil {
+					span.RecordError(err)
 					resultsChan <- struct {
-						review    domain.Review
-						path      string
-						jsonPath  string
-						sarifPath string
-						err       error
+						review domain.Review
+						paths  map[string]string
+						err    error
 					}{err: fmt.Errorf("redaction failed for %s: %w", name, err)}
 					return
 				}
 				providerReq.Prompt = redactedPrompt
 			}
 
+			providerStart := time.Now()
 			review, err := provider.Review(ctx, providerReq)
 			if err != nil {
+				span.RecordError(err)
 				resultsChan <- struct {
-					review    domain.Review
-					path      string
-					jsonPath  string
-					sarifPath string
-					err       error
+					review domain.Review
+					paths  map[string]string
+					err    error
 				}{err: fmt.Errorf("provider %s failed: %w", name, err)}
 				return
 			}
 
-			markdownPath, err := o.deps.Markdown.Write(ctx, domain.MarkdownArtifact{
-				OutputDir:    req.OutputDir,
-				Repository:   req.Repository,
-				BaseRef:      req.BaseRef,
-				TargetRef:    req.TargetRef,
-				Diff:         diff,
-				Review:       review,
-				ProviderName: review.ProviderName,
+			span.SetAttributes(map[string]interface{}{
+				"model":     review.ModelName,
+				"tokensIn":  review.TokensIn,
+				"tokensOut": review.TokensOut,
+				"cost":      review.Cost,
+				"findings":  len(review.Findings),
 			})
-			if err != nil {
-				resultsChan <- struct {
-					review    domain.Review
-					path      string
-					jsonPath  string
-					sarifPath string
-					err       error
-				}{err: fmt.Errorf("markdown write failed for %s: %w", name, err)}
-				return
+			if len(review.FallbackFrom) > 0 {
+				span.SetAttributes(map[string]interface{}{"fallbackFrom": strings.Join(review.FallbackFrom, ",")})
 			}
 
-			jsonPath, err := o.deps.JSON.Write(ctx, domain.JSONArtifact{
-				OutputDir:    req.OutputDir,
-				Repository:   req.Repository,
-				BaseRef:      req.BaseRef,
-				TargetRef:    req.TargetRef,
-				Review:       review,
-				ProviderName: review.ProviderName,
-			})
-			if err != nil {
-				resultsChan <- struct {
-					review    domain.Review
-					path      string
-					jsonPath  string
-					sarifPath string
-					err       error
-				}{err: fmt.Errorf("json write failed for %s: %w", name, err)}
-				return
+			// Apply per-path severity floors and ceilings
+			review.Findings = policies.ApplyToFindings(review.Findings)
+
+			metricsMu.Lock()
+			metrics.Providers[name] = ProviderMetrics{
+				Model:        review.ModelName,
+				FallbackFrom: review.FallbackFrom,
+				Duration:     time.Since(providerStart),
+				TokensIn:     review.TokensIn,
+				TokensOut:    review.TokensOut,
+				Cost:         review.Cost,
+				Findings:     len(review.Findings),
 			}
+			metricsMu.Unlock()
 
-			sarifPath, err := o.deps.SARIF.Write(ctx, SARIFArtifact{
-				OutputDir:    req.OutputDir,
-				Repository:   req.Repository,
-				BaseRef:      req.BaseRef,
-				TargetRef:    req.TargetRef,
-				Review:       review,
-				ProviderName: review.ProviderName,
-			})
-			if err != nil {
-				resultsChan <- struct {
-					review    domain.Review
-					path      string
-					jsonPath  string
-					sarifPath string
-					err       error
-				}{err: fmt.Errorf("sarif write failed for %s: %w", name, err)}
-				return
+			var paths map[string]string
+			if !req.MergedOnly {
+				paths, err = o.writeReviewOutputs(ctx, req, formats, diff, policies, review, name)
+				if err != nil {
+					span.RecordError(err)
+					resultsChan <- struct {
+						review domain.Review
+						paths  map[string]string
+						err    error
+					}{err: err}
+					return
+				}
 			}
 
 			// Save review to store if available
@@ -630,12 +841,10 @@ func (o *Orchestrator) ReviewBranch(ctx context.Context, req BranchRequest) (Res
 			}
 
 			resultsChan <- struct {
-				review    domain.Review
-				path      string
-				jsonPath  string
-				sarifPath string
-				err       error
-			}{review: review, path: markdownPath, jsonPath: jsonPath, sarifPath: sarifPath}
+				review domain.Review
+				paths  map[string]string
+				err    error
+			}{review: review, paths: paths}
 		}(name, provider, runID)
 	}
 
@@ -643,9 +852,7 @@ func (o *Orchestrator) ReviewBranch(ctx context.Context, req BranchRequest) (Res
 	close(resultsChan)
 
 	var reviews []domain.Review
-	markdownPaths := make(map[string]string)
-	jsonPaths := make(map[string]string)
-	sarifPaths := make(map[string]string)
+	paths := make(map[string]map[string]string)
 	var errs []error
 	var totalCost float64
 
@@ -654,9 +861,7 @@ func (o *Orchestrator) ReviewBranch(ctx context.Context, req BranchRequest) (Res
 			errs = append(errs, res.err)
 		} else {
 			reviews = append(reviews, res.review)
-			markdownPaths[res.review.ProviderName] = res.path
-			jsonPaths[res.review.ProviderName] = res.jsonPath
-			sarifPaths[res.review.ProviderName] = res.sarifPath
+			addPaths(paths, res.review.ProviderName, res.paths)
 			totalCost += res.review.Cost
 		}
 	}
@@ -686,19 +891,37 @@ func (o *Orchestrator) ReviewBranch(ctx context.Context, req BranchRequest) (Res
 		}
 	}
 
-	mergedReview := o.deps.Merger.Merge(ctx, reviews)
+	mergeCtx, mergeSpan := o.deps.Tracer.StartSpan(ctx, "review.merge", map[string]interface{}{
+		"reviews": len(reviews),
+	})
+	mergedReview := o.deps.Merger.Merge(mergeCtx, reviews)
 	mergedReview.Cost = totalCost // Merged review gets total cost from all providers
+	mergeSpan.SetAttributes(map[string]interface{}{
+		"findings": len(mergedReview.Findings),
+	})
+	mergeSpan.End()
+
+	for _, r := range reviews {
+		metrics.Merge.InputFindings += len(r.Findings)
+	}
+	metrics.Merge.Findings = len(mergedReview.Findings)
 
 	// Verification stage: verify merged findings if enabled
 	if o.deps.Verifier != nil && !req.SkipVerification && len(mergedReview.Findings) > 0 {
+		verifyCtx, verifySpan := o.deps.Tracer.StartSpan(ctx, "review.verify", map[string]interface{}{
+			"depth":    req.VerificationConfig.Depth,
+			"findings": len(mergedReview.Findings),
+		})
 		candidates, verified, reportable, verifyErr := o.verifyFindings(
-			ctx,
+			verifyCtx,
 			mergedReview.Findings,
 			mergedReview.ProviderName,
 			req.VerificationConfig,
 		)
 
 		if verifyErr != nil {
+			verifySpan.RecordError(verifyErr)
+
 			// Log warning but continue with unverified findings
 			if o.deps.Logger != nil {
 				o.deps.Logger.LogWarning(ctx, "verification failed, using unverified findings", map[string]interface{}{
@@ -713,6 +936,10 @@ func (o *Orchestrator) ReviewBranch(ctx context.Context, req BranchRequest) (Res
 			mergedReview.DiscoveryFindings = candidates
 			mergedReview.VerifiedFindings = verified
 			mergedReview.ReportableFindings = reportable
+			mergedReview.VerificationMode = domain.VerificationModeForDepth(req.VerificationConfig.Depth)
+			tokensIn, tokensOut, verificationCost := verificationUsage(verified)
+			toolCalls := countToolCalls(verified)
+			mergedReview.VerificationCost = verificationCost
 
 			// Replace Findings with only the reportable ones for backward compatibility
 			// This ensures GitHub poster and other consumers use filtered findings
@@ -721,51 +948,51 @@ func (o *Orchestrator) ReviewBranch(ctx context.Context, req BranchRequest) (Res
 			// Log detailed verification results for each finding
 			logVerificationDetails(ctx, verified, reportable, req.VerificationConfig, o.deps.Logger)
 
+			metrics.Verification = VerificationMetrics{
+				Enabled:    true,
+				Candidates: len(candidates),
+				Verified:   countVerified(verified),
+				Reportable: len(reportable),
+				ToolCalls:  toolCalls,
+				TokensIn:   tokensIn,
+				TokensOut:  tokensOut,
+				Cost:       verificationCost,
+			}
+
+			verifySpan.SetAttributes(map[string]interface{}{
+				"candidates": len(candidates),
+				"verified":   len(verified),
+				"reportable": len(reportable),
+				"toolCalls":  toolCalls,
+				"tokensIn":   tokensIn,
+				"tokensOut":  tokensOut,
+				"cost":       verificationCost,
+			})
+
 			if o.deps.Logger != nil {
 				o.deps.Logger.LogInfo(ctx, "verification complete", map[string]interface{}{
 					"candidates": len(candidates),
 					"verified":   len(verified),
 					"reportable": len(reportable),
+					"toolCalls":  toolCalls,
+					"tokensIn":   tokensIn,
+					"tokensOut":  tokensOut,
+					"cost":       verificationCost,
 				})
 			}
 		}
+		verifySpan.End()
 	}
 
-	mergedMarkdownPath, err := o.deps.Markdown.Write(ctx, domain.MarkdownArtifact{
-		OutputDir:    req.OutputDir,
-		Repository:   req.Repository,
-		BaseRef:      req.BaseRef,
-		TargetRef:    req.TargetRef,
-		Diff:         diff,
-		Review:       mergedReview,
-		ProviderName: mergedReview.ProviderName,
-	})
-	if err != nil {
-		return Result{}, fmt.Errorf("markdown write failed for merged review: %w", err)
+	// The stdout format is written for the merged review even if not selected
+	mergedFormats := maps.Clone(formats)
+	if req.Stdout != "" {
+		mergedFormats[req.Stdout] = true
 	}
 
-	mergedJSONPath, err := o.deps.JSON.Write(ctx, domain.JSONArtifact{
-		OutputDir:    req.OutputDir,
-		Repository:   req.Repository,
-		BaseRef:      req.BaseRef,
-		TargetRef:    req.TargetRef,
-		Review:       mergedReview,
-		ProviderName: mergedReview.ProviderName,
-	})
+	mergedPaths, err := o.writeReviewOutputs(ctx, req, mergedFormats, diff, policies, mergedReview, "merged review")
 	if err != nil {
-		return Result{}, fmt.Errorf("json write failed for merged review: %w", err)
-	}
-
-	mergedSARIFPath, err := o.deps.SARIF.Write(ctx, SARIFArtifact{
-		OutputDir:    req.OutputDir,
-		Repository:   req.Repository,
-		BaseRef:      req.BaseRef,
-		TargetRef:    req.TargetRef,
-		Review:       mergedReview,
-		ProviderName: mergedReview.ProviderName,
-	})
-	if err != nil {
-		return Result{}, fmt.Errorf("sarif write failed for merged review: %w", err)
+		return Result{}, err
 	}
 
 	// Save merged review to store if available
@@ -784,16 +1011,40 @@ func (o *Orchestrator) ReviewBranch(ctx context.Context, req BranchRequest) (Res
 		}
 	}
 
-	markdownPaths["merged"] = mergedMarkdownPath
-	jsonPaths["merged"] = mergedJSONPath
-	sarifPaths["merged"] = mergedSARIFPath
+	addPaths(paths, mergedName, mergedPaths)
 
-	// Post review to GitHub if enabled
-	var githubResult *GitHubPostResult
-	if req.PostToGitHub && o.deps.GitHubPoster != nil {
-		result, err := o.deps.GitHubPoster.PostReview(ctx, GitHubPostRequest{
-			Owner:                 req.GitHubOwner,
-			Repo:                  req.GitHubRepo,
+	runPaths, err := o.writeRunOutputs(ctx, req, mergedFormats, RunArtifact{
+		OutputDir:  req.OutputDir,
+		Repository: req.Repository,
+		BaseRef:    req.BaseRef,
+		TargetRef:  req.TargetRef,
+		Diff:       diff,
+		Merged:     mergedReview,
+		Reviews:    reviews,
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	addPaths(paths, mergedName, runPaths)
+
+	if req.Stdout != "" {
+		if err := copyToStdout(o.deps.Stdout, paths[req.Stdout][mergedName]); err != nil {
+			return Result{}, err
+		}
+	}
+
+	// Publish review to the code host if enabled
+	var publishResult *PublishResult
+	if req.PostReview && o.deps.Publisher != nil {
+		publishCtx, publishSpan := o.deps.Tracer.StartSpan(ctx, "review.publish", map[string]interface{}{
+			"platform": platformName(req.Platform),
+			"prNumber": req.PRNumber,
+			"findings": len(mergedReview.Findings),
+		})
+		result, err := o.deps.Publisher.PublishReview(publishCtx, PublishRequest{
+			Platform:              req.Platform,
+			Owner:                 req.RepoOwner,
+			Repo:                  req.RepoName,
 			PRNumber:              req.PRNumber,
 			CommitSHA:             req.CommitSHA,
 			Review:                mergedReview,
@@ -805,45 +1056,107 @@ func (o *Orchestrator) ReviewBranch(ctx context.Context, req BranchRequest) (Res
 			ActionOnClean:         req.ActionOnClean,
 			ActionOnNonBlocking:   req.ActionOnNonBlocking,
 			AlwaysBlockCategories: req.AlwaysBlockCategories,
+			PathPolicies:          policies,
 			BotUsername:           req.BotUsername,
 		})
 		if err != nil {
+			publishSpan.RecordError(err)
+
 			// Log warning but don't fail the review
 			if o.deps.Logger != nil {
-				o.deps.Logger.LogWarning(ctx, "failed to post review to GitHub", map[string]interface{}{
-					"owner":    req.GitHubOwner,
-					"repo":     req.GitHubRepo,
+				o.deps.Logger.LogWarning(ctx, "failed to post review", map[string]interface{}{
+					"platform": platformName(req.Platform),
+					"owner":    req.RepoOwner,
+					"repo":     req.RepoName,
 					"prNumber": req.PRNumber,
 					"error":    err.Error(),
 				})
 			} else {
-				log.Printf("warning: failed to post review to GitHub: %v\n", err)
+				log.Printf("warning: failed to post review to %s: %v\n", platformName(req.Platform), err)
 			}
 		} else {
-			githubResult = result
+			publishResult = result
+			metrics.Publish = PublishMetrics{
+				Enabled:           true,
+				CommentsPosted:    result.CommentsPosted,
+				CommentsSkipped:   result.CommentsSkipped,
+				DuplicatesSkipped: result.DuplicatesSkipped,
+			}
+			publishSpan.SetAttributes(map[string]interface{}{
+				"commentsPosted":    result.CommentsPosted,
+				"commentsSkipped":   result.CommentsSkipped,
+				"duplicatesSkipped": result.DuplicatesSkipped,
+			})
 			if o.deps.Logger != nil {
-				o.deps.Logger.LogInfo(ctx, "posted review to GitHub", map[string]interface{}{
+				o.deps.Logger.LogInfo(ctx, "posted review", map[string]interface{}{
+					"platform":        platformName(req.Platform),
 					"reviewID":        result.ReviewID,
 					"commentsPosted":  result.CommentsPosted,
 					"commentsSkipped": result.CommentsSkipped,
 					"url":             result.HTMLURL,
 				})
 			} else {
-				log.Printf("Posted review to GitHub: %d comments (%d skipped) - %s\n",
-					result.CommentsPosted, result.CommentsSkipped, result.HTMLURL)
+				log.Printf("Posted review to %s: %d comments (%d skipped) - %s\n",
+					platformName(req.Platform), result.CommentsPosted, result.CommentsSkipped, result.HTMLURL)
 			}
 		}
+		publishSpan.End()
+	}
+
+	// Write run metrics last so they cover every stage
+	var metricsPath string
+	if o.deps.Metrics != nil {
+		metrics.Duration = time.Since(started)
+		path, err := o.deps.Metrics.Write(ctx, MetricsArtifact{
+			OutputDir:      req.OutputDir,
+			Repository:     req.Repository,
+			BaseRef:        req.BaseRef,
+			TargetRef:      req.TargetRef,
+			Metrics:        metrics,
+			PrometheusFile: req.MetricsFile,
+		})
+		if err != nil {
+			// Metrics are auxiliary; a write failure shouldn't fail the review
+			if o.deps.Logger != nil {
+				o.deps.Logger.LogWarning(ctx, "failed to write run metrics", map[string]interface{}{
+					"error": err.Error(),
+				})
+			} else {
+				log.Printf("warning: failed to write run metrics: %v\n", err)
+			}
+		} else {
+			metricsPath = path
+		}
 	}
 
 	return Result{
-		MarkdownPaths: markdownPaths,
-		JSONPaths:     jsonPaths,
-		SARIFPaths:    sarifPaths,
+		MarkdownPaths: pathsFor(paths, FormatMarkdown),
+		JSONPaths:     pathsFor(paths, FormatJSON),
+		SARIFPaths:    pathsFor(paths, FormatSARIF),
+		Paths:         paths,
 		Reviews:       append(reviews, mergedReview),
-		GitHubResult:  githubResult,
+		PublishResult: publishResult,
+		MetricsPath:   metricsPath,
+		BaseSHA:       diff.FromCommitHash,
+		TargetSHA:     diff.ToCommitHash,
+		ConfigHash:    calculateConfigHash(req),
 	}, nil
 }
 
+// pathPolicies loads the path policies in effect at the review's base ref.
+// The target's own rules file is never used, so a change cannot relax the
+// policies applied to itself.
+func (o *Orchestrator) pathPolicies(ctx context.Context, req BranchRequest) (PathPolicies, error) {
+	if o.deps.PathPolicies == nil || req.NoRepository {
+		return nil, nil
+	}
+	policies, err := o.deps.PathPolicies(ctx, req.BaseRef)
+	if err != nil {
+		return nil, fmt.Errorf("load path rules: %w", err)
+	}
+	return policies, nil
+}
+
 // CurrentBranch returns the checked-out branch name.
 func (o *Orchestrator) CurrentBranch(ctx context.Context) (string, error) {
 	if o.deps.Git == nil {
@@ -865,6 +1178,20 @@ func validateRequest(req BranchRequest) error {
 	return nil
 }
 
+// platformName returns a display name for the code host a review is posted to.
+func platformName(platform string) string {
+	switch strings.ToLower(platform) {
+	case "gitlab":
+		return "GitLab"
+	case "gitea":
+		return "Gitea"
+	case "bitbucket":
+		return "Bitbucket"
+	default:
+		return "GitHub"
+	}
+}
+
 // FilterBinaryFiles separates a diff into text files and binary files.
 // The text diff is suitable for sending to LLMs (excludes binary files to save tokens).
 // The full diff (with binary files) should be used for GitHub posting so binary
@@ -1,8 +1,11 @@
 package review_test
 
 import (
+	"bytes"
 	"context"
+	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -101,7 +104,7 @@ func (m *mockJSONWriter) Write(ctx context.Context, artifact domain.JSONArtifact
 	if m.err != nil {
 		return "", m.err
 	}
-	return filepath.Join(artifact.OutputDir, "review-"+artifact.ProviderName+".json"), nil
+	return writeOutput(filepath.Join(artifact.OutputDir, "review-"+artifact.ProviderName+".json"))
 }
 
 func (m *mockMarkdownWriter) Write(ctx context.Context, artifact domain.MarkdownArtifact) (string, error) {
@@ -111,7 +114,7 @@ func (m *mockMarkdownWriter) Write(ctx context.Context, artifact domain.Markdown
 	if m.err != nil {
 		return "", m.err
 	}
-	return filepath.Join(artifact.OutputDir, "review-"+artifact.ProviderName+".md"), nil
+	return writeOutput(filepath.Join(artifact.OutputDir, "review-"+artifact.ProviderName+".md"))
 }
 
 func (m *mockSARIFWriter) Write(ctx context.Context, artifact review.SARIFArtifact) (string, error) {
@@ -121,7 +124,16 @@ func (m *mockSARIFWriter) Write(ctx context.Context, artifact review.SARIFArtifa
 	if m.err != nil {
 		return "", m.err
 	}
-	return filepath.Join(artifact.OutputDir, "review-"+artifact.ProviderName+".sarif"), nil
+	return writeOutput(filepath.Join(artifact.OutputDir, "review-"+artifact.ProviderName+".sarif"))
+}
+
+// writeOutput creates the file a mock writer reports, so the orchestrator
+// can copy it to its latest-* path and stdout.
+func writeOutput(path string) (string, error) {
+	if err := os.WriteFile(path, []byte(filepath.Base(path)), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
 }
 
 func TestReviewBranchWithSingleProvider(t *testing.T) {
@@ -933,16 +945,16 @@ func TestFilterBinaryFiles(t *testing.T) {
 	}
 }
 
-// Mock implementations for GitHub integration testing
+// Mock implementations for code host integration testing
 
-type mockGitHubPoster struct {
+type mockPublisher struct {
 	mu       sync.Mutex
-	requests []review.GitHubPostRequest
-	result   *review.GitHubPostResult
+	requests []review.PublishRequest
+	result   *review.PublishResult
 	err      error
 }
 
-func (m *mockGitHubPoster) PostReview(ctx context.Context, req review.GitHubPostRequest) (*review.GitHubPostResult, error) {
+func (m *mockPublisher) PublishReview(ctx context.Context, req review.PublishRequest) (*review.PublishResult, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.requests = append(m.requests, req)
@@ -966,3 +978,456 @@ func (m *mockMergerWithFindings) Merge(ctx context.Context, reviews []domain.Rev
 		Findings:     m.findings,
 	}
 }
+
+type mockMetricsWriter struct {
+	mu        sync.Mutex
+	artifacts []review.MetricsArtifact
+	err       error
+}
+
+func (m *mockMetricsWriter) Scope(ctx context.Context) context.Context {
+	return ctx
+}
+
+func (m *mockMetricsWriter) Write(ctx context.Context, artifact review.MetricsArtifact) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.artifacts = append(m.artifacts, artifact)
+	if m.err != nil {
+		return "", m.err
+	}
+	return filepath.Join(artifact.OutputDir, "metrics.json"), nil
+}
+
+func TestReviewBranch_WritesRunMetrics(t *testing.T) {
+	outputDir := t.TempDir()
+	metricsWriter := &mockMetricsWriter{}
+	orchestrator := review.NewOrchestrator(review.OrchestratorDeps{
+		Git: &mockGitEngine{diff: domain.Diff{Files: []domain.FileDiff{{Path: "main.go", Status: "modified"}}}},
+		Providers: map[string]review.Provider{
+			"openai": &mockProvider{response: domain.Review{
+				ProviderName: "openai", ModelName: "gpt-4o", TokensIn: 100, TokensOut: 10, Cost: 0.5,
+				Findings: []domain.Finding{{File: "main.go", LineStart: 1}, {File: "main.go", LineStart: 2}},
+			}},
+		},
+		Merger:        &mockMergerWithFindings{findings: []domain.Finding{{File: "main.go", LineStart: 1}}},
+		Markdown:      &mockMarkdownWriter{},
+		JSON:          &mockJSONWriter{},
+		SARIF:         &mockSARIFWriter{},
+		Publisher:     &mockPublisher{result: &review.PublishResult{CommentsPosted: 1, DuplicatesSkipped: 3}},
+		Metrics:       metricsWriter,
+		SeedGenerator: func(_, _ string) uint64 { return 1 },
+		PromptBuilder: func(ctx review.ProjectContext, d domain.Diff, req review.BranchRequest, providerName string) (review.ProviderRequest, error) {
+			return review.ProviderRequest{Prompt: "prompt"}, nil
+		},
+	})
+
+	result, err := orchestrator.ReviewBranch(context.Background(), review.BranchRequest{
+		BaseRef:     "main",
+		TargetRef:   "feature",
+		OutputDir:   outputDir,
+		MetricsFile: "/var/lib/node_exporter/cr.prom",
+		PostReview:  true,
+		RepoOwner:   "owner",
+		RepoName:    "repo",
+		PRNumber:    1,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(metricsWriter.artifacts) != 1 {
+		t.Fatalf("expected metrics to be written once, got %d", len(metricsWriter.artifacts))
+	}
+	artifact := metricsWriter.artifacts[0]
+	if artifact.PrometheusFile != "/var/lib/node_exporter/cr.prom" || artifact.TargetRef != "feature" {
+		t.Errorf("unexpected metrics artifact: %+v", artifact)
+	}
+
+	m := artifact.Metrics
+	if p := m.Providers["openai"]; p.Model != "gpt-4o" || p.TokensIn != 100 || p.Cost != 0.5 || p.Findings != 2 {
+		t.Errorf("unexpected provider metrics: %+v", p)
+	}
+	if m.Merge.InputFindings != 2 || m.Merge.Findings != 1 {
+		t.Errorf("unexpected merge metrics: %+v", m.Merge)
+	}
+	if m.Verification.Enabled {
+		t.Error("expected verification metrics to be disabled without a verifier")
+	}
+	if !m.Publish.Enabled || m.Publish.DuplicatesSkipped != 3 {
+		t.Errorf("unexpected publish metrics: %+v", m.Publish)
+	}
+	if m.Duration <= 0 {
+		t.Error("expected the run duration to be recorded")
+	}
+	if result.MetricsPath != filepath.Join(outputDir, "metrics.json") {
+		t.Errorf("expected metrics path in result, got %q", result.MetricsPath)
+	}
+}
+
+// usageVerifier verifies every candidate with fixed usage and tool calls.
+type usageVerifier struct {
+	result domain.VerificationResult
+}
+
+func (v *usageVerifier) Verify(ctx context.Context, candidate domain.CandidateFinding) (domain.VerificationResult, error) {
+	return v.result, nil
+}
+
+func (v *usageVerifier) VerifyBatch(ctx context.Context, candidates []domain.CandidateFinding) ([]domain.VerificationResult, error) {
+	results := make([]domain.VerificationResult, len(candidates))
+	for i := range results {
+		results[i] = v.result
+	}
+	return results, nil
+}
+
+func TestReviewBranch_RecordsVerificationUsage(t *testing.T) {
+	metricsWriter := &mockMetricsWriter{}
+	jsonWriter := &mockJSONWriter{}
+	orchestrator := review.NewOrchestrator(review.OrchestratorDeps{
+		Git: &mockGitEngine{diff: domain.Diff{Files: []domain.FileDiff{{Path: "main.go", Status: "modified"}}}},
+		Providers: map[string]review.Provider{
+			"openai": &mockProvider{response: domain.Review{ProviderName: "openai", ModelName: "gpt-4o", Cost: 0.5}},
+		},
+		Merger: &mockMergerWithFindings{findings: []domain.Finding{
+			{File: "main.go", LineStart: 1, Severity: "high"},
+			{File: "main.go", LineStart: 2, Severity: "high"},
+		}},
+		// Batch verification shares its call's usage across the findings; an
+		// escalated finding adds the agent's usage and tool calls to its share
+		Verifier: &usageVerifier{result: domain.VerificationResult{
+			Verified: true, Confidence: 90, TokensIn: 40, TokensOut: 5, Cost: 0.25,
+			Actions: []domain.VerificationAction{{Tool: "read", Input: "main.go"}, {Tool: "grep", Input: "nil"}},
+		}},
+		Markdown:      &mockMarkdownWriter{},
+		JSON:          jsonWriter,
+		SARIF:         &mockSARIFWriter{},
+		Metrics:       metricsWriter,
+		SeedGenerator: func(_, _ string) uint64 { return 1 },
+		PromptBuilder: func(ctx review.ProjectContext, d domain.Diff, req review.BranchRequest, providerName string) (review.ProviderRequest, error) {
+			return review.ProviderRequest{Prompt: "prompt"}, nil
+		},
+	})
+
+	_, err := orchestrator.ReviewBranch(context.Background(), review.BranchRequest{
+		BaseRef:   "main",
+		TargetRef: "feature",
+		OutputDir: t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	v := metricsWriter.artifacts[0].Metrics.Verification
+	if v.TokensIn != 80 || v.TokensOut != 10 || v.Cost != 0.5 {
+		t.Errorf("expected the usage of both findings, got %+v", v)
+	}
+	if v.ToolCalls != 4 {
+		t.Errorf("expected 4 tool calls, got %d", v.ToolCalls)
+	}
+
+	var merged *domain.Review
+	for i, call := range jsonWriter.calls {
+		if call.Review.ProviderName == "merged" {
+			merged = &jsonWriter.calls[i].Review
+		}
+	}
+	if merged == nil {
+		t.Fatal("expected the merged review to be written")
+	}
+	if merged.VerificationCost != 0.5 {
+		t.Errorf("expected verification cost 0.5 on the merged review, got %v", merged.VerificationCost)
+	}
+}
+
+func TestReviewBranch_MetricsWriteFailureDoesNotFailReview(t *testing.T) {
+	orchestrator := review.NewOrchestrator(review.OrchestratorDeps{
+		Git:           &mockGitEngine{diff: domain.Diff{Files: []domain.FileDiff{{Path: "main.go", Status: "modified"}}}},
+		Providers:     map[string]review.Provider{"openai": &mockProvider{response: domain.Review{ProviderName: "openai"}}},
+		Merger:        &mockMerger{},
+		Markdown:      &mockMarkdownWriter{},
+		JSON:          &mockJSONWriter{},
+		SARIF:         &mockSARIFWriter{},
+		Metrics:       &mockMetricsWriter{err: &testError{msg: "disk full"}},
+		SeedGenerator: func(_, _ string) uint64 { return 1 },
+		PromptBuilder: func(ctx review.ProjectContext, d domain.Diff, req review.BranchRequest, providerName string) (review.ProviderRequest, error) {
+			return review.ProviderRequest{Prompt: "prompt"}, nil
+		},
+	})
+
+	result, err := orchestrator.ReviewBranch(context.Background(), review.BranchRequest{BaseRef: "main", TargetRef: "feature", OutputDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.MetricsPath != "" {
+		t.Errorf("expected no metrics path after a failed write, got %q", result.MetricsPath)
+	}
+}
+
+type mockRunWriter struct {
+	artifacts []review.RunArtifact
+}
+
+func (m *mockRunWriter) Write(ctx context.Context, artifact review.RunArtifact) (string, error) {
+	m.artifacts = append(m.artifacts, artifact)
+	return writeOutput(filepath.Join(artifact.OutputDir, "report.html"))
+}
+
+func TestReviewBranch_WritesHTMLReport(t *testing.T) {
+	outputDir := t.TempDir()
+	htmlWriter := &mockRunWriter{}
+	diff := domain.Diff{Files: []domain.FileDiff{{Path: "main.go", Status: "modified"}}}
+	orchestrator := review.NewOrchestrator(review.OrchestratorDeps{
+		Git: &mockGitEngine{diff: diff},
+		Providers: map[string]review.Provider{
+			"openai": &mockProvider{response: domain.Review{ProviderName: "openai", Findings: []domain.Finding{{File: "main.go", LineStart: 1}}}},
+		},
+		Merger:        &mockMergerWithFindings{findings: []domain.Finding{{File: "main.go", LineStart: 1}}},
+		Markdown:      &mockMarkdownWriter{},
+		JSON:          &mockJSONWriter{},
+		SARIF:         &mockSARIFWriter{},
+		Outputs:       review.NewOutputs().RegisterRun(review.FormatHTML, htmlWriter),
+		SeedGenerator: func(_, _ string) uint64 { return 1 },
+		PromptBuilder: func(ctx review.ProjectContext, d domain.Diff, req review.BranchRequest, providerName string) (review.ProviderRequest, error) {
+			return review.ProviderRequest{Prompt: "prompt"}, nil
+		},
+	})
+
+	result, err := orchestrator.ReviewBranch(context.Background(), review.BranchRequest{BaseRef: "main", TargetRef: "feature", OutputDir: outputDir, Formats: []string{review.FormatHTML}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(htmlWriter.artifacts) != 1 {
+		t.Fatalf("expected the html report to be written once, got %d", len(htmlWriter.artifacts))
+	}
+	artifact := htmlWriter.artifacts[0]
+	if len(artifact.Diff.Files) != 1 || len(artifact.Reviews) != 1 || len(artifact.Merged.Findings) != 1 {
+		t.Errorf("unexpected html artifact: %+v", artifact)
+	}
+	if got := result.Paths[review.FormatHTML]["merged"]; got != filepath.Join(outputDir, "report.html") {
+		t.Errorf("expected html path in result, got %q", got)
+	}
+}
+
+type mockReportWriter struct {
+	mu        sync.Mutex
+	artifacts []review.ReportArtifact
+}
+
+func (m *mockReportWriter) Write(ctx context.Context, artifact review.ReportArtifact) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.artifacts = append(m.artifacts, artifact)
+	return writeOutput(filepath.Join(artifact.OutputDir, "review-"+artifact.ProviderName+".junit.xml"))
+}
+
+func TestReviewBranch_WritesOnlySelectedFormats(t *testing.T) {
+	outputDir := t.TempDir()
+	markdownWriter := &mockMarkdownWriter{}
+	jsonWriter := &mockJSONWriter{}
+	sarifWriter := &mockSARIFWriter{}
+	htmlWriter := &mockRunWriter{}
+	junitWriter := &mockReportWriter{}
+	rdjsonWriter := &mockReportWriter{}
+
+	orchestrator := review.NewOrchestrator(review.OrchestratorDeps{
+		Git:       &mockGitEngine{diff: domain.Diff{Files: []domain.FileDiff{{Path: "main.go", Status: "modified"}}}},
+		Providers: map[string]review.Provider{"openai": &mockProvider{response: domain.Review{ProviderName: "openai"}}},
+		Merger:    &mockMerger{},
+		Markdown:  markdownWriter,
+		JSON:      jsonWriter,
+		SARIF:     sarifWriter,
+		Outputs: review.NewOutputs().
+			RegisterRun(review.FormatHTML, htmlWriter).
+			Register(review.FormatJUnit, junitWriter).
+			Register(review.FormatRDJSON, rdjsonWriter),
+		PathPolicies: func(ctx context.Context, ref string) (review.PathPolicies, error) {
+			return review.PathPolicies{{Paths: []string{"docs/**"}, ActionOnLow: "request_changes"}}, nil
+		},
+		SeedGenerator: func(_, _ string) uint64 { return 1 },
+		PromptBuilder: func(ctx review.ProjectContext, d domain.Diff, req review.BranchRequest, providerName string) (review.ProviderRequest, error) {
+			return review.ProviderRequest{Prompt: "prompt"}, nil
+		},
+	})
+
+	result, err := orchestrator.ReviewBranch(context.Background(), review.BranchRequest{
+		BaseRef:               "main",
+		TargetRef:             "feature",
+		OutputDir:             outputDir,
+		Formats:               []string{review.FormatJSON, review.FormatJUnit},
+		ActionOnHigh:          "comment",
+		ActionOnMedium:        "Request-Changes",
+		AlwaysBlockCategories: []string{"security"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(markdownWriter.calls) != 0 || len(sarifWriter.calls) != 0 || len(htmlWriter.artifacts) != 0 || len(rdjsonWriter.artifacts) != 0 {
+		t.Error("expected unselected formats not to be written")
+	}
+	if len(result.MarkdownPaths) != 0 || len(result.SARIFPaths) != 0 || result.Paths[review.FormatHTML] != nil {
+		t.Errorf("expected no paths for unselected formats, got %+v", result)
+	}
+	if len(jsonWriter.calls) != 2 || len(result.JSONPaths) != 2 {
+		t.Errorf("expected json for the provider and merged review, got %d writes", len(jsonWriter.calls))
+	}
+
+	// One report per provider plus the merged review
+	if len(junitWriter.artifacts) != 2 {
+		t.Fatalf("expected 2 junit reports, got %d", len(junitWriter.artifacts))
+	}
+	if got := result.Paths[review.FormatJUnit]["merged"]; got != filepath.Join(outputDir, "review-merged.junit.xml") {
+		t.Errorf("expected merged junit path in result, got %q", got)
+	}
+	if got := result.Paths[review.FormatJUnit]["openai"]; got == "" {
+		t.Error("expected provider junit path in result")
+	}
+
+	artifact := junitWriter.artifacts[0]
+	if got := strings.Join(artifact.BlockingSeverities, ","); got != "critical,medium" {
+		t.Errorf("expected critical (default) and medium to block, got %q", got)
+	}
+	if !artifact.Blocks(domain.Finding{Severity: "low", Category: "Security"}) {
+		t.Error("expected always-block categories to block")
+	}
+	if artifact.Blocks(domain.Finding{Severity: "high", Category: "bug"}) {
+		t.Error("expected high findings not to block when their action is comment")
+	}
+	if !artifact.Blocks(domain.Finding{File: "docs/api.md", Severity: "low"}) {
+		t.Error("expected path policies to apply to report blocking")
+	}
+}
+
+func TestReviewBranch_DefaultFormats(t *testing.T) {
+	markdownWriter := &mockMarkdownWriter{}
+	junitWriter := &mockReportWriter{}
+	htmlWriter := &mockRunWriter{}
+	orchestrator := review.NewOrchestrator(review.OrchestratorDeps{
+		Git:           &mockGitEngine{diff: domain.Diff{Files: []domain.FileDiff{{Path: "main.go", Status: "modified"}}}},
+		Providers:     map[string]review.Provider{"openai": &mockProvider{response: domain.Review{ProviderName: "openai"}}},
+		Merger:        &mockMerger{},
+		Markdown:      markdownWriter,
+		Outputs:       review.NewOutputs().RegisterRun(review.FormatHTML, htmlWriter).Register(review.FormatJUnit, junitWriter),
+		SeedGenerator: func(_, _ string) uint64 { return 1 },
+		PromptBuilder: func(ctx review.ProjectContext, d domain.Diff, req review.BranchRequest, providerName string) (review.ProviderRequest, error) {
+			return review.ProviderRequest{Prompt: "prompt"}, nil
+		},
+	})
+
+	// Writers for unconfigured formats are optional
+	if _, err := orchestrator.ReviewBranch(context.Background(), review.BranchRequest{BaseRef: "main", TargetRef: "feature", OutputDir: t.TempDir()}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(markdownWriter.calls) != 2 {
+		t.Errorf("expected markdown by default, got %d writes", len(markdownWriter.calls))
+	}
+	if len(junitWriter.artifacts) != 0 {
+		t.Error("expected junit only when selected")
+	}
+	if len(htmlWriter.artifacts) != 0 {
+		t.Error("expected the html report only when selected")
+	}
+}
+
+func TestValidateFormats(t *testing.T) {
+	if err := review.ValidateFormats([]string{"markdown", "rdjsonl", "codeclimate"}); err != nil {
+		t.Errorf("expected valid formats, got %v", err)
+	}
+	if err := review.ValidateFormats([]string{"json", "xml"}); err == nil || !strings.Contains(err.Error(), `"xml"`) {
+		t.Errorf("expected error naming xml, got %v", err)
+	}
+}
+
+func newOutputsOrchestrator(jsonWriter *mockJSONWriter, stdout *bytes.Buffer) *review.Orchestrator {
+	return review.NewOrchestrator(review.OrchestratorDeps{
+		Git:           &mockGitEngine{diff: domain.Diff{Files: []domain.FileDiff{{Path: "main.go", Status: "modified"}}}},
+		Providers:     map[string]review.Provider{"openai": &mockProvider{response: domain.Review{ProviderName: "openai"}}},
+		Merger:        &mockMerger{},
+		JSON:          jsonWriter,
+		Outputs:       review.NewOutputs().Register(review.FormatJUnit, &mockReportWriter{}),
+		Stdout:        stdout,
+		SeedGenerator: func(_, _ string) uint64 { return 1 },
+		PromptBuilder: func(ctx review.ProjectContext, d domain.Diff, req review.BranchRequest, providerName string) (review.ProviderRequest, error) {
+			return review.ProviderRequest{Prompt: "prompt"}, nil
+		},
+	})
+}
+
+func TestReviewBranch_MergedOnly(t *testing.T) {
+	jsonWriter := &mockJSONWriter{}
+	orchestrator := newOutputsOrchestrator(jsonWriter, &bytes.Buffer{})
+
+	result, err := orchestrator.ReviewBranch(context.Background(), review.BranchRequest{
+		BaseRef: "main", TargetRef: "feature", OutputDir: t.TempDir(), MergedOnly: true,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(jsonWriter.calls) != 1 || jsonWriter.calls[0].ProviderName != "merged" {
+		t.Errorf("expected only the merged review to be written, got %+v", jsonWriter.calls)
+	}
+	if _, ok := result.JSONPaths["merged"]; !ok || len(result.JSONPaths) != 1 {
+		t.Errorf("expected only the merged json path, got %v", result.JSONPaths)
+	}
+}
+
+func TestReviewBranch_WritesLatestCopies(t *testing.T) {
+	outputDir := t.TempDir()
+	orchestrator := newOutputsOrchestrator(&mockJSONWriter{}, &bytes.Buffer{})
+
+	req := review.BranchRequest{
+		BaseRef: "main", TargetRef: "feature", Repository: "repo", OutputDir: outputDir,
+		Formats: []string{review.FormatJSON, review.FormatJUnit},
+	}
+	if _, err := orchestrator.ReviewBranch(context.Background(), req); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for name, want := range map[string]string{
+		"latest-merged.json":      "review-merged.json",
+		"latest-openai.json":      "review-openai.json",
+		"latest-merged.junit.xml": "review-merged.junit.xml",
+	} {
+		content, err := os.ReadFile(filepath.Join(outputDir, "repo_feature", name))
+		if err != nil {
+			t.Errorf("expected %s to be written: %v", name, err)
+			continue
+		}
+		if string(content) != want {
+			t.Errorf("expected %s to copy %s, got %q", name, want, content)
+		}
+	}
+}
+
+func TestReviewBranch_WritesMergedReviewToStdout(t *testing.T) {
+	jsonWriter := &mockJSONWriter{}
+	stdout := &bytes.Buffer{}
+	orchestrator := newOutputsOrchestrator(jsonWriter, stdout)
+
+	// The stdout format is written for the merged review even if not selected
+	result, err := orchestrator.ReviewBranch(context.Background(), review.BranchRequest{
+		BaseRef: "main", TargetRef: "feature", OutputDir: t.TempDir(),
+		Formats: []string{review.FormatJUnit}, Stdout: review.FormatJSON,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if stdout.String() != "review-merged.json" {
+		t.Errorf("expected the merged json on stdout, got %q", stdout.String())
+	}
+	if len(jsonWriter.calls) != 1 || len(result.JSONPaths) != 1 {
+		t.Errorf("expected json for the merged review only, got %d writes", len(jsonWriter.calls))
+	}
+
+	_, err = orchestrator.ReviewBranch(context.Background(), review.BranchRequest{
+		BaseRef: "main", TargetRef: "feature", OutputDir: t.TempDir(), Stdout: review.FormatSARIF,
+	})
+	if err == nil || !strings.Contains(err.Error(), `"sarif"`) {
+		t.Errorf("expected error for an unregistered stdout format, got %v", err)
+	}
+}
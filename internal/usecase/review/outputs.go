@@ -0,0 +1,265 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/bkyoung/code-reviewer/internal/domain"
+)
+
+// mergedName names the merged review's outputs, e.g. latest-merged.json.
+const mergedName = "merged"
+
+// formatExtensions are the file extensions of the stable latest-* copies.
+// Formats not listed use the format name.
+var formatExtensions = map[string]string{
+	FormatMarkdown:    ".md",
+	FormatJSON:        ".json",
+	FormatSARIF:       ".sarif",
+	FormatHTML:        ".html",
+	FormatJUnit:       ".junit.xml",
+	FormatCodeClimate: ".codeclimate.json",
+	FormatRDJSON:      ".rdjson",
+	FormatRDJSONL:     ".rdjsonl",
+}
+
+// Outputs is the registry of output writers by format. Review writers are
+// called for each provider's review and the merged review; run writers
+// once per run, after the merged review.
+type Outputs struct {
+	formats []string // Registration order
+	reviews map[string]ReportWriter
+	runs    map[string]RunWriter
+}
+
+// NewOutputs creates an empty output registry.
+func NewOutputs() *Outputs {
+	return &Outputs{
+		reviews: make(map[string]ReportWriter),
+		runs:    make(map[string]RunWriter),
+	}
+}
+
+// Register adds the writer of a per-review format, replacing any writer
+// already registered for it.
+func (o *Outputs) Register(format string, w ReportWriter) *Outputs {
+	o.add(format)
+	delete(o.runs, format)
+	o.reviews[format] = w
+	return o
+}
+
+// RegisterRun adds the writer of a run-wide format, replacing any writer
+// already registered for it.
+func (o *Outputs) RegisterRun(format string, w RunWriter) *Outputs {
+	o.add(format)
+	delete(o.reviews, format)
+	o.runs[format] = w
+	return o
+}
+
+// Formats returns the registered formats in registration order.
+func (o *Outputs) Formats() []string {
+	return append([]string(nil), o.formats...)
+}
+
+// Has reports whether a writer is registered for the format.
+func (o *Outputs) Has(format string) bool {
+	return slices.Contains(o.formats, format)
+}
+
+func (o *Outputs) add(format string) {
+	if !o.Has(format) {
+		o.formats = append(o.formats, format)
+	}
+}
+
+// withShorthands returns a copy of the registry (an empty one if nil) with
+// the given markdown, JSON and SARIF writers registered for the formats it
+// does not already have.
+func (o *Outputs) withShorthands(markdown MarkdownWriter, json JSONWriter, sarif SARIFWriter) *Outputs {
+	outputs := NewOutputs()
+	if o != nil {
+		outputs.formats = append(outputs.formats, o.formats...)
+		for format, w := range o.reviews {
+			outputs.reviews[format] = w
+		}
+		for format, w := range o.runs {
+			outputs.runs[format] = w
+		}
+	}
+
+	if markdown != nil && !outputs.Has(FormatMarkdown) {
+		outputs.Register(FormatMarkdown, markdownOutput{markdown})
+	}
+	if json != nil && !outputs.Has(FormatJSON) {
+		outputs.Register(FormatJSON, jsonOutput{json})
+	}
+	if sarif != nil && !outputs.Has(FormatSARIF) {
+		outputs.Register(FormatSARIF, sarifOutput{sarif})
+	}
+	return outputs
+}
+
+// markdownOutput adapts a MarkdownWriter to the ReportWriter interface.
+type markdownOutput struct{ w MarkdownWriter }
+
+func (m markdownOutput) Write(ctx context.Context, a ReportArtifact) (string, error) {
+	return m.w.Write(ctx, domain.MarkdownArtifact{
+		OutputDir:    a.OutputDir,
+		Repository:   a.Repository,
+		BaseRef:      a.BaseRef,
+		TargetRef:    a.TargetRef,
+		Diff:         a.Diff,
+		Review:       a.Review,
+		ProviderName: a.ProviderName,
+	})
+}
+
+// jsonOutput adapts a JSONWriter to the ReportWriter interface.
+type jsonOutput struct{ w JSONWriter }
+
+func (j jsonOutput) Write(ctx context.Context, a ReportArtifact) (string, error) {
+	return j.w.Write(ctx, domain.JSONArtifact{
+		OutputDir:    a.OutputDir,
+		Repository:   a.Repository,
+		BaseRef:      a.BaseRef,
+		TargetRef:    a.TargetRef,
+		Diff:         a.Diff,
+		ConfigHash:   a.ConfigHash,
+		Review:       a.Review,
+		ProviderName: a.ProviderName,
+	})
+}
+
+// sarifOutput adapts a SARIFWriter to the ReportWriter interface.
+type sarifOutput struct{ w SARIFWriter }
+
+func (s sarifOutput) Write(ctx context.Context, a ReportArtifact) (string, error) {
+	return s.w.Write(ctx, SARIFArtifact{
+		OutputDir:    a.OutputDir,
+		Repository:   a.Repository,
+		BaseRef:      a.BaseRef,
+		TargetRef:    a.TargetRef,
+		Review:       a.Review,
+		ProviderName: a.ProviderName,
+	})
+}
+
+// writeReviewOutputs writes a review in each selected per-review format
+// and returns the paths by format. label names the review in errors.
+func (o *Orchestrator) writeReviewOutputs(ctx context.Context, req BranchRequest, formats map[string]bool, diff domain.Diff, policies PathPolicies, r domain.Review, label string) (map[string]string, error) {
+	paths := make(map[string]string)
+	for _, format := range o.deps.Outputs.formats {
+		writer, ok := o.deps.Outputs.reviews[format]
+		if !ok || !formats[format] {
+			continue
+		}
+		path, err := writer.Write(ctx, ReportArtifact{
+			OutputDir:             req.OutputDir,
+			Repository:            req.Repository,
+			BaseRef:               req.BaseRef,
+			TargetRef:             req.TargetRef,
+			Diff:                  diff,
+			ConfigHash:            calculateConfigHash(req),
+			Review:                r,
+			ProviderName:          r.ProviderName,
+			BlockingSeverities:    blockingSeverities(req),
+			AlwaysBlockCategories: req.AlwaysBlockCategories,
+			PathPolicies:          policies,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s write failed for %s: %w", format, label, err)
+		}
+		if err := copyLatest(req, format, r.ProviderName, path); err != nil {
+			return nil, err
+		}
+		paths[format] = path
+	}
+	return paths, nil
+}
+
+// writeRunOutputs writes the run in each selected run-wide format and
+// returns the paths by format.
+func (o *Orchestrator) writeRunOutputs(ctx context.Context, req BranchRequest, formats map[string]bool, artifact RunArtifact) (map[string]string, error) {
+	paths := make(map[string]string)
+	for _, format := range o.deps.Outputs.formats {
+		writer, ok := o.deps.Outputs.runs[format]
+		if !ok || !formats[format] {
+			continue
+		}
+		path, err := writer.Write(ctx, artifact)
+		if err != nil {
+			return nil, fmt.Errorf("%s write failed: %w", format, err)
+		}
+		if err := copyLatest(req, format, mergedName, path); err != nil {
+			return nil, err
+		}
+		paths[format] = path
+	}
+	return paths, nil
+}
+
+// latestPath returns the stable path of a review's latest output, e.g.
+// out/repo_feature/latest-merged.json, which each run overwrites.
+func latestPath(req BranchRequest, format, name string) string {
+	ext, ok := formatExtensions[format]
+	if !ok {
+		ext = "." + format
+	}
+	return filepath.Join(req.OutputDir, fmt.Sprintf("%s_%s", req.Repository, req.TargetRef), "latest-"+name+ext)
+}
+
+// copyLatest copies a written output to its latest-* path.
+func copyLatest(req BranchRequest, format, name, path string) error {
+	latest := latestPath(req, format, name)
+	if err := os.MkdirAll(filepath.Dir(latest), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s output: %w", format, err)
+	}
+	if err := os.WriteFile(latest, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(latest), err)
+	}
+	return nil
+}
+
+// copyToStdout copies a written output to w.
+func copyToStdout(w io.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open output for stdout: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(w, file); err != nil {
+		return fmt.Errorf("failed to write output to stdout: %w", err)
+	}
+	return nil
+}
+
+// addPaths records a review's output paths under its name.
+func addPaths(all map[string]map[string]string, name string, paths map[string]string) {
+	for format, path := range paths {
+		if all[format] == nil {
+			all[format] = make(map[string]string)
+		}
+		all[format][name] = path
+	}
+}
+
+// pathsFor returns the paths of one format by review name, never nil.
+func pathsFor(all map[string]map[string]string, format string) map[string]string {
+	paths := make(map[string]string, len(all[format]))
+	for name, path := range all[format] {
+		paths[name] = path
+	}
+	return paths
+}
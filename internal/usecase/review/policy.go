@@ -0,0 +1,176 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/pathglob"
+)
+
+// PathPolicy is a review policy for files matching Paths, loaded from the
+// repository's .cr/rules.yaml. Empty fields inherit from earlier policies or
+// the global review configuration.
+type PathPolicy struct {
+	Paths []string // Path globs; "**" matches any number of directories
+
+	// Ignore excludes matching files from review entirely (nil inherits).
+	Ignore *bool
+
+	// Instructions are added to the prompt when a matching file changes.
+	Instructions string
+
+	// MinSeverity drops findings below this severity (a floor).
+	MinSeverity string
+
+	// MaxSeverity lowers findings above this severity to it (a ceiling).
+	MaxSeverity string
+
+	// Review actions per severity for findings in matching files.
+	ActionOnCritical string
+	ActionOnHigh     string
+	ActionOnMedium   string
+	ActionOnLow      string
+
+	// AlwaysBlockCategories replaces the global list for matching files when non-nil.
+	AlwaysBlockCategories []string
+}
+
+// PathPolicyLoader loads the path policies committed at ref. An empty ref
+// means the checked-out commit (e.g. when reviewing a patch file).
+type PathPolicyLoader func(ctx context.Context, ref string) (PathPolicies, error)
+
+// PathPolicies are applied in order; for each setting the last matching
+// policy wins, as in CODEOWNERS.
+type PathPolicies []PathPolicy
+
+// severityRanks orders severities from lowest to highest.
+var severityRanks = map[string]int{
+	"low":      1,
+	"medium":   2,
+	"high":     3,
+	"critical": 4,
+}
+
+// resolve merges the policies matching file into one.
+func (p PathPolicies) resolve(file string) PathPolicy {
+	var result PathPolicy
+	for _, policy := range p {
+		if !pathglob.MatchAny(policy.Paths, file) {
+			continue
+		}
+		if policy.Ignore != nil {
+			result.Ignore = policy.Ignore
+		}
+		if policy.MinSeverity != "" {
+			result.MinSeverity = policy.MinSeverity
+		}
+		if policy.MaxSeverity != "" {
+			result.MaxSeverity = policy.MaxSeverity
+		}
+		if policy.ActionOnCritical != "" {
+			result.ActionOnCritical = policy.ActionOnCritical
+		}
+		if policy.ActionOnHigh != "" {
+			result.ActionOnHigh = policy.ActionOnHigh
+		}
+		if policy.ActionOnMedium != "" {
+			result.ActionOnMedium = policy.ActionOnMedium
+		}
+		if policy.ActionOnLow != "" {
+			result.ActionOnLow = policy.ActionOnLow
+		}
+		if policy.AlwaysBlockCategories != nil {
+			result.AlwaysBlockCategories = policy.AlwaysBlockCategories
+		}
+	}
+	return result
+}
+
+// action returns the policy's review action for a severity, or "" if unset.
+func (p PathPolicy) action(severity string) string {
+	switch severity {
+	case "critical":
+		return p.ActionOnCritical
+	case "high":
+		return p.ActionOnHigh
+	case "medium":
+		return p.ActionOnMedium
+	case "low":
+		return p.ActionOnLow
+	default:
+		return ""
+	}
+}
+
+// Ignored reports whether file is excluded from review.
+func (p PathPolicies) Ignored(file string) bool {
+	ignore := p.resolve(file).Ignore
+	return ignore != nil && *ignore
+}
+
+// FilterDiff removes ignored files from the diff. Returns the filtered diff
+// and the paths of the removed files.
+func (p PathPolicies) FilterDiff(d domain.Diff) (domain.Diff, []string) {
+	if len(p) == 0 {
+		return d, nil
+	}
+	var kept []domain.FileDiff
+	var removed []string
+	for _, file := range d.Files {
+		if p.Ignored(file.Path) {
+			removed = append(removed, file.Path)
+			continue
+		}
+		kept = append(kept, file)
+	}
+	d.Files = kept
+	return d, removed
+}
+
+// Instructions returns the instructions of the policies matching any of the
+// changed paths, each labeled with the globs it applies to.
+func (p PathPolicies) Instructions(changedPaths []string) []string {
+	var instructions []string
+	for _, policy := range p {
+		text := strings.TrimSpace(policy.Instructions)
+		if text == "" {
+			continue
+		}
+		for _, path := range changedPaths {
+			if pathglob.MatchAny(policy.Paths, path) {
+				instructions = append(instructions, fmt.Sprintf("For files matching %s: %s", strings.Join(policy.Paths, ", "), text))
+				break
+			}
+		}
+	}
+	return instructions
+}
+
+// ApplyToFindings drops findings in ignored files and below the severity
+// floor of their file, and lowers findings above its severity ceiling.
+func (p PathPolicies) ApplyToFindings(findings []domain.Finding) []domain.Finding {
+	if len(p) == 0 {
+		return findings
+	}
+	result := make([]domain.Finding, 0, len(findings))
+	for _, finding := range findings {
+		policy := p.resolve(finding.File)
+		if policy.Ignore != nil && *policy.Ignore {
+			continue
+		}
+
+		rank, known := severityRanks[strings.ToLower(finding.Severity)]
+		if known {
+			if floor, ok := severityRanks[strings.ToLower(policy.MinSeverity)]; ok && rank < floor {
+				continue
+			}
+			if ceiling, ok := severityRanks[strings.ToLower(policy.MaxSeverity)]; ok && rank > ceiling {
+				finding.Severity = strings.ToLower(policy.MaxSeverity)
+			}
+		}
+		result = append(result, finding)
+	}
+	return result
+}
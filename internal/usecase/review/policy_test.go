@@ -0,0 +1,126 @@
+package review
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/domain"
+)
+
+func boolPtr(b bool) *bool { return &b }
+
+var testPolicies = PathPolicies{
+	{Paths: []string{"vendor/**", "*.pb.go"}, Ignore: boolPtr(true)},
+	{Paths: []string{"vendor/example.com/patched/**"}, Ignore: boolPtr(false)},
+	{Paths: []string{"auth/**"}, Instructions: "Focus on session handling and token validation."},
+	{Paths: []string{"payments/**"}, MinSeverity: "medium", ActionOnMedium: "request_changes"},
+	{Paths: []string{"**/*_test.go"}, MaxSeverity: "medium"},
+}
+
+func TestPathPolicies_FilterDiff(t *testing.T) {
+	diff, removed := testPolicies.FilterDiff(domain.Diff{Files: []domain.FileDiff{
+		{Path: "main.go"},
+		{Path: "vendor/github.com/lib/lib.go"},
+		{Path: "vendor/example.com/patched/fix.go"},
+		{Path: "api/v1/service.pb.go"},
+	}})
+
+	var kept []string
+	for _, f := range diff.Files {
+		kept = append(kept, f.Path)
+	}
+	if want := []string{"main.go", "vendor/example.com/patched/fix.go"}; !reflect.DeepEqual(kept, want) {
+		t.Errorf("expected kept files %v, got %v", want, kept)
+	}
+	if want := []string{"vendor/github.com/lib/lib.go", "api/v1/service.pb.go"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("expected removed files %v, got %v", want, removed)
+	}
+
+	// No policies leaves the diff untouched
+	unchanged, removed := PathPolicies(nil).FilterDiff(domain.Diff{Files: []domain.FileDiff{{Path: "vendor/a.go"}}})
+	if len(unchanged.Files) != 1 || removed != nil {
+		t.Errorf("expected no filtering without policies")
+	}
+}
+
+func TestPathPolicies_Instructions(t *testing.T) {
+	got := testPolicies.Instructions([]string{"auth/session.go", "auth/token.go", "main.go"})
+	want := []string{"For files matching auth/**: Focus on session handling and token validation."}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if got := testPolicies.Instructions([]string{"main.go"}); len(got) != 0 {
+		t.Errorf("expected no instructions, got %v", got)
+	}
+}
+
+func TestPathPolicies_ApplyToFindings(t *testing.T) {
+	findings := []domain.Finding{
+		{File: "payments/charge.go", Severity: "low", Description: "below the payments floor"},
+		{File: "payments/charge.go", Severity: "high", Description: "kept"},
+		{File: "api/handler_test.go", Severity: "critical", Description: "capped"},
+		{File: "api/handler_test.go", Severity: "low", Description: "under the ceiling"},
+		{File: "vendor/github.com/lib/lib.go", Severity: "high", Description: "ignored file"},
+		{File: "main.go", Severity: "low", Description: "no policy"},
+	}
+
+	got := testPolicies.ApplyToFindings(findings)
+
+	want := []struct{ desc, severity string }{
+		{"kept", "high"},
+		{"capped", "medium"},
+		{"under the ceiling", "low"},
+		{"no policy", "low"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d findings, got %d: %+v", len(want), len(got), got)
+	}
+	for i, w := range want {
+		if got[i].Description != w.desc || got[i].Severity != w.severity {
+			t.Errorf("finding %d: expected %q (%s), got %q (%s)", i, w.desc, w.severity, got[i].Description, got[i].Severity)
+		}
+	}
+
+	// The input slice is not modified
+	if findings[2].Severity != "critical" {
+		t.Errorf("expected input findings to be unchanged, got %s", findings[2].Severity)
+	}
+}
+
+func TestReportArtifact_Blocks_AppliesPathPolicies(t *testing.T) {
+	artifact := ReportArtifact{
+		BlockingSeverities:    []string{"critical", "high"},
+		AlwaysBlockCategories: []string{"security"},
+		PathPolicies: PathPolicies{
+			{Paths: []string{"payments/**"}, ActionOnMedium: "request_changes", AlwaysBlockCategories: []string{"performance"}},
+			{Paths: []string{"docs/**"}, ActionOnHigh: "comment", AlwaysBlockCategories: []string{}},
+			{Paths: []string{"tools/**"}, ActionOnHigh: "approv"}, // typo falls back to the default
+		},
+	}
+
+	tests := []struct {
+		name    string
+		finding domain.Finding
+		want    bool
+	}{
+		{"global severity", domain.Finding{File: "main.go", Severity: "high"}, true},
+		{"global category", domain.Finding{File: "main.go", Severity: "low", Category: "Security"}, true},
+		{"global non-blocking", domain.Finding{File: "main.go", Severity: "medium"}, false},
+		{"path action blocks", domain.Finding{File: "payments/charge.go", Severity: "medium"}, true},
+		{"path categories replace the global list", domain.Finding{File: "payments/charge.go", Severity: "low", Category: "security"}, false},
+		{"path category blocks", domain.Finding{File: "payments/charge.go", Severity: "low", Category: "performance"}, true},
+		{"inherited severity still blocks", domain.Finding{File: "payments/charge.go", Severity: "critical"}, true},
+		{"path action unblocks", domain.Finding{File: "docs/guide.md", Severity: "high"}, false},
+		{"empty path categories block nothing", domain.Finding{File: "docs/guide.md", Severity: "low", Category: "security"}, false},
+		{"unknown path action uses the default", domain.Finding{File: "tools/gen.go", Severity: "high"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := artifact.Blocks(tt.finding); got != tt.want {
+				t.Errorf("Blocks(%+v) = %v, want %v", tt.finding, got, tt.want)
+			}
+		})
+	}
+}
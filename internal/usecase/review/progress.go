@@ -0,0 +1,105 @@
+package review
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Progress event states.
+const (
+	ProgressStarted  = "started"
+	ProgressFinished = "finished"
+	ProgressFailed   = "failed"
+)
+
+// ProgressEvent reports a review stage starting or ending: diff, context,
+// provider (once per provider), merge, verify or publish.
+type ProgressEvent struct {
+	Stage      string
+	State      string // ProgressStarted, ProgressFinished or ProgressFailed
+	Attributes map[string]interface{}
+	Error      string // Set when State is ProgressFailed
+	Time       time.Time
+}
+
+// ProgressFunc receives a review's progress events. Provider stages run
+// concurrently, so it must be safe for concurrent use.
+type ProgressFunc func(ProgressEvent)
+
+type progressKey struct{}
+
+// WithProgress returns a context that reports the progress of a review
+// run with it to fn.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+func progressFrom(ctx context.Context) ProgressFunc {
+	fn, _ := ctx.Value(progressKey{}).(ProgressFunc)
+	return fn
+}
+
+// progressTracer reports the review stages' spans as progress events to
+// the ProgressFunc in the context, if any, and passes them on to the
+// configured Tracer.
+type progressTracer struct {
+	next Tracer
+}
+
+func (t progressTracer) StartSpan(ctx context.Context, name string, attributes map[string]interface{}) (context.Context, Span) {
+	ctx, span := t.next.StartSpan(ctx, name, attributes)
+
+	// The root "review" span covers the whole run; its stages are reported
+	stage, ok := strings.CutPrefix(name, "review.")
+	fn := progressFrom(ctx)
+	if fn == nil || !ok {
+		return ctx, span
+	}
+
+	p := &progressSpan{Span: span, fn: fn, stage: stage, attributes: make(map[string]interface{}, len(attributes))}
+	for k, v := range attributes {
+		p.attributes[k] = v
+	}
+	fn(ProgressEvent{Stage: stage, State: ProgressStarted, Attributes: p.copyAttributes(), Time: time.Now()})
+	return ctx, p
+}
+
+// progressSpan reports the end of a stage with the attributes set on it.
+type progressSpan struct {
+	Span
+	fn         ProgressFunc
+	stage      string
+	attributes map[string]interface{}
+	err        error
+}
+
+func (s *progressSpan) SetAttributes(attributes map[string]interface{}) {
+	s.Span.SetAttributes(attributes)
+	for k, v := range attributes {
+		s.attributes[k] = v
+	}
+}
+
+func (s *progressSpan) RecordError(err error) {
+	s.Span.RecordError(err)
+	s.err = err
+}
+
+func (s *progressSpan) End() {
+	s.Span.End()
+	event := ProgressEvent{Stage: s.stage, State: ProgressFinished, Attributes: s.copyAttributes(), Time: time.Now()}
+	if s.err != nil {
+		event.State = ProgressFailed
+		event.Error = s.err.Error()
+	}
+	s.fn(event)
+}
+
+func (s *progressSpan) copyAttributes() map[string]interface{} {
+	attributes := make(map[string]interface{}, len(s.attributes))
+	for k, v := range s.attributes {
+		attributes[k] = v
+	}
+	return attributes
+}
@@ -0,0 +1,67 @@
+package review_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/review"
+)
+
+func TestReviewBranch_ReportsProgress(t *testing.T) {
+	tracer := &recordingTracer{}
+	orchestrator := review.NewOrchestrator(review.OrchestratorDeps{
+		Git: &mockGitEngine{diff: domain.Diff{Files: []domain.FileDiff{{Path: "main.go", Status: "modified"}}}},
+		Providers: map[string]review.Provider{
+			"openai": &mockProvider{response: domain.Review{ProviderName: "openai", Findings: []domain.Finding{{File: "main.go"}}}},
+			"gemini": &mockProvider{err: errors.New("overloaded")},
+		},
+		Merger:        &mockMerger{},
+		Tracer:        tracer,
+		SeedGenerator: func(_, _ string) uint64 { return 1 },
+		PromptBuilder: func(ctx review.ProjectContext, d domain.Diff, req review.BranchRequest, providerName string) (review.ProviderRequest, error) {
+			return review.ProviderRequest{Prompt: "prompt"}, nil
+		},
+	})
+
+	var mu sync.Mutex
+	var events []review.ProgressEvent
+	ctx := review.WithProgress(context.Background(), func(e review.ProgressEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, e)
+	})
+
+	_, err := orchestrator.ReviewBranch(ctx, review.BranchRequest{BaseRef: "main", TargetRef: "feature", OutputDir: t.TempDir()})
+	if err == nil {
+		t.Fatal("expected the failing provider to fail the review")
+	}
+
+	var got []string
+	for _, e := range events {
+		got = append(got, e.Stage+":"+e.State)
+		if e.Stage == "provider" && e.State == review.ProgressFinished && e.Attributes["findings"] != 1 {
+			t.Errorf("expected finished provider event to carry the span attributes, got %+v", e.Attributes)
+		}
+		if e.Stage == "provider" && e.State == review.ProgressFailed && !strings.Contains(e.Error, "overloaded") {
+			t.Errorf("expected failed provider event to carry the error, got %q", e.Error)
+		}
+	}
+	joined := strings.Join(got, ",")
+	for _, want := range []string{"diff:started", "diff:finished", "context:finished", "provider:finished", "provider:failed"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("expected %s in progress events, got %s", want, joined)
+		}
+	}
+	if !strings.HasPrefix(joined, "diff:started,diff:finished") {
+		t.Errorf("expected the diff stage first, got %s", joined)
+	}
+
+	// The configured tracer still sees the spans
+	if len(tracer.byName("review.diff")) != 1 {
+		t.Error("expected spans to reach the configured tracer")
+	}
+}
@@ -305,6 +305,8 @@ type TemplateData struct {
 	CustomInstructions string
 	CustomContext      string // User-provided files
 	RelevantDocs       string // Concatenated relevant docs
+	PathInstructions   string // Instructions from matching context rules
+	DirectoryContext   string // Concatenated .cr/context.md files
 	ChangeTypes        []string
 	ChangedPaths       []string
 
@@ -314,6 +316,12 @@ type TemplateData struct {
 
 	// Diff content
 	Diff string
+
+	// HasCodeContext is true when the diff includes expanded code context.
+	HasCodeContext bool
+
+	// AffectedCallSites lists callers of the changed functions.
+	AffectedCallSites string
 }
 
 // renderTemplate renders a prompt template with context and diff.
@@ -331,11 +339,15 @@ func (b *EnhancedPromptBuilder) renderTemplate(
 		CustomInstructions: context.CustomInstructions,
 		CustomContext:      strings.Join(context.CustomContextFiles, "\n\n"),
 		RelevantDocs:       strings.Join(context.RelevantDocs, "\n\n"),
+		PathInstructions:   strings.Join(context.PathInstructions, "\n\n"),
+		DirectoryContext:   strings.Join(context.DirectoryContext, "\n\n"),
 		ChangeTypes:        context.ChangeTypes,
 		ChangedPaths:       context.ChangedPaths,
 		BaseRef:            req.BaseRef,
 		TargetRef:          req.TargetRef,
-		Diff:               b.formatDiff(diff),
+		Diff:               b.formatDiff(diff, context.CodeContext),
+		HasCodeContext:     len(context.CodeContext) > 0,
+		AffectedCallSites:  context.AffectedCallSites,
 	}
 
 	// Create template with custom functions
@@ -358,7 +370,8 @@ func (b *EnhancedPromptBuilder) renderTemplate(
 // formatDiff converts a domain.Diff into a human-readable string.
 // Files are sorted with source code first and documentation last to ensure
 // the LLM prioritizes code review over documentation review.
-func (b *EnhancedPromptBuilder) formatDiff(diff domain.Diff) string {
+// Each file's expanded code context, if any, follows its patch.
+func (b *EnhancedPromptBuilder) formatDiff(diff domain.Diff, codeContext map[string]string) string {
 	if len(diff.Files) == 0 {
 		return "(no changes)"
 	}
@@ -378,6 +391,11 @@ func (b *EnhancedPromptBuilder) formatDiff(diff domain.Diff) string {
 			buf.WriteString(file.Patch)
 			buf.WriteString("\n")
 		}
+		if context := codeContext[file.Path]; context != "" {
+			buf.WriteString(fmt.Sprintf("Context for %s (imports and enclosing declarations, new version):\n", file.Path))
+			buf.WriteString(context)
+			buf.WriteString("\n")
+		}
 	}
 
 	return buf.String()
@@ -441,14 +459,36 @@ Target Ref: {{.TargetRef}}
 
 IMPORTANT: Review ALL code files below, especially source code (.go, .py, .js, .ts, etc.).
 Look for: bugs, security vulnerabilities, logic errors, performance issues, and code quality problems.
-
+{{if .HasCodeContext}}
+Some files are followed by their imports and the full declarations enclosing each change, with line numbers.
+Check this context before reporting missing imports, undefined identifiers or unhandled cases; only report what the code shown supports.
+{{end}}
 {{.Diff}}
+{{if .AffectedCallSites}}
+## Affected Call Sites
+
+Callers of the functions changed above, nearest first. Check that they still match the new signature and behavior.
+Report a broken caller on the changed function's line, naming the caller's file and line in the description.
 
+{{.AffectedCallSites}}
+{{end}}
 {{if .CustomInstructions}}
 ## Review Instructions
 {{.CustomInstructions}}
 {{end}}
 
+{{if .PathInstructions}}
+## Instructions for the Changed Areas
+{{.PathInstructions}}
+{{end}}
+
+{{if .DirectoryContext}}
+## Directory Context
+Notes from the maintainers of the changed directories:
+
+{{.DirectoryContext}}
+{{end}}
+
 {{if .CustomContext}}
 ## Additional Context
 {{.CustomContext}}
@@ -177,7 +177,7 @@ func TestFormatDiff(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			builder := &EnhancedPromptBuilder{}
-			result := builder.formatDiff(tt.diff)
+			result := builder.formatDiff(tt.diff, nil)
 
 			for _, expected := range tt.expected {
 				if !strings.Contains(result, expected) {
@@ -453,7 +453,7 @@ func TestFormatDiff_FileOrdering(t *testing.T) {
 		},
 	}
 
-	result := builder.formatDiff(diff)
+	result := builder.formatDiff(diff, nil)
 
 	// Find positions of each file in the output
 	goPos := strings.Index(result, "main.go")
@@ -0,0 +1,38 @@
+package review
+
+import "context"
+
+// Tracer defines the outbound port for tracing the stages of a review.
+// Implementations carry the started span in the returned context, so spans
+// started further down (provider calls, verification, publishing) nest
+// under it.
+type Tracer interface {
+	// StartSpan starts a span named name with the given attributes.
+	// The caller must End the returned span.
+	StartSpan(ctx context.Context, name string, attributes map[string]interface{}) (context.Context, Span)
+}
+
+// Span is a single timed operation within a trace.
+type Span interface {
+	// SetAttributes adds or replaces attributes on the span.
+	SetAttributes(attributes map[string]interface{})
+
+	// RecordError marks the span as failed with err.
+	RecordError(err error)
+
+	// End completes the span.
+	End()
+}
+
+// noopTracer is used when no Tracer is configured.
+type noopTracer struct{}
+
+func (noopTracer) StartSpan(ctx context.Context, _ string, _ map[string]interface{}) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(map[string]interface{}) {}
+func (noopSpan) RecordError(error)                    {}
+func (noopSpan) End()                                 {}
@@ -0,0 +1,176 @@
+package review_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/review"
+)
+
+type spanParentKey struct{}
+
+// recordedSpan is a span captured by recordingTracer.
+type recordedSpan struct {
+	name       string
+	parent     string
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+// recordingTracer records spans and their parent via the context.
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+func (r *recordingTracer) StartSpan(ctx context.Context, name string, attributes map[string]interface{}) (context.Context, review.Span) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	parent, _ := ctx.Value(spanParentKey{}).(string)
+	span := &recordedSpan{name: name, parent: parent, attributes: map[string]interface{}{}}
+	for k, v := range attributes {
+		span.attributes[k] = v
+	}
+	r.spans = append(r.spans, span)
+	return context.WithValue(ctx, spanParentKey{}, name), &recordingSpan{tracer: r, span: span}
+}
+
+func (r *recordingTracer) byName(name string) []*recordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var spans []*recordedSpan
+	for _, s := range r.spans {
+		if s.name == name {
+			spans = append(spans, s)
+		}
+	}
+	return spans
+}
+
+type recordingSpan struct {
+	tracer *recordingTracer
+	span   *recordedSpan
+}
+
+func (s *recordingSpan) SetAttributes(attributes map[string]interface{}) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	for k, v := range attributes {
+		s.span.attributes[k] = v
+	}
+}
+
+func (s *recordingSpan) RecordError(err error) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.span.err = err
+}
+
+func (s *recordingSpan) End() {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.span.ended = true
+}
+
+func TestReviewBranch_TracesStages(t *testing.T) {
+	diff := domain.Diff{
+		Files: []domain.FileDiff{{Path: "main.go", Status: "modified", Patch: "@@ -0,0 +1 @@\n+package main"}},
+	}
+	tracer := &recordingTracer{}
+	publisher := &mockPublisher{result: &review.PublishResult{CommentsPosted: 1, DuplicatesSkipped: 2}}
+
+	orchestrator := review.NewOrchestrator(review.OrchestratorDeps{
+		Git: &mockGitEngine{diff: diff},
+		Providers: map[string]review.Provider{
+			"openai": &mockProvider{response: domain.Review{ProviderName: "openai", ModelName: "gpt-4o", TokensIn: 100, TokensOut: 20, Cost: 0.5}},
+			"gemini": &mockProvider{response: domain.Review{ProviderName: "gemini", ModelName: "gemini-2.5-pro", Cost: 0.25}},
+		},
+		Merger:        &mockMerger{},
+		Markdown:      &mockMarkdownWriter{},
+		JSON:          &mockJSONWriter{},
+		SARIF:         &mockSARIFWriter{},
+		Publisher:     publisher,
+		Tracer:        tracer,
+		SeedGenerator: func(_, _ string) uint64 { return 1 },
+		PromptBuilder: func(ctx review.ProjectContext, d domain.Diff, req review.BranchRequest, providerName string) (review.ProviderRequest, error) {
+			return review.ProviderRequest{Prompt: "prompt"}, nil
+		},
+	})
+
+	_, err := orchestrator.ReviewBranch(context.Background(), review.BranchRequest{
+		BaseRef:    "main",
+		TargetRef:  "feature",
+		OutputDir:  t.TempDir(),
+		PostReview: true,
+		RepoOwner:  "owner",
+		RepoName:   "repo",
+		PRNumber:   7,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, name := range []string{"review.diff", "review.context", "review.merge", "review.publish"} {
+		spans := tracer.byName(name)
+		if len(spans) != 1 || spans[0].parent != "review" || !spans[0].ended {
+			t.Errorf("expected one ended %s span under review, got %+v", name, spans)
+		}
+	}
+
+	providers := tracer.byName("review.provider")
+	if len(providers) != 2 {
+		t.Fatalf("expected a span per provider, got %d", len(providers))
+	}
+	for _, span := range providers {
+		if span.attributes["provider"] == "openai" {
+			if span.attributes["model"] != "gpt-4o" || span.attributes["tokensIn"] != 100 || span.attributes["cost"] != 0.5 {
+				t.Errorf("unexpected openai span attributes: %+v", span.attributes)
+			}
+		}
+		if !span.ended {
+			t.Errorf("expected provider span %v to be ended", span.attributes["provider"])
+		}
+	}
+
+	if publish := tracer.byName("review.publish")[0]; publish.attributes["duplicatesSkipped"] != 2 {
+		t.Errorf("expected duplicatesSkipped on the publish span, got %+v", publish.attributes)
+	}
+	if root := tracer.byName("review")[0]; root.parent != "" || !root.ended {
+		t.Errorf("expected an ended root review span, got %+v", root)
+	}
+}
+
+func TestReviewBranch_TracesProviderError(t *testing.T) {
+	tracer := &recordingTracer{}
+	orchestrator := review.NewOrchestrator(review.OrchestratorDeps{
+		Git: &mockGitEngine{diff: domain.Diff{Files: []domain.FileDiff{{Path: "main.go", Status: "modified"}}}},
+		Providers: map[string]review.Provider{
+			"openai": &mockProvider{err: errors.New("rate limited")},
+		},
+		Merger:        &mockMerger{},
+		Markdown:      &mockMarkdownWriter{},
+		JSON:          &mockJSONWriter{},
+		SARIF:         &mockSARIFWriter{},
+		Tracer:        tracer,
+		SeedGenerator: func(_, _ string) uint64 { return 1 },
+		PromptBuilder: func(ctx review.ProjectContext, d domain.Diff, req review.BranchRequest, providerName string) (review.ProviderRequest, error) {
+			return review.ProviderRequest{Prompt: "prompt"}, nil
+		},
+	})
+
+	_, err := orchestrator.ReviewBranch(context.Background(), review.BranchRequest{BaseRef: "main", TargetRef: "feature", OutputDir: t.TempDir()})
+	if err == nil {
+		t.Fatal("expected provider error")
+	}
+
+	if provider := tracer.byName("review.provider"); len(provider) != 1 || provider[0].err == nil {
+		t.Errorf("expected the provider span to record the error, got %+v", provider)
+	}
+	if root := tracer.byName("review"); len(root) != 1 || root[0].err == nil || !root[0].ended {
+		t.Errorf("expected the review span to record the error, got %+v", root)
+	}
+}
@@ -75,13 +75,17 @@ func buildVerifiedFindings(candidates []domain.CandidateFinding, results []domai
 		result := results[i]
 
 		verified = append(verified, domain.VerifiedFinding{
-			Finding:         candidate.Finding,
-			Verified:        result.Verified,
-			Classification:  result.Classification,
-			Confidence:      result.Confidence,
-			Evidence:        result.Evidence,
-			BlocksOperation: result.BlocksOperation,
-			VerificationLog: result.Actions,
+			Finding:          candidate.Finding,
+			Verified:         result.Verified,
+			Classification:   result.Classification,
+			Confidence:       result.Confidence,
+			Evidence:         result.Evidence,
+			BlocksOperation:  result.BlocksOperation,
+			VerificationLog:  result.Actions,
+			VerificationPath: result.Path,
+			TokensIn:         result.TokensIn,
+			TokensOut:        result.TokensOut,
+			Cost:             result.Cost,
 		})
 	}
 
@@ -168,8 +172,14 @@ func (o *Orchestrator) verifyFindings(
 		return candidates, nil, nil, nil
 	}
 
-	// Verify candidates
-	results, err := o.deps.Verifier.VerifyBatch(ctx, candidates)
+	// Verify candidates, honoring the review's depth if the verifier supports it
+	var results []domain.VerificationResult
+	var err error
+	if dv, ok := o.deps.Verifier.(DepthVerifier); ok {
+		results, err = dv.VerifyBatchAtDepth(ctx, settings.Depth, candidates)
+	} else {
+		results, err = o.deps.Verifier.VerifyBatch(ctx, candidates)
+	}
 	if err != nil {
 		return candidates, nil, nil, err
 	}
@@ -183,6 +193,26 @@ func (o *Orchestrator) verifyFindings(
 	return candidates, verified, reportable, nil
 }
 
+// verificationUsage sums the LLM usage recorded on verified findings.
+func verificationUsage(verified []domain.VerifiedFinding) (tokensIn, tokensOut int, cost float64) {
+	for _, v := range verified {
+		tokensIn += v.TokensIn
+		tokensOut += v.TokensOut
+		cost += v.Cost
+	}
+	return tokensIn, tokensOut, cost
+}
+
+// countToolCalls counts the tools the verification agent ran across all
+// verified findings.
+func countToolCalls(verified []domain.VerifiedFinding) int {
+	count := 0
+	for _, v := range verified {
+		count += len(v.VerificationLog)
+	}
+	return count
+}
+
 // convertVerifiedToFindings converts verified findings back to regular findings
 // for backward compatibility with existing GitHub poster and markdown writer.
 func convertVerifiedToFindings(verified []domain.VerifiedFinding) []domain.Finding {
@@ -205,6 +235,7 @@ func logVerificationDetails(ctx context.Context, verified []domain.VerifiedFindi
 
 	// Log header
 	log.Println("=== VERIFICATION REPORT ===")
+	log.Printf("Mode: %s (depth=%s)", domain.VerificationModeForDepth(settings.Depth), settings.Depth)
 	log.Printf("Total findings: %d | Reportable: %d | Filtered: %d",
 		len(verified), len(reportable), len(verified)-len(reportable))
 	log.Println("")
@@ -235,7 +266,7 @@ func logVerificationDetails(ctx context.Context, verified []domain.VerifiedFindi
 		log.Printf("[%d] %s | %s:%d | %s | confidence=%d threshold=%d",
 			i+1, status, v.Finding.File, v.Finding.LineStart, v.Finding.Severity, v.Confidence, threshold)
 		log.Printf("    Description: %.80s...", truncateString(v.Finding.Description, 80))
-		log.Printf("    Verified: %t | Classification: %s", v.Verified, v.Classification)
+		log.Printf("    Verified: %t | Classification: %s | Path: %s", v.Verified, v.Classification, v.VerificationPath)
 		log.Printf("    Evidence: %.100s", truncateString(v.Evidence, 100))
 		if filterReason != "" {
 			log.Printf("    Filter Reason: %s", filterReason)
@@ -171,6 +171,21 @@ func TestBuildVerifiedFindings(t *testing.T) {
 		}
 	})
 
+	t.Run("records verification path", func(t *testing.T) {
+		withPath := []domain.VerificationResult{
+			{Verified: true, Confidence: 90, Path: domain.VerificationPathEscalated},
+			{Verified: false, Confidence: 30, Path: domain.VerificationModeBatch},
+		}
+		verified := buildVerifiedFindings(candidates, withPath)
+
+		if verified[0].VerificationPath != "batch+agent" {
+			t.Errorf("expected path batch+agent, got %q", verified[0].VerificationPath)
+		}
+		if verified[1].VerificationPath != "batch" {
+			t.Errorf("expected path batch, got %q", verified[1].VerificationPath)
+		}
+	})
+
 	t.Run("returns nil for mismatched lengths", func(t *testing.T) {
 		verified := buildVerifiedFindings(candidates[:1], results)
 		if verified != nil {
@@ -370,3 +385,21 @@ func TestConvertVerifiedToFindings(t *testing.T) {
 		t.Errorf("second finding mismatch: %+v", findings[1])
 	}
 }
+
+func TestVerificationModeForDepth(t *testing.T) {
+	tests := []struct {
+		depth string
+		want  string
+	}{
+		{"minimal", domain.VerificationModeBatch},
+		{"medium", domain.VerificationModeHybrid},
+		{"thorough", domain.VerificationModeAgent},
+		{"", domain.VerificationModeHybrid},
+	}
+
+	for _, tt := range tests {
+		if got := domain.VerificationModeForDepth(tt.depth); got != tt.want {
+			t.Errorf("VerificationModeForDepth(%q) = %q, want %q", tt.depth, got, tt.want)
+		}
+	}
+}
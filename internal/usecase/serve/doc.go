@@ -0,0 +1,7 @@
+// Package serve runs reviews submitted to the HTTP API as queued jobs.
+//
+// A Queue persists each job through a JobStore before running it, so jobs
+// and their results survive restarts: on Start, jobs still queued or
+// interrupted while running are queued again. Subscribers receive a job's
+// status changes and the review's progress events as it runs.
+package serve
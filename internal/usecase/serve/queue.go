@@ -0,0 +1,352 @@
+package serve
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/review"
+)
+
+// ErrJobNotFound is returned for unknown job IDs.
+var ErrJobNotFound = errors.New("job not found")
+
+// ErrQueueFull is returned by Submit when MaxQueued jobs are waiting.
+var ErrQueueFull = errors.New("queue is full")
+
+// ErrQueueClosed is returned by Submit once the queue has shut down.
+var ErrQueueClosed = errors.New("queue is closed")
+
+// Status is the state of a job.
+type Status string
+
+// Job statuses.
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+// Done reports whether the job has finished.
+func (s Status) Done() bool {
+	return s == StatusSucceeded || s == StatusFailed
+}
+
+// Reviewer runs a review. It is implemented by review.Orchestrator.
+type Reviewer interface {
+	ReviewBranch(ctx context.Context, req review.BranchRequest) (review.Result, error)
+}
+
+// JobStore persists jobs.
+type JobStore interface {
+	// SaveJob creates or replaces a job.
+	SaveJob(ctx context.Context, job Job) error
+	// GetJob returns ErrJobNotFound for unknown IDs.
+	GetJob(ctx context.Context, id string) (Job, error)
+	// ListJobs returns the jobs with any of the statuses, oldest first.
+	ListJobs(ctx context.Context, statuses ...Status) ([]Job, error)
+}
+
+// Job is one review submitted to the queue.
+type Job struct {
+	ID         string
+	Status     Status
+	Request    review.BranchRequest
+	Result     *Result // Set when Status is StatusSucceeded
+	Error      string  // Set when Status is StatusFailed
+	CreatedAt  time.Time
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// Result is the outcome of a successful job.
+type Result struct {
+	Review     domain.Review                // The merged review
+	Paths      map[string]map[string]string // Output paths by format and review name
+	BaseSHA    string
+	TargetSHA  string
+	ConfigHash string
+}
+
+// Event reports a change of a job's status or, when Progress is set, the
+// progress of its running review.
+type Event struct {
+	JobID    string
+	Status   Status
+	Progress *review.ProgressEvent
+}
+
+// Options configures a Queue.
+type Options struct {
+	Concurrency int // Reviews run at once (default 1)
+	MaxQueued   int // Jobs waiting to run before Submit fails (default 100)
+}
+
+// subscriberBuffer is the number of events buffered per subscriber; events
+// are dropped for subscribers that fall further behind.
+const subscriberBuffer = 64
+
+// Queue runs submitted jobs with a fixed number of workers.
+type Queue struct {
+	reviewer Reviewer
+	store    JobStore
+	opts     Options
+
+	mu          sync.Mutex
+	cond        *sync.Cond
+	pending     []Job
+	closed      bool
+	subscribers map[string]map[chan Event]struct{}
+	workers     sync.WaitGroup
+}
+
+// NewQueue creates a queue that runs jobs with the reviewer and persists
+// them in the store. Call Start to begin running jobs.
+func NewQueue(reviewer Reviewer, store JobStore, opts Options) *Queue {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if opts.MaxQueued <= 0 {
+		opts.MaxQueued = 100
+	}
+	q := &Queue{
+		reviewer:    reviewer,
+		store:       store,
+		opts:        opts,
+		subscribers: make(map[string]map[chan Event]struct{}),
+	}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// Start queues the jobs left queued or running by a previous process and
+// starts the workers. When ctx is canceled, the workers stop taking jobs
+// and running reviews are canceled; those jobs stay unfinished in the
+// store and run again on the next Start.
+func (q *Queue) Start(ctx context.Context) error {
+	recovered, err := q.store.ListJobs(ctx, StatusQueued, StatusRunning)
+	if err != nil {
+		return fmt.Errorf("failed to load unfinished jobs: %w", err)
+	}
+
+	q.mu.Lock()
+	for _, job := range recovered {
+		job.Status = StatusQueued
+		job.StartedAt = time.Time{}
+		q.pending = append(q.pending, job)
+	}
+	q.mu.Unlock()
+
+	for i := 0; i < q.opts.Concurrency; i++ {
+		q.workers.Add(1)
+		go q.work(ctx)
+	}
+
+	go func() {
+		<-ctx.Done()
+		q.close()
+	}()
+	return nil
+}
+
+// Wait blocks until the workers have stopped.
+func (q *Queue) Wait() {
+	q.workers.Wait()
+}
+
+// Submit persists a new job for the request and queues it.
+func (q *Queue) Submit(ctx context.Context, req review.BranchRequest) (Job, error) {
+	id, err := newJobID(time.Now())
+	if err != nil {
+		return Job{}, err
+	}
+	job := Job{ID: id, Status: StatusQueued, Request: req, CreatedAt: time.Now()}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return Job{}, ErrQueueClosed
+	}
+	if len(q.pending) >= q.opts.MaxQueued {
+		return Job{}, ErrQueueFull
+	}
+	if err := q.store.SaveJob(ctx, job); err != nil {
+		return Job{}, fmt.Errorf("failed to save job: %w", err)
+	}
+	q.pending = append(q.pending, job)
+	q.cond.Signal()
+	return job, nil
+}
+
+// Get returns a job by ID.
+func (q *Queue) Get(ctx context.Context, id string) (Job, error) {
+	return q.store.GetJob(ctx, id)
+}
+
+// Subscribe returns the events of a job from now on and a function that
+// ends the subscription. The channel is closed once the job finishes or the
+// queue shuts down; it is never closed for jobs that have already finished,
+// so check the job's status after subscribing.
+func (q *Queue) Subscribe(id string) (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	if q.subscribers[id] == nil {
+		q.subscribers[id] = make(map[chan Event]struct{})
+	}
+	q.subscribers[id][ch] = struct{}{}
+
+	return ch, func() {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		if _, ok := q.subscribers[id][ch]; ok {
+			delete(q.subscribers[id], ch)
+			close(ch)
+		}
+		if len(q.subscribers[id]) == 0 {
+			delete(q.subscribers, id)
+		}
+	}
+}
+
+// work runs queued jobs until the queue closes.
+func (q *Queue) work(ctx context.Context) {
+	defer q.workers.Done()
+	for {
+		job, ok := q.next()
+		if !ok {
+			return
+		}
+		q.run(ctx, job)
+	}
+}
+
+// next waits for a queued job; it returns false once the queue closes.
+func (q *Queue) next() (Job, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for len(q.pending) == 0 && !q.closed {
+		q.cond.Wait()
+	}
+	if q.closed {
+		return Job{}, false
+	}
+	job := q.pending[0]
+	q.pending = q.pending[1:]
+	return job, true
+}
+
+// run runs one job's review and persists its outcome.
+func (q *Queue) run(ctx context.Context, job Job) {
+	job.Status = StatusRunning
+	job.StartedAt = time.Now()
+	if err := q.store.SaveJob(ctx, job); err != nil {
+		q.finish(ctx, job, nil, fmt.Errorf("failed to save job: %w", err))
+		return
+	}
+	q.publish(Event{JobID: job.ID, Status: StatusRunning})
+
+	progressCtx := review.WithProgress(ctx, func(e review.ProgressEvent) {
+		q.publish(Event{JobID: job.ID, Status: StatusRunning, Progress: &e})
+	})
+	result, err := q.reviewer.ReviewBranch(progressCtx, job.Request)
+
+	// A review canceled by shutdown is left running in the store so the
+	// next Start runs it again
+	if ctx.Err() != nil {
+		return
+	}
+	q.finish(ctx, job, &result, err)
+}
+
+// finish persists a job's outcome and ends its subscriptions.
+func (q *Queue) finish(ctx context.Context, job Job, result *review.Result, err error) {
+	job.FinishedAt = time.Now()
+	if err != nil {
+		job.Status = StatusFailed
+		job.Error = err.Error()
+	} else {
+		job.Status = StatusSucceeded
+		job.Result = newResult(*result)
+	}
+
+	if err := q.store.SaveJob(ctx, job); err != nil {
+		// Subscribers still learn the outcome; a restart runs the job again
+		job.Status = StatusFailed
+		job.Error = fmt.Sprintf("failed to save job: %v", err)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for ch := range q.subscribers[job.ID] {
+		sendEvent(ch, Event{JobID: job.ID, Status: job.Status})
+		close(ch)
+	}
+	delete(q.subscribers, job.ID)
+}
+
+// publish sends an event to the job's subscribers.
+func (q *Queue) publish(e Event) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for ch := range q.subscribers[e.JobID] {
+		sendEvent(ch, e)
+	}
+}
+
+// close stops the workers and ends all subscriptions.
+func (q *Queue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	for id, subscribers := range q.subscribers {
+		for ch := range subscribers {
+			close(ch)
+		}
+		delete(q.subscribers, id)
+	}
+	q.cond.Broadcast()
+}
+
+// sendEvent sends without blocking, dropping the event if ch is full.
+func sendEvent(ch chan Event, e Event) {
+	select {
+	case ch <- e:
+	default:
+	}
+}
+
+// newResult keeps the parts of a review result a job reports.
+func newResult(r review.Result) *Result {
+	result := &Result{
+		Paths:      r.Paths,
+		BaseSHA:    r.BaseSHA,
+		TargetSHA:  r.TargetSHA,
+		ConfigHash: r.ConfigHash,
+	}
+	// The merged review is last
+	if n := len(r.Reviews); n > 0 {
+		result.Review = r.Reviews[n-1]
+	}
+	return result
+}
+
+// newJobID creates a unique, time-ordered job ID.
+func newJobID(now time.Time) (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate job ID: %w", err)
+	}
+	return fmt.Sprintf("job-%s-%s", now.UTC().Format("20060102T150405Z"), hex.EncodeToString(b)), nil
+}
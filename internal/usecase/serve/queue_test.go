@@ -0,0 +1,263 @@
+package serve_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bkyoung/code-reviewer/internal/domain"
+	"github.com/bkyoung/code-reviewer/internal/usecase/review"
+	"github.com/bkyoung/code-reviewer/internal/usecase/serve"
+)
+
+// memoryStore is an in-memory JobStore.
+type memoryStore struct {
+	mu   sync.Mutex
+	jobs map[string]serve.Job
+}
+
+func newMemoryStore(jobs ...serve.Job) *memoryStore {
+	s := &memoryStore{jobs: make(map[string]serve.Job)}
+	for _, job := range jobs {
+		s.jobs[job.ID] = job
+	}
+	return s
+}
+
+func (s *memoryStore) SaveJob(_ context.Context, job serve.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job.ID] = job
+	return nil
+}
+
+func (s *memoryStore) GetJob(_ context.Context, id string) (serve.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return serve.Job{}, serve.ErrJobNotFound
+	}
+	return job, nil
+}
+
+func (s *memoryStore) ListJobs(_ context.Context, statuses ...serve.Status) ([]serve.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var jobs []serve.Job
+	for _, job := range s.jobs {
+		for _, status := range statuses {
+			if job.Status == status {
+				jobs = append(jobs, job)
+			}
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreatedAt.Before(jobs[j].CreatedAt) })
+	return jobs, nil
+}
+
+// fakeReviewer reports a diff stage and returns a merged review, or err.
+// When release is set, reviews wait for it (or cancellation) first.
+type fakeReviewer struct {
+	err     error
+	release chan struct{}
+	started chan string
+
+	mu      sync.Mutex
+	reviews []string
+}
+
+func (r *fakeReviewer) ReviewBranch(ctx context.Context, req review.BranchRequest) (review.Result, error) {
+	r.mu.Lock()
+	r.reviews = append(r.reviews, req.TargetRef)
+	r.mu.Unlock()
+	if r.started != nil {
+		r.started <- req.TargetRef
+	}
+	if r.release != nil {
+		select {
+		case <-r.release:
+		case <-ctx.Done():
+			return review.Result{}, ctx.Err()
+		}
+	}
+
+	if r.err != nil {
+		return review.Result{}, r.err
+	}
+	return review.Result{
+		Reviews:    []domain.Review{{ProviderName: "openai"}, {ProviderName: "merged", Summary: "looks good"}},
+		Paths:      map[string]map[string]string{"json": {"merged": "out/review.json"}},
+		BaseSHA:    "abc",
+		TargetSHA:  "def",
+		ConfigHash: "hash",
+	}, nil
+}
+
+func (r *fakeReviewer) reviewed() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.reviews...)
+}
+
+func startQueue(t *testing.T, reviewer serve.Reviewer, store serve.JobStore, opts serve.Options) (*serve.Queue, context.CancelFunc) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	queue := serve.NewQueue(reviewer, store, opts)
+	if err := queue.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	t.Cleanup(func() {
+		cancel()
+		queue.Wait()
+	})
+	return queue, cancel
+}
+
+func waitForStatus(t *testing.T, queue *serve.Queue, id string, want serve.Status) serve.Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		job, err := queue.Get(context.Background(), id)
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if job.Status == want {
+			return job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected job %s to be %s, got %s", id, want, job.Status)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestQueue_RunsJobAndStoresResult(t *testing.T) {
+	reviewer := &fakeReviewer{release: make(chan struct{})}
+	queue, _ := startQueue(t, reviewer, newMemoryStore(), serve.Options{})
+
+	job, err := queue.Submit(context.Background(), review.BranchRequest{BaseRef: "main", TargetRef: "feature"})
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	if job.Status != serve.StatusQueued || job.ID == "" {
+		t.Errorf("expected a queued job with an ID, got %+v", job)
+	}
+
+	events, unsubscribe := queue.Subscribe(job.ID)
+	defer unsubscribe()
+	close(reviewer.release)
+
+	var statuses []serve.Status
+	for e := range events {
+		statuses = append(statuses, e.Status)
+	}
+	if len(statuses) == 0 || statuses[len(statuses)-1] != serve.StatusSucceeded {
+		t.Errorf("expected events to end with succeeded, got %v", statuses)
+	}
+
+	job = waitForStatus(t, queue, job.ID, serve.StatusSucceeded)
+	if job.Result == nil || job.Result.Review.ProviderName != "merged" || job.Result.TargetSHA != "def" {
+		t.Fatalf("expected the merged review in the result, got %+v", job.Result)
+	}
+	if job.Result.Paths["json"]["merged"] != "out/review.json" {
+		t.Errorf("expected output paths in the result, got %v", job.Result.Paths)
+	}
+	if job.StartedAt.IsZero() || job.FinishedAt.IsZero() {
+		t.Errorf("expected start and finish times, got %+v", job)
+	}
+}
+
+func TestQueue_RecordsFailure(t *testing.T) {
+	queue, _ := startQueue(t, &fakeReviewer{err: errors.New("provider failed")}, newMemoryStore(), serve.Options{})
+
+	job, err := queue.Submit(context.Background(), review.BranchRequest{TargetRef: "feature"})
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+
+	job = waitForStatus(t, queue, job.ID, serve.StatusFailed)
+	if job.Error != "provider failed" || job.Result != nil {
+		t.Errorf("expected the review error, got %+v", job)
+	}
+}
+
+func TestQueue_LimitsConcurrencyAndQueueLength(t *testing.T) {
+	reviewer := &fakeReviewer{release: make(chan struct{}), started: make(chan string, 10)}
+	queue, _ := startQueue(t, reviewer, newMemoryStore(), serve.Options{Concurrency: 2, MaxQueued: 1})
+
+	var ids []string
+	for _, target := range []string{"a", "b"} {
+		job, err := queue.Submit(context.Background(), review.BranchRequest{TargetRef: target})
+		if err != nil {
+			t.Fatalf("Submit failed: %v", err)
+		}
+		ids = append(ids, job.ID)
+		<-reviewer.started // Taken by a worker, so the queue is empty again
+	}
+
+	// Both workers are busy: one job may wait, the next is rejected
+	job, err := queue.Submit(context.Background(), review.BranchRequest{TargetRef: "c"})
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	ids = append(ids, job.ID)
+	if _, err := queue.Submit(context.Background(), review.BranchRequest{TargetRef: "d"}); !errors.Is(err, serve.ErrQueueFull) {
+		t.Errorf("expected ErrQueueFull, got %v", err)
+	}
+	if got := len(reviewer.reviewed()); got != 2 {
+		t.Errorf("expected 2 reviews running at once, got %d", got)
+	}
+
+	close(reviewer.release)
+	for _, id := range ids {
+		waitForStatus(t, queue, id, serve.StatusSucceeded)
+	}
+}
+
+func TestQueue_RequeuesUnfinishedJobsOnStart(t *testing.T) {
+	created := time.Now().Add(-time.Hour)
+	store := newMemoryStore(
+		serve.Job{ID: "job-1", Status: serve.StatusRunning, Request: review.BranchRequest{TargetRef: "interrupted"}, CreatedAt: created},
+		serve.Job{ID: "job-2", Status: serve.StatusQueued, Request: review.BranchRequest{TargetRef: "waiting"}, CreatedAt: created.Add(time.Minute)},
+		serve.Job{ID: "job-3", Status: serve.StatusSucceeded, Request: review.BranchRequest{TargetRef: "done"}, CreatedAt: created},
+	)
+	reviewer := &fakeReviewer{}
+	queue, _ := startQueue(t, reviewer, store, serve.Options{})
+
+	waitForStatus(t, queue, "job-1", serve.StatusSucceeded)
+	waitForStatus(t, queue, "job-2", serve.StatusSucceeded)
+	if got := reviewer.reviewed(); len(got) != 2 || got[0] != "interrupted" || got[1] != "waiting" {
+		t.Errorf("expected unfinished jobs to run oldest first, got %v", got)
+	}
+}
+
+func TestQueue_ShutdownLeavesRunningJobUnfinished(t *testing.T) {
+	reviewer := &fakeReviewer{release: make(chan struct{}), started: make(chan string, 1)}
+	store := newMemoryStore()
+	queue, cancel := startQueue(t, reviewer, store, serve.Options{})
+
+	job, err := queue.Submit(context.Background(), review.BranchRequest{TargetRef: "feature"})
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	events, unsubscribe := queue.Subscribe(job.ID)
+	defer unsubscribe()
+	<-reviewer.started
+
+	cancel()
+	queue.Wait()
+	for range events {
+	}
+
+	job = waitForStatus(t, queue, job.ID, serve.StatusRunning)
+	if job.Error != "" {
+		t.Errorf("expected no error recorded for an interrupted job, got %q", job.Error)
+	}
+	if _, err := queue.Submit(context.Background(), review.BranchRequest{}); !errors.Is(err, serve.ErrQueueClosed) {
+		t.Errorf("expected ErrQueueClosed after shutdown, got %v", err)
+	}
+}